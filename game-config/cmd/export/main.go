@@ -0,0 +1,61 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/tg"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/definition"
+	_ "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/slots" // registers the Go-defined games.
+)
+
+// export dumps a Go-defined game to a declarative game definition file.
+//
+// usage: export -game crw -rtps 92,94,96 -out crw.yaml
+func main() {
+	gameID := flag.String("game", "", "game code (e.g. crw)")
+	rtps := flag.String("rtps", "92,94,96", "comma separated list of RTPs to export")
+	out := flag.String("out", "", "output file (.json, .yaml or .yml); defaults to YAML on stdout")
+	flag.Parse()
+
+	if err := export(*gameID, *rtps, *out); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func export(gameID, rtps, out string) error {
+	nr, err := tg.VerifyGameID(gameID)
+	if err != nil {
+		return err
+	}
+
+	list := make([]int, 0, 4)
+	for _, s := range strings.Split(rtps, ",") {
+		rtp, err2 := strconv.Atoi(strings.TrimSpace(s))
+		if err2 != nil {
+			return fmt.Errorf("invalid RTP [%s]", s)
+		}
+		list = append(list, rtp)
+	}
+
+	d, err := definition.Export(nr.String(), nr.String(), list...)
+	if err != nil {
+		return err
+	}
+
+	if out != "" {
+		return d.WriteFile(out)
+	}
+
+	data, err := d.YAML()
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(data)
+	return err
+}
@@ -0,0 +1,264 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+
+	optimize "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/analysis/optimize/slots"
+	game "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/games/slots"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/simulate"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/utils"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/tg"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/definition"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/slots"
+)
+
+// optimize searches the reel weights of a registered game for a target RTP, starting from the weights of an RTP variant.
+// Candidates are measured with short simulations (-mode sim), or with the exact base game evaluator (-mode exact) for
+// simple payline games. The best weight table is printed, and verified with a longer simulation if -verify is given.
+// With -out the game definition is written with the weights of the variant replaced by the best weight table.
+// Bounds are given as symbol:reel:min:max, where reel 0 applies to all reels.
+//
+// usage: optimize -game crw -rtp 96 -target 94 [-tolerance 0.05] [-hit-rate 25] [-max-free 0.5] [-mode sim]
+// [-rounds 1000000] [-iterations 200] [-verify 10000000] [-bounds 5:0:4:12,9:3:1:2] [-min 1] [-max 500] [-out crw.yaml]
+func main() {
+	gameID := flag.String("game", "", "game code (e.g. crw)")
+	rtp := flag.Int("rtp", 96, "RTP variant to start from")
+	target := flag.Float64("target", 0, "target RTP; defaults to the RTP of the variant")
+	tolerance := flag.Float64("tolerance", 0.05, "accepted deviation from the target RTP (percentage points)")
+	hitRate := flag.Float64("hit-rate", 0, "target hit rate (optional)")
+	hitTolerance := flag.Float64("hit-tolerance", 0.5, "accepted deviation from the target hit rate (percentage points)")
+	maxFree := flag.Float64("max-free", 0, "maximum percentage of rounds with free spins (optional)")
+	mode := flag.String("mode", "sim", "evaluation mode: sim (simulation) or exact (base game paylines only)")
+	rounds := flag.Uint64("rounds", 1000000, "number of rounds to simulate for each candidate")
+	verify := flag.Uint64("verify", 0, "number of rounds to simulate for the best candidate (optional)")
+	iterations := flag.Int("iterations", 200, "maximum number of candidates to evaluate")
+	seed := flag.Uint64("seed", 1, "seed for the search")
+	minWeight := flag.Float64("min", 1, "minimum weight")
+	maxWeight := flag.Float64("max", 0, "maximum weight; 0 for unlimited")
+	bounds := flag.String("bounds", "", "comma separated weight bounds as symbol:reel:min:max")
+	workers := flag.Int("workers", runtime.NumCPU(), "number of workers for the simulations")
+	out := flag.String("out", "", "write the game definition with the best weights to this file (.json, .yaml or .yml)")
+	flag.Parse()
+
+	cfg := config{
+		gameID:       *gameID,
+		rtp:          *rtp,
+		target:       *target,
+		tolerance:    *tolerance,
+		hitRate:      *hitRate,
+		hitTolerance: *hitTolerance,
+		maxFree:      *maxFree,
+		mode:         *mode,
+		rounds:       *rounds,
+		verify:       *verify,
+		iterations:   *iterations,
+		seed:         *seed,
+		minWeight:    *minWeight,
+		maxWeight:    *maxWeight,
+		bounds:       *bounds,
+		workers:      *workers,
+		out:          *out,
+	}
+
+	if err := run(cfg); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+type config struct {
+	gameID       string
+	rtp          int
+	target       float64
+	tolerance    float64
+	hitRate      float64
+	hitTolerance float64
+	maxFree      float64
+	mode         string
+	rounds       uint64
+	verify       uint64
+	iterations   int
+	seed         uint64
+	minWeight    float64
+	maxWeight    float64
+	bounds       string
+	workers      int
+	out          string
+}
+
+func run(cfg config) error {
+	nr, err := tg.VerifyGameID(cfg.gameID)
+	if err != nil {
+		return err
+	}
+
+	reg := slots.Game(nr)
+	if reg == nil {
+		return fmt.Errorf("game %s is not registered", nr.String())
+	}
+	if !reg.HasRTP(cfg.rtp) {
+		return fmt.Errorf("game %s does not support RTP %d", nr.String(), cfg.rtp)
+	}
+
+	d, err := definition.Export(nr.String(), nr.String(), cfg.rtp)
+	if err != nil {
+		return err
+	}
+	v := &d.Variants[0]
+
+	template := simulate.SlotsParams{
+		GameNR:  nr,
+		Symbols: reg.AllSymbols(),
+		Workers: cfg.workers,
+	}
+	if reg.AllActions != nil {
+		template.Actions = reg.AllActions(cfg.rtp)
+	}
+
+	var evaluate optimize.Evaluator
+	switch cfg.mode {
+	case "sim":
+		evaluate = optimize.Simulation(template, cfg.rounds)
+	case "exact":
+		evaluate = optimize.Exact(cfg.hitRate > 0)
+	default:
+		return fmt.Errorf("invalid mode [%s]", cfg.mode)
+	}
+
+	list, err := parseBounds(cfg.bounds)
+	if err != nil {
+		return err
+	}
+
+	target := cfg.target
+	if target <= 0 {
+		target = float64(cfg.rtp)
+	}
+
+	build := func(t optimize.Table) (game.RegularParams, error) {
+		v.Weights = toWeights(t)
+		s, err2 := d.Slots(cfg.rtp)
+		if err2 != nil {
+			return game.RegularParams{}, err2
+		}
+		return game.RegularParams{Slots: s}, nil
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	best, err := optimize.Optimize(ctx, optimize.Params{
+		Start:            fromWeights(v.Weights),
+		Build:            build,
+		Evaluate:         evaluate,
+		TargetRTP:        target,
+		RTPTolerance:     cfg.tolerance,
+		TargetHitRate:    cfg.hitRate,
+		HitRateTolerance: cfg.hitTolerance,
+		MaxFreeSpinRate:  cfg.maxFree,
+		MinWeight:        cfg.minWeight,
+		MaxWeight:        cfg.maxWeight,
+		Bounds:           list,
+		Iterations:       cfg.iterations,
+		Seed:             cfg.seed,
+		Progress: func(iteration int, c *optimize.Candidate) {
+			fmt.Printf("%s-%d: iteration %d: RTP %.4f%%, hit rate %.4f%%, free spins %.4f%% (loss %.4f)\n",
+				nr.String(), cfg.rtp, iteration, c.Stats.RTP, c.Stats.HitRate, c.Stats.FreeSpinRate, c.Loss)
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("game:           %s\n", nr.String())
+	fmt.Printf("start RTP:      %d\n", cfg.rtp)
+	fmt.Printf("target RTP:     %.4f%%\n", target)
+	fmt.Printf("iterations:     %d\n", best.Iterations)
+	fmt.Printf("converged:      %t\n", best.Converged)
+	printStats(best.Stats)
+	fmt.Printf("weights:\n%s", best.Table.String())
+
+	if cfg.verify > 0 {
+		params, err2 := build(best.Table)
+		if err2 != nil {
+			return err2
+		}
+		stats, err2 := optimize.Simulation(template, cfg.verify)(ctx, params)
+		if err2 != nil {
+			return err2
+		}
+		fmt.Printf("verification (%d rounds):\n", stats.Rounds)
+		printStats(stats)
+	}
+
+	if cfg.out != "" {
+		v.Weights = toWeights(best.Table)
+		if err = d.WriteFile(cfg.out); err != nil {
+			return err
+		}
+		fmt.Printf("definition successfully saved to %s\n", cfg.out)
+	}
+
+	return nil
+}
+
+func printStats(s *optimize.Stats) {
+	fmt.Printf("RTP:            %.4f%%", s.RTP)
+	if s.RTPWidth > 0 {
+		fmt.Printf(" (95%% CI width %.4f)", s.RTPWidth)
+	}
+	fmt.Println()
+	fmt.Printf("hit rate:       %.4f%%\n", s.HitRate)
+	fmt.Printf("free spins:     %.4f%% of rounds\n", s.FreeSpinRate)
+	fmt.Printf("std deviation:  %.4f\n", s.StdDev)
+}
+
+func parseBounds(s string) ([]optimize.Bound, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	list := make([]optimize.Bound, 0, 8)
+	for _, b := range strings.Split(s, ",") {
+		parts := strings.Split(strings.TrimSpace(b), ":")
+		if len(parts) != 4 {
+			return nil, fmt.Errorf("invalid bound [%s]", b)
+		}
+
+		symbol, err1 := strconv.Atoi(parts[0])
+		reel, err2 := strconv.Atoi(parts[1])
+		min, err3 := strconv.ParseFloat(parts[2], 64)
+		max, err4 := strconv.ParseFloat(parts[3], 64)
+		if err1 != nil || err2 != nil || err3 != nil || err4 != nil || symbol <= 0 || reel < 0 || reel > 255 || min > max {
+			return nil, fmt.Errorf("invalid bound [%s]", b)
+		}
+
+		list = append(list, optimize.Bound{Symbol: utils.Index(symbol), Reel: uint8(reel), Min: min, Max: max})
+	}
+	return list, nil
+}
+
+func fromWeights(weights []definition.Weights) optimize.Table {
+	t := make(optimize.Table, len(weights))
+	for ix := range weights {
+		t[ix] = optimize.Weights{Symbol: weights[ix].Symbol, Reels: weights[ix].Reels}
+	}
+	return t.Clone()
+}
+
+func toWeights(t optimize.Table) []definition.Weights {
+	weights := make([]definition.Weights, len(t))
+	for ix := range t {
+		weights[ix] = definition.Weights{Symbol: t[ix].Symbol, Reels: t[ix].Reels}
+	}
+	return weights
+}
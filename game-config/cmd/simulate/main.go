@@ -0,0 +1,410 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	exact "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/analysis/exact/slots"
+	analysis "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/analysis/game/slots"
+	metrics "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/analysis/metrics/slots"
+	comp "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/components/slots"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/games/cards"
+	game "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/games/slots"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/simulate"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/tg"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/registry"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/slots"
+)
+
+// simulate runs a multi-core RTP simulation for a registered game and writes the text/CSV reports.
+// The simulation can be interrupted with SIGINT/SIGTERM, in which case the reports contain the completed rounds.
+// With -checkpoint the metrics are saved to a snapshot file periodically, so an interrupted simulation can be
+// continued with -resume. Snapshots of simulations on multiple machines can be combined into a single report with -merge.
+// With -stop-width the simulation stops early once the confidence interval of the RTP is narrow enough.
+// With -exact the exact base game RTP of games with simple paylines is printed as well, to verify the simulation.
+// With -jackpots the bets contribute to progressive jackpot pools, and the amounts awarded from the pools are added to the total RTP.
+// With -gamble the wins of games with a gamble feature are gambled on the color or suit of a card, as often as allowed.
+//
+// usage: simulate -game bot -rtp 96 -rounds 100000000 [-bet 100] [-bb 1] [-workers 16] [-choices wing=north] [-out reports]
+// [-checkpoint bot.snapshot] [-batch 10000000] [-resume bot.snapshot] [-stop-width 0.1] [-stop-level 95] [-exact]
+// [-jackpots 1:1000:0.01,2:50000:0.005:0.001] [-gamble color]
+//
+// usage: simulate -game bot -rtp 96 -merge m1.snapshot,m2.snapshot [-out reports]
+func main() {
+	gameID := flag.String("game", "", "game code (e.g. bot)")
+	rtp := flag.Int("rtp", 96, "RTP of the game")
+	bet := flag.Int64("bet", 100, "bet per round")
+	bonusBuy := flag.Uint("bb", 0, "bonus buy kind; 0 for regular rounds")
+	rounds := flag.Uint64("rounds", 1000000, "number of rounds to play")
+	workers := flag.Int("workers", runtime.NumCPU(), "number of workers")
+	choices := flag.String("choices", "", "comma separated player choices for games which need them (e.g. wing=north)")
+	out := flag.String("out", ".", "output directory for the reports")
+	progress := flag.Duration("progress", 10*time.Second, "interval for progress reporting")
+	checkpoint := flag.String("checkpoint", "", "snapshot file to save the metrics to periodically")
+	batch := flag.Uint64("batch", 10000000, "number of rounds between checkpoints and convergence checks")
+	resume := flag.String("resume", "", "snapshot file of an interrupted simulation to continue from")
+	merge := flag.String("merge", "", "comma separated snapshot files to combine into a report, instead of simulating")
+	stopWidth := flag.Float64("stop-width", 0, "stop once the RTP confidence interval is narrower than this (percentage points)")
+	stopLevel := flag.Int("stop-level", 95, "confidence level for -stop-width (90, 95 or 99)")
+	exactRTP := flag.Bool("exact", false, "print the exact base game RTP of the paylines, if the game supports it")
+	jackpots := flag.String("jackpots", "", "comma separated progressive jackpot pools as level:seed:rate[:reserve-rate] (e.g. 1:1000:0.01)")
+	gamble := flag.String("gamble", "", "gamble the wins of games with a gamble feature on the color or suit of a card (color or suit)")
+	flag.Parse()
+
+	cfg := config{
+		gameID:     *gameID,
+		rtp:        *rtp,
+		bet:        *bet,
+		bonusBuy:   uint8(*bonusBuy),
+		rounds:     *rounds,
+		workers:    *workers,
+		choices:    *choices,
+		out:        *out,
+		progress:   *progress,
+		checkpoint: *checkpoint,
+		batch:      *batch,
+		resume:     *resume,
+		merge:      *merge,
+		stopWidth:  *stopWidth,
+		stopLevel:  *stopLevel,
+		exact:      *exactRTP,
+		jackpots:   *jackpots,
+		gamble:     *gamble,
+	}
+
+	if err := run(cfg); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+type config struct {
+	gameID     string
+	rtp        int
+	bet        int64
+	bonusBuy   uint8
+	rounds     uint64
+	workers    int
+	choices    string
+	out        string
+	progress   time.Duration
+	checkpoint string
+	batch      uint64
+	resume     string
+	merge      string
+	stopWidth  float64
+	stopLevel  int
+	exact      bool
+	jackpots   string
+	gamble     string
+}
+
+func run(cfg config) error {
+	rtp, bonusBuy := cfg.rtp, cfg.bonusBuy
+
+	nr, err := tg.VerifyGameID(cfg.gameID)
+	if err != nil {
+		return err
+	}
+
+	reg := slots.Game(nr)
+	if reg == nil {
+		return fmt.Errorf("game %s is not registered", nr.String())
+	}
+	if !reg.HasRTP(rtp) {
+		return fmt.Errorf("game %s does not support RTP %d", nr.String(), rtp)
+	}
+
+	params := simulate.SlotsParams{
+		GameNR:   nr,
+		NewGame:  func() *game.Regular { return reg.NewGame(rtp, false, false) },
+		Symbols:  reg.AllSymbols(),
+		Rounds:   cfg.rounds,
+		Workers:  cfg.workers,
+		Bet:      cfg.bet,
+		BonusBuy: bonusBuy,
+		Interval: cfg.progress,
+		Progress: func(done, total uint64) {
+			fmt.Printf("%s-%d: %d/%d rounds (%.1f%%)\n", nr.String(), rtp, done, total, float64(done)*100/float64(total))
+		},
+	}
+
+	if reg.AllActions != nil {
+		params.Actions = reg.AllActions(rtp)
+	}
+
+	if cfg.choices != "" {
+		m, err2 := parseChoices(cfg.choices)
+		if err2 != nil {
+			return err2
+		}
+		params.Choices = func() map[string]string { return m }
+	}
+
+	if params.StopLevel, err = confidence(cfg.stopLevel); err != nil {
+		return err
+	}
+	params.StopWidth = cfg.stopWidth
+	params.Batch = cfg.batch
+
+	if cfg.jackpots != "" {
+		if params.Jackpots, err = parseJackpots(cfg.jackpots); err != nil {
+			return err
+		}
+	}
+
+	if cfg.gamble != "" {
+		kind, err2 := gambleKind(cfg.gamble)
+		if err2 != nil {
+			return err2
+		}
+		params.Gamble = func(float64, uint8) (cards.GambleKind, int) { return kind, 0 }
+	}
+
+	if cfg.checkpoint != "" {
+		params.Checkpoint = func(r *analysis.Rounds) error {
+			if err2 := r.SaveSnapshot(cfg.checkpoint); err2 != nil {
+				return err2
+			}
+			fmt.Printf("%s-%d: checkpoint saved to %s (%d rounds)\n", nr.String(), rtp, cfg.checkpoint, r.RoundCount)
+			return nil
+		}
+	}
+
+	started := time.Now()
+
+	var r *analysis.Rounds
+	if cfg.merge != "" {
+		if r, err = mergeSnapshots(nr, reg, rtp, strings.Split(cfg.merge, ",")); err != nil {
+			return err
+		}
+	} else {
+		if cfg.resume != "" {
+			if params.Resume, err = loadSnapshot(nr, reg, rtp, cfg.resume); err != nil {
+				return err
+			}
+			fmt.Printf("%s-%d: resuming from %s (%d rounds)\n", nr.String(), rtp, cfg.resume, params.Resume.RoundCount)
+		}
+
+		ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+		defer cancel()
+
+		if r, err = simulate.Slots(ctx, params); err != nil {
+			return err
+		}
+	}
+	defer r.Release()
+
+	printSummary(nr, rtp, r, time.Since(started))
+	if cfg.exact {
+		printExact(reg, rtp)
+	}
+	if bonusBuy > 0 {
+		printBonusBuy(reg, rtp, bonusBuy, r)
+	}
+
+	out := cfg.out
+	if err = os.MkdirAll(out, 0755); err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%s-%d-%s", nr.String(), rtp, started.Format("20060102-150405"))
+	if bonusBuy > 0 {
+		name = fmt.Sprintf("%s-%d-bb%d-%s", nr.String(), rtp, bonusBuy, started.Format("20060102-150405"))
+	}
+
+	csvPath := filepath.Join(out, name+"-rounds.csv")
+	if err = r.RoundsToCSVFile(csvPath); err != nil {
+		return err
+	}
+	fmt.Printf("CSV file successfully saved to %s\n", csvPath)
+
+	txtPath := filepath.Join(out, name+"-brackets.txt")
+	if err = r.RoundsBracketsToTxtFile(txtPath, &analysis.MyLogger{}); err != nil {
+		return err
+	}
+	fmt.Printf("txt file successfully saved to %s\n", txtPath)
+
+	return nil
+}
+
+// loadSnapshot restores the rounds metrics from a snapshot file.
+func loadSnapshot(nr tg.GameNR, reg *registry.Game, rtp int, path string) (*analysis.Rounds, error) {
+	g := reg.NewGame(rtp, false, false)
+	defer g.Release()
+
+	var actions comp.SpinActions
+	if reg.AllActions != nil {
+		actions = reg.AllActions(rtp)
+	}
+
+	r := simulate.NewRounds(nr, "simulate", 0, g, reg.AllSymbols(), actions)
+	if err := r.LoadSnapshot(path); err != nil {
+		r.Release()
+		return nil, fmt.Errorf("snapshot %s: %w", path, err)
+	}
+	return r, nil
+}
+
+// mergeSnapshots combines the rounds metrics from multiple snapshot files.
+func mergeSnapshots(nr tg.GameNR, reg *registry.Game, rtp int, paths []string) (*analysis.Rounds, error) {
+	var r *analysis.Rounds
+	for _, path := range paths {
+		n, err := loadSnapshot(nr, reg, rtp, strings.TrimSpace(path))
+		if err != nil {
+			if r != nil {
+				r.Release()
+			}
+			return nil, err
+		}
+
+		if r == nil {
+			r = n
+		} else {
+			r.Merge(n)
+			n.Release()
+		}
+	}
+	return r, nil
+}
+
+func confidence(level int) (metrics.Confidence, error) {
+	switch level {
+	case 90:
+		return metrics.Confidence90, nil
+	case 95:
+		return metrics.Confidence95, nil
+	case 99:
+		return metrics.Confidence99, nil
+	default:
+		return 0, fmt.Errorf("invalid confidence level %d", level)
+	}
+}
+
+func parseChoices(s string) (map[string]string, error) {
+	m := make(map[string]string)
+	for _, kv := range strings.Split(s, ",") {
+		k, v, ok := strings.Cut(strings.TrimSpace(kv), "=")
+		if !ok || k == "" || v == "" {
+			return nil, fmt.Errorf("invalid player choice [%s]", kv)
+		}
+		m[k] = v
+	}
+	return m, nil
+}
+
+func parseJackpots(s string) ([]analysis.JackpotPool, error) {
+	out := make([]analysis.JackpotPool, 0, 4)
+	for _, def := range strings.Split(s, ",") {
+		parts := strings.Split(strings.TrimSpace(def), ":")
+		if len(parts) < 3 || len(parts) > 4 {
+			return nil, fmt.Errorf("invalid jackpot pool [%s]", def)
+		}
+
+		level, err1 := strconv.ParseUint(parts[0], 10, 8)
+		seed, err2 := strconv.ParseInt(parts[1], 10, 64)
+		rate, err3 := strconv.ParseFloat(parts[2], 64)
+		var reserve float64
+		var err4 error
+		if len(parts) == 4 {
+			reserve, err4 = strconv.ParseFloat(parts[3], 64)
+		}
+		if err1 != nil || err2 != nil || err3 != nil || err4 != nil || level == 0 || seed < 0 || rate < 0 || reserve < 0 || rate+reserve >= 1 {
+			return nil, fmt.Errorf("invalid jackpot pool [%s]", def)
+		}
+
+		out = append(out, analysis.JackpotPool{Level: uint8(level), Seed: seed, Rate: rate, ReserveRate: reserve})
+	}
+	return out, nil
+}
+
+func gambleKind(s string) (cards.GambleKind, error) {
+	switch s {
+	case cards.GambleColor.String():
+		return cards.GambleColor, nil
+	case cards.GambleSuit.String():
+		return cards.GambleSuit, nil
+	default:
+		return cards.GambleCollect, fmt.Errorf("invalid gamble [%s]", s)
+	}
+}
+
+func printSummary(nr tg.GameNR, rtp int, r *analysis.Rounds, elapsed time.Duration) {
+	fmt.Printf("game:           %s\n", nr.String())
+	fmt.Printf("target RTP:     %d\n", rtp)
+	fmt.Printf("rounds:         %d\n", r.RoundCount)
+	fmt.Printf("duration:       %s (%.0f rounds/s)\n", elapsed.Round(time.Millisecond), float64(r.RoundCount)/elapsed.Seconds())
+	fmt.Printf("RTP:            %.4f%%\n", r.RTP())
+	fmt.Printf("RTP no free:    %.4f%% (rounds without free spins)\n", r.RTPnoFree())
+	fmt.Printf("RTP free:       %.4f%% (rounds with free spins)\n", r.RTPfree())
+	if r.JackpotSeeds > 0 || r.JackpotWins > 0 {
+		fmt.Printf("jackpot RTP:    %.4f%% (progressive jackpots awarded)\n", r.JackpotRTP())
+		fmt.Printf("jackpot seeds:  %d (seed values of the pools, incl. reseeds)\n", r.JackpotSeeds)
+		fmt.Printf("total RTP:      %.4f%%\n", r.TotalRTP())
+	}
+	if r.GambleSteps > 0 {
+		fmt.Printf("gamble RTP:     %.4f%% (included in RTP)\n", r.GambleRTP())
+		fmt.Printf("gamble steps:   %d (%d won)\n", r.GambleSteps, r.GambleWins)
+	}
+	for level, count := range r.Progressives {
+		fmt.Printf("progressive %s:  %d\n", level, count)
+	}
+	fmt.Printf("hit rate:       %.4f%%\n", r.HitRate())
+	fmt.Printf("std deviation:  %.4f\n", r.StdDev())
+	fmt.Printf("volatility:     %.4f (90%%)\n", r.VolatilityIndex(metrics.Confidence90))
+	for _, level := range []metrics.Confidence{metrics.Confidence90, metrics.Confidence95, metrics.Confidence99} {
+		lo, hi := r.RTPConfidence(level)
+		fmt.Printf("RTP %s CI:     %.4f%% - %.4f%%\n", level.String(), lo, hi)
+	}
+	fmt.Printf("free spins:     %d\n", r.FreeSpins)
+	fmt.Printf("max payouts:    %d\n", r.MaxPayouts)
+	fmt.Printf("highest payout: %d\n", r.HighestPayout)
+}
+
+// printExact prints the exact base game RTP of the paylines.
+// Games with unsupported spinners or grids are reported, but do not fail the simulation.
+func printExact(reg *registry.Game, rtp int) {
+	g := reg.NewGame(rtp, false, false)
+	defer g.Release()
+
+	s := g.Slots()
+	reels, err := exact.NewReels(s)
+	if err != nil {
+		fmt.Printf("exact RTP:      n/a (%v)\n", err)
+		return
+	}
+
+	e, err := exact.Evaluate(s, reels)
+	if err != nil {
+		fmt.Printf("exact RTP:      n/a (%v)\n", err)
+		return
+	}
+
+	fmt.Printf("exact RTP:      %.4f%% (base game paylines)\n", e.RTP)
+	fmt.Printf("line hit rate:  %.4f%%\n", e.LineHitRate)
+	if e.Enumerated {
+		fmt.Printf("exact hit rate: %.4f%% (%d grids)\n", e.HitRate, e.Combinations)
+		fmt.Printf("exact std dev:  %.4f\n", e.StdDev())
+	}
+}
+
+// printBonusBuy prints the RTP relative to the cost of the bonus buy.
+func printBonusBuy(reg *registry.Game, rtp int, bonusBuy uint8, r *analysis.Rounds) {
+	g := reg.NewGame(rtp, false, false)
+	defer g.Release()
+
+	if paid := g.ForSale(bonusBuy); paid != nil && paid.BetMultiplier() > 0 {
+		fmt.Printf("bonus buy:      %d (cost %dx bet)\n", bonusBuy, paid.BetMultiplier())
+		fmt.Printf("RTP vs cost:    %.4f%%\n", r.RTP()/float64(paid.BetMultiplier()))
+	}
+}
@@ -0,0 +1,395 @@
+package definition
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	comp "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/components/slots"
+	util "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/utils"
+)
+
+// ActionBuilder is the function signature for building a spin action from its declarative definition.
+type ActionBuilder func(a *Action) (comp.SpinActioner, error)
+
+// ActionExporter is the function signature for exporting a spin action to its declarative definition.
+// It returns nil if the action is not of the kind handled by the exporter.
+type ActionExporter func(a comp.SpinActioner) *Action
+
+// FilterBuilder is the function signature for building a spin data filter from its declarative definition.
+type FilterBuilder func(f *Filter) (comp.SpinDataFilterer, error)
+
+// RegisterAction registers a builder for the given kind of action.
+// It replaces any previously registered builder for the same kind.
+func RegisterAction(kind string, builder ActionBuilder) {
+	actionsMutex.Lock()
+	actionBuilders[kind] = builder
+	actionsMutex.Unlock()
+}
+
+// RegisterExporter registers an exporter for the given kind of action.
+// It replaces any previously registered exporter for the same kind.
+// Actions are only exported as data for kinds that also have a builder registered.
+func RegisterExporter(kind string, exporter ActionExporter) {
+	actionsMutex.Lock()
+	actionExporters[kind] = exporter
+	actionsMutex.Unlock()
+}
+
+// RegisterFilter registers a builder for the given kind of spin data filter.
+// It replaces any previously registered builder for the same kind.
+func RegisterFilter(kind string, builder FilterBuilder) {
+	actionsMutex.Lock()
+	filterBuilders[kind] = builder
+	actionsMutex.Unlock()
+}
+
+// ActionKinds returns the kinds of actions that can be defined declaratively, in alphabetical order.
+func ActionKinds() []string {
+	actionsMutex.RLock()
+	defer actionsMutex.RUnlock()
+
+	out := make([]string, 0, len(actionBuilders))
+	for k := range actionBuilders {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// buildAction builds the spin action with the trigger filters from the declarative definition.
+func buildAction(a *Action) (comp.SpinActioner, error) {
+	actionsMutex.RLock()
+	f := actionBuilders[a.Kind]
+	actionsMutex.RUnlock()
+
+	if f == nil {
+		return nil, fmt.Errorf(errUnknownAction, a.ID, a.Kind)
+	}
+
+	action, err := f(a)
+	if err != nil {
+		return nil, fmt.Errorf(errInvalidAction, a.ID, a.Kind, err)
+	}
+
+	d, ok := action.(describer)
+	if !ok {
+		return nil, fmt.Errorf(errInvalidAction, a.ID, a.Kind, ErrNotDescribable)
+	}
+
+	if len(a.Triggers) > 0 {
+		filters := make([]comp.SpinDataFilterer, len(a.Triggers))
+		for ix := range a.Triggers {
+			if filters[ix], err = buildFilter(&a.Triggers[ix]); err != nil {
+				return nil, fmt.Errorf(errInvalidAction, a.ID, a.Kind, err)
+			}
+		}
+		d.WithTriggerFilters(filters...)
+	}
+
+	d.Describe(a.ID, a.Name)
+	return action, nil
+}
+
+// exportAction exports the spin action to its declarative definition.
+// It returns nil if the action cannot be defined declaratively; e.g. it is of an unregistered kind, or it has filters.
+// The exported definition is verified by building it and comparing the result with the spin action.
+func exportAction(a comp.SpinActioner) *Action {
+	if f, ok := a.(filtered); a.Alternate() != nil || a.PlayerChoice() || (ok && f.HasFilters()) {
+		return nil
+	}
+
+	actionsMutex.RLock()
+	kinds := make([]string, 0, len(actionExporters))
+	for kind := range actionExporters {
+		if actionBuilders[kind] != nil {
+			kinds = append(kinds, kind)
+		}
+	}
+	sort.Strings(kinds)
+	exporters := make([]ActionExporter, len(kinds))
+	for ix := range kinds {
+		exporters[ix] = actionExporters[kinds[ix]]
+	}
+	actionsMutex.RUnlock()
+
+	for ix, kind := range kinds {
+		out := exporters[ix](a)
+		if out == nil {
+			continue
+		}
+
+		out.ID, out.Name, out.Kind = a.ID(), a.Name(), kind
+		b, err := buildAction(out)
+		if err != nil || b.Kind() != a.Kind() || b.Config() != a.Config() || b.Stage() != a.Stage() || b.Result() != a.Result() {
+			return nil
+		}
+		return out
+	}
+
+	return nil
+}
+
+// buildFilter builds the spin data filter from the declarative definition.
+func buildFilter(f *Filter) (comp.SpinDataFilterer, error) {
+	actionsMutex.RLock()
+	b := filterBuilders[f.Kind]
+	actionsMutex.RUnlock()
+
+	if b == nil {
+		return nil, fmt.Errorf(errUnknownFilter, f.Kind)
+	}
+	return b(f)
+}
+
+// describer is the interface for spin actions with a description and trigger filters.
+type describer interface {
+	Describe(id int, name string)
+	WithTriggerFilters(filters ...comp.SpinDataFilterer)
+}
+
+// filtered is the interface for spin actions which can tell if they have filters.
+type filtered interface {
+	HasFilters() bool
+}
+
+func (w *Weighting) build() (util.WeightedGenerator, error) {
+	if w == nil || len(w.Indexes) == 0 {
+		return nil, ErrInvalidWeighting
+	}
+	if len(w.Indexes) != len(w.Weights) {
+		return nil, ErrInvalidWeighting
+	}
+	return util.AcquireWeighting().AddWeights(w.Indexes, w.Weights), nil
+}
+
+// exportWeighting exports the weighted generator.
+// It returns nil if the weighting cannot be rebuilt identically.
+func exportWeighting(w util.WeightedGenerator) *Weighting {
+	n, ok := w.(*util.WeightingNoDedup)
+	if !ok {
+		return nil
+	}
+
+	out := &Weighting{Indexes: append(util.Indexes{}, n.Options()...), Weights: n.Weights()}
+	if b, err := out.build(); err != nil || b.String() != n.String() {
+		return nil
+	}
+	return out
+}
+
+func gridOffsets(in [][2]int) comp.GridOffsets {
+	out := make(comp.GridOffsets, len(in))
+	for ix := range in {
+		out[ix] = in[ix]
+	}
+	return out
+}
+
+func exportOffsets(in comp.GridOffsets) [][2]int {
+	out := make([][2]int, len(in))
+	for ix := range in {
+		out[ix] = in[ix]
+	}
+	return out
+}
+
+var (
+	actionsMutex   sync.RWMutex
+	actionBuilders = map[string]ActionBuilder{
+		"paylines": func(_ *Action) (comp.SpinActioner, error) {
+			return comp.NewPaylinesAction(), nil
+		},
+		"allPaylines": func(a *Action) (comp.SpinActioner, error) {
+			return comp.NewAllPaylinesAction(a.Highest), nil
+		},
+		"generateSymbol": func(a *Action) (comp.SpinActioner, error) {
+			if a.Symbol == 0 || len(a.Chances) == 0 {
+				return nil, ErrMissingParams
+			}
+			action := comp.NewGenerateSymbolAction(a.Symbol, a.Chances, a.Reels...)
+			if a.NoDupes {
+				action.GenerateNoDupes()
+			}
+			return action, nil
+		},
+		"generateShape": func(a *Action) (comp.SpinActioner, error) {
+			if len(a.Shape) == 0 || len(a.Centers) == 0 {
+				return nil, ErrMissingParams
+			}
+			weights, err := a.Weights.build()
+			if err != nil {
+				return nil, err
+			}
+			action := comp.NewGenerateShapeAction(a.Chance, gridOffsets(a.Shape), gridOffsets(a.Centers), weights)
+			if a.AllowPrevious {
+				action.AllowPrevious()
+			}
+			return action, nil
+		},
+		"scatterFreeSpins": func(a *Action) (comp.SpinActioner, error) {
+			if a.Symbol == 0 || a.Count == 0 || a.Spins == 0 {
+				return nil, ErrMissingParams
+			}
+			action := comp.NewScatterFreeSpinsAction(a.Spins, a.AltSymbols, a.Symbol, a.Count, a.BonusSymbol)
+			if len(a.MultiSymbols) > 0 {
+				action.WithMultiSymbols(a.MultiSymbols...)
+			}
+			return action, nil
+		},
+		"scatterPayout": func(a *Action) (comp.SpinActioner, error) {
+			if a.Symbol == 0 || a.Count == 0 {
+				return nil, ErrMissingParams
+			}
+			return comp.NewScatterPayoutAction(a.Symbol, a.Count, a.Payout), nil
+		},
+		"reduction": func(a *Action) (comp.SpinActioner, error) {
+			if a.Symbol == 0 || a.Count == 0 {
+				return nil, ErrMissingParams
+			}
+			return comp.NewReductionAction(a.Symbol, a.Count, a.Factor), nil
+		},
+		"division": func(a *Action) (comp.SpinActioner, error) {
+			if a.Symbol == 0 || a.Count == 0 || a.Factor == 0 {
+				return nil, ErrMissingParams
+			}
+			return comp.NewDivisionAction(a.Symbol, a.Count, a.Factor), nil
+		},
+		"roundFlagIncrease": func(a *Action) (comp.SpinActioner, error) {
+			return comp.NewRoundFlagIncreaseAction(a.Flag), nil
+		},
+		"roundFlagDecrease": func(a *Action) (comp.SpinActioner, error) {
+			return comp.NewRoundFlagDecreaseAction(a.Flag), nil
+		},
+		"roundFlagWeighted": func(a *Action) (comp.SpinActioner, error) {
+			weights, err := a.Weights.build()
+			if err != nil {
+				return nil, err
+			}
+			return comp.NewRoundFlagWeightedAction(a.Flag, weights), nil
+		},
+	}
+
+	actionExporters = map[string]ActionExporter{
+		"paylines": func(a comp.SpinActioner) *Action {
+			if p, ok := a.(*comp.PayoutAction); ok && p.HavePaylines() {
+				return &Action{}
+			}
+			return nil
+		},
+		"allPaylines": func(a comp.SpinActioner) *Action {
+			if p, ok := a.(*comp.PayoutAction); ok && p.HaveAllPaylines() {
+				return &Action{Highest: p.HighestPayout()}
+			}
+			return nil
+		},
+		"generateSymbol": func(a comp.SpinActioner) *Action {
+			r, ok := a.(*comp.ReviseAction)
+			if !ok || !r.HaveGenerateSymbol() || r.PreviousAllowed() || len(r.SpinKinds()) > 0 {
+				return nil
+			}
+			return &Action{Symbol: r.Symbol(), Chances: r.SymbolChances(), Reels: Uint8s(r.GenerateReels()), NoDupes: !r.DupesAllowed()}
+		},
+		"generateShape": func(a comp.SpinActioner) *Action {
+			r, ok := a.(*comp.ReviseAction)
+			if !ok || !r.HaveGenerateShape() || len(r.SpinKinds()) > 0 {
+				return nil
+			}
+			weights := exportWeighting(r.ShapeWeights())
+			if weights == nil {
+				return nil
+			}
+			return &Action{
+				Chance:        r.ShapeChance(),
+				Shape:         exportOffsets(r.ShapeGrid()),
+				Centers:       exportOffsets(r.ShapeCenters()),
+				Weights:       weights,
+				AllowPrevious: r.PreviousAllowed(),
+			}
+		},
+		"scatterFreeSpins": func(a comp.SpinActioner) *Action {
+			if s, ok := a.(*comp.ScatterAction); ok && s.HaveFreeSpins() {
+				return &Action{
+					Symbol:       s.Symbol(),
+					Count:        s.ScatterCount(),
+					Spins:        s.NrOfSpins(nil),
+					AltSymbols:   s.AltSymbols(),
+					BonusSymbol:  s.BonusSymbol(),
+					MultiSymbols: s.MultiSymbols(),
+				}
+			}
+			return nil
+		},
+		"scatterPayout": func(a comp.SpinActioner) *Action {
+			if s, ok := a.(*comp.ScatterAction); ok && s.CanPayout() {
+				return &Action{Symbol: s.Symbol(), Count: s.ScatterCount(), Payout: s.ScatterPayout()}
+			}
+			return nil
+		},
+		"reduction": func(a comp.SpinActioner) *Action {
+			if p, ok := a.(*comp.PenaltyAction); ok && p.HaveReduction() {
+				return &Action{Symbol: p.Symbol(), Count: p.Count(), Factor: p.Factor()}
+			}
+			return nil
+		},
+		"division": func(a comp.SpinActioner) *Action {
+			if p, ok := a.(*comp.PenaltyAction); ok && p.HaveDivision() {
+				return &Action{Symbol: p.Symbol(), Count: p.Count(), Factor: p.Factor()}
+			}
+			return nil
+		},
+		"roundFlagIncrease": func(a comp.SpinActioner) *Action {
+			if r, ok := a.(*comp.RoundFlagAction); ok && r.HaveIncrease() {
+				return &Action{Flag: r.Flag()}
+			}
+			return nil
+		},
+		"roundFlagDecrease": func(a comp.SpinActioner) *Action {
+			if r, ok := a.(*comp.RoundFlagAction); ok && r.HaveDecrease() {
+				return &Action{Flag: r.Flag()}
+			}
+			return nil
+		},
+		"roundFlagWeighted": func(a comp.SpinActioner) *Action {
+			if r, ok := a.(*comp.RoundFlagAction); ok && r.HaveWeights() {
+				if weights := exportWeighting(r.Weights()); weights != nil {
+					return &Action{Flag: r.Flag(), Weights: weights}
+				}
+			}
+			return nil
+		},
+	}
+
+	filterBuilders = map[string]FilterBuilder{
+		"onZeroPayouts": func(_ *Filter) (comp.SpinDataFilterer, error) {
+			return comp.OnZeroPayouts(), nil
+		},
+		"onSpinSequence": func(f *Filter) (comp.SpinDataFilterer, error) {
+			return comp.OnSpinSequence(uint64(f.Value)), nil
+		},
+		"onSpinSequenceAbove": func(f *Filter) (comp.SpinDataFilterer, error) {
+			return comp.OnSpinSequenceAbove(uint64(f.Value)), nil
+		},
+		"onSpinSequenceBelow": func(f *Filter) (comp.SpinDataFilterer, error) {
+			return comp.OnSpinSequenceBelow(uint64(f.Value)), nil
+		},
+		"onRoundFlagValue": func(f *Filter) (comp.SpinDataFilterer, error) {
+			return comp.OnRoundFlagValue(f.Flag, f.Value), nil
+		},
+		"onNotRoundFlagValue": func(f *Filter) (comp.SpinDataFilterer, error) {
+			return comp.OnNotRoundFlagValue(f.Flag, f.Value), nil
+		},
+		"onRoundFlagValues": func(f *Filter) (comp.SpinDataFilterer, error) {
+			if len(f.Values) == 0 {
+				return nil, ErrMissingParams
+			}
+			return comp.OnRoundFlagValues(f.Flag, f.Values...), nil
+		},
+		"onRoundFlagAbove": func(f *Filter) (comp.SpinDataFilterer, error) {
+			return comp.OnRoundFlagAbove(f.Flag, f.Value), nil
+		},
+		"onRoundFlagBelow": func(f *Filter) (comp.SpinDataFilterer, error) {
+			return comp.OnRoundFlagBelow(f.Flag, f.Value), nil
+		},
+	}
+)
@@ -0,0 +1,455 @@
+package definition
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	comp "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/components/slots"
+	game "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/games/slots"
+)
+
+// Game contains the slot machine game built from a definition for all of its RTP variants.
+// It is immutable once built, so it is safe to use across concurrent go-routines.
+type Game struct {
+	code   string
+	rtps   []int
+	params map[int]game.RegularParams
+}
+
+// Build validates the definition and builds the slot machine for all RTP variants.
+func (d *Definition) Build() (*Game, error) {
+	if err := d.Validate(); err != nil {
+		return nil, err
+	}
+
+	g := &Game{
+		code:   d.Game,
+		rtps:   make([]int, 0, len(d.Variants)),
+		params: make(map[int]game.RegularParams, len(d.Variants)),
+	}
+
+	for ix := range d.Variants {
+		v := &d.Variants[ix]
+		s, err := d.buildVariant(v)
+		if err != nil {
+			return nil, err
+		}
+		g.rtps = append(g.rtps, v.RTP)
+		g.params[v.RTP] = game.RegularParams{Slots: s}
+	}
+
+	return g, nil
+}
+
+// Slots builds the slot machine for the given RTP.
+func (d *Definition) Slots(rtp int) (*comp.Slots, error) {
+	if err := d.Validate(); err != nil {
+		return nil, err
+	}
+	for ix := range d.Variants {
+		if v := &d.Variants[ix]; v.RTP == rtp {
+			return d.buildVariant(v)
+		}
+	}
+	return nil, fmt.Errorf(errUnknownRTP, d.Game, rtp)
+}
+
+// Code returns the game code.
+func (g *Game) Code() string {
+	return g.code
+}
+
+// RTPs returns the supported RTPs in the order of the definition.
+func (g *Game) RTPs() []int {
+	return g.rtps
+}
+
+// Slots returns the slot machine for the given RTP or nil if the RTP is not supported.
+func (g *Game) Slots(rtp int) *comp.Slots {
+	if p, ok := g.params[rtp]; ok {
+		return p.Slots
+	}
+	return nil
+}
+
+// Params returns the parameters for a regular game with the given RTP.
+// The boolean is false if the RTP is not supported.
+func (g *Game) Params(rtp int) (game.RegularParams, bool) {
+	p, ok := g.params[rtp]
+	return p, ok
+}
+
+// New returns the game with the given RTP if possible.
+func (g *Game) New(rtp int) *game.Regular {
+	if p, ok := g.params[rtp]; ok {
+		return game.AcquireRegular(p)
+	}
+	return nil
+}
+
+// NewLogged returns the game with the given RTP if possible and activates PRNG logging.
+func (g *Game) NewLogged(rtp int) *game.Regular {
+	if p, ok := g.params[rtp]; ok {
+		p.PrngLog = true
+		return game.AcquireRegular(p)
+	}
+	return nil
+}
+
+// NewWithRoundFlags returns the game with the given RTP if possible and activates returning the round flags.
+func (g *Game) NewWithRoundFlags(rtp int) *game.Regular {
+	if p, ok := g.params[rtp]; ok {
+		p.ReturnFlags = true
+		return game.AcquireRegular(p)
+	}
+	return nil
+}
+
+func (d *Definition) buildVariant(v *Variant) (*comp.Slots, error) {
+	var src *comp.Slots
+	if d.Source != "" {
+		var err error
+		if src, err = getSource(d.Source, v.RTP); err != nil {
+			return nil, err
+		}
+	}
+
+	symbols, altSymbols := d.Symbols, d.AltSymbols
+	if len(v.Symbols) > 0 {
+		symbols = v.Symbols
+	}
+	if len(v.AltSymbols) > 0 {
+		altSymbols = v.AltSymbols
+	}
+
+	s1, err := buildSymbols(v.RTP, symbols, v.Weights)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case v.BonusWeights != nil:
+		w, err2 := v.BonusWeights.build()
+		if err2 != nil {
+			return nil, err2
+		}
+		s1.SetBonusWeights(w)
+	case src != nil && src.Symbols() != nil && src.Symbols().BonusWeights() != nil:
+		s1.SetBonusWeights(src.Symbols().BonusWeights())
+	}
+
+	target := v.Target
+	if target == 0 {
+		target = float64(v.RTP)
+	}
+
+	opts := make([]comp.SlotOption, 0, 32)
+	opts = append(opts, comp.Grid(d.Grid.Reels, d.Grid.Rows))
+	if len(d.Grid.Mask) > 0 {
+		opts = append(opts, comp.WithMask(d.Grid.Mask...))
+	}
+	if d.Grid.NoRepeat > 0 {
+		opts = append(opts, comp.NoRepeat(d.Grid.NoRepeat))
+	}
+
+	opts = append(opts, comp.WithSymbols(s1))
+	if len(altSymbols) > 0 {
+		s2, err2 := buildSymbols(v.RTP, altSymbols, v.AltWeights)
+		if err2 != nil {
+			return nil, err2
+		}
+		if src != nil && src.AltSymbols() != nil && src.AltSymbols().BonusWeights() != nil {
+			s2.SetBonusWeights(src.AltSymbols().BonusWeights())
+		}
+		opts = append(opts, comp.WithAltSymbols(s2))
+	}
+
+	direction, err := parseDirection(d.Paylines.Direction)
+	if err != nil {
+		return nil, err
+	}
+	if len(d.Paylines.Lines) > 0 {
+		paylines := make(comp.Paylines, len(d.Paylines.Lines))
+		for ix := range d.Paylines.Lines {
+			p := &d.Paylines.Lines[ix]
+			paylines[ix] = comp.NewPayline(p.ID, d.Grid.Rows, p.Rows...)
+		}
+		opts = append(opts, comp.WithPaylines(direction, d.Paylines.Highest, paylines...))
+	} else {
+		opts = append(opts, comp.PayDirections(direction))
+		if d.Paylines.Highest {
+			opts = append(opts, comp.HighestPayout())
+		}
+	}
+
+	opts = append(opts, d.Options.slotOptions()...)
+	opts = append(opts, comp.WithRTP(target))
+
+	if len(d.Flags) > 0 {
+		flags := make(comp.RoundFlags, len(d.Flags))
+		for ix := range d.Flags {
+			f := &d.Flags[ix]
+			flags[ix] = comp.NewRoundFlag(f.ID, f.Name)
+			if f.Export {
+				flags[ix].WithExport()
+			}
+		}
+		opts = append(opts, comp.WithRoundFlags(flags...))
+	}
+
+	first, free, firstBB, freeBB, err := d.buildStages(&v.Actions, src)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, comp.WithActions(first, free, firstBB, freeBB))
+
+	if src != nil {
+		if sp := src.Spinner(); sp != nil {
+			opts = append(opts, comp.WithSpinner(sp))
+		}
+		if rf := src.Refiller(); rf != nil {
+			opts = append(opts, comp.WithRefiller(rf))
+		}
+		if sel := src.ScriptedRoundSelector(); sel != nil {
+			opts = append(opts, comp.WithScriptedRoundSelector(sel))
+		}
+	}
+
+	return comp.NewSlots(opts...), nil
+}
+
+func (o *Options) slotOptions() []comp.SlotOption {
+	opts := make([]comp.SlotOption, 0, 16)
+
+	if o.MaxPayout > 0 {
+		opts = append(opts, comp.MaxPayout(o.MaxPayout))
+	}
+	switch {
+	case o.CascadingReels:
+		opts = append(opts, comp.CascadingReels(o.ClusterPays))
+	case o.ClusterPays:
+		opts = append(opts, comp.ClusterPays(true))
+	}
+	if o.DoubleSpin {
+		opts = append(opts, comp.DoubleSpin())
+	}
+	if o.PlayerChoice {
+		opts = append(opts, comp.WithPlayerChoice())
+	}
+	if o.RoundMultiplier {
+		opts = append(opts, comp.WithRoundMultiplier())
+	}
+	if o.MultiplierOnWildsOnly {
+		opts = append(opts, comp.WithMultiplierOnWildsOnly())
+	}
+	if o.ProgressMeter {
+		opts = append(opts, comp.WithProgressMeter())
+	}
+	if o.HotReelsAsBonusSymbol {
+		opts = append(opts, comp.HotReelsAsBonusSymbol())
+	}
+	if o.ReverseWin {
+		opts = append(opts, comp.WithReverseWin())
+	}
+	if o.BonusBuy {
+		opts = append(opts, comp.WithBonusBuy(o.BonusBuyFlag))
+	}
+	if o.SymbolsState {
+		opts = append(opts, comp.WithSymbolsState(o.ExcludeFromState...))
+	}
+	if o.GambleSteps > 0 {
+		opts = append(opts, comp.WithGamble(o.GambleMaxWin, o.GambleSteps))
+	}
+
+	return opts
+}
+
+func (d *Definition) buildStages(stages *ActionStages, src *comp.Slots) (first, free, firstBB, freeBB comp.SpinActions, err error) {
+	library := make(map[int]comp.SpinActioner, len(d.Actions))
+
+	build := func(refs []ActionRef) (comp.SpinActions, error) {
+		if len(refs) == 0 {
+			return nil, nil
+		}
+
+		out := make(comp.SpinActions, len(refs))
+		for ix := range refs {
+			ref := &refs[ix]
+
+			if ref.Ref != "" {
+				a, err2 := resolveRef(ref.Ref, src)
+				if err2 != nil {
+					return nil, err2
+				}
+				out[ix] = a
+				continue
+			}
+
+			// library actions are shared between the stages, just like the Go-defined games.
+			if a, ok := library[ref.ID]; ok {
+				out[ix] = a
+				continue
+			}
+
+			var found bool
+			for iy := range d.Actions {
+				if a := &d.Actions[iy]; a.ID == ref.ID {
+					action, err2 := buildAction(a)
+					if err2 != nil {
+						return nil, err2
+					}
+					library[ref.ID] = action
+					out[ix] = action
+					found = true
+					break
+				}
+			}
+			if !found {
+				return nil, fmt.Errorf(errUnknownActionID, ref.ID)
+			}
+		}
+		return out, nil
+	}
+
+	if first, err = build(stages.First); err != nil {
+		return
+	}
+	if free, err = build(stages.Free); err != nil {
+		return
+	}
+	if firstBB, err = build(stages.FirstBB); err != nil {
+		return
+	}
+	freeBB, err = build(stages.FreeBB)
+	return
+}
+
+// resolveRef resolves a reference to an action of the source game; e.g. "free/3".
+func resolveRef(ref string, src *comp.Slots) (comp.SpinActioner, error) {
+	if src == nil {
+		return nil, fmt.Errorf(errInvalidRef, ref)
+	}
+
+	stage, index, ok := strings.Cut(ref, "/")
+	if !ok {
+		return nil, fmt.Errorf(errInvalidRef, ref)
+	}
+
+	ix, err := strconv.Atoi(index)
+	if err != nil || ix < 0 {
+		return nil, fmt.Errorf(errInvalidRef, ref)
+	}
+
+	var list comp.SpinActions
+	switch stage {
+	case stageFirst:
+		list = src.ActionsFirst()
+	case stageFree:
+		list = src.ActionsFree()
+	case stageFirstBB:
+		list = src.ActionsFirstBB()
+	case stageFreeBB:
+		list = src.ActionsFreeBB()
+	}
+
+	if ix >= len(list) {
+		return nil, fmt.Errorf(errInvalidRef, ref)
+	}
+	return list[ix], nil
+}
+
+func buildSymbols(rtp int, symbols []Symbol, weights []Weights) (*comp.SymbolSet, error) {
+	out := make([]*comp.Symbol, len(symbols))
+
+	for ix := range symbols {
+		s := &symbols[ix]
+
+		kind, err := parseKind(s.Kind)
+		if err != nil {
+			return nil, fmt.Errorf(errInvalidKind, s.ID, s.Kind)
+		}
+
+		opts := make([]comp.SymbolOption, 0, 12)
+		opts = append(opts, comp.WithName(s.Name), comp.WithResource(s.Resource))
+		if len(s.WildFor) > 0 {
+			// WildFor changes the kind to Split, so it must come before an explicit kind.
+			opts = append(opts, comp.WildFor(s.WildFor...))
+		}
+		if s.Kind != "" {
+			opts = append(opts, comp.WithKind(kind))
+		}
+		if len(s.Payouts) > 0 {
+			opts = append(opts, comp.WithPayouts(s.Payouts...))
+		}
+		if len(s.ScatterPayouts) > 0 {
+			opts = append(opts, comp.WithScatterPayouts(s.ScatterPayouts...))
+		}
+		if s.Multiplier != 0 {
+			opts = append(opts, comp.WithMultiplier(s.Multiplier))
+		}
+		if s.Sticky {
+			opts = append(opts, comp.IsSticky())
+		}
+		if s.VaryMultiplier {
+			opts = append(opts, comp.VaryMultiplier())
+		}
+		if s.MorphInto != 0 {
+			opts = append(opts, comp.MorphInto(s.MorphInto))
+		}
+		if len(s.ClearPattern) > 0 {
+			opts = append(opts, comp.ClearPattern(s.ClearPattern...))
+		}
+
+		for iy := range weights {
+			if w := &weights[iy]; w.Symbol == s.ID {
+				opts = append(opts, comp.WithWeights(w.Reels...))
+				break
+			}
+		}
+
+		out[ix] = comp.NewSymbol(s.ID, opts...)
+	}
+
+	for ix := range weights {
+		var found bool
+		for iy := range symbols {
+			if symbols[iy].ID == weights[ix].Symbol {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf(errInvalidWeights, rtp, weights[ix].Symbol)
+		}
+	}
+
+	return comp.NewSymbolSet(out...), nil
+}
+
+func parseKind(kind string) (comp.SymbolKind, error) {
+	if kind == "" {
+		return comp.Standard, nil
+	}
+	if k, ok := comp.ParseSymbolKind(kind); ok {
+		return k, nil
+	}
+	return 0, fmt.Errorf(errInvalidKind, 0, kind)
+}
+
+func parseDirection(direction string) (comp.PayDirection, error) {
+	if direction == "" {
+		return comp.PayLTR, nil
+	}
+	if d, ok := comp.ParsePayDirection(direction); ok {
+		return d, nil
+	}
+	return 0, fmt.Errorf(errInvalidDirection, direction)
+}
+
+const (
+	stageFirst   = "first"
+	stageFree    = "free"
+	stageFirstBB = "firstBB"
+	stageFreeBB  = "freeBB"
+)
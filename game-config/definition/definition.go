@@ -0,0 +1,209 @@
+package definition
+
+import (
+	"fmt"
+
+	"github.com/goccy/go-json"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/utils"
+)
+
+// Definition contains the declarative definition of a slot machine game.
+// It can be loaded from JSON or YAML and builds the game for each of its RTP variants at load time.
+// The grid, paylines, options, symbols, reel weights, round flags and the spin actions of the kinds listed by
+// ActionKinds are defined as data. Everything else is taken from the Go-defined game registered under the Source name:
+// spin actions of other kinds or with filters (as references), the spinner, the refiller, the scripted round selector,
+// and bonus weights that are not defined. A definition with a Source is therefore not independent of the Go code.
+type Definition struct {
+	Game       string    `json:"game" yaml:"game"`                                 // game code; e.g. "crw".
+	Name       string    `json:"name,omitempty" yaml:"name,omitempty"`             // descriptive name of the game.
+	Source     string    `json:"source,omitempty" yaml:"source,omitempty"`         // name of the Go-defined game supplying non-declarative components.
+	Grid       Grid      `json:"grid" yaml:"grid"`                                 // grid dimensions.
+	Paylines   Paylines  `json:"paylines,omitempty" yaml:"paylines,omitempty"`     // payline configuration.
+	Options    Options   `json:"options,omitempty" yaml:"options,omitempty"`       // slot machine options.
+	Symbols    []Symbol  `json:"symbols" yaml:"symbols"`                           // the regular symbol set.
+	AltSymbols []Symbol  `json:"altSymbols,omitempty" yaml:"altSymbols,omitempty"` // the (optional) alternate symbol set.
+	Flags      []Flag    `json:"flags,omitempty" yaml:"flags,omitempty"`           // round flags.
+	Actions    []Action  `json:"actions,omitempty" yaml:"actions,omitempty"`       // library of declarative spin actions.
+	Variants   []Variant `json:"variants" yaml:"variants"`                         // RTP variants.
+}
+
+// Grid contains the grid dimensions of the slot machine.
+type Grid struct {
+	Reels    uint8  `json:"reels" yaml:"reels"`
+	Rows     uint8  `json:"rows" yaml:"rows"`
+	Mask     Uint8s `json:"mask,omitempty" yaml:"mask,omitempty"`
+	NoRepeat uint8  `json:"noRepeat,omitempty" yaml:"noRepeat,omitempty"`
+}
+
+// Paylines contains the payline configuration of the slot machine.
+type Paylines struct {
+	Direction string    `json:"direction,omitempty" yaml:"direction,omitempty"` // "ltr" (default), "rtl", "both", "cluster" or "scatter".
+	Highest   bool      `json:"highest,omitempty" yaml:"highest,omitempty"`
+	Lines     []Payline `json:"lines,omitempty" yaml:"lines,omitempty"`
+}
+
+// Payline contains the row for each reel of a single payline.
+type Payline struct {
+	ID   uint8  `json:"id" yaml:"id"`
+	Rows Uint8s `json:"rows" yaml:"rows"`
+}
+
+// Options contains the optional features of the slot machine.
+type Options struct {
+	MaxPayout             float64       `json:"maxPayout,omitempty" yaml:"maxPayout,omitempty"`
+	CascadingReels        bool          `json:"cascadingReels,omitempty" yaml:"cascadingReels,omitempty"`
+	ClusterPays           bool          `json:"clusterPays,omitempty" yaml:"clusterPays,omitempty"`
+	DoubleSpin            bool          `json:"doubleSpin,omitempty" yaml:"doubleSpin,omitempty"`
+	PlayerChoice          bool          `json:"playerChoice,omitempty" yaml:"playerChoice,omitempty"`
+	RoundMultiplier       bool          `json:"roundMultiplier,omitempty" yaml:"roundMultiplier,omitempty"`
+	MultiplierOnWildsOnly bool          `json:"multiplierOnWildsOnly,omitempty" yaml:"multiplierOnWildsOnly,omitempty"`
+	ProgressMeter         bool          `json:"progressMeter,omitempty" yaml:"progressMeter,omitempty"`
+	HotReelsAsBonusSymbol bool          `json:"hotReelsAsBonusSymbol,omitempty" yaml:"hotReelsAsBonusSymbol,omitempty"`
+	ReverseWin            bool          `json:"reverseWin,omitempty" yaml:"reverseWin,omitempty"`
+	BonusBuy              bool          `json:"bonusBuy,omitempty" yaml:"bonusBuy,omitempty"`
+	BonusBuyFlag          int           `json:"bonusBuyFlag,omitempty" yaml:"bonusBuyFlag,omitempty"`
+	SymbolsState          bool          `json:"symbolsState,omitempty" yaml:"symbolsState,omitempty"`
+	ExcludeFromState      utils.Indexes `json:"excludeFromState,omitempty" yaml:"excludeFromState,omitempty"`
+	GambleMaxWin          float64       `json:"gambleMaxWin,omitempty" yaml:"gambleMaxWin,omitempty"` // zero means capped by maxPayout only.
+	GambleSteps           uint8         `json:"gambleSteps,omitempty" yaml:"gambleSteps,omitempty"`   // zero means no gamble after a win.
+}
+
+// Symbol contains the characteristics of a symbol.
+// The reel weights are defined per RTP variant.
+type Symbol struct {
+	ID             utils.Index   `json:"id" yaml:"id"`
+	Name           string        `json:"name,omitempty" yaml:"name,omitempty"`
+	Resource       string        `json:"resource,omitempty" yaml:"resource,omitempty"`
+	Kind           string        `json:"kind,omitempty" yaml:"kind,omitempty"` // e.g. "Standard" (default), "Wild", "Scatter", "Wild-Scatter".
+	Payouts        []float64     `json:"payouts,omitempty" yaml:"payouts,omitempty"`
+	ScatterPayouts []float64     `json:"scatterPayouts,omitempty" yaml:"scatterPayouts,omitempty"`
+	Multiplier     float64       `json:"multiplier,omitempty" yaml:"multiplier,omitempty"` // zero means the default of 1.0.
+	Sticky         bool          `json:"sticky,omitempty" yaml:"sticky,omitempty"`
+	VaryMultiplier bool          `json:"varyMultiplier,omitempty" yaml:"varyMultiplier,omitempty"`
+	WildFor        utils.Indexes `json:"wildFor,omitempty" yaml:"wildFor,omitempty"`
+	MorphInto      utils.Index   `json:"morphInto,omitempty" yaml:"morphInto,omitempty"`
+	ClearPattern   []int         `json:"clearPattern,omitempty" yaml:"clearPattern,omitempty"`
+}
+
+// Flag contains the details of a round flag.
+type Flag struct {
+	ID     int    `json:"id" yaml:"id"`
+	Name   string `json:"name" yaml:"name"`
+	Export bool   `json:"export,omitempty" yaml:"export,omitempty"`
+}
+
+// Variant contains the details for a single RTP variant of the game.
+type Variant struct {
+	RTP          int          `json:"rtp" yaml:"rtp"`                                       // RTP as used to select the variant; e.g. 96.
+	Target       float64      `json:"target,omitempty" yaml:"target,omitempty"`             // official target RTP; defaults to RTP.
+	Symbols      []Symbol     `json:"symbols,omitempty" yaml:"symbols,omitempty"`           // overrides the regular symbol set if the paytable differs.
+	AltSymbols   []Symbol     `json:"altSymbols,omitempty" yaml:"altSymbols,omitempty"`     // overrides the alternate symbol set if the paytable differs.
+	Weights      []Weights    `json:"weights" yaml:"weights"`                               // reel weights for the regular symbols.
+	AltWeights   []Weights    `json:"altWeights,omitempty" yaml:"altWeights,omitempty"`     // reel weights for the alternate symbols.
+	BonusWeights *Weighting   `json:"bonusWeights,omitempty" yaml:"bonusWeights,omitempty"` // weighting for selecting a bonus symbol.
+	Actions      ActionStages `json:"actions" yaml:"actions"`                               // spin actions by stage.
+}
+
+// Weights contains the reel weights for a single symbol.
+type Weights struct {
+	Symbol utils.Index `json:"symbol" yaml:"symbol"`
+	Reels  []float64   `json:"reels" yaml:"reels"`
+}
+
+// Weighting contains the details to build a weighted generator.
+type Weighting struct {
+	Indexes utils.Indexes `json:"indexes" yaml:"indexes"`
+	Weights []float64     `json:"weights" yaml:"weights"`
+}
+
+// ActionStages contains the spin actions for each of the game stages.
+type ActionStages struct {
+	First   []ActionRef `json:"first,omitempty" yaml:"first,omitempty"`
+	Free    []ActionRef `json:"free,omitempty" yaml:"free,omitempty"`
+	FirstBB []ActionRef `json:"firstBB,omitempty" yaml:"firstBB,omitempty"`
+	FreeBB  []ActionRef `json:"freeBB,omitempty" yaml:"freeBB,omitempty"`
+}
+
+// ActionRef refers to a spin action.
+// If Ref is set, the action is taken from the source game; e.g. "free/3" is the fourth action in the free spins
+// stage of the source game for the same RTP. Otherwise, ID refers to an action in the actions library.
+// Name and Kind are informational only for actions taken from the source game.
+type ActionRef struct {
+	ID   int    `json:"id,omitempty" yaml:"id,omitempty"`
+	Ref  string `json:"ref,omitempty" yaml:"ref,omitempty"`
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+	Kind string `json:"kind,omitempty" yaml:"kind,omitempty"`
+}
+
+// Action contains the declarative definition of a spin action.
+// Which of the parameters are used depends on the kind of action. See actions.go for the supported kinds.
+type Action struct {
+	ID            int           `json:"id" yaml:"id"`
+	Name          string        `json:"name" yaml:"name"`
+	Kind          string        `json:"kind" yaml:"kind"`
+	Symbol        utils.Index   `json:"symbol,omitempty" yaml:"symbol,omitempty"`
+	Count         uint8         `json:"count,omitempty" yaml:"count,omitempty"`
+	Spins         uint8         `json:"spins,omitempty" yaml:"spins,omitempty"`
+	Flag          int           `json:"flag,omitempty" yaml:"flag,omitempty"`
+	Factor        float64       `json:"factor,omitempty" yaml:"factor,omitempty"`
+	Payout        float64       `json:"payout,omitempty" yaml:"payout,omitempty"`
+	Chance        float64       `json:"chance,omitempty" yaml:"chance,omitempty"`
+	Chances       []float64     `json:"chances,omitempty" yaml:"chances,omitempty"`
+	Reels         Uint8s        `json:"reels,omitempty" yaml:"reels,omitempty"`
+	Shape         [][2]int      `json:"shape,omitempty" yaml:"shape,omitempty"`
+	Centers       [][2]int      `json:"centers,omitempty" yaml:"centers,omitempty"`
+	Weights       *Weighting    `json:"weights,omitempty" yaml:"weights,omitempty"`
+	AltSymbols    bool          `json:"altSymbols,omitempty" yaml:"altSymbols,omitempty"`
+	BonusSymbol   bool          `json:"bonusSymbol,omitempty" yaml:"bonusSymbol,omitempty"`
+	Highest       bool          `json:"highest,omitempty" yaml:"highest,omitempty"`
+	NoDupes       bool          `json:"noDupes,omitempty" yaml:"noDupes,omitempty"`
+	AllowPrevious bool          `json:"allowPrevious,omitempty" yaml:"allowPrevious,omitempty"`
+	Triggers      []Filter      `json:"triggers,omitempty" yaml:"triggers,omitempty"`
+	MultiSymbols  utils.Indexes `json:"multiSymbols,omitempty" yaml:"multiSymbols,omitempty"`
+}
+
+// Filter contains the declarative definition of a spin data filter.
+// See actions.go for the supported kinds.
+type Filter struct {
+	Kind   string `json:"kind" yaml:"kind"`
+	Flag   int    `json:"flag,omitempty" yaml:"flag,omitempty"`
+	Value  int    `json:"value,omitempty" yaml:"value,omitempty"`
+	Values []int  `json:"values,omitempty" yaml:"values,omitempty"`
+}
+
+// Uint8s is a list of small numbers.
+// It is encoded as a JSON array of numbers instead of the default base64 string, so it remains human-editable.
+type Uint8s []uint8
+
+// MarshalJSON implements the json.Marshaler interface.
+func (u Uint8s) MarshalJSON() ([]byte, error) {
+	if u == nil {
+		return []byte("null"), nil
+	}
+	ints := make([]int, len(u))
+	for ix := range u {
+		ints[ix] = int(u[ix])
+	}
+	return json.Marshal(ints)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (u *Uint8s) UnmarshalJSON(data []byte) error {
+	var ints []int
+	if err := json.Unmarshal(data, &ints); err != nil {
+		return err
+	}
+	if ints == nil {
+		*u = nil
+		return nil
+	}
+	*u = make(Uint8s, len(ints))
+	for ix := range ints {
+		if ints[ix] < 0 || ints[ix] > 255 {
+			return fmt.Errorf(errInvalidUint8, ints[ix])
+		}
+		(*u)[ix] = uint8(ints[ix])
+	}
+	return nil
+}
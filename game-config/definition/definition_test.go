@@ -0,0 +1,503 @@
+package definition
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/encode/zjson"
+
+	comp "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/components/slots"
+	game "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/games/slots"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/interfaces"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/rng"
+	util "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/utils"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/slots/crw"
+)
+
+// crwYAML is a fully declarative definition of the crw game, which must produce identical results to the Go-defined game.
+const crwYAML = `
+game: crw
+name: declarative crw
+grid: {reels: 5, rows: 1}
+paylines:
+  direction: ltr
+  lines:
+    - {id: 1, rows: [0, 0, 0, 0, 0]}
+options: {maxPayout: 1000, reverseWin: true}
+symbols:
+  - {id: 1, name: Cherries, resource: h3, payouts: [0, 0, 0.5, 5, 10]}
+  - {id: 2, name: BAR, resource: h2, payouts: [0, 0, 5, 10, 25]}
+  - {id: 3, name: "7", resource: h1, payouts: [0, 0, 50, 100, 1000]}
+  - {id: 4, name: Gift, resource: scatter, kind: Scatter}
+  - {id: 5, name: Poison, resource: poison}
+  - {id: 6, name: Skull, resource: skull}
+flags:
+  - {id: 0, name: free spin count}
+actions:
+  - {id: 1, name: insert scatters, kind: generateSymbol, symbol: 4, chances: [50, 27.5, 7.5, 100, 100]}
+  - id: 11
+    name: award full bonus on first free spin
+    kind: generateShape
+    chance: 100
+    shape: [[0, 0], [1, 0], [2, 0], [3, 0], [4, 0]]
+    centers: [[0, 0]]
+    weights: {indexes: [3], weights: [100]}
+    allowPrevious: true
+    triggers: [{kind: onSpinSequence, value: 2}]
+  - {id: 21, name: inject poison, kind: generateSymbol, symbol: 5, chances: [50], triggers: [{kind: onSpinSequenceAbove, value: 2}]}
+  - {id: 22, name: impose 10x penalty (poison), kind: reduction, symbol: 5, count: 1, factor: 10}
+  - {id: 25, name: inject skull, kind: generateSymbol, symbol: 6, chances: [59.2], triggers: [{kind: onSpinSequenceAbove, value: 2}]}
+  - {id: 26, name: impose 50% penalty (skull), kind: division, symbol: 6, count: 1, factor: 2}
+  - {id: 31, name: award regular payouts, kind: paylines}
+  - {id: 41, name: award 10 free spins from 5 scatters, kind: scatterFreeSpins, symbol: 4, count: 5, spins: 10}
+  - {id: 91, name: count number of free spins (flag 0), kind: roundFlagIncrease, flag: 0}
+variants:
+  - rtp: 96
+    weights:
+      - {symbol: 1, reels: [155, 95, 155, 95, 155]}
+      - {symbol: 2, reels: [75, 85, 70, 85, 75]}
+      - {symbol: 3, reels: [30, 15, 40, 20, 35]}
+      - {symbol: 4, reels: [0, 0, 0, 0, 0]}
+      - {symbol: 5, reels: [0, 0, 0, 0, 0]}
+      - {symbol: 6, reels: [0, 0, 0, 0, 0]}
+    actions:
+      first: [{id: 1}, {id: 31}, {id: 41}]
+      free: [{id: 11}, {id: 21}, {id: 25}, {id: 31}, {id: 22}, {id: 26}, {id: 91}]
+`
+
+func TestLoad(t *testing.T) {
+	t.Run("load yaml", func(t *testing.T) {
+		d, err := Load([]byte(crwYAML))
+		require.NoError(t, err)
+		require.NotNil(t, d)
+
+		assert.Equal(t, "crw", d.Game)
+		assert.Equal(t, uint8(5), d.Grid.Reels)
+		assert.Equal(t, 6, len(d.Symbols))
+		assert.Equal(t, "Scatter", d.Symbols[3].Kind)
+		assert.Equal(t, 9, len(d.Actions))
+		assert.Equal(t, 1, len(d.Variants))
+		assert.EqualValues(t, [][2]int{{0, 0}}, d.Actions[1].Centers)
+	})
+
+	t.Run("yaml to json and back", func(t *testing.T) {
+		d, err := Load([]byte(crwYAML))
+		require.NoError(t, err)
+
+		j, err := d.JSON()
+		require.NoError(t, err)
+		require.True(t, isJSON(j))
+
+		d2, err := Load(j)
+		require.NoError(t, err)
+		assert.EqualValues(t, d, d2)
+
+		y, err := d2.YAML()
+		require.NoError(t, err)
+
+		d3, err := Load(y)
+		require.NoError(t, err)
+		assert.EqualValues(t, d, d3)
+	})
+
+	t.Run("files", func(t *testing.T) {
+		d, err := Load([]byte(crwYAML))
+		require.NoError(t, err)
+
+		dir := t.TempDir()
+		for _, name := range []string{"crw.json", "crw.yaml", "crw.yml"} {
+			path := filepath.Join(dir, name)
+			require.NoError(t, d.WriteFile(path))
+
+			d2, err2 := LoadFile(path)
+			require.NoError(t, err2)
+			assert.EqualValues(t, d, d2)
+		}
+
+		require.Error(t, d.WriteFile(filepath.Join(dir, "crw.txt")))
+
+		_, err = LoadFile(filepath.Join(dir, "crw.txt"))
+		require.Error(t, err)
+
+		_, err = LoadFile(filepath.Join(dir, "missing.json"))
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		name   string
+		modify func(d *Definition)
+		err    error
+	}{
+		{name: "ok", modify: func(_ *Definition) {}},
+		{name: "no game", modify: func(d *Definition) { d.Game = "" }, err: ErrMissingGame},
+		{name: "no reels", modify: func(d *Definition) { d.Grid.Reels = 0 }, err: ErrInvalidGrid},
+		{name: "bad mask", modify: func(d *Definition) { d.Grid.Mask = []uint8{1, 1} }, err: ErrInvalidGrid},
+		{name: "no symbols", modify: func(d *Definition) { d.Symbols = nil }, err: ErrMissingSymbols},
+		{name: "no variants", modify: func(d *Definition) { d.Variants = nil }, err: ErrMissingVariants},
+		{name: "duplicate symbol", modify: func(d *Definition) { d.Symbols[1].ID = 1 }},
+		{name: "invalid symbol", modify: func(d *Definition) { d.Symbols[1].ID = 100 }},
+		{name: "invalid kind", modify: func(d *Definition) { d.Symbols[1].Kind = "Joker" }},
+		{name: "invalid direction", modify: func(d *Definition) { d.Paylines.Direction = "up" }},
+		{name: "invalid payline", modify: func(d *Definition) { d.Paylines.Lines[0].Rows[2] = 1 }},
+		{name: "duplicate action", modify: func(d *Definition) { d.Actions[1].ID = 1 }},
+		{name: "duplicate rtp", modify: func(d *Definition) { d.Variants = append(d.Variants, d.Variants[0]) }},
+		{name: "invalid weights", modify: func(d *Definition) { d.Variants[0].Weights[0].Reels = []float64{1} }},
+		{name: "negative weights", modify: func(d *Definition) { d.Variants[0].Weights[0].Reels[0] = -1 }},
+		{name: "unknown action", modify: func(d *Definition) { d.Variants[0].Actions.First[0].ID = 99 }},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := Load([]byte(crwYAML))
+			require.NoError(t, err)
+
+			tc.modify(d)
+			err = d.Validate()
+
+			if tc.name == "ok" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestBuildFail(t *testing.T) {
+	testCases := []struct {
+		name   string
+		modify func(d *Definition)
+	}{
+		{name: "unknown action kind", modify: func(d *Definition) { d.Actions[0].Kind = "magic" }},
+		{name: "missing params", modify: func(d *Definition) { d.Actions[0].Symbol = 0 }},
+		{name: "unknown filter", modify: func(d *Definition) { d.Actions[1].Triggers[0].Kind = "onMagic" }},
+		{name: "invalid weighting", modify: func(d *Definition) { d.Actions[1].Weights = nil }},
+		{name: "unknown source", modify: func(d *Definition) { d.Source = "xyz" }},
+		{name: "ref without source", modify: func(d *Definition) { d.Variants[0].Actions.First[0].Ref = "first/0" }},
+		{name: "unknown rtp", modify: func(d *Definition) { d.Variants[0].RTP = 94 }},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := Load([]byte(crwYAML))
+			require.NoError(t, err)
+
+			tc.modify(d)
+
+			_, err = d.Slots(96)
+			require.Error(t, err)
+
+			if tc.name != "unknown rtp" {
+				g, err2 := d.Build()
+				require.Error(t, err2)
+				require.Nil(t, g)
+			}
+		})
+	}
+}
+
+func TestResolveRef(t *testing.T) {
+	src := crw.New(96).Slots()
+
+	testCases := []struct {
+		name string
+		ref  string
+		want comp.SpinActioner
+		fail bool
+	}{
+		{name: "first/0", ref: "first/0", want: src.ActionsFirst()[0]},
+		{name: "free/2", ref: "free/2", want: src.ActionsFree()[2]},
+		{name: "no stage", ref: "3", fail: true},
+		{name: "bad index", ref: "free/x", fail: true},
+		{name: "negative index", ref: "free/-1", fail: true},
+		{name: "out of range", ref: "free/99", fail: true},
+		{name: "empty stage", ref: "firstBB/0", fail: true},
+		{name: "unknown stage", ref: "other/0", fail: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a, err := resolveRef(tc.ref, src)
+			if tc.fail {
+				require.Error(t, err)
+				require.Nil(t, a)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.want, a)
+			}
+		})
+	}
+}
+
+func TestBuild(t *testing.T) {
+	t.Run("declarative crw", func(t *testing.T) {
+		d, err := Load([]byte(crwYAML))
+		require.NoError(t, err)
+
+		g, err := d.Build()
+		require.NoError(t, err)
+		require.NotNil(t, g)
+
+		assert.Equal(t, "crw", g.Code())
+		assert.Equal(t, []int{96}, g.RTPs())
+		assert.Nil(t, g.Slots(94))
+		assert.Nil(t, g.New(94))
+		assert.Nil(t, g.NewLogged(94))
+		assert.Nil(t, g.NewWithRoundFlags(94))
+
+		_, ok := g.Params(94)
+		assert.False(t, ok)
+
+		p, ok := g.Params(96)
+		require.True(t, ok)
+		require.NotNil(t, p.Slots)
+
+		s := g.Slots(96)
+		require.NotNil(t, s)
+		assert.Equal(t, 96.0, s.RTP())
+		assert.Equal(t, 1000.0, s.MaxPayout())
+		assert.True(t, s.ReverseWin())
+		assert.Equal(t, 3, len(s.ActionsFirst()))
+		assert.Equal(t, 7, len(s.ActionsFree()))
+		assert.Equal(t, s.ActionsFirst()[1], s.ActionsFree()[3])
+
+		g2 := g.NewLogged(96)
+		require.NotNil(t, g2)
+		g2.Release()
+
+		g3 := g.NewWithRoundFlags(96)
+		require.NotNil(t, g3)
+		g3.Release()
+
+		assert.False(t, s.Gamble())
+	})
+
+	t.Run("gamble", func(t *testing.T) {
+		d, err := Load([]byte(strings.Replace(crwYAML, "reverseWin: true}", "reverseWin: true, gambleMaxWin: 500, gambleSteps: 5}", 1)))
+		require.NoError(t, err)
+
+		g, err := d.Build()
+		require.NoError(t, err)
+
+		s := g.Slots(96)
+		require.NotNil(t, s)
+		assert.True(t, s.Gamble())
+		assert.Equal(t, 500.0, s.GambleMaxWin())
+		assert.Equal(t, uint8(5), s.GambleSteps())
+	})
+}
+
+func TestRoundTrip(t *testing.T) {
+	RegisterSource("crw", func(rtp int) *comp.Slots {
+		if g := crw.New(rtp); g != nil {
+			defer g.Release()
+			return g.Slots()
+		}
+		return nil
+	})
+
+	t.Run("declarative crw", func(t *testing.T) {
+		d, err := Load([]byte(crwYAML))
+		require.NoError(t, err)
+
+		g, err := d.Build()
+		require.NoError(t, err)
+
+		compareRounds(t, func() *game.Regular { return crw.New(96) }, func() *game.Regular { return g.New(96) }, 20000)
+	})
+
+	t.Run("exported crw", func(t *testing.T) {
+		d, err := Export("crw", "crw", 96)
+		require.NoError(t, err)
+		require.NotNil(t, d)
+
+		assert.Equal(t, "crw", d.Source)
+		assert.Equal(t, 6, len(d.Symbols))
+		assert.Equal(t, 3, len(d.Variants[0].Actions.First))
+		assert.Equal(t, ActionRef{ID: 1}, d.Variants[0].Actions.First[0])
+		assert.Equal(t, "free/0", d.Variants[0].Actions.Free[0].Ref)
+
+		// the actions without filters are exported as data, exactly as in the fully declarative definition.
+		want, err := Load([]byte(crwYAML))
+		require.NoError(t, err)
+		require.Equal(t, 6, len(d.Actions))
+		for ix := range d.Actions {
+			for iy := range want.Actions {
+				if want.Actions[iy].ID == d.Actions[ix].ID {
+					assert.Equal(t, want.Actions[iy], d.Actions[ix])
+				}
+			}
+		}
+
+		j, err := d.JSON()
+		require.NoError(t, err)
+
+		d2, err := Load(j)
+		require.NoError(t, err)
+
+		g, err := d2.Build()
+		require.NoError(t, err)
+
+		compareRounds(t, func() *game.Regular { return crw.New(96) }, func() *game.Regular { return g.New(96) }, 20000)
+	})
+
+	t.Run("export fail", func(t *testing.T) {
+		d, err := Export("crw", "crw", 94)
+		require.Error(t, err)
+		require.Nil(t, d)
+
+		d, err = Export("crw", "crw")
+		require.ErrorIs(t, err, ErrMissingVariants)
+		require.Nil(t, d)
+	})
+}
+
+func TestExportAction(t *testing.T) {
+	weights := util.AcquireWeighting().AddWeights(util.Indexes{1, 2, 3}, []float64{60, 30, 10})
+
+	filtered := comp.NewGenerateSymbolAction(5, []float64{50})
+	filtered.WithTriggerFilters(comp.OnSpinSequenceAbove(2))
+
+	rescheduled := comp.NewPaylinesAction()
+	rescheduled.WithStage(comp.ExtraPayouts)
+
+	testCases := []struct {
+		name   string
+		action comp.SpinActioner
+		want   *Action
+	}{
+		{name: "paylines", action: comp.NewPaylinesAction(), want: &Action{Kind: "paylines"}},
+		{name: "all paylines", action: comp.NewAllPaylinesAction(true), want: &Action{Kind: "allPaylines", Highest: true}},
+		{
+			name:   "generate symbol",
+			action: comp.NewGenerateSymbolAction(4, []float64{50, 25}, 2, 3, 4).GenerateNoDupes(),
+			want:   &Action{Kind: "generateSymbol", Symbol: 4, Chances: []float64{50, 25}, Reels: Uint8s{2, 3, 4}, NoDupes: true},
+		},
+		{
+			name:   "scatter free spins",
+			action: comp.NewScatterFreeSpinsAction(10, true, 9, 3, true).WithMultiSymbols(10),
+			want:   &Action{Kind: "scatterFreeSpins", Symbol: 9, Count: 3, Spins: 10, AltSymbols: true, BonusSymbol: true, MultiSymbols: util.Indexes{10}},
+		},
+		{name: "scatter payout", action: comp.NewScatterPayoutAction(9, 3, 7.5), want: &Action{Kind: "scatterPayout", Symbol: 9, Count: 3, Payout: 7.5}},
+		{name: "reduction", action: comp.NewReductionAction(5, 1, 10), want: &Action{Kind: "reduction", Symbol: 5, Count: 1, Factor: 10}},
+		{name: "division", action: comp.NewDivisionAction(6, 1, 2), want: &Action{Kind: "division", Symbol: 6, Count: 1, Factor: 2}},
+		{name: "round flag decrease", action: comp.NewRoundFlagDecreaseAction(2), want: &Action{Kind: "roundFlagDecrease", Flag: 2}},
+		{
+			name:   "round flag weighted",
+			action: comp.NewRoundFlagWeightedAction(1, weights),
+			want:   &Action{Kind: "roundFlagWeighted", Flag: 1, Weights: &Weighting{Indexes: util.Indexes{1, 2, 3}, Weights: []float64{60, 30, 10}}},
+		},
+		{name: "filtered", action: filtered},
+		{name: "alternate", action: comp.NewScatterPayoutAction(9, 4, 20).WithAlternate(comp.NewScatterPayoutAction(9, 3, 5))},
+		{name: "allow previous", action: comp.NewGenerateSymbolAction(4, []float64{50}).AllowPrevious()},
+		{name: "rescheduled", action: rescheduled},
+		{name: "not registered", action: comp.NewGenerateBonusAction(5, []float64{0, 10, 10})},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, exportAction(tc.action))
+		})
+	}
+}
+
+func TestExportActions(t *testing.T) {
+	paylines := comp.NewPaylinesAction()
+	paylines.Describe(1, "paylines")
+	all := comp.NewAllPaylinesAction(false)
+	all.Describe(1, "all paylines")
+
+	d := &Definition{}
+	refs := d.exportActions(stageFree, comp.SpinActions{paylines, paylines, all})
+
+	// the id of the last action is already used in the library, so it refers to the source game.
+	want := []ActionRef{{ID: 1}, {ID: 1}, {ID: 1, Ref: "free/2", Name: "all paylines", Kind: "PayoutAction"}}
+	assert.Equal(t, want, refs)
+	assert.Equal(t, []Action{{ID: 1, Name: "paylines", Kind: "paylines"}}, d.Actions)
+}
+
+// compareRounds plays the given number of rounds with both games using an identical PRNG sequence.
+// It fails the test if any of the results differ.
+func compareRounds(t *testing.T, want, got func() *game.Regular, count int) {
+	res1 := playRounds(t, want, count)
+	res2 := playRounds(t, got, count)
+
+	for ix := range res1 {
+		require.Equal(t, len(res1[ix]), len(res2[ix]), "round %d", ix)
+		for iy := range res1[ix] {
+			require.True(t, bytes.Equal(res1[ix][iy], res2[ix][iy]), "round %d result %d", ix, iy)
+		}
+	}
+}
+
+// playRounds plays the given number of rounds with a fixed PRNG sequence and returns the encoded results.
+// The games are played one after the other, as games built from the same slot machine share their spin actions.
+func playRounds(t *testing.T, acquire func() *game.Regular, count int) [][][]byte {
+	rng.AcquireRNG = func() interfaces.Generator { return newSeeded(12345) }
+	g := acquire()
+	require.NotNil(t, g)
+	defer g.Release()
+
+	out := make([][][]byte, count)
+	for ix := range out {
+		res := g.Round(0)
+		out[ix] = make([][]byte, len(res))
+		for iy := range res {
+			enc := zjson.AcquireEncoder(1024)
+			enc.Object(res[iy])
+			out[ix][iy] = append([]byte{}, enc.Bytes()...)
+			enc.Release()
+		}
+	}
+	return out
+}
+
+// seeded is a deterministic PRNG (splitmix64) to play rounds with a fixed PRNG sequence.
+type seeded struct {
+	state uint64
+}
+
+func newSeeded(seed uint64) *seeded {
+	return &seeded{state: seed}
+}
+
+func (s *seeded) ReturnToPool() {}
+
+func (s *seeded) Uint64() uint64 {
+	s.state += 0x9e3779b97f4a7c15
+	z := s.state
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+func (s *seeded) Uint32() uint32 {
+	return uint32(s.Uint64() >> 32)
+}
+
+func (s *seeded) IntN(n int) int {
+	return int(s.Uint64() % uint64(n))
+}
+
+func (s *seeded) IntsN(n int, out []int) {
+	for ix := range out {
+		out[ix] = s.IntN(n)
+	}
+}
+
+func init() {
+	rng.AcquireRNG = func() interfaces.Generator { return newSeeded(1) }
+}
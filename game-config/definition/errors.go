@@ -0,0 +1,37 @@
+package definition
+
+import (
+	"errors"
+)
+
+var (
+	ErrMissingGame         = errors.New("missing game code")
+	ErrInvalidGrid         = errors.New("invalid grid dimensions")
+	ErrMissingSymbols      = errors.New("missing symbols")
+	ErrMissingVariants     = errors.New("missing RTP variants")
+	ErrMissingParams       = errors.New("missing action parameters")
+	ErrInvalidWeighting    = errors.New("invalid weighting")
+	ErrNotDescribable      = errors.New("action cannot be described")
+	ErrInconsistentSources = errors.New("source variants are inconsistent")
+)
+
+const (
+	errUnknownRTP       = "game %s: unknown RTP [%d]"
+	errUnknownAction    = "action %d: unknown kind [%s]"
+	errInvalidAction    = "action %d (%s): %v"
+	errUnknownFilter    = "unknown filter kind [%s]"
+	errUnknownSource    = "unknown source game [%s]"
+	errMissingSource    = "source game [%s] has no RTP [%d]"
+	errInvalidRef       = "invalid action reference [%s]"
+	errUnknownActionID  = "unknown action id [%d]"
+	errDuplicateAction  = "duplicate action id [%d]"
+	errDuplicateSymbol  = "duplicate symbol id [%d]"
+	errInvalidSymbol    = "invalid symbol id [%d]"
+	errInvalidKind      = "symbol %d: invalid kind [%s]"
+	errInvalidDirection = "invalid payline direction [%s]"
+	errInvalidPayline   = "payline %d: invalid rows"
+	errInvalidWeights   = "RTP %d: invalid weights for symbol %d"
+	errDuplicateRTP     = "duplicate RTP variant [%d]"
+	errUnsupportedFile  = "unsupported file type [%s]"
+	errInvalidUint8     = "invalid value [%d]; must be 0-255"
+)
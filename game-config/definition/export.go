@@ -0,0 +1,204 @@
+package definition
+
+import (
+	"fmt"
+	"reflect"
+
+	comp "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/components/slots"
+)
+
+// Export creates the declarative definition for the Go-defined game registered under the given source name.
+// The symbols, weights, paylines, round flags and options are exported as data.
+// Spin actions of the kinds registered in actions.go are exported as data into the actions library.
+// Other spin actions, including those with filters, are exported as references to the source game.
+// The spinner, refiller and scripted round selector of the source are not exported; the built game takes them
+// from the source, so the definition keeps depending on it if the source has any of these.
+func Export(code, source string, rtps ...int) (*Definition, error) {
+	d := &Definition{
+		Game:     code,
+		Source:   source,
+		Variants: make([]Variant, 0, len(rtps)),
+	}
+
+	for ix, rtp := range rtps {
+		s, err := getSource(source, rtp)
+		if err != nil {
+			return nil, err
+		}
+
+		grid, paylines, options, flags := exportGrid(s), exportPaylines(s), exportOptions(s), exportFlags(s.RoundFlags())
+		symbols, weights := exportSymbols(s.Symbols())
+		altSymbols, altWeights := exportSymbols(s.AltSymbols())
+
+		v := Variant{
+			RTP:        rtp,
+			Target:     s.RTP(),
+			Weights:    weights,
+			AltWeights: altWeights,
+			Actions: ActionStages{
+				First:   d.exportActions(stageFirst, s.ActionsFirst()),
+				Free:    d.exportActions(stageFree, s.ActionsFree()),
+				FirstBB: d.exportActions(stageFirstBB, s.ActionsFirstBB()),
+				FreeBB:  d.exportActions(stageFreeBB, s.ActionsFreeBB()),
+			},
+		}
+		if v.Target == float64(rtp) {
+			v.Target = 0
+		}
+
+		if ix == 0 {
+			d.Grid, d.Paylines, d.Options, d.Flags = grid, paylines, options, flags
+			d.Symbols, d.AltSymbols = symbols, altSymbols
+		} else {
+			if !reflect.DeepEqual(d.Grid, grid) || !reflect.DeepEqual(d.Paylines, paylines) ||
+				!reflect.DeepEqual(d.Options, options) || !reflect.DeepEqual(d.Flags, flags) {
+				return nil, fmt.Errorf("%w: RTP %d", ErrInconsistentSources, rtp)
+			}
+			if !reflect.DeepEqual(d.Symbols, symbols) {
+				v.Symbols = symbols
+			}
+			if !reflect.DeepEqual(d.AltSymbols, altSymbols) {
+				v.AltSymbols = altSymbols
+			}
+		}
+
+		d.Variants = append(d.Variants, v)
+	}
+
+	if len(d.Variants) == 0 {
+		return nil, ErrMissingVariants
+	}
+	return d, nil
+}
+
+func exportGrid(s *comp.Slots) Grid {
+	return Grid{
+		Reels:    uint8(s.ReelCount()),
+		Rows:     uint8(s.RowCount()),
+		Mask:     Uint8s(s.ReelMask()),
+		NoRepeat: s.NoRepeat(),
+	}
+}
+
+func exportPaylines(s *comp.Slots) Paylines {
+	p := Paylines{
+		Direction: s.PayDirections().String(),
+		Highest:   s.HighestPayout(),
+	}
+
+	if set := s.Paylines(); set != nil {
+		lines := set.Paylines()
+		p.Lines = make([]Payline, len(lines))
+		for ix := range lines {
+			p.Lines[ix] = Payline{ID: lines[ix].ID(), Rows: Uint8s(lines[ix].RowMap())}
+		}
+	}
+
+	return p
+}
+
+func exportOptions(s *comp.Slots) Options {
+	return Options{
+		MaxPayout:             s.MaxPayout(),
+		CascadingReels:        s.CascadingReels(),
+		ClusterPays:           s.ClusterPays(),
+		DoubleSpin:            s.DoubleSpin(),
+		PlayerChoice:          s.PlayerChoice(),
+		RoundMultiplier:       s.RoundMultiplier(),
+		MultiplierOnWildsOnly: s.MultiplierOnWildsOnly(),
+		ProgressMeter:         s.ProgressMeter(),
+		HotReelsAsBonusSymbol: s.HotReelsAsBonusSymbol(),
+		ReverseWin:            s.ReverseWin(),
+		BonusBuy:              s.BonusBuy(),
+		BonusBuyFlag:          s.BonusBuyFlag(),
+		SymbolsState:          s.SymbolsState(),
+		ExcludeFromState:      s.ExcludeFromState(),
+		GambleMaxWin:          s.GambleMaxWin(),
+		GambleSteps:           s.GambleSteps(),
+	}
+}
+
+func exportFlags(flags comp.RoundFlags) []Flag {
+	if len(flags) == 0 {
+		return nil
+	}
+	out := make([]Flag, len(flags))
+	for ix := range flags {
+		out[ix] = Flag{ID: flags[ix].ID(), Name: flags[ix].Name(), Export: flags[ix].Export()}
+	}
+	return out
+}
+
+func exportSymbols(set *comp.SymbolSet) ([]Symbol, []Weights) {
+	if set == nil {
+		return nil, nil
+	}
+
+	symbols := set.Symbols()
+	out := make([]Symbol, len(symbols))
+	weights := make([]Weights, 0, len(symbols))
+
+	for ix := range symbols {
+		s := symbols[ix]
+
+		out[ix] = Symbol{
+			ID:             s.ID(),
+			Name:           s.Name(),
+			Resource:       s.Resource(),
+			Payouts:        s.Payouts(),
+			ScatterPayouts: s.ScatterPayouts(),
+			Sticky:         s.IsSticky(),
+			VaryMultiplier: s.VaryMultiplier(),
+			WildFor:        s.WildForSymbols(),
+			MorphInto:      s.MorphInto(),
+			ClearPattern:   s.ClearPattern(),
+		}
+		if k := s.Kind(); k != comp.Standard {
+			out[ix].Kind = k.String()
+		}
+		if m := s.Multiplier(); m != 1.0 {
+			out[ix].Multiplier = m
+		}
+
+		if w := s.Weights(); len(w) > 0 {
+			weights = append(weights, Weights{Symbol: s.ID(), Reels: w})
+		}
+	}
+
+	return out, weights
+}
+
+// exportActions exports the spin actions of a stage.
+// An action is referred to by id if it can be added to the actions library; otherwise it refers to the source game.
+func (d *Definition) exportActions(stage string, actions comp.SpinActions) []ActionRef {
+	if len(actions) == 0 {
+		return nil
+	}
+	out := make([]ActionRef, len(actions))
+	for ix := range actions {
+		a := actions[ix]
+		if e := exportAction(a); e != nil && d.addAction(e) {
+			out[ix] = ActionRef{ID: e.ID}
+			continue
+		}
+		out[ix] = ActionRef{
+			ID:   a.ID(),
+			Ref:  fmt.Sprintf("%s/%d", stage, ix),
+			Name: a.Name(),
+			Kind: a.Kind(),
+		}
+	}
+	return out
+}
+
+// addAction adds the action to the actions library.
+// It returns false if the id of the action is already used by a different action.
+func (d *Definition) addAction(a *Action) bool {
+	for ix := range d.Actions {
+		if d.Actions[ix].ID == a.ID {
+			return reflect.DeepEqual(&d.Actions[ix], a)
+		}
+	}
+	d.Actions = append(d.Actions, *a)
+	return true
+}
@@ -0,0 +1,211 @@
+package definition
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/goccy/go-json"
+	"gopkg.in/yaml.v3"
+
+	comp "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/components/slots"
+)
+
+// Load decodes a definition from JSON or YAML data and validates it.
+// Data starting with '{' is decoded as JSON, anything else as YAML.
+func Load(data []byte) (*Definition, error) {
+	d := &Definition{}
+
+	var err error
+	if isJSON(data) {
+		err = json.Unmarshal(data, d)
+	} else {
+		err = yaml.Unmarshal(data, d)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err = d.Validate(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// LoadFile reads and decodes a definition from the given JSON (.json) or YAML (.yaml, .yml) file.
+func LoadFile(path string) (*Definition, error) {
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json", ".yaml", ".yml":
+	default:
+		return nil, fmt.Errorf(errUnsupportedFile, ext)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Load(data)
+}
+
+// JSON encodes the definition as indented JSON.
+func (d *Definition) JSON() ([]byte, error) {
+	return json.MarshalIndent(d, "", "  ")
+}
+
+// YAML encodes the definition as YAML.
+func (d *Definition) YAML() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	enc := yaml.NewEncoder(buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(d); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// WriteFile encodes the definition to the given JSON (.json) or YAML (.yaml, .yml) file.
+func (d *Definition) WriteFile(path string) error {
+	var data []byte
+	var err error
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		data, err = d.JSON()
+	case ".yaml", ".yml":
+		data, err = d.YAML()
+	default:
+		err = fmt.Errorf(errUnsupportedFile, ext)
+	}
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0644)
+}
+
+// Validate verifies the consistency of the definition.
+// It does not verify action references to the source game, as these are only resolved when the game is built.
+func (d *Definition) Validate() error {
+	if d.Game == "" {
+		return ErrMissingGame
+	}
+	if d.Grid.Reels == 0 || d.Grid.Rows == 0 || d.Grid.Reels > 15 || d.Grid.Rows > 15 {
+		return ErrInvalidGrid
+	}
+	if l := len(d.Grid.Mask); l > 0 && l != int(d.Grid.Reels) {
+		return ErrInvalidGrid
+	}
+	if len(d.Symbols) == 0 {
+		return ErrMissingSymbols
+	}
+	if len(d.Variants) == 0 {
+		return ErrMissingVariants
+	}
+
+	if err := validateSymbols(d.Symbols); err != nil {
+		return err
+	}
+	if err := validateSymbols(d.AltSymbols); err != nil {
+		return err
+	}
+
+	if _, err := parseDirection(d.Paylines.Direction); err != nil {
+		return err
+	}
+	for ix := range d.Paylines.Lines {
+		p := &d.Paylines.Lines[ix]
+		if len(p.Rows) != int(d.Grid.Reels) {
+			return fmt.Errorf(errInvalidPayline, p.ID)
+		}
+		for _, row := range p.Rows {
+			if row >= d.Grid.Rows {
+				return fmt.Errorf(errInvalidPayline, p.ID)
+			}
+		}
+	}
+
+	ids := make(map[int]bool, len(d.Actions))
+	for ix := range d.Actions {
+		a := &d.Actions[ix]
+		if ids[a.ID] {
+			return fmt.Errorf(errDuplicateAction, a.ID)
+		}
+		ids[a.ID] = true
+	}
+
+	rtps := make(map[int]bool, len(d.Variants))
+	for ix := range d.Variants {
+		v := &d.Variants[ix]
+		if rtps[v.RTP] {
+			return fmt.Errorf(errDuplicateRTP, v.RTP)
+		}
+		rtps[v.RTP] = true
+
+		if err := validateSymbols(v.Symbols); err != nil {
+			return err
+		}
+		if err := validateSymbols(v.AltSymbols); err != nil {
+			return err
+		}
+		if err := d.validateWeights(v.RTP, v.Weights); err != nil {
+			return err
+		}
+		if err := d.validateWeights(v.RTP, v.AltWeights); err != nil {
+			return err
+		}
+
+		for _, refs := range [][]ActionRef{v.Actions.First, v.Actions.Free, v.Actions.FirstBB, v.Actions.FreeBB} {
+			for iy := range refs {
+				if ref := &refs[iy]; ref.Ref == "" && !ids[ref.ID] {
+					return fmt.Errorf(errUnknownActionID, ref.ID)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateSymbols(symbols []Symbol) error {
+	ids := make(map[uint16]bool, len(symbols))
+	for ix := range symbols {
+		s := &symbols[ix]
+		if s.ID == 0 || s.ID > comp.MaxSymbolID {
+			return fmt.Errorf(errInvalidSymbol, s.ID)
+		}
+		if ids[uint16(s.ID)] {
+			return fmt.Errorf(errDuplicateSymbol, s.ID)
+		}
+		ids[uint16(s.ID)] = true
+
+		if _, err := parseKind(s.Kind); err != nil {
+			return fmt.Errorf(errInvalidKind, s.ID, s.Kind)
+		}
+	}
+	return nil
+}
+
+func (d *Definition) validateWeights(rtp int, weights []Weights) error {
+	for ix := range weights {
+		w := &weights[ix]
+		if len(w.Reels) != int(d.Grid.Reels) {
+			return fmt.Errorf(errInvalidWeights, rtp, w.Symbol)
+		}
+		for _, weight := range w.Reels {
+			if weight < 0 {
+				return fmt.Errorf(errInvalidWeights, rtp, w.Symbol)
+			}
+		}
+	}
+	return nil
+}
+
+func isJSON(data []byte) bool {
+	data = bytes.TrimSpace(data)
+	return len(data) > 0 && data[0] == '{'
+}
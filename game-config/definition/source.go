@@ -0,0 +1,41 @@
+package definition
+
+import (
+	"fmt"
+	"sync"
+
+	comp "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/components/slots"
+)
+
+// SourceFunc is the function signature for retrieving a Go-defined slot machine with the given RTP.
+// It must return nil if the RTP is not supported.
+type SourceFunc func(rtp int) *comp.Slots
+
+// RegisterSource registers a Go-defined game under the given name.
+// Definitions referring to the name will take their non-declarative components from the Go-defined game.
+// It replaces any previously registered source with the same name.
+func RegisterSource(name string, f SourceFunc) {
+	sourcesMutex.Lock()
+	sources[name] = f
+	sourcesMutex.Unlock()
+}
+
+// getSource retrieves the slot machine with the given RTP from the named source.
+func getSource(name string, rtp int) (*comp.Slots, error) {
+	sourcesMutex.RLock()
+	f := sources[name]
+	sourcesMutex.RUnlock()
+
+	if f == nil {
+		return nil, fmt.Errorf(errUnknownSource, name)
+	}
+	if s := f(rtp); s != nil {
+		return s, nil
+	}
+	return nil, fmt.Errorf(errMissingSource, name, rtp)
+}
+
+var (
+	sourcesMutex sync.RWMutex
+	sources      = make(map[string]SourceFunc, 16)
+)
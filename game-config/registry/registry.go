@@ -0,0 +1,135 @@
+package registry
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	magic "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/analysis/simulation/slots"
+	comp "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/components/slots"
+	game "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/games/slots"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/tg"
+)
+
+// Game contains the registration details of a slot machine game.
+// Each game package registers itself once during initialization, and consumers enumerate the registry.
+type Game struct {
+	NR                tg.GameNR                                                                 // game number.
+	RTPs              []int                                                                     // supported RTPs in ascending order.
+	New               func(rtp int) *game.Regular                                               // instantiates the game (required).
+	NewLogged         func(rtp int) *game.Regular                                               // instantiates the game with PRNG logging (required).
+	NewWithRoundFlags func(rtp int) *game.Regular                                               // instantiates the game with round flags (optional).
+	RoundFlags        bool                                                                      // indicates the game must always be instantiated with round flags.
+	AllSymbols        func() *comp.SymbolSet                                                    // returns the complete symbol set (required).
+	AllActions        func(rtp int) comp.SpinActions                                            // returns all spin actions for analysis (optional).
+	Conditions        func() map[string]*magic.Condition                                        // returns the rng-magic conditions (optional).
+	MakeMatcher       func(key string, params map[string]any, game *game.Regular) magic.Matcher // creates an rng-magic matcher (optional).
+	NoFair            bool                                                                      // indicates the game uses PRNGs outside of the game, so it cannot be played provably-fair.
+}
+
+// Code returns the game code; e.g. "bot".
+func (g *Game) Code() string {
+	return g.NR.String()
+}
+
+// HasRTP returns whether the game supports the given RTP.
+func (g *Game) HasRTP(rtp int) bool {
+	for _, r := range g.RTPs {
+		if r == rtp {
+			return true
+		}
+	}
+	return false
+}
+
+// HasMagic returns whether the game supports rng-magic.
+func (g *Game) HasMagic() bool {
+	return g.Conditions != nil && g.MakeMatcher != nil
+}
+
+// Fair returns whether the game can be played in provably-fair mode.
+// This requires that all randomness in a round comes from the PRNG of the game.
+func (g *Game) Fair() bool {
+	return !g.NoFair
+}
+
+// NewGame instantiates the game with the given RTP.
+// If logged is true, PRNG logging is activated. If flags is true, the game is instantiated with round flags if supported.
+// The function returns nil if the RTP is not supported.
+func (g *Game) NewGame(rtp int, logged, flags bool) *game.Regular {
+	if !g.HasRTP(rtp) {
+		return nil
+	}
+	switch {
+	case logged:
+		return g.NewLogged(rtp)
+	case (flags || g.RoundFlags) && g.NewWithRoundFlags != nil:
+		return g.NewWithRoundFlags(rtp)
+	default:
+		return g.New(rtp)
+	}
+}
+
+// Register adds the game to the registry.
+// It is meant to be called from the init() function of the game package, and panics if the registration is
+// incomplete or if the game has already been registered.
+func Register(g *Game) {
+	if g == nil || g.NR == 0 {
+		panic(errInvalid)
+	}
+	if len(g.RTPs) == 0 || g.New == nil || g.NewLogged == nil || g.AllSymbols == nil {
+		panic(fmt.Sprintf(errIncomplete, g.Code()))
+	}
+	if !sort.IntsAreSorted(g.RTPs) {
+		panic(fmt.Sprintf(errUnsorted, g.Code()))
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if games[g.NR] != nil {
+		panic(fmt.Sprintf(errDuplicate, g.Code()))
+	}
+	games[g.NR] = g
+}
+
+// Get returns the registered game with the given number, or nil if the game is not registered.
+func Get(nr tg.GameNR) *Game {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	return games[nr]
+}
+
+// NewGame instantiates the registered game with the given number and RTP.
+// The function returns nil if the game is not registered or the RTP is not supported.
+func NewGame(nr tg.GameNR, rtp int, logged, flags bool) *game.Regular {
+	if g := Get(nr); g != nil {
+		return g.NewGame(rtp, logged, flags)
+	}
+	return nil
+}
+
+// Games returns the registered games in order of their game number.
+func Games() []*Game {
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	out := make([]*Game, 0, len(games))
+	for _, g := range games {
+		out = append(out, g)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].NR < out[j].NR })
+	return out
+}
+
+const (
+	errInvalid    = "registry: invalid game registration"
+	errIncomplete = "registry: incomplete registration for game [%s]"
+	errUnsorted   = "registry: RTPs for game [%s] must be in ascending order"
+	errDuplicate  = "registry: game [%s] already registered"
+)
+
+var (
+	mutex sync.RWMutex
+	games = make(map[tg.GameNR]*Game, 32)
+)
@@ -0,0 +1,99 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	comp "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/components/slots"
+	game "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/games/slots"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/tg"
+)
+
+func TestRegister(t *testing.T) {
+	testCases := []struct {
+		name  string
+		game  *Game
+		panic bool
+	}{
+		{name: "nil", panic: true},
+		{name: "no number", game: &Game{RTPs: []int{96}, New: newTest, NewLogged: newTest, AllSymbols: testSymbols}, panic: true},
+		{name: "no RTPs", game: &Game{NR: tg.ANAnr, New: newTest, NewLogged: newTest, AllSymbols: testSymbols}, panic: true},
+		{name: "unsorted RTPs", game: &Game{NR: tg.ANAnr, RTPs: []int{96, 92}, New: newTest, NewLogged: newTest, AllSymbols: testSymbols}, panic: true},
+		{name: "no New", game: &Game{NR: tg.ANAnr, RTPs: []int{96}, NewLogged: newTest, AllSymbols: testSymbols}, panic: true},
+		{name: "no NewLogged", game: &Game{NR: tg.ANAnr, RTPs: []int{96}, New: newTest, AllSymbols: testSymbols}, panic: true},
+		{name: "no symbols", game: &Game{NR: tg.ANAnr, RTPs: []int{96}, New: newTest, NewLogged: newTest}, panic: true},
+		{name: "good", game: &Game{NR: tg.ANAnr, RTPs: []int{94, 96}, New: newTest, NewLogged: newTest, AllSymbols: testSymbols}},
+		{name: "duplicate", game: &Game{NR: tg.ANAnr, RTPs: []int{96}, New: newTest, NewLogged: newTest, AllSymbols: testSymbols}, panic: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.panic {
+				assert.Panics(t, func() { Register(tc.game) })
+				return
+			}
+
+			Register(tc.game)
+			g := Get(tc.game.NR)
+			require.NotNil(t, g)
+			assert.Equal(t, tc.game, g)
+			assert.Equal(t, "ana", g.Code())
+			assert.False(t, g.HasMagic())
+			assert.Contains(t, Games(), g)
+		})
+	}
+}
+
+func TestGame_NewGame(t *testing.T) {
+	var called string
+	g := &Game{
+		NR:                tg.FRJnr,
+		RTPs:              []int{92, 96},
+		New:               func(rtp int) *game.Regular { called = "new"; return nil },
+		NewLogged:         func(rtp int) *game.Regular { called = "logged"; return nil },
+		NewWithRoundFlags: func(rtp int) *game.Regular { called = "flags"; return nil },
+		AllSymbols:        testSymbols,
+	}
+	Register(g)
+
+	testCases := []struct {
+		name       string
+		rtp        int
+		logged     bool
+		flags      bool
+		roundFlags bool
+		want       string
+	}{
+		{name: "bad rtp", rtp: 94},
+		{name: "new", rtp: 92, want: "new"},
+		{name: "logged", rtp: 96, logged: true, want: "logged"},
+		{name: "logged flags", rtp: 96, logged: true, flags: true, want: "logged"},
+		{name: "flags", rtp: 96, flags: true, want: "flags"},
+		{name: "round flags", rtp: 96, roundFlags: true, want: "flags"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			called = ""
+			g.RoundFlags = tc.roundFlags
+			assert.Equal(t, tc.rtp != 94, g.HasRTP(tc.rtp))
+			NewGame(tg.FRJnr, tc.rtp, tc.logged, tc.flags)
+			assert.Equal(t, tc.want, called)
+		})
+	}
+
+	assert.Nil(t, NewGame(tg.HOGnr, 96, false, false))
+}
+
+func TestGames(t *testing.T) {
+	list := Games()
+	for ix := 1; ix < len(list); ix++ {
+		assert.Less(t, list[ix-1].NR, list[ix].NR)
+	}
+}
+
+func newTest(_ int) *game.Regular { return nil }
+
+func testSymbols() *comp.SymbolSet { return nil }
@@ -0,0 +1,19 @@
+package cas
+
+import (
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/tg"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/registry"
+)
+
+// init registers Casino Heist with the game registry.
+func init() {
+	registry.Register(&registry.Game{
+		NR:         tg.CASnr,
+		RTPs:       []int{96},
+		New:        New,
+		NewLogged:  NewLogged,
+		AllSymbols: AllSymbols,
+		AllActions: AllActions,
+	})
+}
@@ -0,0 +1,22 @@
+package fpr
+
+import (
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/tg"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/registry"
+)
+
+// init registers Frosty Princess with the game registry.
+func init() {
+	registry.Register(&registry.Game{
+		NR:                tg.FPRnr,
+		RTPs:              []int{92, 94, 96},
+		New:               New,
+		NewLogged:         NewLogged,
+		NewWithRoundFlags: NewWithRoundFlags,
+		AllSymbols:        AllSymbols,
+		AllActions:        AllActions,
+		Conditions:        Conditions,
+		MakeMatcher:       MakeMatcher,
+	})
+}
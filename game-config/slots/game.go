@@ -1,159 +1,68 @@
 package slots
 
 import (
+	"errors"
+
 	game "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/games/slots"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/results"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/rng"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/tg"
 
-	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/slots/ber"
-	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/slots/bot"
-	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/slots/btr"
-	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/slots/cas"
-	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/slots/ccb"
-	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/slots/crw"
-	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/slots/fpr"
-	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/slots/frm"
-	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/slots/lam"
-	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/slots/mgd"
-	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/slots/ofg"
-	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/slots/owl"
-	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/slots/yyl"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/registry"
+
+	// import all games so they are registered.
+	_ "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/slots/ber"
+	_ "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/slots/bot"
+	_ "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/slots/btr"
+	_ "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/slots/cas"
+	_ "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/slots/ccb"
+	_ "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/slots/crw"
+	_ "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/slots/fpr"
+	_ "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/slots/frm"
+	_ "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/slots/lam"
+	_ "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/slots/mgd"
+	_ "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/slots/ofg"
+	_ "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/slots/owl"
+	_ "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/slots/yyl"
 )
 
-func NewGame(game tg.GameNR, rtp int, rngBuf, flags bool) *game.Regular {
-	switch game {
-	case tg.BOTnr:
-		return NewGameBOT(rtp, rngBuf, flags)
-	case tg.CCBnr:
-		return NewGameCCB(rtp, rngBuf, flags)
-	case tg.MGDnr:
-		return NewGameMGD(rtp, rngBuf, flags)
-	case tg.LAMnr:
-		return NewGameLAM(rtp, rngBuf, flags)
-	case tg.OWLnr:
-		return NewGameOWL(rtp, rngBuf, flags)
-	case tg.FRMnr:
-		return NewGameFRM(rtp, rngBuf, flags)
-	case tg.OFGnr:
-		return NewGameOFG(rtp, rngBuf, flags)
-	case tg.FPRnr:
-		return NewGameFPR(rtp, rngBuf, flags)
-	case tg.BTRnr:
-		return NewGameBTR(rtp, rngBuf, flags)
-	case tg.BERnr:
-		return NewGameBER(rtp, rngBuf, flags)
-	case tg.CRWnr:
-		return NewGameCRW(rtp, rngBuf, flags)
-	case tg.YYLnr:
-		return NewGameYYL(rtp, rngBuf, flags)
-	case tg.CASnr:
-		return NewGameCAS(rtp, rngBuf, flags)
-	default:
-		return nil
-	}
-}
-
-func NewGameBOT(rtp int, rngBuf, flags bool) *game.Regular {
-	if rngBuf {
-		return bot.NewLogged(rtp)
-	}
-	return bot.New(rtp)
-}
-
-func NewGameCCB(rtp int, rngBuf, flags bool) *game.Regular {
-	if rngBuf {
-		return ccb.NewLogged(rtp)
-	}
-	return ccb.New(rtp)
-}
-
-func NewGameMGD(rtp int, rngBuf, flags bool) *game.Regular {
-	if rngBuf {
-		return mgd.NewLogged(rtp)
-	}
-	if flags {
-		return mgd.NewWithRoundFlags(rtp)
-	}
-	return mgd.New(rtp)
-}
-
-func NewGameLAM(rtp int, rngBuf, flags bool) *game.Regular {
-	if rngBuf {
-		return lam.NewLogged(rtp)
-	}
-	return lam.New(rtp)
-}
-
-func NewGameOWL(rtp int, rngBuf, flags bool) *game.Regular {
-	if rngBuf {
-		return owl.NewLogged(rtp)
-	}
-	return owl.NewWithRoundFlags(rtp)
-}
-
-func NewGameFRM(rtp int, rngBuf, flags bool) *game.Regular {
-	if rngBuf {
-		return frm.NewLogged(rtp)
-	}
-	return frm.New(rtp)
+// NewGame instantiates the registered game with the given RTP.
+// If rngBuf is true, PRNG logging is activated. If flags is true, the game is instantiated with round flags if supported.
+// The function returns nil if the game is not registered or the RTP is not supported.
+func NewGame(nr tg.GameNR, rtp int, rngBuf, flags bool) *game.Regular {
+	return registry.NewGame(nr, rtp, rngBuf, flags)
 }
 
-func NewGameOFG(rtp int, rngBuf, flags bool) *game.Regular {
-	if rngBuf {
-		return ofg.NewLogged(rtp)
-	}
-	if flags {
-		return ofg.NewWithRoundFlags(rtp)
-	}
-	return ofg.New(rtp)
-}
-
-func NewGameFPR(rtp int, rngBuf, flags bool) *game.Regular {
-	if rngBuf {
-		return fpr.NewLogged(rtp)
-	}
-	if flags {
-		return fpr.NewWithRoundFlags(rtp)
-	}
-	return fpr.New(rtp)
+// Games returns all registered games in order of their game number.
+func Games() []*registry.Game {
+	return registry.Games()
 }
 
-func NewGameBTR(rtp int, rngBuf, flags bool) *game.Regular {
-	if rngBuf {
-		return btr.NewLogged(rtp)
-	}
-	if flags {
-		return btr.NewWithRoundFlags(rtp)
-	}
-	return btr.New(rtp)
+// Game returns the registered game with the given number, or nil if the game is not registered.
+func Game(nr tg.GameNR) *registry.Game {
+	return registry.Get(nr)
 }
 
-func NewGameBER(rtp int, rngBuf, flags bool) *game.Regular {
-	if rngBuf {
-		return ber.NewLogged(rtp)
-	}
-	if flags {
-		return ber.NewWithRoundFlags(rtp)
+// VerifyFair replays a provably-fair round of the registered game with the given RTP, and verifies it against the
+// recorded results. Players can use it to verify a round offline, once the server seed has been revealed.
+// maxPayout is the max win cap of the jurisdiction the round was played in, or zero if there was none.
+// See game.VerifyFair for the errors returned.
+func VerifyFair(nr tg.GameNR, rtp int, maxPayout float64, seeds *rng.FairSeeds, commitment string, bonusBuy uint8, recorded results.Results) error {
+	if r := registry.Get(nr); r != nil && !r.Fair() {
+		return ErrNoFair
 	}
-	return ber.New(rtp)
-}
 
-func NewGameCRW(rtp int, rngBuf, flags bool) *game.Regular {
-	if rngBuf {
-		return crw.NewLogged(rtp)
+	g := registry.NewGame(nr, rtp, true, false)
+	if g == nil {
+		return ErrUnknownGame
 	}
-	return crw.New(rtp)
-}
+	defer g.Release()
 
-func NewGameYYL(rtp int, rngBuf, flags bool) *game.Regular {
-	if rngBuf {
-		return yyl.NewLogged(rtp)
-	}
-	return yyl.New(rtp)
+	g.LimitMaxPayout(maxPayout)
+	return game.VerifyFair(g, seeds, commitment, bonusBuy, recorded)
 }
 
-func NewGameCAS(rtp int, rngBuf, flags bool) *game.Regular {
-	if rngBuf {
-		return cas.NewLogged(rtp)
-	}
-	return cas.New(rtp)
-}
+var (
+	ErrUnknownGame = errors.New("game or RTP is not registered")
+	ErrNoFair      = errors.New("game cannot be played provably-fair")
+)
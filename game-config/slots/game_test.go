@@ -0,0 +1,82 @@
+package slots
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/rng"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/tg"
+)
+
+func TestGames(t *testing.T) {
+	want := []tg.GameNR{
+		tg.BOTnr, tg.CCBnr, tg.MGDnr, tg.LAMnr, tg.OWLnr, tg.FRMnr, tg.OFGnr,
+		tg.FPRnr, tg.BTRnr, tg.BERnr, tg.CASnr, tg.CRWnr, tg.YYLnr,
+	}
+
+	list := Games()
+	require.Equal(t, len(want), len(list))
+	for ix := range want {
+		assert.Equal(t, want[ix], list[ix].NR)
+		assert.Equal(t, list[ix], Game(want[ix]))
+	}
+
+	assert.Nil(t, Game(tg.HOGnr))
+	assert.Nil(t, NewGame(tg.HOGnr, 96, false, false))
+}
+
+func TestNewGame(t *testing.T) {
+	for _, g := range Games() {
+		for _, rtp := range []int{42, 92, 94, 96} {
+			t.Run(fmt.Sprintf("%s-%d", g.Code(), rtp), func(t *testing.T) {
+				for _, mode := range [][2]bool{{false, false}, {true, false}, {false, true}} {
+					r := NewGame(g.NR, rtp, mode[0], mode[1])
+					if !g.HasRTP(rtp) {
+						assert.Nil(t, r)
+						continue
+					}
+
+					require.NotNil(t, r)
+					r.Release()
+				}
+			})
+		}
+	}
+}
+
+func TestVerifyFair(t *testing.T) {
+	seeds := &rng.FairSeeds{ServerSeed: rng.NewServerSeed(), ClientSeed: "player", Nonce: 1}
+	commitment := seeds.Commitment()
+
+	for _, g := range Games() {
+		if !g.Fair() {
+			assert.Equal(t, ErrNoFair, VerifyFair(g.NR, g.RTPs[0], 0, seeds, commitment, 0, nil))
+			continue
+		}
+
+		t.Run(g.Code(), func(t *testing.T) {
+			rtp := g.RTPs[len(g.RTPs)-1]
+			for nonce := uint64(1); nonce <= 10; nonce++ {
+				seeds.Nonce = nonce
+
+				r := NewGame(g.NR, rtp, true, false)
+				require.NotNil(t, r)
+				recorded := r.Fair(seeds, 0)
+				require.NotEmpty(t, recorded)
+
+				assert.NoError(t, VerifyFair(g.NR, rtp, 0, seeds, commitment, 0, recorded))
+
+				seeds.Nonce++
+				assert.Error(t, VerifyFair(g.NR, rtp, 0, seeds, commitment, 0, recorded))
+				seeds.Nonce--
+
+				r.Release()
+			}
+		})
+	}
+
+	assert.Equal(t, ErrUnknownGame, VerifyFair(tg.HOGnr, 96, 0, seeds, commitment, 0, nil))
+}
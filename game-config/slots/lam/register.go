@@ -0,0 +1,21 @@
+package lam
+
+import (
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/tg"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/registry"
+)
+
+// init registers La Modelo with the game registry.
+func init() {
+	registry.Register(&registry.Game{
+		NR:          tg.LAMnr,
+		RTPs:        []int{92, 94, 96},
+		New:         New,
+		NewLogged:   NewLogged,
+		AllSymbols:  AllSymbols,
+		AllActions:  AllActions,
+		Conditions:  Conditions,
+		MakeMatcher: MakeMatcher,
+	})
+}
@@ -0,0 +1,22 @@
+package ofg
+
+import (
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/tg"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/registry"
+)
+
+// init registers 150 Ships with the game registry.
+func init() {
+	registry.Register(&registry.Game{
+		NR:                tg.OFGnr,
+		RTPs:              []int{92, 94, 96},
+		New:               New,
+		NewLogged:         NewLogged,
+		NewWithRoundFlags: NewWithRoundFlags,
+		AllSymbols:        AllSymbols,
+		AllActions:        AllActions,
+		Conditions:        Conditions,
+		MakeMatcher:       MakeMatcher,
+	})
+}
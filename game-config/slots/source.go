@@ -0,0 +1,28 @@
+package slots
+
+import (
+	comp "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/components/slots"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/tg"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/definition"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/registry"
+)
+
+// init registers all Go-defined games as sources for declarative game definitions.
+func init() {
+	for _, g := range registry.Games() {
+		definition.RegisterSource(g.Code(), sourceFunc(g.NR))
+	}
+}
+
+// sourceFunc returns the function to retrieve the slot machine of a Go-defined game.
+func sourceFunc(nr tg.GameNR) definition.SourceFunc {
+	return func(rtp int) *comp.Slots {
+		g := NewGame(nr, rtp, false, false)
+		if g == nil {
+			return nil
+		}
+		defer g.Release()
+		return g.Slots()
+	}
+}
@@ -0,0 +1,135 @@
+package slots
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/encode/zjson"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/tg"
+
+	game "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/games/slots"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/interfaces"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/rng"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/definition"
+)
+
+func TestExportRoundTrip(t *testing.T) {
+	for _, g := range Games() {
+		nr := g.NR
+		for _, rtp := range g.RTPs {
+			src := sourceFunc(nr)(rtp)
+			require.NotNil(t, src)
+
+			t.Run(fmt.Sprintf("%s-%d", nr.String(), rtp), func(t *testing.T) {
+				d, err := definition.Export(nr.String(), nr.String(), rtp)
+				require.NoError(t, err)
+				require.NotNil(t, d)
+
+				j, err := d.JSON()
+				require.NoError(t, err)
+				y, err := d.YAML()
+				require.NoError(t, err)
+
+				d1, err := definition.Load(j)
+				require.NoError(t, err)
+				d2, err := definition.Load(y)
+				require.NoError(t, err)
+				assert.EqualValues(t, d1, d2)
+
+				g, err := d1.Build()
+				require.NoError(t, err)
+				require.NotNil(t, g)
+
+				s := g.Slots(rtp)
+				require.NotNil(t, s)
+				assert.Equal(t, src.RTP(), s.RTP())
+				assert.Equal(t, src.MaxPayout(), s.MaxPayout())
+
+				if nr == tg.FRMnr {
+					// frm injects clusters using GridNeighbors.RandomNeighbor, which depends on map iteration order.
+					return
+				}
+
+				compareRounds(t, game.RegularParams{Slots: src}, game.RegularParams{Slots: s}, 2500)
+			})
+		}
+	}
+}
+
+// compareRounds plays the given number of rounds with both games using an identical PRNG sequence.
+// It fails the test if any of the results differ.
+func compareRounds(t *testing.T, want, got game.RegularParams, count int) {
+	res1 := playRounds(t, func() *game.Regular { return game.AcquireRegular(want) }, count)
+	res2 := playRounds(t, func() *game.Regular { return game.AcquireRegular(got) }, count)
+
+	for ix := range res1 {
+		require.Equal(t, len(res1[ix]), len(res2[ix]), "round %d", ix)
+		for iy := range res1[ix] {
+			require.True(t, bytes.Equal(res1[ix][iy], res2[ix][iy]), "round %d result %d", ix, iy)
+		}
+	}
+}
+
+// playRounds plays the given number of rounds with a fixed PRNG sequence and returns the encoded results.
+// The games are played one after the other, as games built from the same slot machine share their spin actions.
+func playRounds(t *testing.T, acquire func() *game.Regular, count int) [][][]byte {
+	rng.AcquireRNG = func() interfaces.Generator { return newSeeded(54321) }
+	g := acquire()
+	require.NotNil(t, g)
+	defer g.Release()
+
+	out := make([][][]byte, count)
+	for ix := range out {
+		res := g.Round(0)
+		out[ix] = make([][]byte, len(res))
+		for iy := range res {
+			enc := zjson.AcquireEncoder(1024)
+			enc.Object(res[iy])
+			out[ix][iy] = append([]byte{}, enc.Bytes()...)
+			enc.Release()
+		}
+	}
+	return out
+}
+
+// seeded is a deterministic PRNG (splitmix64) to play rounds with a fixed PRNG sequence.
+type seeded struct {
+	state uint64
+}
+
+func newSeeded(seed uint64) *seeded {
+	return &seeded{state: seed}
+}
+
+func (s *seeded) ReturnToPool() {}
+
+func (s *seeded) Uint64() uint64 {
+	s.state += 0x9e3779b97f4a7c15
+	z := s.state
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+func (s *seeded) Uint32() uint32 {
+	return uint32(s.Uint64() >> 32)
+}
+
+func (s *seeded) IntN(n int) int {
+	return int(s.Uint64() % uint64(n))
+}
+
+func (s *seeded) IntsN(n int, out []int) {
+	for ix := range out {
+		out[ix] = s.IntN(n)
+	}
+}
+
+func init() {
+	rng.AcquireRNG = func() interfaces.Generator { return newSeeded(1) }
+}
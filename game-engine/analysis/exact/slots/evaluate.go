@@ -0,0 +1,281 @@
+package slots
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	comp "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/components/slots"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/results"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/utils"
+)
+
+// Result contains the exact base game metrics of a slot machine with paylines.
+// RTP and contributions are expressed as a percentage of the bet.
+type Result struct {
+	RTP           float64         `json:"rtp"`                     // return to player of the paylines.
+	LineHitRate   float64         `json:"lineHitRate"`             // percentage of spins with a payout on a single payline.
+	Lines         int             `json:"lines"`                   // number of paylines.
+	LineTuples    uint64          `json:"lineTuples"`              // number of symbol combinations evaluated on a single payline.
+	Enumerated    bool            `json:"enumerated"`              // indicates if all grids were enumerated.
+	Combinations  uint64          `json:"combinations,omitempty"`  // number of grids evaluated; only if enumerated.
+	HitRate       float64         `json:"hitRate,omitempty"`       // percentage of spins with any payout; only if enumerated.
+	Variance      float64         `json:"variance,omitempty"`      // variance of the return per spin in multiples of the bet; only if enumerated.
+	Contributions []*Contribution `json:"contributions,omitempty"` // contribution of each payout to the RTP.
+	enumRTP       float64         // RTP from the enumerated grids; used internally for unit tests!
+}
+
+// Contribution contains the contribution to the RTP of a symbol paying for a number of reels in a direction.
+type Contribution struct {
+	Symbol    utils.Index       `json:"symbol"`
+	Count     uint8             `json:"count"`
+	Direction comp.PayDirection `json:"direction"`
+	RTP       float64           `json:"rtp"`       // contribution to the RTP.
+	Frequency float64           `json:"frequency"` // expected number of payouts per spin across all paylines.
+}
+
+// StdDev returns the standard deviation of the return per spin in multiples of the bet.
+// It returns 0 if the grids were not enumerated.
+func (r *Result) StdDev() float64 {
+	return math.Sqrt(r.Variance)
+}
+
+// Symbol returns the total contribution to the RTP of the given symbol.
+func (r *Result) Symbol(symbol utils.Index) float64 {
+	var total float64
+	for _, c := range r.Contributions {
+		if c.Symbol == symbol {
+			total += c.RTP
+		}
+	}
+	return total
+}
+
+// Option is the function signature for evaluation options.
+type Option func(e *evaluator)
+
+// MaxCombinations sets the maximum number of grids to enumerate for the hit rate and variance.
+// If the reels have more distinct grids, only the RTP, line hit rate and contributions are determined.
+// The default is 5 million; 0 disables the enumeration.
+func MaxCombinations(max uint64) Option {
+	return func(e *evaluator) {
+		e.maxCombinations = max
+	}
+}
+
+// Evaluate calculates the exact base game RTP of a slot machine with paylines.
+//
+// The RTP and contributions follow from the linearity of expectation: as each row of a reel has the same
+// symbol distribution, and the reels are independent, every payline has the same expected payout.
+// It is determined by enumerating all symbol combinations on a single payline through the paylines of the game.
+// If the number of distinct grids is within bounds, all grids are enumerated to determine the hit rate and variance.
+//
+// Only the payline payouts of a single spin are evaluated. Scatter payouts, cascades, free spins, spin actions,
+// round multipliers and the max payout cap are not taken into account.
+func Evaluate(s *comp.Slots, reels Reels, opts ...Option) (*Result, error) {
+	set := s.Paylines()
+	if set == nil || len(set.Paylines()) == 0 {
+		return nil, ErrNoPaylines
+	}
+	if len(reels) != s.ReelCount() {
+		return nil, ErrReelCount
+	}
+	for _, reel := range reels {
+		if reel.rows != s.RowCount() {
+			return nil, ErrReelCount
+		}
+	}
+
+	e := &evaluator{
+		slots:           s,
+		reels:           reels,
+		maxCombinations: 5000000,
+		contributions:   make(map[contributionKey]*Contribution, 64),
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	e.spin = comp.AcquireSpin(s, &sequence{})
+	defer e.spin.Release()
+	e.result = results.AcquireResult(nil, 0)
+	defer e.result.Release()
+
+	lines := len(set.Paylines())
+	out := &Result{Lines: lines}
+
+	e.evaluateLines(out)
+
+	if n := reels.Combinations(); n > 0 && n <= e.maxCombinations {
+		e.evaluateGrids(out)
+	}
+
+	out.Contributions = make([]*Contribution, 0, len(e.contributions))
+	for _, c := range e.contributions {
+		c.RTP *= 100
+		out.Contributions = append(out.Contributions, c)
+	}
+	sort.Slice(out.Contributions, func(i, j int) bool {
+		ci, cj := out.Contributions[i], out.Contributions[j]
+		if ci.Symbol != cj.Symbol {
+			return ci.Symbol < cj.Symbol
+		}
+		if ci.Count != cj.Count {
+			return ci.Count < cj.Count
+		}
+		return ci.Direction < cj.Direction
+	})
+
+	return out, nil
+}
+
+type evaluator struct {
+	maxCombinations uint64
+	slots           *comp.Slots
+	reels           Reels
+	spin            *comp.Spin
+	result          *results.Result
+	contributions   map[contributionKey]*Contribution
+}
+
+type contributionKey struct {
+	symbol    utils.Index
+	count     uint8
+	direction comp.PayDirection
+}
+
+// evaluateLines determines the expected payout of a single payline, and multiplies it by the number of paylines.
+// It uses a straight payline on the top row, as every payline has the same symbol distribution.
+func (e *evaluator) evaluateLines(out *Result) {
+	set := e.slots.Paylines()
+	rowCount := e.slots.RowCount()
+	lines := float64(out.Lines)
+
+	line := comp.NewPaylineSet(set.Directions(), set.HighestPayout(), comp.NewPayline(1, uint8(rowCount), make([]uint8, len(e.reels))...))
+
+	var rtp, hits float64
+
+	var next func(reel int, p float64)
+	next = func(reel int, p float64) {
+		if reel == len(e.reels) {
+			out.LineTuples++
+
+			e.result.ReleasePayouts()
+			if !line.GetPayouts(e.spin, e.result) {
+				return
+			}
+
+			hits += p
+			rtp += p * e.result.Total
+
+			for _, payout := range e.result.Payouts {
+				if sp, ok := payout.(*comp.SpinPayout); ok {
+					c := e.contribution(sp)
+					c.RTP += p * sp.Total() * lines
+					c.Frequency += p * lines
+				}
+			}
+			return
+		}
+
+		for _, o := range e.reels[reel].outcomes {
+			e.spin.ModifyTile(reel*rowCount, o.Symbol, false, 0)
+			next(reel+1, p*o.Probability)
+		}
+	}
+	next(0, 1.0)
+
+	e.result.ReleasePayouts()
+
+	out.RTP = rtp * lines * 100
+	out.LineHitRate = hits * 100
+}
+
+// evaluateGrids enumerates all distinct grids to determine the hit rate and variance.
+func (e *evaluator) evaluateGrids(out *Result) {
+	set := e.slots.Paylines()
+	rowCount := e.slots.RowCount()
+
+	windows := make([][]Window, len(e.reels))
+	for reel := range e.reels {
+		windows[reel] = e.reels[reel].Windows()
+	}
+
+	var mean, squares, hits float64
+
+	var next func(reel int, p float64)
+	next = func(reel int, p float64) {
+		if reel == len(windows) {
+			out.Combinations++
+
+			e.result.ReleasePayouts()
+			if !set.GetPayouts(e.spin, e.result) {
+				return
+			}
+
+			total := e.result.Total
+			hits += p
+			mean += p * total
+			squares += p * total * total
+			return
+		}
+
+		offset := reel * rowCount
+		for _, w := range windows[reel] {
+			for row, symbol := range w.Symbols {
+				e.spin.ModifyTile(offset+row, symbol, false, 0)
+			}
+			next(reel+1, p*w.Probability)
+		}
+	}
+	next(0, 1.0)
+
+	e.result.ReleasePayouts()
+
+	out.Enumerated = true
+	out.HitRate = hits * 100
+	out.Variance = squares - mean*mean
+	out.enumRTP = mean * 100
+}
+
+func (e *evaluator) contribution(p *comp.SpinPayout) *Contribution {
+	key := contributionKey{symbol: p.Symbol(), count: p.Count(), direction: p.Direction()}
+	c, ok := e.contributions[key]
+	if !ok {
+		c = &Contribution{Symbol: key.symbol, Count: key.count, Direction: key.direction}
+		e.contributions[key] = c
+	}
+	return c
+}
+
+// sequence is a deterministic generator, used to instantiate the spin which is then filled with the enumerated symbols.
+// It cycles through all values, so the spin can resolve any random replacements during the initial spin.
+type sequence struct {
+	next uint64
+}
+
+func (g *sequence) ReturnToPool() {}
+
+func (g *sequence) Uint32() uint32 {
+	return uint32(g.Uint64())
+}
+
+func (g *sequence) Uint64() uint64 {
+	g.next++
+	return g.next
+}
+
+func (g *sequence) IntN(n int) int {
+	return int(g.Uint64() % uint64(n))
+}
+
+func (g *sequence) IntsN(n int, out []int) {
+	for ix := range out {
+		out[ix] = g.IntN(n)
+	}
+}
+
+var (
+	ErrNoPaylines = errors.New("slot machine has no paylines")
+	ErrReelCount  = errors.New("reels do not match the grid of the slot machine")
+)
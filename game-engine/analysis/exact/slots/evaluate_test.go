@@ -0,0 +1,191 @@
+package slots
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/prng.git/rng"
+
+	comp "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/components/slots"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/results"
+)
+
+var (
+	sym1 = comp.NewSymbol(1, comp.WithPayouts(0, 0, 3, 6, 12), comp.WithWeights(90, 70, 90, 70, 90))
+	sym2 = comp.NewSymbol(2, comp.WithPayouts(0, 0, 3, 6, 15), comp.WithWeights(90, 70, 90, 70, 90))
+	sym3 = comp.NewSymbol(3, comp.WithPayouts(0, 0, 6, 7.5, 15), comp.WithWeights(70, 90, 70, 90, 70))
+	sym4 = comp.NewSymbol(4, comp.WithPayouts(0, 0, 6, 12, 21), comp.WithWeights(70, 90, 70, 90, 70))
+	wild = comp.NewSymbol(5, comp.WithKind(comp.Wild), comp.WithPayouts(0, 0, 6, 15, 45), comp.WithWeights(0, 8, 8, 8, 0))
+
+	symbols = comp.NewSymbolSet(sym1, sym2, sym3, sym4, wild)
+
+	pl1 = comp.NewPayline(1, 3, 1, 1, 1, 1, 1)
+	pl2 = comp.NewPayline(2, 3, 0, 0, 0, 0, 0)
+	pl3 = comp.NewPayline(3, 3, 2, 2, 2, 2, 2)
+	pl4 = comp.NewPayline(4, 3, 0, 1, 2, 1, 0)
+	pl5 = comp.NewPayline(5, 3, 2, 1, 0, 1, 2)
+)
+
+func TestEvaluate_Simple(t *testing.T) {
+	a := comp.NewSymbol(1, comp.WithPayouts(0, 0, 10))
+	b := comp.NewSymbol(2, comp.WithPayouts(0, 0, 5))
+	strips := comp.NewSymbolReels(
+		comp.NewSymbolReel(1, 1, 2),
+		comp.NewSymbolReel(1, 2, 1),
+		comp.NewSymbolReel(1, 1, 2),
+	)
+
+	s := comp.NewSlots(comp.Grid(3, 1), comp.WithSymbols(comp.NewSymbolSet(a, b)), comp.WithSpinner(strips),
+		comp.WithPaylines(comp.PayLTR, false, comp.NewPayline(1, 1, 0, 0, 0)))
+
+	reels, err := NewReels(s)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(reels))
+	assert.Equal(t, uint64(8), reels.Combinations())
+
+	r, err := Evaluate(s, reels)
+	require.NoError(t, err)
+	require.NotNil(t, r)
+
+	// AAA pays 10 and BBB pays 5, each with a chance of 1/8.
+	assert.InDelta(t, 187.5, r.RTP, 1e-9)
+	assert.InDelta(t, 25.0, r.LineHitRate, 1e-9)
+	assert.Equal(t, 1, r.Lines)
+	assert.Equal(t, uint64(8), r.LineTuples)
+
+	assert.True(t, r.Enumerated)
+	assert.Equal(t, uint64(8), r.Combinations)
+	assert.InDelta(t, 25.0, r.HitRate, 1e-9)
+	assert.InDelta(t, 187.5, r.enumRTP, 1e-9)
+	assert.InDelta(t, (100.0+25.0)/8-1.875*1.875, r.Variance, 1e-9)
+	assert.InDelta(t, math.Sqrt(r.Variance), r.StdDev(), 1e-9)
+
+	require.Equal(t, 2, len(r.Contributions))
+	assert.Equal(t, &Contribution{Symbol: 1, Count: 3, Direction: comp.PayLTR, RTP: 125, Frequency: 0.125}, r.Contributions[0])
+	assert.Equal(t, &Contribution{Symbol: 2, Count: 3, Direction: comp.PayLTR, RTP: 62.5, Frequency: 0.125}, r.Contributions[1])
+	assert.InDelta(t, 125.0, r.Symbol(1), 1e-9)
+	assert.Zero(t, r.Symbol(3))
+}
+
+func TestEvaluate_Enumerated(t *testing.T) {
+	strips := comp.NewSymbolReels(
+		comp.NewSymbolReel(3, 1, 2, 5, 3, 4, 1, 3, 2, 4),
+		comp.NewSymbolReel(3, 2, 1, 4, 5, 3, 3, 1),
+		comp.NewSymbolReel(3, 3, 4, 1, 2, 5, 2, 4, 1),
+		comp.NewSymbolReel(3, 4, 3, 2, 1, 1, 5),
+		comp.NewSymbolReel(3, 1, 3, 2, 4, 2, 3, 4),
+	)
+
+	testCases := []struct {
+		name      string
+		direction comp.PayDirection
+		highest   bool
+	}{
+		{name: "ltr", direction: comp.PayLTR},
+		{name: "rtl", direction: comp.PayRTL},
+		{name: "both", direction: comp.PayBoth},
+		{name: "ltr highest", direction: comp.PayLTR, highest: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := comp.NewSlots(comp.Grid(5, 3), comp.WithSymbols(symbols), comp.WithSpinner(strips),
+				comp.WithPaylines(tc.direction, tc.highest, pl1, pl2, pl3, pl4, pl5))
+
+			reels, err := NewReels(s)
+			require.NoError(t, err)
+
+			r, err := Evaluate(s, reels)
+			require.NoError(t, err)
+			require.NotNil(t, r)
+
+			assert.True(t, r.Enumerated)
+			assert.Equal(t, uint64(9*7*8*6*7), r.Combinations)
+			assert.Greater(t, r.RTP, 0.0)
+			assert.InDelta(t, r.enumRTP, r.RTP, 1e-9)
+			assert.GreaterOrEqual(t, r.HitRate, r.LineHitRate)
+
+			var total float64
+			for _, c := range r.Contributions {
+				total += c.RTP
+			}
+			assert.InDelta(t, r.RTP, total, 1e-9)
+		})
+	}
+}
+
+func TestEvaluate_MonteCarlo(t *testing.T) {
+	s := comp.NewSlots(comp.Grid(5, 3), comp.WithSymbols(symbols), comp.WithPaylines(comp.PayLTR, false, pl1, pl2, pl3, pl4, pl5))
+
+	reels, err := NewReels(s)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(64*125*125*125*64), reels.Combinations())
+
+	r, err := Evaluate(s, reels)
+	require.NoError(t, err)
+	require.NotNil(t, r)
+	assert.False(t, r.Enumerated)
+	assert.Zero(t, r.HitRate)
+	assert.Equal(t, uint64(4*5*5*5*4), r.LineTuples)
+
+	prng := rng.NewRNG()
+	defer prng.ReturnToPool()
+
+	spin := comp.AcquireSpin(s, prng)
+	defer spin.Release()
+
+	result := results.AcquireResult(nil, 0)
+	defer result.Release()
+
+	const rounds = 1000000
+	var sum, squares float64
+	for ix := 0; ix < rounds; ix++ {
+		spin.Spin()
+		result.ReleasePayouts()
+		s.Paylines().GetPayouts(spin, result)
+		sum += result.Total
+		squares += result.Total * result.Total
+	}
+
+	mean := sum / rounds
+	stdErr := math.Sqrt((squares/rounds - mean*mean) / rounds)
+	assert.InDelta(t, r.RTP, mean*100, 5*stdErr*100)
+}
+
+func TestEvaluate_MaxCombinations(t *testing.T) {
+	s := comp.NewSlots(comp.Grid(5, 1), comp.WithSymbols(symbols), comp.WithPaylines(comp.PayLTR, false, comp.NewPayline(1, 1, 0, 0, 0, 0, 0)))
+
+	reels, err := NewReels(s)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4*5*5*5*4), reels.Combinations())
+
+	r1, err := Evaluate(s, reels, MaxCombinations(0))
+	require.NoError(t, err)
+	assert.False(t, r1.Enumerated)
+	assert.Zero(t, r1.Combinations)
+
+	r2, err := Evaluate(s, reels)
+	require.NoError(t, err)
+	assert.True(t, r2.Enumerated)
+	assert.Equal(t, uint64(4*5*5*5*4), r2.Combinations)
+	assert.InDelta(t, r2.enumRTP, r2.RTP, 1e-9)
+	assert.InDelta(t, r2.LineHitRate, r2.HitRate, 1e-9)
+	assert.Equal(t, r1.RTP, r2.RTP)
+	assert.Equal(t, r1.LineHitRate, r2.LineHitRate)
+}
+
+func TestEvaluate_Fail(t *testing.T) {
+	noLines := comp.NewSlots(comp.Grid(5, 3), comp.WithSymbols(symbols))
+	reels, err := NewReels(noLines)
+	require.NoError(t, err)
+
+	_, err = Evaluate(noLines, reels)
+	assert.ErrorIs(t, err, ErrNoPaylines)
+
+	s := comp.NewSlots(comp.Grid(5, 3), comp.WithSymbols(symbols), comp.WithPaylines(comp.PayLTR, false, pl1))
+	_, err = Evaluate(s, reels[:4])
+	assert.ErrorIs(t, err, ErrReelCount)
+}
@@ -0,0 +1,242 @@
+package slots
+
+import (
+	"errors"
+	"sort"
+
+	comp "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/components/slots"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/utils"
+)
+
+// Outcome represents a symbol and the probability it lands on a single row of a reel.
+type Outcome struct {
+	Symbol      utils.Index `json:"symbol"`
+	Probability float64     `json:"probability"`
+}
+
+// Window represents the symbols visible on a reel after a spin, and the probability they land together.
+type Window struct {
+	Symbols     utils.Indexes `json:"symbols"`
+	Probability float64       `json:"probability"`
+}
+
+// Reel contains the probability distribution of the symbols on a single reel.
+type Reel struct {
+	rows     int
+	outcomes []Outcome // distribution of a single row; identical for all rows.
+	windows  []Window  // distinct windows; nil if the rows are independent.
+}
+
+// Reels represents the probability distributions of all reels of a slot machine.
+type Reels []*Reel
+
+// NewReels determines the reel distributions from the spinner configured for the slot machine.
+// The built-in spinner and SymbolReels are supported.
+// Other spinners, the noRepeat option and non-rectangular grids cannot be evaluated exactly.
+func NewReels(s *comp.Slots) (Reels, error) {
+	switch spinner := s.Spinner().(type) {
+	case nil:
+		return ReelsFromWeights(s)
+	case *comp.SymbolReels:
+		return ReelsFromSymbolReels(s, spinner)
+	default:
+		return nil, ErrUnsupportedSpinner
+	}
+}
+
+// ReelsFromWeights determines the reel distributions from the symbol weights, as used by the built-in spinner.
+// Each row of a reel is an independent draw from the weights of the symbols for that reel.
+func ReelsFromWeights(s *comp.Slots) (Reels, error) {
+	if s.NoRepeat() > 0 {
+		return nil, ErrNoRepeat
+	}
+	if err := verifyGrid(s); err != nil {
+		return nil, err
+	}
+
+	symbols := s.Symbols().Symbols()
+	reels := make(Reels, s.ReelCount())
+
+	for reel := range reels {
+		var total float64
+		for _, symbol := range symbols {
+			if w := symbol.Weights(); reel < len(w) && w[reel] > 0 {
+				total += w[reel]
+			}
+		}
+		if total <= 0 {
+			return nil, ErrEmptyReel
+		}
+
+		outcomes := make([]Outcome, 0, len(symbols))
+		for _, symbol := range symbols {
+			if w := symbol.Weights(); reel < len(w) && w[reel] > 0 {
+				outcomes = append(outcomes, Outcome{Symbol: symbol.ID(), Probability: w[reel] / total})
+			}
+		}
+
+		reels[reel] = newReel(s.RowCount(), outcomes, nil)
+	}
+
+	return reels, nil
+}
+
+// ReelsFromSymbolReels determines the reel distributions from the stops of the given symbol reels.
+// Each stop of a reel strip is equally likely, and the strips wrap around.
+func ReelsFromSymbolReels(s *comp.Slots, sr *comp.SymbolReels) (Reels, error) {
+	if err := verifyGrid(s); err != nil {
+		return nil, err
+	}
+
+	rows := s.RowCount()
+	reels := make(Reels, s.ReelCount())
+
+	for reel := range reels {
+		stops := sr.Reel(uint8(reel + 1))
+		max := len(stops)
+		if max == 0 {
+			return nil, ErrEmptyReel
+		}
+
+		p := 1.0 / float64(max)
+
+		counts := make(map[utils.Index]int, 16)
+		for _, stop := range stops {
+			counts[stop]++
+		}
+
+		outcomes := make([]Outcome, 0, len(counts))
+		for symbol, count := range counts {
+			outcomes = append(outcomes, Outcome{Symbol: symbol, Probability: float64(count) * p})
+		}
+
+		windows := make([]Window, 0, max)
+		found := make(map[string]int, max)
+		key := make([]byte, 0, rows*2)
+
+		for stop := range stops {
+			window := make(utils.Indexes, rows)
+			key = key[:0]
+			for row := range window {
+				window[row] = stops[(stop+row)%max]
+				key = append(key, byte(window[row]), byte(window[row]>>8))
+			}
+
+			if ix, ok := found[string(key)]; ok {
+				windows[ix].Probability += p
+			} else {
+				found[string(key)] = len(windows)
+				windows = append(windows, Window{Symbols: window, Probability: p})
+			}
+		}
+
+		reels[reel] = newReel(rows, outcomes, windows)
+	}
+
+	return reels, nil
+}
+
+// Rows returns the number of rows of the reel.
+func (r *Reel) Rows() int {
+	return r.rows
+}
+
+// Outcomes returns the probability distribution of the symbols on a single row of the reel, ordered by symbol.
+func (r *Reel) Outcomes() []Outcome {
+	return r.outcomes
+}
+
+// Probability returns the probability of the given symbol landing on a single row of the reel.
+func (r *Reel) Probability(symbol utils.Index) float64 {
+	for ix := range r.outcomes {
+		if o := r.outcomes[ix]; o.Symbol == symbol {
+			return o.Probability
+		}
+	}
+	return 0
+}
+
+// WindowCount returns the number of distinct windows of the reel.
+// It returns 0 if the number overflows.
+func (r *Reel) WindowCount() uint64 {
+	if r.windows != nil {
+		return uint64(len(r.windows))
+	}
+	return power(uint64(len(r.outcomes)), r.rows)
+}
+
+// Windows returns the distinct windows of the reel with their probabilities.
+// For reels with independent rows, the windows are generated from the outcomes of each row.
+// Use WindowCount() first, as the number of windows grows exponentially with the number of rows.
+func (r *Reel) Windows() []Window {
+	if r.windows != nil {
+		return r.windows
+	}
+
+	windows := make([]Window, 0, r.WindowCount())
+	window := make(utils.Indexes, r.rows)
+
+	var generate func(row int, p float64)
+	generate = func(row int, p float64) {
+		if row == r.rows {
+			windows = append(windows, Window{Symbols: utils.CopyIndexes(window, nil), Probability: p})
+			return
+		}
+		for _, o := range r.outcomes {
+			window[row] = o.Symbol
+			generate(row+1, p*o.Probability)
+		}
+	}
+	generate(0, 1.0)
+
+	return windows
+}
+
+// Combinations returns the number of distinct grids of the reels.
+// It returns 0 if the number overflows.
+func (r Reels) Combinations() uint64 {
+	total := uint64(1)
+	for _, reel := range r {
+		n := reel.WindowCount()
+		if n == 0 || total > maxCombinations/n {
+			return 0
+		}
+		total *= n
+	}
+	return total
+}
+
+func newReel(rows int, outcomes []Outcome, windows []Window) *Reel {
+	sort.Slice(outcomes, func(i, j int) bool { return outcomes[i].Symbol < outcomes[j].Symbol })
+	return &Reel{rows: rows, outcomes: outcomes, windows: windows}
+}
+
+func verifyGrid(s *comp.Slots) error {
+	rows := uint8(s.RowCount())
+	for _, m := range s.GridDefinition().GridMask() {
+		if m != rows {
+			return ErrGridMask
+		}
+	}
+	return nil
+}
+
+func power(n uint64, exp int) uint64 {
+	total := uint64(1)
+	for ix := 0; ix < exp; ix++ {
+		if n > 0 && total > maxCombinations/n {
+			return 0
+		}
+		total *= n
+	}
+	return total
+}
+
+const maxCombinations = 1<<63 - 1
+
+var (
+	ErrUnsupportedSpinner = errors.New("spinner does not support exact evaluation")
+	ErrNoRepeat           = errors.New("reels with the noRepeat option do not support exact evaluation")
+	ErrGridMask           = errors.New("non-rectangular grids do not support exact evaluation")
+	ErrEmptyReel          = errors.New("reel has no symbols")
+)
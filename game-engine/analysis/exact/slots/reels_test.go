@@ -0,0 +1,84 @@
+package slots
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	comp "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/components/slots"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/utils"
+)
+
+func TestReelsFromWeights(t *testing.T) {
+	s := comp.NewSlots(comp.Grid(5, 3), comp.WithSymbols(symbols))
+
+	reels, err := ReelsFromWeights(s)
+	require.NoError(t, err)
+	require.Equal(t, 5, len(reels))
+
+	r := reels[0]
+	assert.Equal(t, 3, r.Rows())
+	assert.Equal(t, []Outcome{{1, 90.0 / 320}, {2, 90.0 / 320}, {3, 70.0 / 320}, {4, 70.0 / 320}}, r.Outcomes())
+	assert.Zero(t, r.Probability(5))
+	assert.Equal(t, uint64(64), r.WindowCount())
+
+	r = reels[1]
+	assert.InDelta(t, 8.0/328, r.Probability(5), 1e-12)
+	assert.Equal(t, uint64(125), r.WindowCount())
+
+	windows := r.Windows()
+	require.Equal(t, 125, len(windows))
+	assert.Equal(t, utils.Indexes{1, 1, 1}, windows[0].Symbols)
+	assert.Equal(t, utils.Indexes{5, 5, 5}, windows[124].Symbols)
+
+	var total float64
+	for _, w := range windows {
+		total += w.Probability
+	}
+	assert.InDelta(t, 1.0, total, 1e-12)
+}
+
+func TestReelsFromSymbolReels(t *testing.T) {
+	strips := comp.NewSymbolReels(
+		comp.NewSymbolReel(3, 1, 2, 1, 2),
+		comp.NewSymbolReel(3, 1, 1, 1, 3),
+	)
+	s := comp.NewSlots(comp.Grid(2, 3), comp.WithSymbols(symbols), comp.WithSpinner(strips))
+
+	reels, err := NewReels(s)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(reels))
+
+	r := reels[0]
+	assert.Equal(t, []Outcome{{1, 0.5}, {2, 0.5}}, r.Outcomes())
+	assert.Equal(t, []Window{{utils.Indexes{1, 2, 1}, 0.5}, {utils.Indexes{2, 1, 2}, 0.5}}, r.Windows())
+
+	r = reels[1]
+	assert.Equal(t, []Outcome{{1, 0.75}, {3, 0.25}}, r.Outcomes())
+	assert.Equal(t, uint64(4), r.WindowCount())
+	assert.Equal(t, utils.Indexes{1, 3, 1}, r.Windows()[2].Symbols)
+
+	assert.Equal(t, uint64(8), reels.Combinations())
+}
+
+func TestNewReelsFail(t *testing.T) {
+	testCases := []struct {
+		name  string
+		slots *comp.Slots
+		err   error
+	}{
+		{name: "no repeat", slots: comp.NewSlots(comp.Grid(5, 3), comp.WithSymbols(symbols), comp.NoRepeat(2)), err: ErrNoRepeat},
+		{name: "grid mask", slots: comp.NewSlots(comp.Grid(5, 3), comp.WithSymbols(symbols), comp.WithMask(2, 3, 3, 3, 2)), err: ErrGridMask},
+		{name: "spinner", slots: comp.NewSlots(comp.Grid(5, 3), comp.WithSymbols(symbols), comp.WithSpinner(comp.NewReelUpdater())), err: ErrUnsupportedSpinner},
+		{name: "empty reel", slots: comp.NewSlots(comp.Grid(6, 3), comp.WithSymbols(symbols)), err: ErrEmptyReel},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			reels, err := NewReels(tc.slots)
+			assert.ErrorIs(t, err, tc.err)
+			assert.Nil(t, reels)
+		})
+	}
+}
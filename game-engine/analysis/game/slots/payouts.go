@@ -2,7 +2,9 @@ package slots
 
 import (
 	"fmt"
+	"slices"
 
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/encode/zjson"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/object-pool/pool"
 
 	analyse "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/analysis/metrics/slots"
@@ -212,3 +214,98 @@ func (p *Payouts) ResetData() {
 		}
 	}
 }
+
+// EncodeFields implements the zjson.Encoder.EncodeFields interface.
+func (p *Payouts) EncodeFields(enc *zjson.Encoder) {
+	enc.Uint64FieldOpt("count", p.Count)
+	enc.Int64FieldOpt("total", p.Total)
+	enc.ObjectField("wildPayouts", p.WildPayouts)
+	enc.ObjectField("scatterPayouts", p.ScatterPayouts)
+	enc.ObjectField("bonusPayouts", p.BonusPayouts)
+	enc.ObjectField("superPayouts", p.SuperPayouts)
+	enc.ObjectField("otherPayouts", p.OtherPayouts)
+
+	enc.StartArrayField("paylines")
+	for ix := range p.Paylines {
+		if l := p.Paylines[ix]; l != nil {
+			enc.Object(l)
+		}
+	}
+	enc.EndArray()
+
+	ids := make([]int, 0, len(p.AllPaylines))
+	for id := range p.AllPaylines {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	enc.StartArrayField("allPaylines")
+	for _, id := range ids {
+		enc.Object(p.AllPaylines[id])
+	}
+	enc.EndArray()
+}
+
+// DecodeField implements the zjson.Decoder.DecodeField interface.
+// The payout metrics must have been instantiated with the same configuration as the encoded metrics.
+func (p *Payouts) DecodeField(dec *zjson.Decoder, key []byte) error {
+	var ok bool
+
+	if string(key) == "count" {
+		p.Count, ok = dec.Uint64()
+	} else if string(key) == "total" {
+		p.Total, ok = dec.Int64()
+	} else if string(key) == "wildPayouts" {
+		ok = dec.Object(p.WildPayouts)
+	} else if string(key) == "scatterPayouts" {
+		ok = dec.Object(p.ScatterPayouts)
+	} else if string(key) == "bonusPayouts" {
+		ok = dec.Object(p.BonusPayouts)
+	} else if string(key) == "superPayouts" {
+		ok = dec.Object(p.SuperPayouts)
+	} else if string(key) == "otherPayouts" {
+		ok = dec.Object(p.OtherPayouts)
+	} else if string(key) == "paylines" {
+		ok = dec.Array(p.decodePayline)
+	} else if string(key) == "allPaylines" {
+		ok = dec.Array(p.decodeAllPayline)
+	} else {
+		return fmt.Errorf("Payouts.DecodeField invalid field: %s", string(key))
+	}
+
+	if ok {
+		return nil
+	}
+	return dec.Error()
+}
+
+func (p *Payouts) decodePayline(dec *zjson.Decoder) error {
+	l := analyse.NewPayline(0, p.maxSymbol, nil)
+	if !dec.Object(l) {
+		l.Release()
+		return dec.Error()
+	}
+
+	if l.ID < 0 || l.ID >= len(p.Paylines) || p.Paylines[l.ID] == nil {
+		l.Release()
+		return ErrSnapshotMismatch
+	}
+
+	p.Paylines[l.ID].Release()
+	p.Paylines[l.ID] = l
+	return nil
+}
+
+func (p *Payouts) decodeAllPayline(dec *zjson.Decoder) error {
+	l := analyse.NewPayline(0, p.maxSymbol, nil)
+	if !dec.Object(l) {
+		l.Release()
+		return dec.Error()
+	}
+
+	if old := p.AllPaylines[l.ID]; old != nil {
+		old.Release()
+	}
+	p.AllPaylines[l.ID] = l
+	return nil
+}
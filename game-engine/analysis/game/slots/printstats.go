@@ -268,6 +268,31 @@ func BetTotals(all *slots.Rounds) SimKVs {
 	}
 }
 
+// RTPStatistics returns the RTP with its variance, volatility index and confidence intervals.
+func RTPStatistics(r *Rounds) SimKVs {
+	out := SimKVs{
+		{Key: "Rounds", Values: []string{formatter.Sprintf("%d", r.RoundCount)}},
+		{Key: "RTP", Values: []string{formatter.Sprintf("%.4f%%", r.RTP())}},
+		{Key: "Variance", Values: []string{formatter.Sprintf("%.4f", r.Variance())}},
+		{Key: "Standard deviation", Values: []string{formatter.Sprintf("%.4f", r.StdDev())}},
+		{Key: "Volatility index (90%)", Values: []string{formatter.Sprintf("%.4f", r.VolatilityIndex(slots.Confidence90))}},
+	}
+
+	for _, level := range []slots.Confidence{slots.Confidence90, slots.Confidence95, slots.Confidence99} {
+		lo, hi := r.RTPConfidence(level)
+		out = append(out, SimKV{
+			Key: "RTP " + level.String() + " confidence",
+			Values: []string{
+				formatter.Sprintf("%.4f%%", lo),
+				formatter.Sprintf("%.4f%%", hi),
+				formatter.Sprintf("+/- %.4f%%", (hi-lo)/2),
+			},
+		})
+	}
+
+	return out
+}
+
 func BetSpread(all *slots.Rounds) *SimTable {
 	out := &SimTable{Keys: []string{"bet", "count"}}
 	for k, v := range all.Bets.Counts {
@@ -470,20 +495,29 @@ func (r *Rounds) RoundsBracketsToTxt(rtp int, prt Reporter) {
 	desktopPath := filepath.Join(homeDir, "Desktop", fname)
 
 	// Create the txt file on the Desktop
-	file, err := os.Create(desktopPath)
-	if err != nil {
-		fmt.Println("Error creating txt file:", err)
+	if err = r.RoundsBracketsToTxtFile(desktopPath, prt); err != nil {
+		fmt.Println("Error writing txt file:", err)
 		return
 	}
+
+	fmt.Printf("txt file successfully saved to %s\n", desktopPath)
+}
+
+// RoundsBracketsToTxtFile writes the stats to the given txt file.
+func (r *Rounds) RoundsBracketsToTxtFile(path string, prt Reporter) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
 	defer file.Close()
 
 	writer := bufio.NewWriter(file)
-	defer writer.Flush()
 
-	// STEP 2 - DO THE LOGIC FROM THE SIMULATOR
+	// write the stats in the format of the simulator.
 
 	printBlock(writer, prt, "bet totals", BetTotals(r.AllRounds))
 	printTable(writer, prt, "bet spread", BetSpread(r.AllRounds), false)
+	printBlock(writer, prt, "RTP statistics", RTPStatistics(r))
 
 	rounds := r.AllRounds
 
@@ -531,7 +565,7 @@ func (r *Rounds) RoundsBracketsToTxt(rtp int, prt Reporter) {
 
 	printTable(writer, prt, "symbol occurrence per round", RoundSymbols(r), false)
 
-	fmt.Printf("txt file successfully saved to %s\n", desktopPath)
+	return writer.Flush()
 }
 
 func (r *Rounds) WriteZeroDistributionReport(rtp int, prt Reporter) {
@@ -16,6 +16,8 @@ import (
 	analyse "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/analysis/metrics/slots"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/components/slots"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/consts"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/games/cards"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/games/pickem"
 	wheel2 "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/games/wheel"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/results"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/utils"
@@ -105,6 +107,26 @@ func (r *Rounds) SetBestThreshold(threshold float64, maxBest int) {
 	r.maxBest = maxBest
 }
 
+// SetJackpotPools sets the progressive jackpot pools the bets of the analyzed rounds contribute to.
+// The pools are seeded immediately, and a pool is awarded when a round triggers its level in the game engine.
+// The state of the pools is not part of a snapshot, so the pools are seeded again when a simulation is resumed.
+func (r *Rounds) SetJackpotPools(pools ...JackpotPool) {
+	r.jackpots = r.jackpots[:0]
+	for ix := range pools {
+		r.jackpots = append(r.jackpots, jackpotPool{JackpotPool: pools[ix]})
+	}
+	r.seedJackpots()
+}
+
+// JackpotPools returns the definitions of the progressive jackpot pools.
+func (r *Rounds) JackpotPools() []JackpotPool {
+	out := make([]JackpotPool, len(r.jackpots))
+	for ix := range r.jackpots {
+		out[ix] = r.jackpots[ix].JackpotPool
+	}
+	return out
+}
+
 // BestThreshold returns the current threshold for best rounds.
 func (r *Rounds) BestThreshold() float64 {
 	return r.bestThreshold
@@ -123,6 +145,29 @@ func (r *Rounds) RTP() float64 {
 	return 0
 }
 
+// JackpotRTP returns the RTP (return-to-player) of the progressive jackpots awarded in the analyzed rounds.
+// It includes the seed values of the awarded pools, but not the contributions still in the pools.
+func (r *Rounds) JackpotRTP() float64 {
+	if r.AllRounds.Bets.Total > 0 {
+		return float64(r.JackpotWins) * 100.0 / float64(r.AllRounds.Bets.Total)
+	}
+	return 0
+}
+
+// TotalRTP returns the RTP (return-to-player) for the analyzed results including the progressive jackpots awarded.
+func (r *Rounds) TotalRTP() float64 {
+	return r.RTP() + r.JackpotRTP()
+}
+
+// GambleRTP returns the part of the RTP (return-to-player) from gambling the wins of rounds.
+// It is included in RTP(), and is negative if the players lost more than they won by gambling.
+func (r *Rounds) GambleRTP() float64 {
+	if r.AllRounds.Bets.Total > 0 {
+		return float64(r.GambleWin) * 100.0 / float64(r.AllRounds.Bets.Total)
+	}
+	return 0
+}
+
 // RTPnoFree returns the RTP (return-to-player) for the analyzed results with no free spins.
 func (r *Rounds) RTPnoFree() float64 {
 	if r.AllRounds.BetsNoFree.Total > 0 {
@@ -147,6 +192,40 @@ func (r *Rounds) HitRate() float64 {
 	return 0
 }
 
+// Variance returns the sample variance of the return per round, expressed in multiples of the bet.
+func (r *Rounds) Variance() float64 {
+	return r.Returns.Variance()
+}
+
+// StdDev returns the sample standard deviation of the return per round, expressed in multiples of the bet.
+func (r *Rounds) StdDev() float64 {
+	return r.Returns.StdDev()
+}
+
+// VolatilityIndex returns the volatility index for the given confidence level.
+// The volatility index is the standard deviation of the return per round multiplied by the critical value of the
+// confidence level. It indicates the expected spread of the RTP for a given number of rounds (VI / sqrt(rounds)).
+func (r *Rounds) VolatilityIndex(level analyse.Confidence) float64 {
+	return level.Z() * r.Returns.StdDev()
+}
+
+// RTPConfidence returns the lower and upper bound of the confidence interval of the RTP for the given level.
+// The interval is centered on the mean return per round, which equals RTP() if all rounds are played with the same bet.
+func (r *Rounds) RTPConfidence(level analyse.Confidence) (float64, float64) {
+	lo, hi := r.Returns.ConfidenceInterval(level)
+	return lo * 100.0, hi * 100.0
+}
+
+// RTPConfidenceWidth returns the width of the confidence interval of the RTP for the given level.
+// It returns +Inf if there are not enough rounds to determine the interval.
+func (r *Rounds) RTPConfidenceWidth(level analyse.Confidence) float64 {
+	if r.Returns.Count < 2 {
+		return math.Inf(1)
+	}
+	lo, hi := r.RTPConfidence(level)
+	return hi - lo
+}
+
 // WinningProbability returns the winning probability.
 func (r *Rounds) WinningProbability() float64 {
 	if r.AllRounds.Count > 0 {
@@ -189,6 +268,15 @@ func (r *Rounds) Merge(other *Rounds) {
 	r.SecondFreeSpins += other.SecondFreeSpins
 	r.SuperSpinsFree += other.SuperSpinsFree
 	r.SuperRefillsFree += other.SuperRefillsFree
+	r.HoldAndWinTimes += other.HoldAndWinTimes
+	r.HoldAndWinRespins += other.HoldAndWinRespins
+	r.PickemTimes += other.PickemTimes
+	r.PickemPicks += other.PickemPicks
+	r.GambleSteps += other.GambleSteps
+	r.GambleWins += other.GambleWins
+	r.GambleWin += other.GambleWin
+	r.JackpotWins += other.JackpotWins
+	r.JackpotSeeds += other.JackpotSeeds
 	r.BadSpins += other.BadSpins
 	r.MaxPayouts += other.MaxPayouts
 	r.PositiveBal += other.PositiveBal
@@ -220,8 +308,13 @@ func (r *Rounds) Merge(other *Rounds) {
 	r.Count2500x.Merge(other.Count2500x)
 	r.CountPlusBal.Merge(other.CountPlusBal)
 	r.BonusWheel.Merge(other.BonusWheel)
+	r.HoldAndWinCoins.Merge(other.HoldAndWinCoins)
+	r.HoldAndWinPayouts.Merge(other.HoldAndWinPayouts)
+	r.PickemLevels.Merge(other.PickemLevels)
+	r.PickemPayouts.Merge(other.PickemPayouts)
 	r.MultiplierMarks.Merge(other.MultiplierMarks)
 	r.Multipliers.Merge(other.Multipliers)
+	r.Returns.Merge(other.Returns)
 
 	for k, v := range other.BonusRounds {
 		br, ok := r.BonusRounds[k]
@@ -247,6 +340,15 @@ func (r *Rounds) Merge(other *Rounds) {
 	for key, count := range other.PlayerChoice {
 		r.PlayerChoice[key] = r.PlayerChoice[key] + count
 	}
+	for key, count := range other.Jackpots {
+		r.Jackpots[key] = r.Jackpots[key] + count
+	}
+	for key, count := range other.Progressives {
+		r.Progressives[key] = r.Progressives[key] + count
+	}
+	if len(r.jackpots) == 0 {
+		r.jackpots = append(r.jackpots, other.jackpots...)
+	}
 	for key, count := range other.Scripts {
 		r.Scripts[key] = r.Scripts[key] + count
 	}
@@ -334,6 +436,9 @@ func (r *Rounds) Analyse(bet, bonusBet int64, res results.Results) {
 	}
 
 	win := int64(math.Round(float64(bet) * grandTotal))
+	if bet > 0 {
+		r.Returns.Increase(float64(win) / float64(bet))
+	}
 	if win > 0 {
 		r.WinCount++
 		if win > r.HighestPayout {
@@ -365,6 +470,12 @@ func (r *Rounds) Analyse(bet, bonusBet int64, res results.Results) {
 		}
 	}
 
+	// gamble steps are played after the round, and only change the win of the round.
+	all := res
+	res, gamble := splitGamble(res)
+	r.analyseGamble(bet, gamble)
+	r.analyseJackpots(bonusBet, res)
+
 	m := len(res) - 1
 
 	var wildRespin bool
@@ -447,11 +558,11 @@ func (r *Rounds) Analyse(bet, bonusBet int64, res results.Results) {
 
 	if !r.noBest {
 		if grandTotal >= r.bestThreshold {
-			r.addBest(res, grandTotal)
+			r.addBest(all, grandTotal)
 		}
 
 		if free == 0 && grandTotal > r.bestNoFreeThreshold {
-			r.addBestNoFree(res, grandTotal)
+			r.addBestNoFree(all, grandTotal)
 		}
 	}
 }
@@ -465,6 +576,8 @@ func (r *Rounds) analyseResult(result *results.Result, bonusKind, aggregateBonus
 		}
 
 		r.analyseSpinActions(spin)
+		r.analyseHoldAndWin(spin, result.Payouts)
+		r.analyseProgressive(spin)
 		r.analyseRoundFlags(spin.RoundFlags(), last)
 		r.analyseScript(spin, first)
 
@@ -512,6 +625,14 @@ func (r *Rounds) analyseResult(result *results.Result, bonusKind, aggregateBonus
 		return
 	}
 
+	if game, ok := data.(*pickem.PickemResult); ok {
+		r.analysePickem(game, result.Total)
+
+		events, _, _ := game.Log()
+		r.analyseActions(slots.FirstSpin, events)
+		return
+	}
+
 	if selector, ok := data.(*results.BonusSelector); ok {
 		// TODO: analyse selector?
 
@@ -599,6 +720,8 @@ func (r *Rounds) analyseSpins(result results.Results, wildRespin bool) (uint64,
 			case slots.SecondFreeSpin:
 				r.SecondFreeSpins++
 				free++
+			case slots.Respin:
+				r.HoldAndWinRespins++
 			default:
 				r.BadSpins++
 			}
@@ -740,12 +863,131 @@ func (r *Rounds) analyseActions(kind slots.SpinKind, events results.Events) {
 			a.IncreaseSecondFree(e.Triggered())
 		case slots.SuperSpin:
 			a.IncreaseSuper(e.Triggered())
-		case slots.RefillSpin:
+		case slots.RefillSpin, slots.Respin:
 			a.IncreaseRefill(e.Triggered())
 		}
 	}
 }
 
+// analyseHoldAndWin updates the hold-and-win metrics.
+// A feature is counted when it triggers; its coins, jackpots and payouts are counted when it completes.
+func (r *Rounds) analyseHoldAndWin(spin *slots.SpinResult, payouts results.Payouts) {
+	coins := spin.Coins()
+	if len(coins) == 0 {
+		return
+	}
+
+	if spin.Kind() != slots.Respin {
+		r.HoldAndWinTimes++
+	}
+	if spin.Respins() > 0 {
+		return
+	}
+
+	var count uint64
+	for ix := range coins {
+		if coins[ix] > 0 {
+			count++
+		}
+	}
+	r.HoldAndWinCoins.Increase(count)
+
+	var total float64
+	for ix := range payouts {
+		if p, ok := payouts[ix].(*slots.SpinPayout); ok {
+			switch p.Kind() {
+			case results.SlotHoldAndWin:
+				total += p.Total()
+			case results.SlotJackpot:
+				total += p.Total()
+				key := p.Jackpot().String()
+				r.Jackpots[key] = r.Jackpots[key] + 1
+			}
+		}
+	}
+	r.HoldAndWinPayouts.Increase(total)
+}
+
+// analysePickem updates the pick'em metrics.
+// Each pick of a game is a separate result, so the game is only counted when it ends.
+func (r *Rounds) analysePickem(game *pickem.PickemResult, total float64) {
+	if !game.Done() {
+		return
+	}
+
+	r.PickemTimes++
+	r.PickemPicks += uint64(len(game.Picks()))
+	r.PickemLevels.Increase(uint64(game.LevelsReached()))
+	r.PickemPayouts.Increase(total)
+}
+
+// splitGamble splits the gamble steps from the end of the results of a round.
+func splitGamble(res results.Results) (results.Results, results.Results) {
+	l := len(res)
+	for l > 1 && cards.IsGamble(res[l-1]) {
+		l--
+	}
+	return res[:l], res[l:]
+}
+
+// analyseGamble counts the gamble steps and wins, and the change of the win of the round from gambling.
+func (r *Rounds) analyseGamble(bet int64, steps results.Results) {
+	for ix := range steps {
+		total := steps[ix].Total
+		r.GambleSteps++
+		if total > 0 {
+			r.GambleWins++
+		}
+		r.GambleWin += int64(math.Round(float64(bet) * total))
+	}
+}
+
+// analyseJackpots adds the contributions for the total bet of the round to the progressive jackpot pools.
+// Like the jackpot pools of the round manager, the pool with the highest level triggered in the round is awarded.
+func (r *Rounds) analyseJackpots(bet int64, res results.Results) {
+	if len(r.jackpots) == 0 {
+		return
+	}
+
+	var level uint8
+	for ix := range res {
+		if spin, ok := res[ix].Data.(*slots.SpinResult); ok && spin.Progressive() > level {
+			level = spin.Progressive()
+		}
+	}
+
+	for ix := range r.jackpots {
+		j := &r.jackpots[ix]
+		j.value += float64(bet) * j.Rate
+		j.reserve += float64(bet) * j.ReserveRate
+
+		if level > 0 && j.Level == level {
+			amount := math.Floor(j.value)
+			r.JackpotWins += int64(amount)
+			r.JackpotSeeds += j.Seed
+			j.value = float64(j.Seed) + j.reserve + (j.value - amount)
+			j.reserve = 0
+		}
+	}
+}
+
+// seedJackpots (re)starts the progressive jackpot pools at their seed values.
+func (r *Rounds) seedJackpots() {
+	for ix := range r.jackpots {
+		j := &r.jackpots[ix]
+		j.value, j.reserve = float64(j.Seed), 0
+		r.JackpotSeeds += j.Seed
+	}
+}
+
+// analyseProgressive counts the progressive jackpots triggered by the game engine, per level.
+func (r *Rounds) analyseProgressive(spin *slots.SpinResult) {
+	if level := spin.Progressive(); level > 0 {
+		key := strconv.Itoa(int(level))
+		r.Progressives[key] = r.Progressives[key] + 1
+	}
+}
+
 func (r *Rounds) analyseRoundFlags(flags []int, final bool) {
 	for id := range flags {
 		value := flags[id]
@@ -865,6 +1107,23 @@ func (r *Rounds) BestNoFreeSorted() []results.Results {
 	return r.BestNoFree
 }
 
+// JackpotPool contains the definition of a progressive jackpot pool for a simulation.
+// It mirrors the jackpot pools of the round manager, without the must-drop value.
+// Amounts are in cents.
+type JackpotPool struct {
+	Level       uint8   // level of the progressive jackpot action in the game engine which awards the pool.
+	Seed        int64   // value the pool starts at, and is reseeded to after a hit.
+	Rate        float64 // fraction of each bet added to the pool.
+	ReserveRate float64 // fraction of each bet added to the hidden reserve, which is added to the seed after a hit.
+}
+
+// jackpotPool contains the definition and the state of a progressive jackpot pool.
+type jackpotPool struct {
+	JackpotPool
+	value   float64
+	reserve float64
+}
+
 // Rounds contains all metrics for a set of spin rounds by the same player.
 type Rounds struct {
 	noPaylines          bool
@@ -891,6 +1150,12 @@ type Rounds struct {
 	SuperSpins          uint64 `json:"superSpins,omitempty"`
 	SuperRefills        uint64 `json:"superRefills,omitempty"`
 	WildRespins         uint64 `json:"wildRespins,omitempty"`
+	HoldAndWinTimes     uint64 `json:"holdAndWinTimes,omitempty"`
+	HoldAndWinRespins   uint64 `json:"holdAndWinRespins,omitempty"`
+	PickemTimes         uint64 `json:"pickemTimes,omitempty"`
+	PickemPicks         uint64 `json:"pickemPicks,omitempty"`
+	GambleSteps         uint64 `json:"gambleSteps,omitempty"`
+	GambleWins          uint64 `json:"gambleWins,omitempty"`
 	FreeTimes           uint64 `json:"freeTimes,omitempty"`
 	FirstTimes          uint64 `json:"firstTimes,omitempty"`
 	SecondTimes         uint64 `json:"secondTimes,omitempty"`
@@ -914,11 +1179,15 @@ type Rounds struct {
 	NegativeBal         uint64 `json:"negativeBal,omitempty"`
 	Balance             int64  `json:"balance,omitempty"`
 	HighestPayout       int64  `json:"highestPayout,omitempty"`
+	GambleWin           int64  `json:"gambleWin,omitempty"`
+	JackpotWins         int64  `json:"jackpotWins,omitempty"`
+	JackpotSeeds        int64  `json:"jackpotSeeds,omitempty"`
 	LowestBalance       int64  `json:"lowestBalance,omitempty"`
 	HighestBalance      int64  `json:"highestBalance,omitempty"`
 	maxPayout           float64
 	bestThreshold       float64
 	bestNoFreeThreshold float64
+	jackpots            []jackpotPool
 	ss                  *slots.SymbolSet
 	AllRounds           *analyse.Rounds                       `json:"allRounds,omitempty"`
 	BonusRounds         map[analyse.BonusKind]*analyse.Rounds `json:"bonusRounds,omitempty"`
@@ -937,13 +1206,20 @@ type Rounds struct {
 	Count2500x          *analyse.MinMaxUInt64                 `json:"count2500x,omitempty"`
 	CountPlusBal        *analyse.MinMaxUInt64                 `json:"countPlusBal,omitempty"`
 	BonusWheel          *analyse.MinMaxUInt64                 `json:"bonusWheel,omitempty"`
+	HoldAndWinCoins     *analyse.MinMaxUInt64                 `json:"holdAndWinCoins,omitempty"`
+	HoldAndWinPayouts   *analyse.MinMaxFloat64                `json:"holdAndWinPayouts,omitempty"`
+	PickemLevels        *analyse.MinMaxUInt64                 `json:"pickemLevels,omitempty"`
+	PickemPayouts       *analyse.MinMaxFloat64                `json:"pickemPayouts,omitempty"`
 	MultiplierMarks     *analyse.MinMaxUInt64                 `json:"multiplierMarks,omitempty"`
 	Multipliers         *analyse.MinMaxFloat64                `json:"multipliers,omitempty"`
+	Returns             *analyse.Welford                      `json:"returns,omitempty"`
 	Best                []results.Results                     `json:"best,omitempty"`
 	BestNoFree          []results.Results                     `json:"bestNoFree,omitempty"`
 	RoundFlags          []*analyse.RoundFlag                  `json:"roundFlags,omitempty"`
 	InstantBonus        map[string]uint64                     `json:"instantBonus,omitempty"`
 	PlayerChoice        map[string]uint64                     `json:"playerChoice,omitempty"`
+	Jackpots            map[string]uint64                     `json:"jackpots,omitempty"`
+	Progressives        map[string]uint64                     `json:"progressives,omitempty"`
 	Scripts             map[int]uint64                        `json:"scripts,omitempty"`
 	PlayerID            string                                `json:"playerID,omitempty"`
 	Symbols             analyse.Symbols                       `json:"symbols,omitempty"`
@@ -974,10 +1250,17 @@ var roundsProvider = pool.NewProducer(func() (pool.Objecter, func()) {
 		Count2500x:          analyse.AcquireMinMaxUInt64(),
 		CountPlusBal:        analyse.AcquireMinMaxUInt64(),
 		BonusWheel:          analyse.AcquireMinMaxUInt64(),
+		HoldAndWinCoins:     analyse.AcquireMinMaxUInt64(),
+		HoldAndWinPayouts:   analyse.AcquireMinMaxFloat64(2),
+		PickemLevels:        analyse.AcquireMinMaxUInt64(),
+		PickemPayouts:       analyse.AcquireMinMaxFloat64(2),
 		MultiplierMarks:     analyse.AcquireMinMaxUInt64(),
 		Multipliers:         analyse.AcquireMinMaxFloat64(1),
+		Returns:             analyse.AcquireWelford(),
 		InstantBonus:        make(map[string]uint64, 8),
 		PlayerChoice:        make(map[string]uint64, 8),
+		Jackpots:            make(map[string]uint64, 8),
+		Progressives:        make(map[string]uint64, 4),
 		Scripts:             make(map[int]uint64, 32),
 		RoundFlags:          make([]*analyse.RoundFlag, 0, 16),
 	}
@@ -987,6 +1270,7 @@ var roundsProvider = pool.NewProducer(func() (pool.Objecter, func()) {
 // reset clears the rounds metric.
 func (r *Rounds) reset() {
 	if r != nil {
+		r.jackpots = r.jackpots[:0]
 		r.ResetData()
 
 		r.doubleSpin = false
@@ -1060,6 +1344,15 @@ func (r *Rounds) ResetData() {
 	r.SecondFreeSpins = 0
 	r.SuperSpinsFree = 0
 	r.SuperRefillsFree = 0
+	r.HoldAndWinTimes = 0
+	r.HoldAndWinRespins = 0
+	r.PickemTimes = 0
+	r.PickemPicks = 0
+	r.GambleSteps = 0
+	r.GambleWins = 0
+	r.GambleWin = 0
+	r.JackpotWins = 0
+	r.JackpotSeeds = 0
 	r.BadSpins = 0
 	r.MaxPayouts = 0
 	r.PositiveBal = 0
@@ -1101,13 +1394,23 @@ func (r *Rounds) ResetData() {
 	r.CountPlusBal.ResetData()
 
 	r.BonusWheel.ResetData()
+	r.HoldAndWinCoins.ResetData()
+	r.HoldAndWinPayouts.ResetData()
+	r.PickemLevels.ResetData()
+	r.PickemPayouts.ResetData()
 	r.MultiplierMarks.ResetData()
 	r.Multipliers.ResetData()
+	r.Returns.ResetData()
 
 	clear(r.InstantBonus)
 	clear(r.PlayerChoice)
+	clear(r.Jackpots)
+	clear(r.Progressives)
 	clear(r.Scripts)
 
+	// the pools restart with the metrics.
+	r.seedJackpots()
+
 	for id := range r.Symbols {
 		if s := r.Symbols[id]; s != nil {
 			s.ResetData()
@@ -1152,15 +1455,23 @@ func (r *Rounds) RoundsToCSV(rtp int) {
 	desktopPath := filepath.Join(homeDir, "Desktop", fname)
 
 	// Create the CSV file on the Desktop
-	file, err := os.Create(desktopPath)
-	if err != nil {
-		fmt.Println("Error creating CSV file:", err)
+	if err = r.RoundsToCSVFile(desktopPath); err != nil {
+		fmt.Println("Error writing CSV file:", err)
 		return
 	}
+
+	fmt.Printf("CSV file successfully saved to %s\n", desktopPath)
+}
+
+// RoundsToCSVFile writes the rounds metrics to the given CSV file.
+func (r *Rounds) RoundsToCSVFile(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
 	defer file.Close()
 
 	writer := csv.NewWriter(file)
-	defer writer.Flush()
 
 	header := []string{
 		"NoPayouts", "NoBest", "NoSpins", "NoCounts", "GameNR",
@@ -1171,7 +1482,9 @@ func (r *Rounds) RoundsToCSV(rtp int) {
 		"Count250x", "Count1000x", "Count2500x", "CountPlusBal", "Multipliers",
 		"LowestBalance", "HighestBalance", "PlayerID",
 	}
-	writer.Write(header)
+	if err = writer.Write(header); err != nil {
+		return err
+	}
 
 	var bonusRounds []string
 	for k, v := range r.BonusRounds {
@@ -1190,6 +1503,7 @@ func (r *Rounds) RoundsToCSV(rtp int) {
 		strconv.FormatUint(r.WinCount, 10),
 		strconv.FormatUint(r.TotalSpins, 10),
 		strconv.FormatUint(r.RegularSpins, 10),
+		strconv.FormatInt(r.Balance, 10),
 		strconv.FormatUint(r.FreeSpins, 10),
 		strconv.FormatUint(r.MaxPayouts, 10),
 		strconv.FormatFloat(r.maxPayout, 'f', -1, 64),
@@ -1214,12 +1528,12 @@ func (r *Rounds) RoundsToCSV(rtp int) {
 		r.PlayerID,
 	}
 
-	err = writer.Write(row)
-	if err != nil {
-		fmt.Println("Error writing to CSV:", err)
+	if err = writer.Write(row); err != nil {
+		return err
 	}
 
-	fmt.Printf("CSV file successfully saved to %s\n", desktopPath)
+	writer.Flush()
+	return writer.Error()
 }
 
 const (
@@ -1,13 +1,17 @@
 package slots
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/encode/zjson"
+
 	analyse "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/analysis/metrics/slots"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/components/slots"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/games/cards"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/results"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/utils"
 )
@@ -393,6 +397,143 @@ func TestRounds_BestSorted(t *testing.T) {
 	}
 }
 
+func TestRounds_Statistics(t *testing.T) {
+	testCases := []struct {
+		name    string
+		results []*results.Result
+	}{
+		{name: "empty"},
+		{name: "single", results: []*results.Result{r1}},
+		{name: "few", results: []*results.Result{r1, r2, r3}},
+		{name: "many", results: []*results.Result{r4, r2, r5, r1, r3, r6, r7, r1, r1}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := AcquireRounds(0, "x", 1000000, 5, 3, false, 10000, set1, nil, paylines, nil)
+			require.NotNil(t, r)
+			defer r.Release()
+
+			var sum float64
+			for _, res := range tc.results {
+				r.Analyse(100, 100, results.Results{res})
+				sum += results.GrandTotal(results.Results{res})
+			}
+
+			var variance float64
+			n := float64(len(tc.results))
+			if n > 1 {
+				mean := sum / n
+				for _, res := range tc.results {
+					d := results.GrandTotal(results.Results{res}) - mean
+					variance += d * d
+				}
+				variance /= n - 1
+			}
+
+			assert.InDelta(t, variance, r.Variance(), 1e-6)
+			assert.InDelta(t, math.Sqrt(variance), r.StdDev(), 1e-6)
+			assert.InDelta(t, analyse.Confidence90.Z()*math.Sqrt(variance), r.VolatilityIndex(analyse.Confidence90), 1e-6)
+
+			lo, hi := r.RTPConfidence(analyse.Confidence95)
+			assert.InDelta(t, r.RTP(), (lo+hi)/2, 1e-6)
+			assert.LessOrEqual(t, lo, hi)
+
+			lo99, hi99 := r.RTPConfidence(analyse.Confidence99)
+			assert.LessOrEqual(t, lo99, lo)
+			assert.GreaterOrEqual(t, hi99, hi)
+
+			if n < 2 {
+				assert.True(t, math.IsInf(r.RTPConfidenceWidth(analyse.Confidence95), 1))
+			} else {
+				assert.InDelta(t, hi-lo, r.RTPConfidenceWidth(analyse.Confidence95), 1e-9)
+			}
+
+			m := AcquireRounds(0, "x", 1000000, 5, 3, false, 10000, set1, nil, paylines, nil)
+			require.NotNil(t, m)
+			defer m.Release()
+
+			m.Merge(r)
+			assert.InDelta(t, r.Variance(), m.Variance(), 1e-9)
+			assert.Equal(t, r.Returns.Count, m.Returns.Count)
+
+			r.ResetData()
+			assert.Zero(t, r.Returns.Count)
+			assert.Zero(t, r.Variance())
+		})
+	}
+}
+
+func TestRounds_JackpotRTP(t *testing.T) {
+	r := AcquireRounds(0, "x", 1000000, 5, 3, false, 10000, set1, nil, paylines, nil)
+	require.NotNil(t, r)
+	defer r.Release()
+
+	pools := []JackpotPool{{Level: 1, Seed: 1000, Rate: 0.01}, {Level: 2, Seed: 5000, Rate: 0.005, ReserveRate: 0.001}}
+	r.SetJackpotPools(pools...)
+	assert.Equal(t, pools, r.JackpotPools())
+	assert.Equal(t, int64(6000), r.JackpotSeeds)
+	assert.Zero(t, r.JackpotRTP())
+
+	// contributions still in the pools are not part of the jackpot RTP.
+	for _, res := range []*results.Result{r1, r2} {
+		r.Analyse(100, 100, results.Results{res})
+	}
+	assert.Zero(t, r.JackpotWins)
+	assert.Zero(t, r.JackpotRTP())
+
+	// the pool of the triggered level is awarded, including its seed, and reseeded.
+	spin := slots.AcquireSpinResultFromData(utils.Indexes{1, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2, 3}, nil, nil, nil, nil, 0, 0, 0)
+	dec := zjson.AcquireDecoder([]byte(`{"progressive":1}`))
+	require.True(t, dec.Object(spin), dec.Error())
+	dec.Release()
+
+	hit := results.AcquireResult(spin, results.SpinData)
+	defer hit.Release()
+
+	r.Analyse(100, 100, results.Results{hit})
+	assert.Equal(t, int64(1003), r.JackpotWins)
+	assert.Equal(t, int64(7000), r.JackpotSeeds)
+	assert.InDelta(t, 1003*100.0/300, r.JackpotRTP(), 1e-9)
+	assert.InDelta(t, r.RTP()+r.JackpotRTP(), r.TotalRTP(), 1e-9)
+	assert.Equal(t, map[string]uint64{"1": 1}, r.Progressives)
+
+	r.Analyse(100, 100, results.Results{hit})
+	assert.Equal(t, int64(2004), r.JackpotWins)
+
+	r.ResetData()
+	assert.Zero(t, r.JackpotWins)
+	assert.Equal(t, int64(6000), r.JackpotSeeds)
+}
+
+func TestRounds_Gamble(t *testing.T) {
+	r := AcquireRounds(0, "x", 1000000, 5, 3, false, 10000, set1, nil, paylines, nil)
+	require.NotNil(t, r)
+	defer r.Release()
+
+	gamble := func(change float64) *results.Result {
+		return results.AcquireResult(cards.AcquireBonusCallColorData(), results.BonusCallColorData, results.AcquirePlayerChoice(change))
+	}
+
+	won, lost := gamble(225), gamble(-225)
+	defer won.Release()
+	defer lost.Release()
+
+	r.Analyse(100, 100, results.Results{r1, won})
+	r.Analyse(100, 100, results.Results{r1, lost})
+
+	assert.Equal(t, uint64(2), r.RoundCount)
+	assert.Equal(t, uint64(1), r.WinCount)
+	assert.Equal(t, uint64(2), r.GambleSteps)
+	assert.Equal(t, uint64(1), r.GambleWins)
+	assert.Zero(t, r.GambleWin)
+	assert.Equal(t, int64(45000), r.HighestPayout)
+	assert.Zero(t, r.GambleRTP())
+	assert.InDelta(t, 22500.0, r.RTP(), 1e-9)
+	assert.Equal(t, uint64(1), r.FirstPayouts.Count)
+	assert.Equal(t, int64(45000), r.FirstPayouts.Total)
+}
+
 var (
 	s1   = slots.NewSymbol(1, slots.WithName("A"))
 	s2   = slots.NewSymbol(2, slots.WithName("B"))
@@ -0,0 +1,631 @@
+package slots
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/encode/zjson"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/tg"
+
+	analyse "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/analysis/metrics/slots"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/utils"
+)
+
+// Snapshot encodes the player rounds metrics into a snapshot.
+// A snapshot can be restored with ReadSnapshot() and merged with other rounds metrics using Merge().
+// This allows long-running simulations to be checkpointed and resumed, or distributed across multiple machines.
+// Note that the best rounds are not included in the snapshot.
+func (r *Rounds) Snapshot() []byte {
+	enc := zjson.AcquireEncoder(64 * 1024)
+	defer enc.Release()
+
+	enc.Object(r)
+	return append([]byte{}, enc.Bytes()...)
+}
+
+// ReadSnapshot restores the player rounds metrics from the given snapshot.
+// The rounds metrics must have been acquired for the same game configuration, and must not contain any data yet.
+// The analysis options and best round thresholds are not part of the snapshot; they are retained from the rounds metrics.
+func (r *Rounds) ReadSnapshot(data []byte) error {
+	if r.RoundCount > 0 {
+		return ErrSnapshotNotEmpty
+	}
+
+	dec := zjson.AcquireDecoder(data)
+	defer dec.Release()
+
+	s := &snapshotDecoder{r: r}
+	if !dec.Object(s) {
+		return dec.Error()
+	}
+	if s.version != snapshotVersion {
+		return ErrSnapshotVersion
+	}
+	return nil
+}
+
+// SaveSnapshot writes a snapshot of the player rounds metrics to the given file.
+// The snapshot is written to a temporary file first, so an existing snapshot is never left in a partial state.
+func (r *Rounds) SaveSnapshot(path string) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, r.Snapshot(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, filepath.Clean(path))
+}
+
+// LoadSnapshot restores the player rounds metrics from the given snapshot file.
+// See ReadSnapshot() for details.
+func (r *Rounds) LoadSnapshot(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return r.ReadSnapshot(data)
+}
+
+// EncodeFields implements the zjson.Encoder.EncodeFields interface.
+func (r *Rounds) EncodeFields(enc *zjson.Encoder) {
+	enc.IntField("version", snapshotVersion)
+	enc.Uint32Field("gameNR", uint32(r.gameNR))
+	enc.IntField("reelCount", r.reelCount)
+	enc.IntField("rowCount", r.rowCount)
+	enc.Uint16Field("maxSymbol", uint16(r.maxSymbol))
+	enc.IntField("actionCount", len(r.Actions))
+	enc.BoolField("doubleSpin", r.doubleSpin)
+	enc.FloatField("maxPayout", r.maxPayout, 'g', -1)
+	enc.Int64Field("startBalance", r.startBalance)
+	enc.EscapedStringFieldOpt("playerID", r.PlayerID)
+
+	enc.Uint64FieldOpt("roundCount", r.RoundCount)
+	enc.Uint64FieldOpt("winCount", r.WinCount)
+	enc.Uint64FieldOpt("totalSpins", r.TotalSpins)
+	enc.Uint64FieldOpt("regularSpins", r.RegularSpins)
+	enc.Uint64FieldOpt("firstSpins", r.FirstSpins)
+	enc.Uint64FieldOpt("secondSpins", r.SecondSpins)
+	enc.Uint64FieldOpt("refillSpins", r.RefillSpins)
+	enc.Uint64FieldOpt("superSpins", r.SuperSpins)
+	enc.Uint64FieldOpt("superRefills", r.SuperRefills)
+	enc.Uint64FieldOpt("wildRespins", r.WildRespins)
+	enc.Uint64FieldOpt("freeTimes", r.FreeTimes)
+	enc.Uint64FieldOpt("firstTimes", r.FirstTimes)
+	enc.Uint64FieldOpt("secondTimes", r.SecondTimes)
+	enc.Uint64FieldOpt("freeTimesSuper", r.FreeTimesSuper)
+	enc.Uint64FieldOpt("firstTimesSuper", r.FirstTimesSuper)
+	enc.Uint64FieldOpt("secondTimesSuper", r.SecondTimesSuper)
+	enc.Uint64FieldOpt("freeAwarded", r.FreeAwarded)
+	enc.Uint64FieldOpt("firstAwarded", r.FirstAwarded)
+	enc.Uint64FieldOpt("secondAwarded", r.SecondAwarded)
+	enc.Uint64FieldOpt("freeAwardedSuper", r.FreeAwardedSuper)
+	enc.Uint64FieldOpt("firstAwardedSuper", r.FirstAwardedSuper)
+	enc.Uint64FieldOpt("secondAwardedSuper", r.SecondAwardedSuper)
+	enc.Uint64FieldOpt("freeSpins", r.FreeSpins)
+	enc.Uint64FieldOpt("firstFreeSpins", r.FirstFreeSpins)
+	enc.Uint64FieldOpt("secondFreeSpins", r.SecondFreeSpins)
+	enc.Uint64FieldOpt("superSpinsFree", r.SuperSpinsFree)
+	enc.Uint64FieldOpt("superRefillsFree", r.SuperRefillsFree)
+	enc.Uint64FieldOpt("holdAndWinTimes", r.HoldAndWinTimes)
+	enc.Uint64FieldOpt("holdAndWinRespins", r.HoldAndWinRespins)
+	enc.Uint64FieldOpt("pickemTimes", r.PickemTimes)
+	enc.Uint64FieldOpt("pickemPicks", r.PickemPicks)
+	enc.Uint64FieldOpt("gambleSteps", r.GambleSteps)
+	enc.Uint64FieldOpt("gambleWins", r.GambleWins)
+	enc.Int64FieldOpt("gambleWin", r.GambleWin)
+	enc.Int64FieldOpt("jackpotWins", r.JackpotWins)
+	enc.Int64FieldOpt("jackpotSeeds", r.JackpotSeeds)
+	enc.Uint64FieldOpt("badSpins", r.BadSpins)
+	enc.Uint64FieldOpt("maxPayouts", r.MaxPayouts)
+	enc.Uint64FieldOpt("positiveBal", r.PositiveBal)
+	enc.Uint64FieldOpt("negativeBal", r.NegativeBal)
+	enc.Int64Field("balance", r.Balance)
+	enc.Int64FieldOpt("highestPayout", r.HighestPayout)
+	enc.Int64Field("lowestBalance", r.LowestBalance)
+	enc.Int64Field("highestBalance", r.HighestBalance)
+
+	enc.ObjectField("allRounds", r.AllRounds)
+	enc.ObjectField("firstPayouts", r.FirstPayouts)
+
+	kinds := make([]analyse.BonusKind, 0, len(r.BonusRounds))
+	for k := range r.BonusRounds {
+		kinds = append(kinds, k)
+	}
+	slices.Sort(kinds)
+
+	enc.StartObjectField("bonusRounds")
+	for _, k := range kinds {
+		enc.ObjectField(strconv.Itoa(int(k)), r.BonusRounds[k])
+	}
+	enc.EndObject()
+
+	kinds = kinds[:0]
+	for k := range r.BonusPayouts {
+		kinds = append(kinds, k)
+	}
+	slices.Sort(kinds)
+
+	enc.StartObjectField("bonusPayouts")
+	for _, k := range kinds {
+		enc.ObjectField(strconv.Itoa(int(k)), r.BonusPayouts[k])
+	}
+	enc.EndObject()
+
+	enc.ObjectField("spinsTo25x", r.SpinsTo25x)
+	enc.ObjectField("spinsTo100x", r.SpinsTo100x)
+	enc.ObjectField("spinsTo250x", r.SpinsTo250x)
+	enc.ObjectField("spinsTo1000x", r.SpinsTo1000x)
+	enc.ObjectField("spinsTo2500x", r.SpinsTo2500x)
+	enc.ObjectField("spinsToPlusBal", r.SpinsToPlusBal)
+	enc.ObjectField("count25x", r.Count25x)
+	enc.ObjectField("count100x", r.Count100x)
+	enc.ObjectField("count250x", r.Count250x)
+	enc.ObjectField("count1000x", r.Count1000x)
+	enc.ObjectField("count2500x", r.Count2500x)
+	enc.ObjectField("countPlusBal", r.CountPlusBal)
+	enc.ObjectField("bonusWheel", r.BonusWheel)
+	enc.ObjectField("holdAndWinCoins", r.HoldAndWinCoins)
+	enc.ObjectField("holdAndWinPayouts", r.HoldAndWinPayouts)
+	enc.ObjectField("pickemLevels", r.PickemLevels)
+	enc.ObjectField("pickemPayouts", r.PickemPayouts)
+	enc.ObjectField("multiplierMarks", r.MultiplierMarks)
+	enc.ObjectField("multipliers", r.Multipliers)
+	enc.ObjectField("returns", r.Returns)
+
+	encodeCounts(enc, "instantBonus", r.InstantBonus)
+	encodeCounts(enc, "playerChoice", r.PlayerChoice)
+	encodeCounts(enc, "jackpots", r.Jackpots)
+	encodeCounts(enc, "progressives", r.Progressives)
+
+	ids := make([]int, 0, len(r.Scripts))
+	for id := range r.Scripts {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	enc.StartArrayField("scripts")
+	for _, id := range ids {
+		enc.StartArray()
+		enc.Int64(int64(id))
+		enc.Uint64(r.Scripts[id])
+		enc.EndArray()
+	}
+	enc.EndArray()
+
+	enc.StartArrayField("roundFlags")
+	for ix := range r.RoundFlags {
+		if f := r.RoundFlags[ix]; f != nil {
+			enc.Object(f)
+		}
+	}
+	enc.EndArray()
+
+	enc.StartArrayField("symbols")
+	for ix := range r.Symbols {
+		if s := r.Symbols[ix]; s != nil {
+			enc.Object(s)
+		}
+	}
+	enc.EndArray()
+
+	enc.StartArrayField("actions")
+	for ix := range r.Actions {
+		if a := r.Actions[ix]; a != nil {
+			enc.Object(a)
+		}
+	}
+	enc.EndArray()
+}
+
+// DecodeField implements the zjson.Decoder.DecodeField interface.
+// The configuration fields in the snapshot are verified against the configuration of the rounds metrics.
+func (r *Rounds) DecodeField(dec *zjson.Decoder, key []byte) error {
+	var ok bool
+	var i16 uint16
+	var i32 uint32
+	var i int
+	var f float64
+	var b bool
+
+	if string(key) == "gameNR" {
+		if i32, ok = dec.Uint32(); ok && tg.GameNR(i32) != r.gameNR {
+			return ErrSnapshotMismatch
+		}
+	} else if string(key) == "reelCount" {
+		if i, ok = dec.Int(); ok && i != r.reelCount {
+			return ErrSnapshotMismatch
+		}
+	} else if string(key) == "rowCount" {
+		if i, ok = dec.Int(); ok && i != r.rowCount {
+			return ErrSnapshotMismatch
+		}
+	} else if string(key) == "maxSymbol" {
+		if i16, ok = dec.Uint16(); ok && utils.Index(i16) != r.maxSymbol {
+			return ErrSnapshotMismatch
+		}
+	} else if string(key) == "actionCount" {
+		if i, ok = dec.Int(); ok && i != len(r.Actions) {
+			return ErrSnapshotMismatch
+		}
+	} else if string(key) == "doubleSpin" {
+		if b, ok = dec.Bool(); ok && b != r.doubleSpin {
+			return ErrSnapshotMismatch
+		}
+	} else if string(key) == "maxPayout" {
+		if f, ok = dec.Float(); ok && f != r.maxPayout {
+			return ErrSnapshotMismatch
+		}
+	} else if string(key) == "startBalance" {
+		r.startBalance, ok = dec.Int64()
+	} else if string(key) == "playerID" {
+		r.PlayerID, ok = decodeString(dec)
+	} else if string(key) == "roundCount" {
+		r.RoundCount, ok = dec.Uint64()
+	} else if string(key) == "winCount" {
+		r.WinCount, ok = dec.Uint64()
+	} else if string(key) == "totalSpins" {
+		r.TotalSpins, ok = dec.Uint64()
+	} else if string(key) == "regularSpins" {
+		r.RegularSpins, ok = dec.Uint64()
+	} else if string(key) == "firstSpins" {
+		r.FirstSpins, ok = dec.Uint64()
+	} else if string(key) == "secondSpins" {
+		r.SecondSpins, ok = dec.Uint64()
+	} else if string(key) == "refillSpins" {
+		r.RefillSpins, ok = dec.Uint64()
+	} else if string(key) == "superSpins" {
+		r.SuperSpins, ok = dec.Uint64()
+	} else if string(key) == "superRefills" {
+		r.SuperRefills, ok = dec.Uint64()
+	} else if string(key) == "wildRespins" {
+		r.WildRespins, ok = dec.Uint64()
+	} else if string(key) == "freeTimes" {
+		r.FreeTimes, ok = dec.Uint64()
+	} else if string(key) == "firstTimes" {
+		r.FirstTimes, ok = dec.Uint64()
+	} else if string(key) == "secondTimes" {
+		r.SecondTimes, ok = dec.Uint64()
+	} else if string(key) == "freeTimesSuper" {
+		r.FreeTimesSuper, ok = dec.Uint64()
+	} else if string(key) == "firstTimesSuper" {
+		r.FirstTimesSuper, ok = dec.Uint64()
+	} else if string(key) == "secondTimesSuper" {
+		r.SecondTimesSuper, ok = dec.Uint64()
+	} else if string(key) == "freeAwarded" {
+		r.FreeAwarded, ok = dec.Uint64()
+	} else if string(key) == "firstAwarded" {
+		r.FirstAwarded, ok = dec.Uint64()
+	} else if string(key) == "secondAwarded" {
+		r.SecondAwarded, ok = dec.Uint64()
+	} else if string(key) == "freeAwardedSuper" {
+		r.FreeAwardedSuper, ok = dec.Uint64()
+	} else if string(key) == "firstAwardedSuper" {
+		r.FirstAwardedSuper, ok = dec.Uint64()
+	} else if string(key) == "secondAwardedSuper" {
+		r.SecondAwardedSuper, ok = dec.Uint64()
+	} else if string(key) == "freeSpins" {
+		r.FreeSpins, ok = dec.Uint64()
+	} else if string(key) == "firstFreeSpins" {
+		r.FirstFreeSpins, ok = dec.Uint64()
+	} else if string(key) == "secondFreeSpins" {
+		r.SecondFreeSpins, ok = dec.Uint64()
+	} else if string(key) == "superSpinsFree" {
+		r.SuperSpinsFree, ok = dec.Uint64()
+	} else if string(key) == "superRefillsFree" {
+		r.SuperRefillsFree, ok = dec.Uint64()
+	} else if string(key) == "holdAndWinTimes" {
+		r.HoldAndWinTimes, ok = dec.Uint64()
+	} else if string(key) == "holdAndWinRespins" {
+		r.HoldAndWinRespins, ok = dec.Uint64()
+	} else if string(key) == "pickemTimes" {
+		r.PickemTimes, ok = dec.Uint64()
+	} else if string(key) == "pickemPicks" {
+		r.PickemPicks, ok = dec.Uint64()
+	} else if string(key) == "gambleSteps" {
+		r.GambleSteps, ok = dec.Uint64()
+	} else if string(key) == "gambleWins" {
+		r.GambleWins, ok = dec.Uint64()
+	} else if string(key) == "gambleWin" {
+		r.GambleWin, ok = dec.Int64()
+	} else if string(key) == "jackpotWins" {
+		r.JackpotWins, ok = dec.Int64()
+	} else if string(key) == "jackpotSeeds" {
+		r.JackpotSeeds, ok = dec.Int64()
+	} else if string(key) == "badSpins" {
+		r.BadSpins, ok = dec.Uint64()
+	} else if string(key) == "maxPayouts" {
+		r.MaxPayouts, ok = dec.Uint64()
+	} else if string(key) == "positiveBal" {
+		r.PositiveBal, ok = dec.Uint64()
+	} else if string(key) == "negativeBal" {
+		r.NegativeBal, ok = dec.Uint64()
+	} else if string(key) == "balance" {
+		r.Balance, ok = dec.Int64()
+	} else if string(key) == "highestPayout" {
+		r.HighestPayout, ok = dec.Int64()
+	} else if string(key) == "lowestBalance" {
+		r.LowestBalance, ok = dec.Int64()
+	} else if string(key) == "highestBalance" {
+		r.HighestBalance, ok = dec.Int64()
+	} else if string(key) == "allRounds" {
+		ok = dec.Object(r.AllRounds)
+	} else if string(key) == "firstPayouts" {
+		ok = dec.Object(r.FirstPayouts)
+	} else if string(key) == "bonusRounds" {
+		ok = dec.Object(fieldDecoder(r.decodeBonusRounds))
+	} else if string(key) == "bonusPayouts" {
+		ok = dec.Object(fieldDecoder(r.decodeBonusPayouts))
+	} else if string(key) == "spinsTo25x" {
+		ok = dec.Object(r.SpinsTo25x)
+	} else if string(key) == "spinsTo100x" {
+		ok = dec.Object(r.SpinsTo100x)
+	} else if string(key) == "spinsTo250x" {
+		ok = dec.Object(r.SpinsTo250x)
+	} else if string(key) == "spinsTo1000x" {
+		ok = dec.Object(r.SpinsTo1000x)
+	} else if string(key) == "spinsTo2500x" {
+		ok = dec.Object(r.SpinsTo2500x)
+	} else if string(key) == "spinsToPlusBal" {
+		ok = dec.Object(r.SpinsToPlusBal)
+	} else if string(key) == "count25x" {
+		ok = dec.Object(r.Count25x)
+	} else if string(key) == "count100x" {
+		ok = dec.Object(r.Count100x)
+	} else if string(key) == "count250x" {
+		ok = dec.Object(r.Count250x)
+	} else if string(key) == "count1000x" {
+		ok = dec.Object(r.Count1000x)
+	} else if string(key) == "count2500x" {
+		ok = dec.Object(r.Count2500x)
+	} else if string(key) == "countPlusBal" {
+		ok = dec.Object(r.CountPlusBal)
+	} else if string(key) == "bonusWheel" {
+		ok = dec.Object(r.BonusWheel)
+	} else if string(key) == "holdAndWinCoins" {
+		ok = dec.Object(r.HoldAndWinCoins)
+	} else if string(key) == "holdAndWinPayouts" {
+		ok = dec.Object(r.HoldAndWinPayouts)
+	} else if string(key) == "pickemLevels" {
+		ok = dec.Object(r.PickemLevels)
+	} else if string(key) == "pickemPayouts" {
+		ok = dec.Object(r.PickemPayouts)
+	} else if string(key) == "multiplierMarks" {
+		ok = dec.Object(r.MultiplierMarks)
+	} else if string(key) == "multipliers" {
+		ok = dec.Object(r.Multipliers)
+	} else if string(key) == "returns" {
+		ok = dec.Object(r.Returns)
+	} else if string(key) == "instantBonus" {
+		ok = decodeCounts(dec, r.InstantBonus)
+	} else if string(key) == "playerChoice" {
+		ok = decodeCounts(dec, r.PlayerChoice)
+	} else if string(key) == "jackpots" {
+		ok = decodeCounts(dec, r.Jackpots)
+	} else if string(key) == "progressives" {
+		ok = decodeCounts(dec, r.Progressives)
+	} else if string(key) == "scripts" {
+		ok = dec.Array(r.decodeScript)
+	} else if string(key) == "roundFlags" {
+		ok = dec.Array(r.decodeRoundFlag)
+	} else if string(key) == "symbols" {
+		ok = dec.Array(r.decodeSymbol)
+	} else if string(key) == "actions" {
+		ok = dec.Array(r.decodeAction)
+	} else {
+		return fmt.Errorf("Rounds.DecodeField invalid field: %s", string(key))
+	}
+
+	if ok {
+		return nil
+	}
+	return dec.Error()
+}
+
+func (r *Rounds) decodeBonusRounds(dec *zjson.Decoder, key []byte) error {
+	k, err := strconv.Atoi(string(key))
+	if err != nil {
+		return err
+	}
+
+	br, ok := r.BonusRounds[analyse.BonusKind(k)]
+	if !ok {
+		br = analyse.NewRounds(r.ss)
+		r.BonusRounds[analyse.BonusKind(k)] = br
+	}
+
+	if dec.Object(br) {
+		return nil
+	}
+	return dec.Error()
+}
+
+func (r *Rounds) decodeBonusPayouts(dec *zjson.Decoder, key []byte) error {
+	k, err := strconv.Atoi(string(key))
+	if err != nil {
+		return err
+	}
+
+	bp, ok := r.BonusPayouts[analyse.BonusKind(k)]
+	if !ok {
+		bp = NewPayouts(r.rowCount, r.maxSymbol, r.pl, r.noPaylines)
+		r.BonusPayouts[analyse.BonusKind(k)] = bp
+	}
+
+	if dec.Object(bp) {
+		return nil
+	}
+	return dec.Error()
+}
+
+func (r *Rounds) decodeScript(dec *zjson.Decoder) error {
+	var id int64
+	var count uint64
+	var ix int
+
+	ok := dec.Array(func(dec *zjson.Decoder) error {
+		if ix == 0 {
+			id, _ = dec.Int64()
+		} else {
+			count, _ = dec.Uint64()
+		}
+		ix++
+		return dec.Error()
+	})
+
+	if ok {
+		r.Scripts[int(id)] = count
+		return nil
+	}
+	return dec.Error()
+}
+
+func (r *Rounds) decodeRoundFlag(dec *zjson.Decoder) error {
+	f := analyse.NewRoundFlag(0, "")
+	if !dec.Object(f) {
+		f.Release()
+		return dec.Error()
+	}
+
+	if f.ID < 0 {
+		f.Release()
+		return ErrSnapshotMismatch
+	}
+
+	for f.ID >= len(r.RoundFlags) {
+		r.RoundFlags = append(r.RoundFlags, nil)
+	}
+	if old := r.RoundFlags[f.ID]; old != nil {
+		old.Release()
+	}
+	r.RoundFlags[f.ID] = f
+	return nil
+}
+
+func (r *Rounds) decodeSymbol(dec *zjson.Decoder) error {
+	s := analyse.NewSymbol(0, "", "", r.reelCount)
+	if !dec.Object(s) {
+		s.Release()
+		return dec.Error()
+	}
+
+	if int(s.ID) >= len(r.Symbols) || r.Symbols[s.ID] == nil || len(s.TotalReels) != r.reelCount {
+		s.Release()
+		return ErrSnapshotMismatch
+	}
+
+	r.Symbols[s.ID].Release()
+	r.Symbols[s.ID] = s
+	return nil
+}
+
+func (r *Rounds) decodeAction(dec *zjson.Decoder) error {
+	a := analyse.NewAction(0, "", "", "")
+	if !dec.Object(a) {
+		a.Release()
+		return dec.Error()
+	}
+
+	if a.ID < 0 || a.ID >= len(r.Actions) {
+		a.Release()
+		return ErrSnapshotMismatch
+	}
+
+	if old := r.Actions[a.ID]; old != nil {
+		old.Release()
+	}
+	r.Actions[a.ID] = a
+	return nil
+}
+
+// snapshotDecoder verifies the version of a snapshot, and passes all other fields to the rounds metrics.
+type snapshotDecoder struct {
+	version int
+	r       *Rounds
+}
+
+// DecodeField implements the zjson.Decoder.DecodeField interface.
+func (s *snapshotDecoder) DecodeField(dec *zjson.Decoder, key []byte) error {
+	if string(key) != "version" {
+		if s.version != snapshotVersion {
+			return ErrSnapshotVersion
+		}
+		return s.r.DecodeField(dec, key)
+	}
+
+	var ok bool
+	if s.version, ok = dec.Int(); ok {
+		return nil
+	}
+	return dec.Error()
+}
+
+// fieldDecoder adapts a function to the zjson.ObjectDecoder interface.
+type fieldDecoder func(dec *zjson.Decoder, key []byte) error
+
+// DecodeField implements the zjson.Decoder.DecodeField interface.
+func (f fieldDecoder) DecodeField(dec *zjson.Decoder, key []byte) error {
+	return f(dec, key)
+}
+
+// encodeCounts encodes a map of counters as an array of key/count pairs, as the keys may need escaping.
+func encodeCounts(enc *zjson.Encoder, key string, m map[string]uint64) {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	enc.StartArrayField(key)
+	for _, k := range keys {
+		enc.StartArray()
+		enc.EscapedString(k)
+		enc.Uint64(m[k])
+		enc.EndArray()
+	}
+	enc.EndArray()
+}
+
+func decodeCounts(dec *zjson.Decoder, m map[string]uint64) bool {
+	return dec.Array(func(dec *zjson.Decoder) error {
+		var key string
+		var count uint64
+		var ix int
+
+		ok := dec.Array(func(dec *zjson.Decoder) error {
+			if ix == 0 {
+				key, _ = decodeString(dec)
+			} else {
+				count, _ = dec.Uint64()
+			}
+			ix++
+			return dec.Error()
+		})
+
+		if ok {
+			m[key] = count
+			return nil
+		}
+		return dec.Error()
+	})
+}
+
+func decodeString(dec *zjson.Decoder) (string, bool) {
+	b, esc, ok := dec.String()
+	if !ok {
+		return "", false
+	}
+	if esc {
+		return string(dec.Unescaped(b)), true
+	}
+	return string(b), true
+}
+
+const snapshotVersion = 1
+
+var (
+	ErrSnapshotVersion  = errors.New("unsupported rounds snapshot version")
+	ErrSnapshotMismatch = errors.New("rounds snapshot does not match the game configuration")
+	ErrSnapshotNotEmpty = errors.New("rounds metrics must be empty to restore a snapshot")
+)
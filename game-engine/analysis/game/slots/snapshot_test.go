@@ -0,0 +1,169 @@
+package slots
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/results"
+)
+
+func TestRounds_Snapshot(t *testing.T) {
+	testCases := []struct {
+		name    string
+		results []*results.Result
+	}{
+		{name: "empty"},
+		{name: "single", results: []*results.Result{r1}},
+		{name: "few", results: []*results.Result{r1, r2, r3}},
+		{name: "many", results: []*results.Result{r4, r2, r5, r1, r3, r6, r7}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := AcquireRounds(0, "jimmy", 1000000, 5, 3, false, 10000, set1, nil, paylines, nil)
+			require.NotNil(t, r)
+			defer r.Release()
+
+			for ix := range tc.results {
+				r.Analyse(100, 100, results.Results{tc.results[ix]})
+			}
+
+			data := r.Snapshot()
+			require.NotEmpty(t, data)
+
+			n := AcquireRounds(0, "", 1000000, 5, 3, false, 10000, set1, nil, paylines, nil)
+			require.NotNil(t, n)
+			defer n.Release()
+
+			require.NoError(t, n.ReadSnapshot(data))
+			assertSnapshotRounds(t, r, n)
+			assert.Zero(t, len(n.Best))
+
+			assert.Equal(t, data, n.Snapshot())
+
+			m := AcquireRounds(0, "jimmy", 1000000, 5, 3, false, 10000, set1, nil, paylines, nil)
+			require.NotNil(t, m)
+			defer m.Release()
+
+			for ix := range tc.results {
+				m.Analyse(100, 100, results.Results{tc.results[ix]})
+			}
+			m.Merge(n)
+
+			assert.Equal(t, 2*r.RoundCount, m.RoundCount)
+			assert.Equal(t, 2*r.AllRounds.Count, m.AllRounds.Count)
+			assert.Equal(t, 2*r.AllRounds.Wins.Total, m.AllRounds.Wins.Total)
+			assert.Equal(t, 2*r.FirstPayouts.Total, m.FirstPayouts.Total)
+		})
+	}
+}
+
+func TestRounds_SaveSnapshot(t *testing.T) {
+	r := AcquireRounds(0, "jimmy", 1000000, 5, 3, false, 10000, set1, nil, paylines, nil)
+	require.NotNil(t, r)
+	defer r.Release()
+
+	r.Analyse(100, 100, results.Results{r1})
+	r.Analyse(100, 100, results.Results{r2})
+
+	path := filepath.Join(t.TempDir(), "rounds.snapshot")
+	require.NoError(t, r.SaveSnapshot(path))
+
+	_, err := os.Stat(path + ".tmp")
+	assert.True(t, os.IsNotExist(err))
+
+	n := AcquireRounds(0, "", 1000000, 5, 3, false, 10000, set1, nil, paylines, nil)
+	require.NotNil(t, n)
+	defer n.Release()
+
+	require.NoError(t, n.LoadSnapshot(path))
+	assertSnapshotRounds(t, r, n)
+
+	err = n.LoadSnapshot(path)
+	assert.ErrorIs(t, err, ErrSnapshotNotEmpty)
+}
+
+func TestRounds_ReadSnapshotFail(t *testing.T) {
+	r := AcquireRounds(0, "jimmy", 1000000, 5, 3, false, 10000, set1, nil, paylines, nil)
+	require.NotNil(t, r)
+	defer r.Release()
+
+	r.Analyse(100, 100, results.Results{r1})
+	data := r.Snapshot()
+
+	testCases := []struct {
+		name      string
+		reelCount int
+		rowCount  int
+		data      []byte
+		wantErr   error
+	}{
+		{name: "reel count", reelCount: 6, rowCount: 3, data: data, wantErr: ErrSnapshotMismatch},
+		{name: "row count", reelCount: 5, rowCount: 4, data: data, wantErr: ErrSnapshotMismatch},
+		{name: "version", reelCount: 5, rowCount: 3, data: []byte(`{"version":99,"roundCount":1}`), wantErr: ErrSnapshotVersion},
+		{name: "no version", reelCount: 5, rowCount: 3, data: []byte(`{"roundCount":1}`), wantErr: ErrSnapshotVersion},
+		{name: "invalid field", reelCount: 5, rowCount: 3, data: []byte(`{"version":1,"bad":1}`)},
+		{name: "invalid json", reelCount: 5, rowCount: 3, data: data[:len(data)/2]},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			n := AcquireRounds(0, "", 1000000, tc.reelCount, tc.rowCount, false, 10000, set1, nil, paylines, nil)
+			require.NotNil(t, n)
+			defer n.Release()
+
+			err := n.ReadSnapshot(tc.data)
+			require.Error(t, err)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func assertSnapshotRounds(t *testing.T, want, got *Rounds) {
+	assert.Equal(t, want.PlayerID, got.PlayerID)
+	assert.Equal(t, want.RoundCount, got.RoundCount)
+	assert.Equal(t, want.WinCount, got.WinCount)
+	assert.Equal(t, want.TotalSpins, got.TotalSpins)
+	assert.Equal(t, want.Balance, got.Balance)
+	assert.Equal(t, want.LowestBalance, got.LowestBalance)
+	assert.Equal(t, want.HighestBalance, got.HighestBalance)
+	assert.Equal(t, want.HighestPayout, got.HighestPayout)
+
+	if !want.AllRounds.Equals(got.AllRounds) {
+		assert.EqualValues(t, want.AllRounds, got.AllRounds)
+	}
+
+	require.Equal(t, len(want.Symbols), len(got.Symbols))
+	for ix := range want.Symbols {
+		if s := want.Symbols[ix]; s != nil {
+			if !s.Equals(got.Symbols[ix]) {
+				assert.EqualValues(t, s, got.Symbols[ix])
+			}
+		} else {
+			assert.Nil(t, got.Symbols[ix])
+		}
+	}
+
+	assert.Equal(t, want.FirstPayouts.Count, got.FirstPayouts.Count)
+	assert.Equal(t, want.FirstPayouts.Total, got.FirstPayouts.Total)
+	require.Equal(t, len(want.FirstPayouts.Paylines), len(got.FirstPayouts.Paylines))
+	for ix := range want.FirstPayouts.Paylines {
+		if l := want.FirstPayouts.Paylines[ix]; l != nil {
+			if !l.Equals(got.FirstPayouts.Paylines[ix]) {
+				assert.EqualValues(t, l, got.FirstPayouts.Paylines[ix])
+			}
+		} else {
+			assert.Nil(t, got.FirstPayouts.Paylines[ix])
+		}
+	}
+
+	if !want.SpinsTo25x.Equals(got.SpinsTo25x) {
+		assert.EqualValues(t, want.SpinsTo25x, got.SpinsTo25x)
+	}
+}
@@ -1,6 +1,9 @@
 package slots
 
 import (
+	"fmt"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/encode/zjson"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/object-pool/pool"
 )
 
@@ -189,3 +192,75 @@ func PurgeActions(list Actions, capacity int) Actions {
 	}
 	return list
 }
+
+// EncodeFields implements the zjson.Encoder.EncodeFields interface.
+func (a *Action) EncodeFields(enc *zjson.Encoder) {
+	enc.IntField("id", a.ID)
+	enc.EscapedStringFieldOpt("name", a.Name)
+	enc.EscapedStringFieldOpt("kind", a.Kind)
+	enc.EscapedStringFieldOpt("config", a.Config)
+	enc.Uint64FieldOpt("totalCount", a.TotalCount)
+	enc.Uint64FieldOpt("firstCount", a.FirstCount)
+	enc.Uint64FieldOpt("secondCount", a.SecondCount)
+	enc.Uint64FieldOpt("freeCount", a.FreeCount)
+	enc.Uint64FieldOpt("freeSecondCount", a.FreeSecondCount)
+	enc.Uint64FieldOpt("superCount", a.SuperCount)
+	enc.Uint64FieldOpt("refillCount", a.RefillCount)
+	enc.Uint64FieldOpt("totalTriggered", a.TotalTriggered)
+	enc.Uint64FieldOpt("firstTriggered", a.FirstTriggered)
+	enc.Uint64FieldOpt("secondTriggered", a.SecondTriggered)
+	enc.Uint64FieldOpt("freeTriggered", a.FreeTriggered)
+	enc.Uint64FieldOpt("freeSecondTriggered", a.FreeSecondTriggered)
+	enc.Uint64FieldOpt("superTriggered", a.SuperTriggered)
+	enc.Uint64FieldOpt("refillTriggered", a.RefillTriggered)
+}
+
+// DecodeField implements the zjson.Decoder.DecodeField interface.
+func (a *Action) DecodeField(dec *zjson.Decoder, key []byte) error {
+	var ok bool
+
+	if string(key) == "id" {
+		a.ID, ok = dec.Int()
+	} else if string(key) == "name" {
+		a.Name, ok = decodeString(dec)
+	} else if string(key) == "kind" {
+		a.Kind, ok = decodeString(dec)
+	} else if string(key) == "config" {
+		a.Config, ok = decodeString(dec)
+	} else if string(key) == "totalCount" {
+		a.TotalCount, ok = dec.Uint64()
+	} else if string(key) == "firstCount" {
+		a.FirstCount, ok = dec.Uint64()
+	} else if string(key) == "secondCount" {
+		a.SecondCount, ok = dec.Uint64()
+	} else if string(key) == "freeCount" {
+		a.FreeCount, ok = dec.Uint64()
+	} else if string(key) == "freeSecondCount" {
+		a.FreeSecondCount, ok = dec.Uint64()
+	} else if string(key) == "superCount" {
+		a.SuperCount, ok = dec.Uint64()
+	} else if string(key) == "refillCount" {
+		a.RefillCount, ok = dec.Uint64()
+	} else if string(key) == "totalTriggered" {
+		a.TotalTriggered, ok = dec.Uint64()
+	} else if string(key) == "firstTriggered" {
+		a.FirstTriggered, ok = dec.Uint64()
+	} else if string(key) == "secondTriggered" {
+		a.SecondTriggered, ok = dec.Uint64()
+	} else if string(key) == "freeTriggered" {
+		a.FreeTriggered, ok = dec.Uint64()
+	} else if string(key) == "freeSecondTriggered" {
+		a.FreeSecondTriggered, ok = dec.Uint64()
+	} else if string(key) == "superTriggered" {
+		a.SuperTriggered, ok = dec.Uint64()
+	} else if string(key) == "refillTriggered" {
+		a.RefillTriggered, ok = dec.Uint64()
+	} else {
+		return fmt.Errorf("Action.DecodeField invalid field: %s", string(key))
+	}
+
+	if ok {
+		return nil
+	}
+	return dec.Error()
+}
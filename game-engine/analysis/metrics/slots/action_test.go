@@ -154,6 +154,13 @@ func TestNewAction(t *testing.T) {
 				assert.Equal(t, tc.j, string(j))
 			}
 
+			d := NewAction(0, "", "", "")
+			defer d.Release()
+			roundTrip(t, a, d)
+			if !tc.want.Equals(d) {
+				assert.EqualValues(t, tc.want, d)
+			}
+
 			n := a.Clone().(*Action)
 			require.NotNil(t, n)
 			defer n.Release()
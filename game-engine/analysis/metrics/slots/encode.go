@@ -0,0 +1,79 @@
+package slots
+
+import (
+	"slices"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/encode/zjson"
+)
+
+// The metrics implement the zjson encoder/decoder interfaces, so they can be written to and restored from a snapshot.
+// Unlike the standard json tags, which are used for reporting, the zjson encoding is lossless.
+// Decoding expects the metrics to be in the initial state, as returned by the corresponding constructor.
+
+func encodeUint64s(enc *zjson.Encoder, key string, list []uint64) {
+	enc.StartArrayField(key)
+	for ix := range list {
+		enc.Uint64(list[ix])
+	}
+	enc.EndArray()
+}
+
+func decodeUint64s(dec *zjson.Decoder, list []uint64) ([]uint64, bool) {
+	list = list[:0]
+	ok := dec.Array(func(dec *zjson.Decoder) error {
+		if v, ok2 := dec.Uint64(); ok2 {
+			list = append(list, v)
+		}
+		return dec.Error()
+	})
+	return list, ok
+}
+
+func encodeUint64ss(enc *zjson.Encoder, key string, list [][]uint64) {
+	enc.StartArrayField(key)
+	for ix := range list {
+		enc.StartArray()
+		for iy := range list[ix] {
+			enc.Uint64(list[ix][iy])
+		}
+		enc.EndArray()
+	}
+	enc.EndArray()
+}
+
+// decodeUint64ss decodes a nested array, reusing the inner slices where possible.
+func decodeUint64ss(dec *zjson.Decoder, list [][]uint64) ([][]uint64, bool) {
+	var ix int
+	ok := dec.Array(func(dec *zjson.Decoder) error {
+		if ix < len(list) {
+			list[ix], _ = decodeUint64s(dec, list[ix])
+		} else {
+			var l []uint64
+			l, _ = decodeUint64s(dec, l)
+			list = append(list, l)
+		}
+		ix++
+		return dec.Error()
+	})
+	return list, ok
+}
+
+func decodeString(dec *zjson.Decoder) (string, bool) {
+	b, esc, ok := dec.String()
+	if !ok {
+		return "", false
+	}
+	if esc {
+		return string(dec.Unescaped(b)), true
+	}
+	return string(b), true
+}
+
+func sortedKeys[K int64 | uint64](m map[K]uint64) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
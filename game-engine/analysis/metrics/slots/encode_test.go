@@ -0,0 +1,22 @@
+package slots
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/encode/zjson"
+)
+
+// roundTrip encodes the input and decodes it into the output.
+func roundTrip(t *testing.T, in zjson.ObjectEncoder, out zjson.ObjectDecoder) {
+	enc := zjson.AcquireEncoder(1024)
+	defer enc.Release()
+
+	enc.Object(in)
+
+	dec := zjson.AcquireDecoder(enc.Bytes())
+	defer dec.Release()
+
+	require.True(t, dec.Object(out), dec.Error())
+}
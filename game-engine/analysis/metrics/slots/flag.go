@@ -1,6 +1,9 @@
 package slots
 
 import (
+	"fmt"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/encode/zjson"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/object-pool/pool"
 )
 
@@ -90,3 +93,33 @@ func PurgeRoundFlags(list RoundFlags, capacity int) RoundFlags {
 	}
 	return list[:0]
 }
+
+// EncodeFields implements the zjson.Encoder.EncodeFields interface.
+func (f *RoundFlag) EncodeFields(enc *zjson.Encoder) {
+	enc.IntField("id", f.ID)
+	enc.EscapedStringFieldOpt("name", f.Name)
+	enc.ObjectField("counts", f.Counts)
+	enc.ObjectField("countsFinal", f.CountsFinal)
+}
+
+// DecodeField implements the zjson.Decoder.DecodeField interface.
+func (f *RoundFlag) DecodeField(dec *zjson.Decoder, key []byte) error {
+	var ok bool
+
+	if string(key) == "id" {
+		f.ID, ok = dec.Int()
+	} else if string(key) == "name" {
+		f.Name, ok = decodeString(dec)
+	} else if string(key) == "counts" {
+		ok = dec.Object(f.Counts)
+	} else if string(key) == "countsFinal" {
+		ok = dec.Object(f.CountsFinal)
+	} else {
+		return fmt.Errorf("RoundFlag.DecodeField invalid field: %s", string(key))
+	}
+
+	if ok {
+		return nil
+	}
+	return dec.Error()
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/goccy/go-json"
 
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/encode/zjson"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/object-pool/pool"
 )
 
@@ -419,3 +420,191 @@ func (m *MinMaxFloat64) equalFloat(x, y float64) bool {
 }
 
 const minMaxSize = 32
+
+// EncodeFields implements the zjson.Encoder.EncodeFields interface.
+func (m *MinMaxInt64) EncodeFields(enc *zjson.Encoder) {
+	enc.BoolField("first", m.first)
+	enc.Uint64Field("count", m.Count)
+	enc.Int64Field("total", m.Total)
+	enc.Int64Field("min", m.Min)
+	enc.Int64Field("max", m.Max)
+
+	keys := sortedKeys(m.Counts)
+	enc.StartArrayField("keys")
+	for ix := range keys {
+		enc.Int64(keys[ix])
+	}
+	enc.EndArray()
+
+	enc.StartArrayField("counts")
+	for ix := range keys {
+		enc.Uint64(m.Counts[keys[ix]])
+	}
+	enc.EndArray()
+}
+
+// DecodeField implements the zjson.Decoder.DecodeField interface.
+func (m *MinMaxInt64) DecodeField(dec *zjson.Decoder, key []byte) error {
+	var ok bool
+
+	if string(key) == "first" {
+		m.first, ok = dec.Bool()
+	} else if string(key) == "count" {
+		m.Count, ok = dec.Uint64()
+	} else if string(key) == "total" {
+		m.Total, ok = dec.Int64()
+	} else if string(key) == "min" {
+		m.Min, ok = dec.Int64()
+	} else if string(key) == "max" {
+		m.Max, ok = dec.Int64()
+	} else if string(key) == "keys" {
+		ok = dec.Array(func(dec *zjson.Decoder) error {
+			if k, ok2 := dec.Int64(); ok2 {
+				m.Keys = append(m.Keys, k)
+			}
+			return dec.Error()
+		})
+	} else if string(key) == "counts" {
+		var ix int
+		ok = dec.Array(func(dec *zjson.Decoder) error {
+			if ix >= len(m.Keys) {
+				return fmt.Errorf("MinMaxInt64.DecodeField too many counts")
+			}
+			if c, ok2 := dec.Uint64(); ok2 {
+				m.Counts[m.Keys[ix]] = c
+			}
+			ix++
+			return dec.Error()
+		})
+	} else {
+		return fmt.Errorf("MinMaxInt64.DecodeField invalid field: %s", string(key))
+	}
+
+	if ok {
+		return nil
+	}
+	return dec.Error()
+}
+
+// EncodeFields implements the zjson.Encoder.EncodeFields interface.
+func (m *MinMaxUInt64) EncodeFields(enc *zjson.Encoder) {
+	enc.BoolField("first", m.first)
+	enc.Uint64Field("count", m.Count)
+	enc.Uint64Field("total", m.Total)
+	enc.Uint64Field("min", m.Min)
+	enc.Uint64Field("max", m.Max)
+
+	keys := sortedKeys(m.Counts)
+	encodeUint64s(enc, "keys", keys)
+
+	enc.StartArrayField("counts")
+	for ix := range keys {
+		enc.Uint64(m.Counts[keys[ix]])
+	}
+	enc.EndArray()
+}
+
+// DecodeField implements the zjson.Decoder.DecodeField interface.
+func (m *MinMaxUInt64) DecodeField(dec *zjson.Decoder, key []byte) error {
+	var ok bool
+
+	if string(key) == "first" {
+		m.first, ok = dec.Bool()
+	} else if string(key) == "count" {
+		m.Count, ok = dec.Uint64()
+	} else if string(key) == "total" {
+		m.Total, ok = dec.Uint64()
+	} else if string(key) == "min" {
+		m.Min, ok = dec.Uint64()
+	} else if string(key) == "max" {
+		m.Max, ok = dec.Uint64()
+	} else if string(key) == "keys" {
+		m.Keys, ok = decodeUint64s(dec, m.Keys)
+	} else if string(key) == "counts" {
+		var ix int
+		ok = dec.Array(func(dec *zjson.Decoder) error {
+			if ix >= len(m.Keys) {
+				return fmt.Errorf("MinMaxUInt64.DecodeField too many counts")
+			}
+			if c, ok2 := dec.Uint64(); ok2 {
+				m.Counts[m.Keys[ix]] = c
+			}
+			ix++
+			return dec.Error()
+		})
+	} else {
+		return fmt.Errorf("MinMaxUInt64.DecodeField invalid field: %s", string(key))
+	}
+
+	if ok {
+		return nil
+	}
+	return dec.Error()
+}
+
+// EncodeFields implements the zjson.Encoder.EncodeFields interface.
+// The counts are encoded with their internal keys (e.g. value * factor), so the encoding is lossless.
+func (m *MinMaxFloat64) EncodeFields(enc *zjson.Encoder) {
+	enc.BoolField("first", m.first)
+	enc.Uint64Field("count", m.Count)
+	enc.FloatField("total", m.Total, 'g', -1)
+	enc.FloatField("min", m.Min, 'g', -1)
+	enc.FloatField("max", m.Max, 'g', -1)
+
+	keys := sortedKeys(m.Counts)
+	enc.StartArrayField("keys")
+	for ix := range keys {
+		enc.Int64(keys[ix])
+	}
+	enc.EndArray()
+
+	enc.StartArrayField("counts")
+	for ix := range keys {
+		enc.Uint64(m.Counts[keys[ix]])
+	}
+	enc.EndArray()
+}
+
+// DecodeField implements the zjson.Decoder.DecodeField interface.
+// The number of decimals is not decoded, so the metric must be acquired with the same number of decimals.
+func (m *MinMaxFloat64) DecodeField(dec *zjson.Decoder, key []byte) error {
+	var ok bool
+
+	if string(key) == "first" {
+		m.first, ok = dec.Bool()
+	} else if string(key) == "count" {
+		m.Count, ok = dec.Uint64()
+	} else if string(key) == "total" {
+		m.Total, ok = dec.Float()
+	} else if string(key) == "min" {
+		m.Min, ok = dec.Float()
+	} else if string(key) == "max" {
+		m.Max, ok = dec.Float()
+	} else if string(key) == "keys" {
+		ok = dec.Array(func(dec *zjson.Decoder) error {
+			if k, ok2 := dec.Int64(); ok2 {
+				m.Keys = append(m.Keys, k)
+			}
+			return dec.Error()
+		})
+	} else if string(key) == "counts" {
+		var ix int
+		ok = dec.Array(func(dec *zjson.Decoder) error {
+			if ix >= len(m.Keys) {
+				return fmt.Errorf("MinMaxFloat64.DecodeField too many counts")
+			}
+			if c, ok2 := dec.Uint64(); ok2 {
+				m.Counts[m.Keys[ix]] = c
+			}
+			ix++
+			return dec.Error()
+		})
+	} else {
+		return fmt.Errorf("MinMaxFloat64.DecodeField invalid field: %s", string(key))
+	}
+
+	if ok {
+		return nil
+	}
+	return dec.Error()
+}
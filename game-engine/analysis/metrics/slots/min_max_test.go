@@ -74,6 +74,13 @@ func TestNewMinMaxInt64(t *testing.T) {
 				assert.EqualValues(t, tc.want, m)
 			}
 
+			d := AcquireMinMaxInt64()
+			defer d.Release()
+			roundTrip(t, m, d)
+			if !tc.want.Equals(d) {
+				assert.EqualValues(t, tc.want, d)
+			}
+
 			m.ResetData()
 			assert.Zero(t, m.Total)
 			assert.Equal(t, int64(0), m.Min)
@@ -193,6 +200,13 @@ func TestNewMinMaxUInt64(t *testing.T) {
 				assert.EqualValues(t, tc.want, m)
 			}
 
+			d := AcquireMinMaxUInt64()
+			defer d.Release()
+			roundTrip(t, m, d)
+			if !tc.want.Equals(d) {
+				assert.EqualValues(t, tc.want, d)
+			}
+
 			m.ResetData()
 			assert.Zero(t, m.Total)
 			assert.Equal(t, uint64(0), m.Min)
@@ -336,6 +350,13 @@ func TestNewMinMaxFloat64(t *testing.T) {
 			require.NotNil(t, j)
 			assert.Equal(t, tc.j, string(j))
 
+			d := AcquireMinMaxFloat64(1)
+			defer d.Release()
+			roundTrip(t, m, d)
+			if !tc.want.Equals(d) {
+				assert.EqualValues(t, tc.want, d)
+			}
+
 			m.ResetData()
 			assert.Zero(t, m.Total)
 			assert.Zero(t, m.Min)
@@ -1,8 +1,10 @@
 package slots
 
 import (
+	"fmt"
 	"reflect"
 
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/encode/zjson"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/object-pool/pool"
 
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/consts"
@@ -173,3 +175,54 @@ func PurgePaylines(list Paylines, capacity int) Paylines {
 const (
 	maxReels = 15 // maximum possible count; increase when a game has more reels than this.
 )
+
+// EncodeFields implements the zjson.Encoder.EncodeFields interface.
+func (p *Payline) EncodeFields(enc *zjson.Encoder) {
+	enc.IntField("id", p.ID)
+	enc.Uint64FieldOpt("count", p.Count)
+	enc.ObjectField("payouts", p.Payouts)
+
+	enc.StartArrayField("rowMap")
+	for ix := range p.RowMap {
+		enc.Uint64(uint64(p.RowMap[ix]))
+	}
+	enc.EndArray()
+
+	encodeUint64s(enc, "symbols", p.Symbols)
+	encodeUint64s(enc, "lengths", p.Lengths)
+	encodeUint64ss(enc, "symbolLengths", p.SymbolLengths)
+}
+
+// DecodeField implements the zjson.Decoder.DecodeField interface.
+func (p *Payline) DecodeField(dec *zjson.Decoder, key []byte) error {
+	var ok bool
+
+	if string(key) == "id" {
+		p.ID, ok = dec.Int()
+	} else if string(key) == "count" {
+		p.Count, ok = dec.Uint64()
+	} else if string(key) == "payouts" {
+		ok = dec.Object(p.Payouts)
+	} else if string(key) == "rowMap" {
+		p.RowMap = p.RowMap[:0]
+		ok = dec.Array(func(dec *zjson.Decoder) error {
+			if i8, ok2 := dec.Uint8(); ok2 {
+				p.RowMap = append(p.RowMap, i8)
+			}
+			return dec.Error()
+		})
+	} else if string(key) == "symbols" {
+		p.Symbols, ok = decodeUint64s(dec, p.Symbols)
+	} else if string(key) == "lengths" {
+		p.Lengths, ok = decodeUint64s(dec, p.Lengths)
+	} else if string(key) == "symbolLengths" {
+		p.SymbolLengths, ok = decodeUint64ss(dec, p.SymbolLengths)
+	} else {
+		return fmt.Errorf("Payline.DecodeField invalid field: %s", string(key))
+	}
+
+	if ok {
+		return nil
+	}
+	return dec.Error()
+}
@@ -121,6 +121,13 @@ func TestNewPayline(t *testing.T) {
 				assert.EqualValues(t, tc.want, p)
 			}
 
+			d := NewPayline(0, tc.maxSymbol, nil)
+			defer d.Release()
+			roundTrip(t, p, d)
+			if !tc.want.Equals(d) {
+				assert.EqualValues(t, tc.want, d)
+			}
+
 			n := p.Clone().(*Payline)
 			require.NotNil(t, n)
 			defer n.Release()
@@ -1,8 +1,10 @@
 package slots
 
 import (
+	"fmt"
 	"reflect"
 
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/encode/zjson"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/object-pool/pool"
 
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/components/slots"
@@ -293,3 +295,69 @@ func ExpandUInt64s(input []uint64, capacity int) []uint64 {
 	}
 	return input[:capacity]
 }
+
+// EncodeFields implements the zjson.Encoder.EncodeFields interface.
+func (r *Rounds) EncodeFields(enc *zjson.Encoder) {
+	enc.Uint64Field("count", r.Count)
+	enc.ObjectField("bets", r.Bets)
+	enc.ObjectField("betsNoFree", r.BetsNoFree)
+	enc.ObjectField("betsFree", r.BetsFree)
+	enc.ObjectField("wins", r.Wins)
+	enc.ObjectField("winsNoFree", r.WinsNoFree)
+	enc.ObjectField("winsFree", r.WinsFree)
+	enc.ObjectField("freeSpins", r.FreeSpins)
+	enc.ObjectField("refillSpins", r.RefillSpins)
+	enc.ObjectField("superSpins", r.SuperSpins)
+	encodeUint64s(enc, "freeSpinRounds", r.FreeSpinRounds)
+	encodeUint64s(enc, "refillRounds", r.RefillRounds)
+	encodeUint64s(enc, "superRounds", r.SuperRounds)
+	encodeUint64s(enc, "symbolsUsed", r.SymbolsUsed)
+	encodeUint64s(enc, "symbolsNoFree", r.SymbolsNoFree)
+	encodeUint64s(enc, "symbolsFree", r.SymbolsFree)
+}
+
+// DecodeField implements the zjson.Decoder.DecodeField interface.
+func (r *Rounds) DecodeField(dec *zjson.Decoder, key []byte) error {
+	var ok bool
+
+	if string(key) == "count" {
+		r.Count, ok = dec.Uint64()
+	} else if string(key) == "bets" {
+		ok = dec.Object(r.Bets)
+	} else if string(key) == "betsNoFree" {
+		ok = dec.Object(r.BetsNoFree)
+	} else if string(key) == "betsFree" {
+		ok = dec.Object(r.BetsFree)
+	} else if string(key) == "wins" {
+		ok = dec.Object(r.Wins)
+	} else if string(key) == "winsNoFree" {
+		ok = dec.Object(r.WinsNoFree)
+	} else if string(key) == "winsFree" {
+		ok = dec.Object(r.WinsFree)
+	} else if string(key) == "freeSpins" {
+		ok = dec.Object(r.FreeSpins)
+	} else if string(key) == "refillSpins" {
+		ok = dec.Object(r.RefillSpins)
+	} else if string(key) == "superSpins" {
+		ok = dec.Object(r.SuperSpins)
+	} else if string(key) == "freeSpinRounds" {
+		r.FreeSpinRounds, ok = decodeUint64s(dec, r.FreeSpinRounds)
+	} else if string(key) == "refillRounds" {
+		r.RefillRounds, ok = decodeUint64s(dec, r.RefillRounds)
+	} else if string(key) == "superRounds" {
+		r.SuperRounds, ok = decodeUint64s(dec, r.SuperRounds)
+	} else if string(key) == "symbolsUsed" {
+		r.SymbolsUsed, ok = decodeUint64s(dec, r.SymbolsUsed)
+	} else if string(key) == "symbolsNoFree" {
+		r.SymbolsNoFree, ok = decodeUint64s(dec, r.SymbolsNoFree)
+	} else if string(key) == "symbolsFree" {
+		r.SymbolsFree, ok = decodeUint64s(dec, r.SymbolsFree)
+	} else {
+		return fmt.Errorf("Rounds.DecodeField invalid field: %s", string(key))
+	}
+
+	if ok {
+		return nil
+	}
+	return dec.Error()
+}
@@ -246,6 +246,13 @@ func TestNewRounds(t *testing.T) {
 				assert.EqualValues(t, tc.want, r)
 			}
 
+			d := NewRounds(nil)
+			defer d.Release()
+			roundTrip(t, r, d)
+			if !tc.want.Equals(d) {
+				assert.EqualValues(t, tc.want, d)
+			}
+
 			n := r.Clone().(*Rounds)
 			require.NotNil(t, n)
 			defer n.Release()
@@ -1,8 +1,10 @@
 package slots
 
 import (
+	"fmt"
 	"reflect"
 
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/encode/zjson"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/object-pool/pool"
 
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/consts"
@@ -158,3 +160,36 @@ func (s *ScatterPayout) Equals(other *ScatterPayout) bool {
 		reflect.DeepEqual(s.Lengths, other.Lengths) &&
 		reflect.DeepEqual(s.SymbolLengths, other.SymbolLengths)
 }
+
+// EncodeFields implements the zjson.Encoder.EncodeFields interface.
+func (s *ScatterPayout) EncodeFields(enc *zjson.Encoder) {
+	enc.Uint64FieldOpt("count", s.Count)
+	enc.ObjectField("payouts", s.Payouts)
+	encodeUint64s(enc, "symbols", s.Symbols)
+	encodeUint64s(enc, "lengths", s.Lengths)
+	encodeUint64ss(enc, "symbolLengths", s.SymbolLengths)
+}
+
+// DecodeField implements the zjson.Decoder.DecodeField interface.
+func (s *ScatterPayout) DecodeField(dec *zjson.Decoder, key []byte) error {
+	var ok bool
+
+	if string(key) == "count" {
+		s.Count, ok = dec.Uint64()
+	} else if string(key) == "payouts" {
+		ok = dec.Object(s.Payouts)
+	} else if string(key) == "symbols" {
+		s.Symbols, ok = decodeUint64s(dec, s.Symbols)
+	} else if string(key) == "lengths" {
+		s.Lengths, ok = decodeUint64s(dec, s.Lengths)
+	} else if string(key) == "symbolLengths" {
+		s.SymbolLengths, ok = decodeUint64ss(dec, s.SymbolLengths)
+	} else {
+		return fmt.Errorf("ScatterPayout.DecodeField invalid field: %s", string(key))
+	}
+
+	if ok {
+		return nil
+	}
+	return dec.Error()
+}
@@ -122,6 +122,13 @@ func TestNewScatterPayout(t *testing.T) {
 				assert.EqualValues(t, tc.want, s)
 			}
 
+			d := NewScatterPayout(tc.maxSymbol)
+			defer d.Release()
+			roundTrip(t, s, d)
+			if !tc.want.Equals(d) {
+				assert.EqualValues(t, tc.want, d)
+			}
+
 			n := s.Clone().(*ScatterPayout)
 			require.NotNil(t, n)
 			defer n.Release()
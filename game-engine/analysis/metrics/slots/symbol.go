@@ -1,8 +1,10 @@
 package slots
 
 import (
+	"fmt"
 	"reflect"
 
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/encode/zjson"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/object-pool/pool"
 
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/consts"
@@ -235,3 +237,75 @@ func PurgeSymbols(list Symbols, capacity int) Symbols {
 	}
 	return list
 }
+
+// EncodeFields implements the zjson.Encoder.EncodeFields interface.
+func (s *Symbol) EncodeFields(enc *zjson.Encoder) {
+	enc.Uint16Field("id", uint16(s.ID))
+	enc.EscapedStringFieldOpt("name", s.Name)
+	enc.EscapedStringFieldOpt("resource", s.Resource)
+	enc.Uint64FieldOpt("totalCount", s.TotalCount)
+	enc.Uint64FieldOpt("firstCount", s.FirstCount)
+	enc.Uint64FieldOpt("secondCount", s.SecondCount)
+	enc.Uint64FieldOpt("freeCount", s.FreeCount)
+	enc.Uint64FieldOpt("freeSecondCount", s.FreeSecondCount)
+	enc.Uint64FieldOpt("bonusCount", s.BonusCount)
+	enc.Uint64FieldOpt("stickyCount", s.StickyCount)
+	enc.Uint64FieldOpt("superCount", s.SuperCount)
+	encodeUint64s(enc, "totalReels", s.TotalReels)
+	encodeUint64s(enc, "firstReels", s.FirstReels)
+	encodeUint64s(enc, "secondReels", s.SecondReels)
+	encodeUint64s(enc, "freeReels", s.FreeReels)
+	encodeUint64s(enc, "freeSecondReels", s.FreeSecondReels)
+	encodeUint64s(enc, "payouts", s.Payouts)
+}
+
+// DecodeField implements the zjson.Decoder.DecodeField interface.
+func (s *Symbol) DecodeField(dec *zjson.Decoder, key []byte) error {
+	var ok bool
+	var i16 uint16
+
+	if string(key) == "id" {
+		if i16, ok = dec.Uint16(); ok {
+			s.ID = utils.Index(i16)
+		}
+	} else if string(key) == "name" {
+		s.Name, ok = decodeString(dec)
+	} else if string(key) == "resource" {
+		s.Resource, ok = decodeString(dec)
+	} else if string(key) == "totalCount" {
+		s.TotalCount, ok = dec.Uint64()
+	} else if string(key) == "firstCount" {
+		s.FirstCount, ok = dec.Uint64()
+	} else if string(key) == "secondCount" {
+		s.SecondCount, ok = dec.Uint64()
+	} else if string(key) == "freeCount" {
+		s.FreeCount, ok = dec.Uint64()
+	} else if string(key) == "freeSecondCount" {
+		s.FreeSecondCount, ok = dec.Uint64()
+	} else if string(key) == "bonusCount" {
+		s.BonusCount, ok = dec.Uint64()
+	} else if string(key) == "stickyCount" {
+		s.StickyCount, ok = dec.Uint64()
+	} else if string(key) == "superCount" {
+		s.SuperCount, ok = dec.Uint64()
+	} else if string(key) == "totalReels" {
+		s.TotalReels, ok = decodeUint64s(dec, s.TotalReels)
+	} else if string(key) == "firstReels" {
+		s.FirstReels, ok = decodeUint64s(dec, s.FirstReels)
+	} else if string(key) == "secondReels" {
+		s.SecondReels, ok = decodeUint64s(dec, s.SecondReels)
+	} else if string(key) == "freeReels" {
+		s.FreeReels, ok = decodeUint64s(dec, s.FreeReels)
+	} else if string(key) == "freeSecondReels" {
+		s.FreeSecondReels, ok = decodeUint64s(dec, s.FreeSecondReels)
+	} else if string(key) == "payouts" {
+		s.Payouts, ok = decodeUint64s(dec, s.Payouts)
+	} else {
+		return fmt.Errorf("Symbol.DecodeField invalid field: %s", string(key))
+	}
+
+	if ok {
+		return nil
+	}
+	return dec.Error()
+}
@@ -292,6 +292,13 @@ func TestNewSymbol(t *testing.T) {
 				assert.Equal(t, tc.j, string(j))
 			}
 
+			d := NewSymbol(0, "", "", tc.reelCount)
+			defer d.Release()
+			roundTrip(t, s, d)
+			if !tc.want.Equals(d) {
+				assert.EqualValues(t, tc.want, d)
+			}
+
 			n := s.Clone().(*Symbol)
 			require.NotNil(t, n)
 			defer n.Release()
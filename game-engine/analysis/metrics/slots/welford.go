@@ -0,0 +1,168 @@
+package slots
+
+import (
+	"fmt"
+	"math"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/encode/zjson"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/object-pool/pool"
+)
+
+// AcquireWelford instantiates a new running mean/variance metric from the memory pool.
+func AcquireWelford() *Welford {
+	return welfordPool.Acquire().(*Welford)
+}
+
+// Welford contains the running mean and sum of squared differences of a float64 metric.
+// It uses Welford's online algorithm, which is numerically stable for billions of inputs.
+type Welford struct {
+	Count uint64  `json:"count"`
+	Mean  float64 `json:"mean"`
+	M2    float64 `json:"m2"`
+	pool.Object
+}
+
+var welfordPool = pool.NewProducer(func() (pool.Objecter, func()) {
+	w := &Welford{}
+	return w, w.reset
+})
+
+// reset clears the metric.
+func (w *Welford) reset() {
+	if w != nil {
+		w.ResetData()
+	}
+}
+
+// ResetData implements the Objecter interface.
+func (w *Welford) ResetData() {
+	w.Count = 0
+	w.Mean = 0
+	w.M2 = 0
+}
+
+// Increase updates the running mean/variance with the given input.
+func (w *Welford) Increase(in float64) {
+	w.Count++
+	delta := in - w.Mean
+	w.Mean += delta / float64(w.Count)
+	w.M2 += delta * (in - w.Mean)
+}
+
+// Merge merges the given metrics.
+// It uses the parallel variant of Welford's algorithm (Chan et al.).
+func (w *Welford) Merge(other *Welford) {
+	if other.Count == 0 {
+		return
+	}
+	if w.Count == 0 {
+		w.Count, w.Mean, w.M2 = other.Count, other.Mean, other.M2
+		return
+	}
+
+	n1, n2 := float64(w.Count), float64(other.Count)
+	n := n1 + n2
+	delta := other.Mean - w.Mean
+
+	w.Count += other.Count
+	w.Mean += delta * n2 / n
+	w.M2 += other.M2 + delta*delta*n1*n2/n
+}
+
+// Variance returns the sample variance of the inputs.
+func (w *Welford) Variance() float64 {
+	if w.Count < 2 {
+		return 0
+	}
+	return w.M2 / float64(w.Count-1)
+}
+
+// StdDev returns the sample standard deviation of the inputs.
+func (w *Welford) StdDev() float64 {
+	return math.Sqrt(w.Variance())
+}
+
+// StdErr returns the standard error of the mean.
+func (w *Welford) StdErr() float64 {
+	if w.Count < 2 {
+		return 0
+	}
+	return math.Sqrt(w.Variance() / float64(w.Count))
+}
+
+// ConfidenceInterval returns the lower and upper bound of the confidence interval of the mean for the given level.
+func (w *Welford) ConfidenceInterval(level Confidence) (float64, float64) {
+	d := level.Z() * w.StdErr()
+	return w.Mean - d, w.Mean + d
+}
+
+// Equals is used internally for unit tests!
+func (w *Welford) Equals(other *Welford) bool {
+	return w.Count == other.Count &&
+		w.Mean == other.Mean &&
+		w.M2 == other.M2
+}
+
+// EncodeFields implements the zjson.Encoder.EncodeFields interface.
+func (w *Welford) EncodeFields(enc *zjson.Encoder) {
+	enc.Uint64FieldOpt("count", w.Count)
+	enc.FloatField("mean", w.Mean, 'g', -1)
+	enc.FloatField("m2", w.M2, 'g', -1)
+}
+
+// DecodeField implements the zjson.Decoder.DecodeField interface.
+func (w *Welford) DecodeField(dec *zjson.Decoder, key []byte) error {
+	var ok bool
+
+	if string(key) == "count" {
+		w.Count, ok = dec.Uint64()
+	} else if string(key) == "mean" {
+		w.Mean, ok = dec.Float()
+	} else if string(key) == "m2" {
+		w.M2, ok = dec.Float()
+	} else {
+		return fmt.Errorf("Welford.DecodeField invalid field: %s", string(key))
+	}
+
+	if ok {
+		return nil
+	}
+	return dec.Error()
+}
+
+// Confidence represents a confidence level for statistical intervals.
+type Confidence uint8
+
+const (
+	Confidence90 Confidence = iota + 1
+	Confidence95
+	Confidence99
+)
+
+// Z returns the two-sided critical value of the standard normal distribution for the confidence level.
+func (c Confidence) Z() float64 {
+	switch c {
+	case Confidence90:
+		return 1.6448536269514722
+	case Confidence95:
+		return 1.959963984540054
+	case Confidence99:
+		return 2.5758293035489004
+	default:
+		return 0
+	}
+}
+
+// String implements the Stringer interface.
+func (c Confidence) String() string {
+	switch c {
+	case Confidence90:
+		return "90%"
+	case Confidence95:
+		return "95%"
+	case Confidence99:
+		return "99%"
+	default:
+		return "unknown"
+	}
+}
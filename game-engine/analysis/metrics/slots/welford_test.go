@@ -0,0 +1,120 @@
+package slots
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWelford(t *testing.T) {
+	testCases := []struct {
+		name      string
+		increases []float64
+		mean      float64
+		variance  float64
+	}{
+		{name: "empty"},
+		{name: "single", increases: []float64{5}, mean: 5},
+		{name: "zeroes", increases: []float64{0, 0, 0, 0}},
+		{name: "1,2,3,4,5", increases: []float64{1, 2, 3, 4, 5}, mean: 3, variance: 2.5},
+		{name: "2,4,4,4,5,5,7,9", increases: []float64{2, 4, 4, 4, 5, 5, 7, 9}, mean: 5, variance: 32.0 / 7},
+		{name: "0,0,0,10", increases: []float64{0, 0, 0, 10}, mean: 2.5, variance: 25},
+		{name: "large offset", increases: []float64{1e9 + 4, 1e9 + 7, 1e9 + 13, 1e9 + 16}, mean: 1e9 + 10, variance: 30},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := AcquireWelford()
+			require.NotNil(t, w)
+			defer w.Release()
+
+			for _, in := range tc.increases {
+				w.Increase(in)
+			}
+
+			assert.Equal(t, uint64(len(tc.increases)), w.Count)
+			assert.InDelta(t, tc.mean, w.Mean, 1e-9)
+			assert.InDelta(t, tc.variance, w.Variance(), 1e-9)
+			assert.InDelta(t, math.Sqrt(tc.variance), w.StdDev(), 1e-9)
+
+			lo, hi := w.ConfidenceInterval(Confidence95)
+			assert.InDelta(t, tc.mean, (lo+hi)/2, 1e-9)
+			if tc.variance > 0 {
+				assert.InDelta(t, 2*1.96*math.Sqrt(tc.variance/float64(len(tc.increases))), hi-lo, 1e-3)
+			} else {
+				assert.Equal(t, lo, hi)
+			}
+
+			d := AcquireWelford()
+			defer d.Release()
+			roundTrip(t, w, d)
+			assert.True(t, w.Equals(d))
+
+			w.ResetData()
+			assert.Zero(t, w.Count)
+			assert.Zero(t, w.Mean)
+			assert.Zero(t, w.M2)
+		})
+	}
+}
+
+func TestWelford_Merge(t *testing.T) {
+	testCases := []struct {
+		name  string
+		in    []float64
+		other []float64
+	}{
+		{name: "merge empties"},
+		{name: "merge with empty", in: []float64{1, 2, 3}},
+		{name: "merge to empty", other: []float64{1, 2, 3}},
+		{name: "merge non-empties", in: []float64{1, 2, 3, 4}, other: []float64{10, 20, 0, 0, 0, 5}},
+		{name: "merge singles", in: []float64{1}, other: []float64{9}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w1 := AcquireWelford()
+			defer w1.Release()
+			w2 := AcquireWelford()
+			defer w2.Release()
+			want := AcquireWelford()
+			defer want.Release()
+
+			for _, in := range tc.in {
+				w1.Increase(in)
+				want.Increase(in)
+			}
+			for _, in := range tc.other {
+				w2.Increase(in)
+				want.Increase(in)
+			}
+
+			w1.Merge(w2)
+			assert.Equal(t, want.Count, w1.Count)
+			assert.InDelta(t, want.Mean, w1.Mean, 1e-9)
+			assert.InDelta(t, want.M2, w1.M2, 1e-9)
+		})
+	}
+}
+
+func TestConfidence(t *testing.T) {
+	testCases := []struct {
+		level Confidence
+		z     float64
+		s     string
+	}{
+		{level: 0, z: 0, s: "unknown"},
+		{level: Confidence90, z: 1.645, s: "90%"},
+		{level: Confidence95, z: 1.960, s: "95%"},
+		{level: Confidence99, z: 2.576, s: "99%"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.s, func(t *testing.T) {
+			assert.InDelta(t, tc.z, tc.level.Z(), 0.001)
+			assert.Equal(t, tc.s, tc.level.String())
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package slots
+
+import (
+	"context"
+
+	exact "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/analysis/exact/slots"
+	metrics "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/analysis/metrics/slots"
+	game "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/games/slots"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/simulate"
+)
+
+// Stats contains the measured stats of a candidate game.
+type Stats struct {
+	RTP          float64 `json:"rtp"`                // RTP in %.
+	HitRate      float64 `json:"hitRate"`            // percentage of rounds with a win.
+	FreeSpinRate float64 `json:"freeSpinRate"`       // percentage of rounds with free spins.
+	Rounds       uint64  `json:"rounds,omitempty"`   // number of simulated rounds; 0 for exact stats.
+	Exact        bool    `json:"exact,omitempty"`    // indicates the stats were calculated analytically.
+	StdDev       float64 `json:"stdDev,omitempty"`   // standard deviation of the return per round.
+	RTPWidth     float64 `json:"rtpWidth,omitempty"` // width of the 95% confidence interval of the RTP; 0 for exact stats.
+}
+
+// Evaluator measures the stats of a candidate game.
+type Evaluator func(ctx context.Context, params game.RegularParams) (*Stats, error)
+
+// Exact returns an evaluator which calculates the exact base game stats of the paylines.
+// It is fast and free of noise, but only supports simple games (see the analysis/exact/slots package).
+// Games with free spins or other features will have a higher RTP than calculated.
+// If needHitRate is set, the evaluator fails for games with too many distinct grids to determine the hit rate.
+func Exact(needHitRate bool, opts ...exact.Option) Evaluator {
+	return func(_ context.Context, params game.RegularParams) (*Stats, error) {
+		reels, err := exact.NewReels(params.Slots)
+		if err != nil {
+			return nil, err
+		}
+
+		r, err := exact.Evaluate(params.Slots, reels, opts...)
+		if err != nil {
+			return nil, err
+		}
+		if needHitRate && !r.Enumerated {
+			return nil, ErrNoHitRate
+		}
+
+		return &Stats{RTP: r.RTP, HitRate: r.HitRate, Exact: true, StdDev: r.StdDev()}, nil
+	}
+}
+
+// Simulation returns an evaluator which simulates the given number of rounds for each candidate game.
+// The template supplies the other simulation parameters, such as the game number, symbols, actions and workers.
+// Its NewGame, Rounds, Resume and Checkpoint parameters are ignored.
+func Simulation(template simulate.SlotsParams, rounds uint64) Evaluator {
+	return func(ctx context.Context, params game.RegularParams) (*Stats, error) {
+		p := template
+		p.NewGame = func() *game.Regular { return game.AcquireRegular(params) }
+		p.Rounds = rounds
+		p.Resume = nil
+		p.Checkpoint = nil
+
+		r, err := simulate.Slots(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+		defer r.Release()
+
+		if err = ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		s := &Stats{
+			RTP:      r.RTP(),
+			HitRate:  r.HitRate(),
+			Rounds:   r.RoundCount,
+			StdDev:   r.StdDev(),
+			RTPWidth: r.RTPConfidenceWidth(metrics.Confidence95),
+		}
+		if r.AllRounds.Count > 0 {
+			s.FreeSpinRate = float64(r.AllRounds.BetsFree.Count) * 100 / float64(r.AllRounds.Count)
+		}
+		return s, nil
+	}
+}
@@ -0,0 +1,256 @@
+package slots
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+
+	game "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/games/slots"
+)
+
+// Builder builds the game parameters for a candidate weight table.
+type Builder func(t Table) (game.RegularParams, error)
+
+// Params contains the parameters for a reel weight optimization.
+type Params struct {
+	Start    Table     // weight table to start from (required).
+	Build    Builder   // builds the game for a candidate weight table (required).
+	Evaluate Evaluator // measures the stats of a candidate game (required).
+
+	TargetRTP        float64 // target RTP in % (required).
+	RTPTolerance     float64 // accepted deviation from the target RTP in percentage points; defaults to 0.05.
+	TargetHitRate    float64 // target hit rate in % (optional).
+	HitRateTolerance float64 // accepted deviation from the target hit rate in percentage points; defaults to 0.5.
+	MaxFreeSpinRate  float64 // maximum percentage of rounds with free spins (optional).
+
+	MinWeight float64 // default minimum weight for symbols which start with a non-zero weight; defaults to 1.
+	MaxWeight float64 // default maximum weight; defaults to unlimited.
+	Bounds    []Bound // specific limits per symbol and reel; these override MinWeight and MaxWeight.
+
+	Iterations int                                  // maximum number of candidates to evaluate; defaults to 1000.
+	Step       float64                              // maximum relative change of a weight per mutation; defaults to 0.25.
+	Seed       uint64                               // seed for selecting the mutations, so the search can be repeated.
+	Progress   func(iteration int, best *Candidate) // called whenever a better candidate is found (optional).
+}
+
+// Candidate contains a weight table and its measured stats.
+type Candidate struct {
+	Table      Table   `json:"table"`
+	Stats      *Stats  `json:"stats"`
+	Loss       float64 `json:"loss"`       // weighted distance from the targets; 0 if all targets are met exactly.
+	Iterations int     `json:"iterations"` // number of candidates evaluated.
+	Converged  bool    `json:"converged"`  // indicates if all targets are met within tolerance.
+}
+
+// Optimize searches for a weight table which meets the target RTP and constraints.
+//
+// It performs a randomized hill-climb: each iteration mutates one or two weights of the best table so far within
+// their bounds, and keeps the mutation if it brings the stats closer to the targets. The search stops when all targets
+// are met within tolerance, when the number of iterations is exhausted, or when the context is cancelled.
+// Weights are rounded to whole numbers, and weights which start at zero remain zero unless bounds are given for them.
+//
+// The best candidate is always returned, even if it did not converge.
+// With a simulation evaluator the stats are subject to noise, so the final candidate should be verified with a long
+// simulation before it is used.
+func Optimize(ctx context.Context, params Params) (*Candidate, error) {
+	if len(params.Start) == 0 || params.Build == nil || params.Evaluate == nil || params.TargetRTP <= 0 {
+		return nil, ErrInvalidParams
+	}
+
+	o := &optimizer{params: &params, rand: rand.New(rand.NewPCG(params.Seed, params.Seed^0x9e3779b97f4a7c15))}
+	if err := o.init(); err != nil {
+		return nil, err
+	}
+
+	best, err := o.evaluate(ctx, o.clamp(params.Start.Clone()))
+	if err != nil {
+		return nil, err
+	}
+	best.Iterations = 1
+
+	iterations := params.iterations()
+	for best.Iterations < iterations && !best.Converged && ctx.Err() == nil {
+		t := o.mutate(best.Table)
+		if t == nil {
+			continue
+		}
+
+		c, err2 := o.evaluate(ctx, t)
+		best.Iterations++
+		if err2 != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			return nil, err2
+		}
+
+		if c.Loss < best.Loss {
+			c.Iterations = best.Iterations
+			best = c
+			if params.Progress != nil {
+				params.Progress(best.Iterations, best)
+			}
+		}
+	}
+
+	return best, nil
+}
+
+// Loss returns the weighted distance of the stats from the targets.
+// Each term is scaled by its tolerance, so a loss below 1 means all targets are nearly met.
+func (p *Params) Loss(s *Stats) float64 {
+	d := (s.RTP - p.TargetRTP) / p.rtpTolerance()
+	loss := d * d
+
+	if p.TargetHitRate > 0 {
+		d = (s.HitRate - p.TargetHitRate) / p.hitRateTolerance()
+		loss += d * d
+	}
+
+	if p.MaxFreeSpinRate > 0 && s.FreeSpinRate > p.MaxFreeSpinRate {
+		d = (s.FreeSpinRate - p.MaxFreeSpinRate) * 100 / p.MaxFreeSpinRate
+		loss += d * d
+	}
+
+	return loss
+}
+
+// Converged returns true if the stats meet all targets within tolerance.
+func (p *Params) Converged(s *Stats) bool {
+	if math.Abs(s.RTP-p.TargetRTP) > p.rtpTolerance() {
+		return false
+	}
+	if p.TargetHitRate > 0 && math.Abs(s.HitRate-p.TargetHitRate) > p.hitRateTolerance() {
+		return false
+	}
+	return p.MaxFreeSpinRate <= 0 || s.FreeSpinRate <= p.MaxFreeSpinRate
+}
+
+type optimizer struct {
+	params *Params
+	rand   *rand.Rand
+	cells  []cell
+}
+
+// cell is a weight which can be mutated.
+type cell struct {
+	symbol   int
+	reel     int
+	min, max float64
+}
+
+func (o *optimizer) init() error {
+	t := o.params.Start
+	o.cells = make([]cell, 0, len(t)*8)
+
+	for ix := range t {
+		for reel, w := range t[ix].Reels {
+			if min, max, ok := o.params.bounds(t[ix].Symbol, reel, w); ok {
+				o.cells = append(o.cells, cell{symbol: ix, reel: reel, min: min, max: max})
+			}
+		}
+	}
+
+	if len(o.cells) == 0 {
+		return ErrNoWeights
+	}
+	return nil
+}
+
+func (o *optimizer) evaluate(ctx context.Context, t Table) (*Candidate, error) {
+	params, err := o.params.Build(t)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := o.params.Evaluate(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Candidate{
+		Table:     t,
+		Stats:     stats,
+		Loss:      o.params.Loss(stats),
+		Converged: o.params.Converged(stats),
+	}, nil
+}
+
+// clamp forces the weights of the table within their bounds.
+func (o *optimizer) clamp(t Table) Table {
+	for _, c := range o.cells {
+		w := &t[c.symbol].Reels[c.reel]
+		*w = math.Max(c.min, math.Min(c.max, math.Round(*w)))
+	}
+	return t
+}
+
+// mutate returns a copy of the table with one or two weights changed.
+// It returns nil if the mutation did not change any weight, e.g. because the weight is at its bound.
+func (o *optimizer) mutate(t Table) Table {
+	out := t.Clone()
+	step := o.params.step()
+
+	var changed bool
+	for n := 1 + o.rand.IntN(2); n > 0; n-- {
+		c := o.cells[o.rand.IntN(len(o.cells))]
+		w := &out[c.symbol].Reels[c.reel]
+
+		delta := math.Max(1, math.Round(*w*step*o.rand.Float64()))
+		if o.rand.IntN(2) == 0 {
+			delta = -delta
+		}
+
+		if v := math.Max(c.min, math.Min(c.max, *w+delta)); v != *w {
+			*w = v
+			changed = true
+		}
+	}
+
+	if !changed {
+		return nil
+	}
+	return out
+}
+
+func (p *Params) rtpTolerance() float64 {
+	if p.RTPTolerance > 0 {
+		return p.RTPTolerance
+	}
+	return 0.05
+}
+
+func (p *Params) hitRateTolerance() float64 {
+	if p.HitRateTolerance > 0 {
+		return p.HitRateTolerance
+	}
+	return 0.5
+}
+
+func (p *Params) minWeight() float64 {
+	if p.MinWeight > 0 {
+		return p.MinWeight
+	}
+	return 1
+}
+
+func (p *Params) iterations() int {
+	if p.Iterations > 0 {
+		return p.Iterations
+	}
+	return 1000
+}
+
+func (p *Params) step() float64 {
+	if p.Step > 0 {
+		return p.Step
+	}
+	return 0.25
+}
+
+var (
+	ErrInvalidParams = errors.New("invalid optimization parameters")
+	ErrNoWeights     = errors.New("no weights can be changed within the given bounds")
+	ErrNoHitRate     = errors.New("evaluator cannot determine the hit rate")
+)
@@ -0,0 +1,271 @@
+package slots
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/tg"
+
+	comp "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/components/slots"
+	game "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/games/slots"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/simulate"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/utils"
+)
+
+var (
+	payouts = [][]float64{
+		{0, 0, 3, 6, 12},
+		{0, 0, 3, 6, 15},
+		{0, 0, 6, 7.5, 15},
+		{0, 0, 6, 12, 21},
+		{0, 0, 6, 15, 45},
+	}
+
+	start = Table{
+		{Symbol: 1, Reels: []float64{90, 70, 90, 70, 90}},
+		{Symbol: 2, Reels: []float64{90, 70, 90, 70, 90}},
+		{Symbol: 3, Reels: []float64{70, 90, 70, 90, 70}},
+		{Symbol: 4, Reels: []float64{70, 90, 70, 90, 70}},
+		{Symbol: 5, Reels: []float64{0, 8, 8, 8, 0}},
+	}
+
+	paylines = []*comp.Payline{
+		comp.NewPayline(1, 3, 1, 1, 1, 1, 1),
+		comp.NewPayline(2, 3, 0, 0, 0, 0, 0),
+		comp.NewPayline(3, 3, 2, 2, 2, 2, 2),
+		comp.NewPayline(4, 3, 0, 1, 2, 1, 0),
+		comp.NewPayline(5, 3, 2, 1, 0, 1, 2),
+	}
+)
+
+func build(t Table) (game.RegularParams, error) {
+	symbols := make([]*comp.Symbol, len(t))
+	for ix := range t {
+		opts := []comp.SymbolOption{comp.WithPayouts(payouts[ix]...), comp.WithWeights(t[ix].Reels...)}
+		if t[ix].Symbol == 5 {
+			opts = append(opts, comp.WithKind(comp.Wild))
+		}
+		symbols[ix] = comp.NewSymbol(t[ix].Symbol, opts...)
+	}
+
+	s := comp.NewSlots(comp.Grid(5, 3), comp.WithSymbols(comp.NewSymbolSet(symbols...)),
+		comp.WithPaylines(comp.PayLTR, false, paylines...), comp.WithActions(comp.SpinActions{comp.NewPaylinesAction()}, nil, nil, nil),
+		comp.MaxPayout(5000))
+	return game.RegularParams{Slots: s}, nil
+}
+
+func TestOptimize(t *testing.T) {
+	params, err := build(start)
+	require.NoError(t, err)
+	initial, err := Exact(false)(context.Background(), params)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name   string
+		target float64
+		bounds []Bound
+	}{
+		{name: "lower", target: initial.RTP - 5},
+		{name: "higher", target: initial.RTP + 5},
+		{name: "bounded", target: initial.RTP - 3, bounds: []Bound{{Symbol: 4, Min: 60, Max: 100}, {Symbol: 5, Reel: 3, Min: 8, Max: 8}}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var progress int
+			c, err2 := Optimize(context.Background(), Params{
+				Start:      start,
+				Build:      build,
+				Evaluate:   Exact(false),
+				TargetRTP:  tc.target,
+				Bounds:     tc.bounds,
+				Iterations: 5000,
+				Seed:       42,
+				Progress:   func(int, *Candidate) { progress++ },
+			})
+			require.NoError(t, err2)
+			require.NotNil(t, c)
+
+			assert.True(t, c.Converged)
+			assert.InDelta(t, tc.target, c.Stats.RTP, 0.05)
+			assert.True(t, c.Stats.Exact)
+			assert.Less(t, c.Loss, 1.0)
+			assert.Greater(t, progress, 0)
+			assert.False(t, c.Table.Equals(start))
+
+			// zero weights are fixed, and bounds are honoured.
+			assert.Zero(t, c.Table.Weights(5)[0])
+			assert.Zero(t, c.Table.Weights(5)[4])
+			for _, b := range tc.bounds {
+				for reel, w := range c.Table.Weights(b.Symbol) {
+					if b.Reel == 0 || int(b.Reel) == reel+1 {
+						assert.GreaterOrEqual(t, w, b.Min)
+						assert.LessOrEqual(t, w, b.Max)
+					}
+				}
+			}
+			for _, w := range c.Table {
+				for _, f := range w.Reels {
+					assert.Equal(t, math.Round(f), f)
+				}
+			}
+
+			// the search is repeatable.
+			c2, err2 := Optimize(context.Background(), Params{Start: start, Build: build, Evaluate: Exact(false), TargetRTP: tc.target,
+				Bounds: tc.bounds, Iterations: 5000, Seed: 42})
+			require.NoError(t, err2)
+			assert.True(t, c.Table.Equals(c2.Table))
+			assert.Equal(t, c.Iterations, c2.Iterations)
+		})
+	}
+}
+
+func TestOptimizeHitRate(t *testing.T) {
+	build1 := func(t Table) (game.RegularParams, error) {
+		a := comp.NewSymbol(1, comp.WithPayouts(0, 0, 10), comp.WithWeights(t[0].Reels...))
+		b := comp.NewSymbol(2, comp.WithPayouts(0, 0, 5), comp.WithWeights(t[1].Reels...))
+		c := comp.NewSymbol(3, comp.WithWeights(t[2].Reels...))
+		s := comp.NewSlots(comp.Grid(3, 1), comp.WithSymbols(comp.NewSymbolSet(a, b, c)),
+			comp.WithPaylines(comp.PayLTR, false, comp.NewPayline(1, 1, 0, 0, 0)))
+		return game.RegularParams{Slots: s}, nil
+	}
+
+	table := Table{
+		{Symbol: 1, Reels: []float64{10, 10, 10}},
+		{Symbol: 2, Reels: []float64{10, 10, 10}},
+		{Symbol: 3, Reels: []float64{10, 10, 10}},
+	}
+
+	c, err := Optimize(context.Background(), Params{
+		Start:            table,
+		Build:            build1,
+		Evaluate:         Exact(true),
+		TargetRTP:        90,
+		RTPTolerance:     0.5,
+		TargetHitRate:    10,
+		HitRateTolerance: 0.5,
+		Iterations:       10000,
+		Seed:             1,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, c)
+
+	assert.True(t, c.Converged)
+	assert.InDelta(t, 90, c.Stats.RTP, 0.5)
+	assert.InDelta(t, 10, c.Stats.HitRate, 0.5)
+}
+
+func TestOptimizeSimulation(t *testing.T) {
+	params, err := build(start)
+	require.NoError(t, err)
+	initial, err := Exact(false)(context.Background(), params)
+	require.NoError(t, err)
+
+	template := simulate.SlotsParams{GameNR: tg.BOTnr, Workers: 2}
+
+	c, err := Optimize(context.Background(), Params{
+		Start:        start,
+		Build:        build,
+		Evaluate:     Simulation(template, 10000),
+		TargetRTP:    initial.RTP,
+		RTPTolerance: 20,
+		Iterations:   3,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, c)
+
+	assert.True(t, c.Converged)
+	assert.Equal(t, 1, c.Iterations)
+	assert.Equal(t, uint64(10000), c.Stats.Rounds)
+	assert.False(t, c.Stats.Exact)
+	assert.Greater(t, c.Stats.RTP, 0.0)
+	assert.Greater(t, c.Stats.HitRate, 0.0)
+	assert.Greater(t, c.Stats.RTPWidth, 0.0)
+	assert.Zero(t, c.Stats.FreeSpinRate)
+}
+
+func TestOptimizeFail(t *testing.T) {
+	testCases := []struct {
+		name   string
+		params Params
+		err    error
+	}{
+		{name: "no start", params: Params{Build: build, Evaluate: Exact(false), TargetRTP: 90}, err: ErrInvalidParams},
+		{name: "no build", params: Params{Start: start, Evaluate: Exact(false), TargetRTP: 90}, err: ErrInvalidParams},
+		{name: "no evaluate", params: Params{Start: start, Build: build, TargetRTP: 90}, err: ErrInvalidParams},
+		{name: "no target", params: Params{Start: start, Build: build, Evaluate: Exact(false)}, err: ErrInvalidParams},
+		{name: "fixed weights", params: Params{Start: start, Build: build, Evaluate: Exact(false), TargetRTP: 90, MinWeight: 10, MaxWeight: 10}, err: ErrNoWeights},
+		{name: "no hit rate", params: Params{Start: start, Build: build, Evaluate: Exact(true), TargetRTP: 90}, err: ErrNoHitRate},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := Optimize(context.Background(), tc.params)
+			assert.ErrorIs(t, err, tc.err)
+			assert.Nil(t, c)
+		})
+	}
+}
+
+func TestOptimizeCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	c, err := Optimize(ctx, Params{
+		Start:      start,
+		Build:      build,
+		Evaluate:   Exact(false),
+		TargetRTP:  10,
+		Iterations: 1000000,
+		Progress:   func(int, *Candidate) { cancel() },
+	})
+	require.NoError(t, err)
+	require.NotNil(t, c)
+	assert.False(t, c.Converged)
+	assert.Less(t, c.Iterations, 1000000)
+}
+
+func TestParams_Loss(t *testing.T) {
+	p := Params{TargetRTP: 96, TargetHitRate: 25, MaxFreeSpinRate: 0.5}
+
+	testCases := []struct {
+		name      string
+		stats     Stats
+		loss      float64
+		converged bool
+	}{
+		{name: "exact", stats: Stats{RTP: 96, HitRate: 25}, converged: true},
+		{name: "within tolerance", stats: Stats{RTP: 96.05, HitRate: 24.5, FreeSpinRate: 0.5}, loss: 2, converged: true},
+		{name: "rtp off", stats: Stats{RTP: 95, HitRate: 25}, loss: 400},
+		{name: "hit rate off", stats: Stats{RTP: 96, HitRate: 26}, loss: 4},
+		{name: "free spins off", stats: Stats{RTP: 96, HitRate: 25, FreeSpinRate: 0.55}, loss: 100},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.InDelta(t, tc.loss, p.Loss(&tc.stats), 1e-9)
+			assert.Equal(t, tc.converged, p.Converged(&tc.stats))
+		})
+	}
+}
+
+func TestTable(t *testing.T) {
+	params, err := build(start)
+	require.NoError(t, err)
+
+	table := TableFromSymbols(params.Slots.Symbols())
+	assert.True(t, table.Equals(start))
+	assert.Equal(t, []float64{0, 8, 8, 8, 0}, table.Weights(5))
+	assert.Nil(t, table.Weights(utils.Index(9)))
+
+	clone := table.Clone()
+	assert.True(t, clone.Equals(table))
+	clone[0].Reels[0] = 1
+	assert.False(t, clone.Equals(table))
+	assert.Equal(t, 90.0, table[0].Reels[0])
+
+	assert.Equal(t, "comp.WithWeights(0, 8, 8, 8, 0), // symbol 5\n", Table{table[4]}.String())
+}
@@ -0,0 +1,121 @@
+package slots
+
+import (
+	"strconv"
+	"strings"
+
+	comp "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/components/slots"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/utils"
+)
+
+// Weights contains the reel weights of a single symbol.
+type Weights struct {
+	Symbol utils.Index `json:"symbol"`
+	Reels  []float64   `json:"reels"`
+}
+
+// Table contains the reel weights of all symbols of a slot machine.
+type Table []Weights
+
+// TableFromSymbols creates a weight table from the reel weights of the given symbols.
+func TableFromSymbols(symbols *comp.SymbolSet) Table {
+	list := symbols.Symbols()
+	t := make(Table, 0, len(list))
+	for _, symbol := range list {
+		reels := make([]float64, len(symbol.Weights()))
+		copy(reels, symbol.Weights())
+		t = append(t, Weights{Symbol: symbol.ID(), Reels: reels})
+	}
+	return t
+}
+
+// Clone returns a deep copy of the table.
+func (t Table) Clone() Table {
+	out := make(Table, len(t))
+	for ix := range t {
+		out[ix].Symbol = t[ix].Symbol
+		out[ix].Reels = make([]float64, len(t[ix].Reels))
+		copy(out[ix].Reels, t[ix].Reels)
+	}
+	return out
+}
+
+// Equals returns true if both tables contain the same weights.
+func (t Table) Equals(other Table) bool {
+	if len(t) != len(other) {
+		return false
+	}
+	for ix := range t {
+		if t[ix].Symbol != other[ix].Symbol || len(t[ix].Reels) != len(other[ix].Reels) {
+			return false
+		}
+		for iy := range t[ix].Reels {
+			if t[ix].Reels[iy] != other[ix].Reels[iy] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Weights returns the reel weights for the given symbol, or nil if the symbol is not in the table.
+func (t Table) Weights(symbol utils.Index) []float64 {
+	for ix := range t {
+		if t[ix].Symbol == symbol {
+			return t[ix].Reels
+		}
+	}
+	return nil
+}
+
+// String implements the Stringer interface.
+// It formats the table as symbol options, so it can be pasted into a Go-defined game configuration.
+func (t Table) String() string {
+	b := strings.Builder{}
+	for ix := range t {
+		b.WriteString("comp.WithWeights(")
+		for iy, w := range t[ix].Reels {
+			if iy > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(strconv.FormatFloat(w, 'f', -1, 64))
+		}
+		b.WriteString("), // symbol ")
+		b.WriteString(strconv.Itoa(int(t[ix].Symbol)))
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// Bound limits the weights of a symbol during the optimization.
+type Bound struct {
+	Symbol utils.Index `json:"symbol"`
+	Reel   uint8       `json:"reel,omitempty"` // 1-based reel; 0 applies the bound to all reels.
+	Min    float64     `json:"min"`
+	Max    float64     `json:"max"`
+}
+
+// bounds returns the limits for the given symbol and (0-based) reel.
+// The last matching bound wins, so specific reels can override a bound for all reels.
+// Weights which start at zero remain zero unless a bound is given for them.
+func (p *Params) bounds(symbol utils.Index, reel int, start float64) (float64, float64, bool) {
+	min, max, found := p.minWeight(), p.MaxWeight, false
+	for ix := range p.Bounds {
+		if b := &p.Bounds[ix]; b.Symbol == symbol && (b.Reel == 0 || int(b.Reel) == reel+1) {
+			min, max, found = b.Min, b.Max, true
+		}
+	}
+
+	if start <= 0 && !found {
+		return 0, 0, false
+	}
+	if min < 0 {
+		min = 0
+	}
+	if max <= 0 {
+		max = maxWeight
+	}
+	return min, max, max > min
+}
+
+const maxWeight = 1000000
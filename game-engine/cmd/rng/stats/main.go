@@ -0,0 +1,97 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/prng.git/stats"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/rng"
+)
+
+// stats runs the statistical test battery of the prng module on a PRNG backend, and prints a pass/fail report
+// with p-values. The backend defaults to the shared library (libprng.so), unless built with the nosharedlib tag.
+// With -seed the deterministic (seeded) backend is used instead.
+// The exit code is 1 if any of the tests failed.
+//
+// usage: stats [-backend sharedlib] [-seed abc] [-samples 1000000] [-alpha 0.01] [-json] [-out report.txt]
+func main() {
+	backend := flag.String("backend", rng.Current(), "PRNG backend ("+strings.Join(rng.Backends(), ", ")+")")
+	seed := flag.String("seed", "", "seed for the deterministic backend (optional)")
+	samples := flag.Int("samples", 1000000, "number of random values for each test")
+	alpha := flag.Float64("alpha", 0.01, "significance level")
+	asJSON := flag.Bool("json", false, "write the report as JSON")
+	out := flag.String("out", "", "also write the report to this file")
+	flag.Parse()
+
+	cfg := config{
+		backend: *backend,
+		seed:    *seed,
+		samples: *samples,
+		alpha:   *alpha,
+		asJSON:  *asJSON,
+		out:     *out,
+	}
+
+	passed, err := run(cfg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if !passed {
+		os.Exit(1)
+	}
+}
+
+type config struct {
+	backend string
+	seed    string
+	samples int
+	alpha   float64
+	asJSON  bool
+	out     string
+}
+
+func run(cfg config) (bool, error) {
+	name := cfg.backend
+	if cfg.seed != "" {
+		rng.UseSeed([]byte(cfg.seed))
+		name = fmt.Sprintf("%s [%s]", rng.BackendSeeded, cfg.seed)
+	} else if err := rng.Use(cfg.backend); err != nil {
+		return false, fmt.Errorf("%w [%s]", err, cfg.backend)
+	}
+
+	prng := rng.AcquireRNG()
+	defer prng.ReturnToPool()
+
+	report := stats.Run(name, prng, stats.Params{Samples: cfg.samples, Alpha: cfg.alpha})
+
+	var w io.Writer = os.Stdout
+	if cfg.out != "" {
+		f, err := os.Create(cfg.out)
+		if err != nil {
+			return false, err
+		}
+		defer f.Close()
+		w = io.MultiWriter(os.Stdout, f)
+	}
+
+	if err := write(w, report, cfg.asJSON); err != nil {
+		return false, err
+	}
+	return report.Passed, nil
+}
+
+func write(w io.Writer, report *stats.Report, asJSON bool) error {
+	if !asJSON {
+		return report.WriteText(w)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
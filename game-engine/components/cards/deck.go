@@ -8,7 +8,6 @@ import (
 
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/interfaces"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/rng"
-	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/sharedlib"
 )
 
 // Deck represents a deck of cards.
@@ -47,7 +46,7 @@ var deckPool = sync.Pool{
 func NewDeck(opts ...DeckOption) *Deck {
 	d := deckPool.Get().(*Deck)
 	d.prng = rng.AcquireRNG()
-	d.shuffler = sharedlib.AcquireShuffler()
+	d.shuffler = rng.AcquireShuffler()
 	if d.cards == nil {
 		d.cards = make(Cards, 0, 32)
 	}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/goccy/go-json"
 
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/games/pickem"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/games/wheel"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/interfaces"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/results"
@@ -30,6 +31,10 @@ type BonusAction struct {
 	wheel         bool
 	wheelFlag     int
 	wheelWeights  utils.WeightedGenerator
+	pickem        bool
+	pickChoice    int
+	pickFlag      int
+	pickLevels    []pickem.Level
 }
 
 // NewInstantBonusAction instantiates an instant bonus award action.
@@ -63,6 +68,22 @@ func NewInstantBonusWheelAction(flag int, weights utils.WeightedGenerator) *Bonu
 	return a.finalizer()
 }
 
+// NewPickemBonusAction instantiates a pick'em bonus game action.
+// The outcomes of all levels are determined when the game starts, and the tiles are then revealed one at a time.
+// Use WithPlayerChoice to let the player make the picks. The game then pauses before each pick, and the position of
+// the picked tile (1-based) is taken from the choice flag; an invalid position picks the first hidden tile instead.
+// Without a player choice the tiles are picked automatically.
+// If flag >= 0, it is set to the number of levels reached when the game ends.
+func NewPickemBonusAction(choiceFlag, flag int, levels ...pickem.Level) *BonusAction {
+	a := newBonusAction()
+	a.result = BonusGame
+	a.pickem = true
+	a.pickChoice = choiceFlag
+	a.pickFlag = flag
+	a.pickLevels = levels
+	return a.finalizer()
+}
+
 // WithTease can be used to indicate that the instant bonus is a teaser and won't actually happen.
 func (a *BonusAction) WithTease() *BonusAction {
 	a.tease = true
@@ -94,13 +115,18 @@ func (a *BonusAction) Triggered(spin *Spin) SpinActioner {
 			return a.alternate.Triggered(spin)
 		}
 
-	case a.selector, a.wheel:
+	case a.selector, a.wheel, a.pickem:
 		return a // always triggers, as the action happens somewhere else.
 	}
 
 	return nil
 }
 
+// IsPickem returns true if the action plays a pick'em bonus game.
+func (a *BonusAction) IsPickem() bool {
+	return a.pickem
+}
+
 // InstantBonus instantiates an instant bonus result determined by the kind of bonus.
 func (a *BonusAction) InstantBonus(_ *Spin) interfaces.Objecter2 {
 	switch {
@@ -149,11 +175,55 @@ func (a *BonusAction) BonusGame(spin *Spin) interfaces.Objecter2 {
 		}
 		return result
 
+	case a.pickem:
+		return a.pickemGame(spin)
+
 	default:
 		return nil
 	}
 }
 
+// pickemGame starts a new pick'em game, or continues the game kept in the spin, and plays the next pick(s).
+// An unfinished game is kept in the spin, so it can be restored from the spin state when the round is resumed.
+// The result is a copy of the game after the pick(s).
+func (a *BonusAction) pickemGame(spin *Spin) interfaces.Objecter2 {
+	if spin.pickem == nil {
+		b := pickem.AcquireBonusPickem(spin.prng, a.pickLevels...)
+		spin.pickem = b.Start()
+		b.Release()
+
+		if a.playerChoice && !spin.pickem.Done() {
+			// wait for the first pick of the player.
+			return spin.pickem.DeepCopy()
+		}
+	}
+
+	p := spin.pickem
+	if a.playerChoice {
+		var pick int
+		if a.pickChoice >= 0 {
+			pick = spin.roundFlags[a.pickChoice]
+			spin.roundFlags[a.pickChoice] = 0
+		}
+		p.PickOrFirst(pick - 1)
+	} else {
+		for !p.Done() {
+			p.PickOrFirst(0)
+		}
+	}
+
+	if !p.Done() {
+		return p.DeepCopy()
+	}
+
+	if a.pickFlag >= 0 {
+		spin.roundFlags[a.pickFlag] = p.LevelsReached()
+	}
+
+	spin.pickem = nil
+	return p
+}
+
 // FeatureTransition returns the applicable bonus feature transition kind.
 func (a *BonusAction) FeatureTransition() FeatureTransitionKind {
 	switch {
@@ -165,6 +235,8 @@ func (a *BonusAction) FeatureTransition() FeatureTransitionKind {
 		return InstantBonusResulted
 	case a.wheel:
 		return BonusWheelTransition
+	case a.pickem:
+		return PickemTransition
 	default:
 		return 0
 	}
@@ -223,6 +295,17 @@ func (a *BonusAction) finalizer() *BonusAction {
 		b.WriteString(a.wheelWeights.String())
 	}
 
+	if a.pickem {
+		b.WriteString(",pickem=true")
+		b.WriteString(",choiceFlag=")
+		b.WriteString(strconv.Itoa(a.pickChoice))
+		b.WriteString(",flag=")
+		b.WriteString(strconv.Itoa(a.pickFlag))
+		b.WriteString(",levels=")
+		j, _ := json.Marshal(a.pickLevels)
+		b.WriteString(string(j))
+	}
+
 	if a.alternate != nil {
 		b.WriteString(",alternate=true")
 	}
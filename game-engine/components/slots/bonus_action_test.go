@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/games/pickem"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/games/wheel"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/results"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/utils"
@@ -273,3 +274,81 @@ func TestNewInstantBonusWheelAction(t *testing.T) {
 		})
 	}
 }
+
+func TestNewPickemBonusAction(t *testing.T) {
+	level1 := pickem.Level{pickem.PrizeTile(1), pickem.PrizeTile(2), pickem.MultiplierTile(2), pickem.AdvanceTile(), pickem.CollectTile()}
+	level2 := pickem.Level{pickem.PrizeTile(10), pickem.PrizeTile(20), pickem.CollectTile()}
+
+	prng := rng.NewRNG()
+	defer prng.ReturnToPool()
+
+	slots := NewSlots(Grid(5, 3), WithSymbols(setF1))
+
+	spin := AcquireSpin(slots, prng)
+	defer spin.Release()
+
+	t.Run("auto", func(t *testing.T) {
+		a := NewPickemBonusAction(-1, 2, level1, level2)
+		require.NotNil(t, a)
+		assert.True(t, a.IsPickem())
+		assert.False(t, a.wheel)
+		assert.False(t, a.selector)
+		assert.Equal(t, a, a.Triggered(spin))
+		assert.Equal(t, PickemTransition, a.FeatureTransition())
+
+		for ix := 0; ix < 1000; ix++ {
+			spin.ResetSpin()
+
+			data := a.BonusGame(spin)
+			require.NotNil(t, data)
+
+			result, ok := data.(*pickem.PickemResult)
+			require.True(t, ok)
+			assert.True(t, result.Done())
+			assert.Equal(t, result.LevelsReached(), spin.roundFlags[2])
+			assert.Nil(t, spin.pickem)
+			assert.False(t, spin.PickemPending())
+
+			data.Release()
+		}
+	})
+
+	t.Run("player choice", func(t *testing.T) {
+		a := NewPickemBonusAction(1, 2, level1, level2).WithPlayerChoice("pick")
+		require.NotNil(t, a)
+		assert.True(t, a.playerChoice)
+
+		spin.ResetSpin()
+
+		data := a.BonusGame(spin)
+		require.NotNil(t, data)
+		result, ok := data.(*pickem.PickemResult)
+		require.True(t, ok)
+		assert.False(t, result.Done())
+		assert.Empty(t, result.Picks())
+		assert.True(t, spin.PickemPending())
+		data.Release()
+
+		var picks int
+		for spin.PickemPending() {
+			spin.roundFlags[1] = 1 // an already revealed tile falls back to the first hidden tile.
+			data = a.BonusGame(spin)
+			require.NotNil(t, data)
+			picks++
+
+			result, ok = data.(*pickem.PickemResult)
+			require.True(t, ok)
+			assert.Equal(t, picks, len(result.Picks()))
+			assert.Zero(t, spin.roundFlags[1])
+
+			if result.Done() {
+				assert.Equal(t, result.LevelsReached(), spin.roundFlags[2])
+				assert.Nil(t, spin.pickem)
+			}
+			data.Release()
+		}
+
+		assert.NotZero(t, picks)
+		assert.LessOrEqual(t, picks, len(level1)+len(level2))
+	})
+}
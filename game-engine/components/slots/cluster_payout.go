@@ -142,6 +142,7 @@ func (c *ClusterPayouts) Find(spin *Spin, res *results.Result) int {
 	minimum := spin.symbols.minPayout
 
 	// find the highest start offset that can still warrant a payout.
+	// tiles outside the reel mask or the reel heights of the spin are always empty, so they can never start a cluster.
 	maxStart := len(c.connections) - int(minimum) + int(spin.mask[c.reels-1]) - int(c.rows)
 
 	// keep track of multiplier.
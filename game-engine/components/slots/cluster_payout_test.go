@@ -1135,3 +1135,50 @@ func BenchmarkClusterPayouts_FindHexagonal4567654six(b *testing.B) {
 		c.Find(spin, nil)
 	}
 }
+
+func TestClusterPayouts_FindReelHeights(t *testing.T) {
+	prng := rng.NewRNG()
+	defer prng.ReturnToPool()
+
+	heights := make([]utils.WeightedGenerator, 4)
+	for ix := range heights {
+		heights[ix] = utils.AcquireWeighting().AddWeights(utils.Indexes{1, 2, 3}, []float64{1, 1, 1})
+	}
+
+	game := NewSlots(Grid(4, 3), WithSymbols(setF1), WithReelHeights(heights...))
+
+	spin := AcquireSpin(game, prng)
+	defer spin.Release()
+
+	c := NewClusterPayouts(4, 3)
+	require.NotNil(t, c)
+
+	testCases := []struct {
+		name    string
+		indexes utils.Indexes
+		want    utils.UInt8s
+		payouts int
+	}{
+		{
+			name:    "no hit",
+			indexes: utils.Indexes{4, 2, 0, 1, 0, 0, 4, 4, 0, 2, 3, 1},
+			want:    utils.UInt8s{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+		},
+		{
+			name:    "4x 4",
+			indexes: utils.Indexes{4, 4, 0, 4, 0, 0, 4, 2, 0, 1, 3, 1},
+			want:    utils.UInt8s{1, 1, 0, 1, 0, 0, 1, 0, 0, 0, 0, 0},
+			payouts: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			spin.Debug(tc.indexes)
+
+			payouts := c.Find(spin, nil)
+			assert.EqualValues(t, tc.want, spin.payouts)
+			assert.Equal(t, tc.payouts, payouts)
+		})
+	}
+}
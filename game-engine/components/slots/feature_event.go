@@ -16,6 +16,8 @@ const (
 	InstantBonusResulted
 	BonusWheelTransition
 	ChestFeatureTransition
+	HoldAndWinTransition
+	PickemTransition
 )
 
 // AcquireFeatureTransition instantiates a new bonus feature transition animation event.
@@ -98,6 +98,7 @@ func (n GridNeighbors) RandomNeighbor(prng interfaces.Generator) uint8 {
 // GridDefinition contains the details of a grid.
 type GridDefinition struct {
 	haveMask     bool            // indicates if a grid mask was supplied during instantiation.
+	dynamic      bool            // indicates the reel heights are selected for each spin.
 	reels        int             // number of reels in the grid.
 	rows         int             // number of rows in the grid.
 	gridSize     int             // number of tiles in the grid.
@@ -162,6 +163,17 @@ func NewGridDefinition(reels, rows int, mask utils.UInt8s) *GridDefinition {
 	return g.init()
 }
 
+// NewDynamicGridDefinition instantiates a new grid definition for grids where the reel heights change with each spin.
+// The reels are at most rows high, and the used positions of each reel are always at the top.
+// Neighbors and valid offsets for a specific spin can be found with NeighborsIn() and IsValidOffsetIn().
+// This function is "expensive". It should only be called once for each possible grid during app initialization.
+// The function panics if invalid parameters are given.
+func NewDynamicGridDefinition(reels, rows int) *GridDefinition {
+	g := NewGridDefinition(reels, rows, nil)
+	g.dynamic = true
+	return g
+}
+
 // ReelCount returns the number of reels in the grid.
 func (g *GridDefinition) ReelCount() uint8 {
 	return uint8(g.reels)
@@ -177,6 +189,11 @@ func (g *GridDefinition) HaveMask() bool {
 	return g.haveMask
 }
 
+// Dynamic returns true if the reel heights of the grid are selected for each spin.
+func (g *GridDefinition) Dynamic() bool {
+	return g.dynamic
+}
+
 // GridMask returns the grid mask.
 // Unless specified during instantiation, it defaults to a slice of size ReelCount() filled with RowCount().
 func (g *GridDefinition) GridMask() utils.UInt8s {
@@ -188,16 +205,39 @@ func (g *GridDefinition) Neighbors(offset uint8) GridNeighbors {
 	return g.neighbors[offset]
 }
 
+// NeighborsIn returns a map with neighboring tiles for a given tile, limited to the given reel heights.
+// This is used for grids with dynamic reel heights, where the heights are the mask of a specific spin.
+// The given map is cleared and filled with the result.
+func (g *GridDefinition) NeighborsIn(offset uint8, heights utils.UInt8s, out GridNeighbors) GridNeighbors {
+	clear(out)
+	for k, v := range g.neighbors[offset] {
+		if g.IsValidOffsetIn(int(k), heights) {
+			out[k] = v
+		}
+	}
+	return out
+}
+
 // IsOnTheEdge returns true if the given offset is on the edge of the grid.
 func (g *GridDefinition) IsOnTheEdge(offset int) bool {
+	return g.IsOnTheEdgeIn(offset, g.mask)
+}
+
+// IsOnTheEdgeIn returns true if the given offset is on the edge of the grid with the given reel heights.
+func (g *GridDefinition) IsOnTheEdgeIn(offset int, heights utils.UInt8s) bool {
 	reel, row := g.ReelRowFromOffset(offset)
-	return reel == 0 || row == 0 || reel >= g.reels-1 || row >= int(g.mask[reel])-1
+	return reel == 0 || row == 0 || reel >= g.reels-1 || row >= int(heights[reel])-1
 }
 
 // IsValidOffset returns true if the given offset is a valid tile on the grid.
 func (g *GridDefinition) IsValidOffset(offset int) bool {
+	return g.IsValidOffsetIn(offset, g.mask)
+}
+
+// IsValidOffsetIn returns true if the given offset is a valid tile on the grid with the given reel heights.
+func (g *GridDefinition) IsValidOffsetIn(offset int, heights utils.UInt8s) bool {
 	reel, row := g.ReelRowFromOffset(offset)
-	return reel < g.reels && row < int(g.mask[reel])
+	return reel < g.reels && row < int(heights[reel])
 }
 
 // TilesFromEdge returns the number of tiles which are the given number of steps away from the edge.
@@ -252,6 +292,7 @@ func (g *GridDefinition) IsEmpty() bool {
 func (g *GridDefinition) EncodeFields(enc *zjson.Encoder) {
 	enc.IntField("reels", g.reels)
 	enc.IntField("rows", g.rows)
+	enc.IntBoolFieldOpt("dynamic", g.dynamic)
 	if g.haveMask {
 		enc.StartArrayField("mask")
 		for ix := range g.mask {
@@ -244,3 +244,33 @@ func TestGridNeighbors_RandomNeighbor(t *testing.T) {
 		}
 	})
 }
+
+func TestGridDefinition_NeighborsIn(t *testing.T) {
+	g := NewDynamicGridDefinition(3, 4)
+	require.NotNil(t, g)
+	assert.True(t, g.Dynamic())
+	assert.False(t, g.HaveMask())
+
+	heights := utils.UInt8s{2, 4, 1}
+
+	t.Run("neighbors", func(t *testing.T) {
+		got := g.NeighborsIn(5, heights, make(GridNeighbors, MaxNeighbors))
+		want := GridNeighbors{0: GridLeftUp, 1: GridLeft, 4: GridUp, 5: 0, 6: GridDown, 8: GridRightUp}
+		assert.EqualValues(t, want, got)
+		assert.Equal(t, 9, len(g.Neighbors(5)))
+	})
+
+	t.Run("valid offsets", func(t *testing.T) {
+		for offset := 0; offset < 12; offset++ {
+			want := offset == 0 || offset == 1 || (offset >= 4 && offset <= 8)
+			assert.Equal(t, want, g.IsValidOffsetIn(offset, heights), offset)
+			assert.True(t, g.IsValidOffset(offset), offset)
+		}
+	})
+
+	t.Run("on the edge", func(t *testing.T) {
+		assert.True(t, g.IsOnTheEdgeIn(1, heights))
+		assert.False(t, g.IsOnTheEdgeIn(5, heights))
+		assert.True(t, g.IsOnTheEdgeIn(5, utils.UInt8s{2, 2, 1}))
+	})
+}
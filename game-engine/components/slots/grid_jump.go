@@ -94,7 +94,7 @@ func (j *gridJump) jump() {
 }
 
 func (j *gridJump) test(jumps GridJumps) bool {
-	if j.params.offGrid > 0 && j.grid.IsOnTheEdge(int(j.from)) {
+	if j.params.offGrid > 0 && j.spin.IsOnTheEdge(int(j.from)) {
 		// may jump off grid.
 		if float64(j.spin.prng.IntN(10000)) < j.params.offGrid*100 {
 			j.offGrid = true
@@ -105,7 +105,7 @@ func (j *gridJump) test(jumps GridJumps) bool {
 	// get list of neighbors.
 	offs := make(utils.UInt8s, 0, MaxNeighbors)
 	dirs := make([]GridDirection, 0, MaxNeighbors)
-	for k, v := range j.grid.neighbors[j.from] {
+	for k, v := range j.spin.Neighbors(j.from) {
 		if (j.params.onSymbols || j.spin.indexes[k] == utils.NullIndex) &&
 			j.directionOK(j.params.direction, v) &&
 			!jumps.offsetUsed(k) {
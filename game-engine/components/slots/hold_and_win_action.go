@@ -0,0 +1,268 @@
+package slots
+
+import (
+	"bytes"
+	"reflect"
+	"strconv"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/consts"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/results"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/utils"
+)
+
+// Jackpot represents a fixed jackpot of a hold-and-win feature.
+type Jackpot uint8
+
+// always add new elements at the end!!
+const (
+	// NoJackpot indicates a coin with a cash value.
+	NoJackpot Jackpot = iota
+	// MiniJackpot indicates a coin holding the mini jackpot.
+	MiniJackpot
+	// MinorJackpot indicates a coin holding the minor jackpot.
+	MinorJackpot
+	// MajorJackpot indicates a coin holding the major jackpot.
+	MajorJackpot
+	// GrandJackpot is the jackpot awarded when all tiles of the grid hold a coin.
+	GrandJackpot
+)
+
+// String implements the Stringer interface.
+func (j Jackpot) String() string {
+	switch j {
+	case NoJackpot:
+		return "none"
+	case MiniJackpot:
+		return "Mini"
+	case MinorJackpot:
+		return "Minor"
+	case MajorJackpot:
+		return "Major"
+	case GrandJackpot:
+		return "Grand"
+	default:
+		return "???"
+	}
+}
+
+// HoldAndWinAction is an action that triggers and plays a hold-and-win feature.
+// The feature triggers when enough coin symbols land on the grid.
+// Coins are locked in place with a random cash value, and the remaining tiles are respun.
+// The number of respins is reset whenever a new coin lands.
+// When there are no respins left or all tiles hold a coin, the coin values are paid out,
+// together with any jackpots held by the coins and the grand jackpot if the grid is full.
+type HoldAndWinAction struct {
+	SpinAction
+	count    uint8
+	respins  uint8
+	values   utils.WeightedGenerator
+	jackpots utils.WeightedGenerator
+	factors  [GrandJackpot + 1]float64
+}
+
+// NewHoldAndWinAction instantiates a new hold-and-win action.
+// The feature triggers when at least count coin symbols land on the grid.
+// The weighted options of values are the cash values of the coins as a factor of the bet.
+// The number of respins defaults to 3.
+func NewHoldAndWinAction(symbol utils.Index, count uint8, values utils.WeightedGenerator) *HoldAndWinAction {
+	if symbol == 0 || count == 0 || values == nil || len(values.Options()) == 0 {
+		panic(consts.MsgInvalidHoldAndWin)
+	}
+
+	a := &HoldAndWinAction{count: count, respins: 3, values: values}
+	a.init(AwardBonuses, HoldAndWin, reflect.TypeOf(a).String())
+	a.symbol = symbol
+	return a.finalizer()
+}
+
+// WithRespins sets the number of respins, which is also the number the respins are reset to when a new coin lands.
+func (a *HoldAndWinAction) WithRespins(respins uint8) *HoldAndWinAction {
+	if respins == 0 {
+		panic(consts.MsgInvalidHoldAndWin)
+	}
+	a.respins = respins
+	return a.finalizer()
+}
+
+// WithJackpots adds fixed jackpots to the feature.
+// The weighted options of weights determine the jackpot held by a new coin (NoJackpot, MiniJackpot, MinorJackpot or MajorJackpot).
+// The grand jackpot is awarded when all tiles of the grid hold a coin.
+// The jackpot values are a factor of the bet.
+func (a *HoldAndWinAction) WithJackpots(weights utils.WeightedGenerator, mini, minor, major, grand float64) *HoldAndWinAction {
+	if weights != nil {
+		for _, o := range weights.Options() {
+			if Jackpot(o) > MajorJackpot {
+				panic(consts.MsgInvalidHoldAndWin)
+			}
+		}
+	}
+
+	a.jackpots = weights
+	a.factors = [GrandJackpot + 1]float64{0, mini, minor, major, grand}
+	return a.finalizer()
+}
+
+// Respins returns the number of respins.
+func (a *HoldAndWinAction) Respins() uint8 {
+	return a.respins
+}
+
+// JackpotFactor returns the value of the given jackpot as a factor of the bet.
+func (a *HoldAndWinAction) JackpotFactor(jackpot Jackpot) float64 {
+	if jackpot > GrandJackpot {
+		return 0
+	}
+	return a.factors[jackpot]
+}
+
+// Triggered implements the SpinActioner.Triggered interface.
+// During a respin it locks any new coins and resets the number of respins if there were new coins.
+// Outside of a respin it starts the feature if enough coin symbols landed on the grid.
+func (a *HoldAndWinAction) Triggered(spin *Spin) SpinActioner {
+	if spin.kind == Respin {
+		if len(spin.coins) == 0 {
+			return nil
+		}
+		if a.lockCoins(spin) > 0 {
+			spin.respins = a.respins
+		}
+		if spin.CoinsFull() {
+			spin.respins = 0
+		}
+		return a
+	}
+
+	if len(spin.coins) > 0 && spin.respins > 0 {
+		// the feature is already in progress.
+		return nil
+	}
+
+	if a.countCoins(spin) < a.count {
+		return nil
+	}
+
+	spin.startCoins(a.respins)
+	a.lockCoins(spin)
+	if spin.CoinsFull() {
+		spin.respins = 0
+	}
+	return a
+}
+
+// Payout implements the SpinActioner.Payout interface.
+// The coin values and jackpots are only paid out when the feature has completed.
+func (a *HoldAndWinAction) Payout(spin *Spin, res *results.Result) SpinActioner {
+	if len(spin.coins) == 0 || spin.respins > 0 {
+		return nil
+	}
+
+	var total float64
+	var count uint8
+	payMap := make(utils.UInt8s, 0, len(spin.coins))
+
+	for ix := range spin.coins {
+		if spin.coins[ix] > 0 {
+			payMap = append(payMap, uint8(ix))
+			if Jackpot(spin.jackpots[ix]) == NoJackpot {
+				total += spin.coins[ix]
+				count++
+			}
+		}
+	}
+
+	if total > 0 {
+		res.AddPayouts(HoldAndWinPayout(total, a.symbol, count, payMap))
+	}
+
+	for ix := range spin.jackpots {
+		if j := Jackpot(spin.jackpots[ix]); j != NoJackpot {
+			res.AddPayouts(JackpotPayout(spin.coins[ix], a.symbol, j))
+		}
+	}
+
+	if spin.CoinsFull() && a.factors[GrandJackpot] > 0 {
+		res.AddPayouts(JackpotPayout(a.factors[GrandJackpot], a.symbol, GrandJackpot))
+	}
+
+	return a
+}
+
+// FeatureTransition returns the applicable bonus feature transition kind.
+func (a *HoldAndWinAction) FeatureTransition() FeatureTransitionKind {
+	return HoldAndWinTransition
+}
+
+// countCoins counts the coin symbols on the grid.
+func (a *HoldAndWinAction) countCoins(spin *Spin) uint8 {
+	var count uint8
+	var offset int
+	for reel := range spin.mask {
+		m := offset + int(spin.mask[reel])
+		for ix := offset; ix < m; ix++ {
+			if spin.indexes[ix] == a.symbol {
+				count++
+			}
+		}
+		offset += spin.rowCount
+	}
+	return count
+}
+
+// lockCoins locks all new coin symbols on the grid with a random value and returns the number of new coins.
+func (a *HoldAndWinAction) lockCoins(spin *Spin) int {
+	var count int
+	var offset int
+	for reel := range spin.mask {
+		m := offset + int(spin.mask[reel])
+		for ix := offset; ix < m; ix++ {
+			if spin.indexes[ix] == a.symbol && spin.coins[ix] == 0 {
+				a.lockCoin(spin, ix)
+				count++
+			}
+		}
+		offset += spin.rowCount
+	}
+	return count
+}
+
+// lockCoin locks a coin with a random cash value or jackpot.
+func (a *HoldAndWinAction) lockCoin(spin *Spin, offset int) {
+	if a.jackpots != nil {
+		if j := Jackpot(a.jackpots.RandomIndex(spin.prng)); j != NoJackpot {
+			spin.setCoin(offset, a.factors[j], j)
+			return
+		}
+	}
+	spin.setCoin(offset, float64(a.values.RandomIndex(spin.prng)), NoJackpot)
+}
+
+func (a *HoldAndWinAction) finalizer() *HoldAndWinAction {
+	b := bytes.Buffer{}
+
+	b.WriteString("stage=")
+	b.WriteString(a.stage.String())
+	b.WriteString(",result=")
+	b.WriteString(a.result.String())
+	b.WriteString(",symbol=")
+	b.WriteString(strconv.Itoa(int(a.symbol)))
+	b.WriteString(",count=")
+	b.WriteString(strconv.Itoa(int(a.count)))
+	b.WriteString(",respins=")
+	b.WriteString(strconv.Itoa(int(a.respins)))
+	b.WriteString(",values=")
+	b.WriteString(a.values.String())
+
+	if a.jackpots != nil {
+		b.WriteString(",jackpots=")
+		b.WriteString(a.jackpots.String())
+		for j := MiniJackpot; j <= GrandJackpot; j++ {
+			b.WriteString(",")
+			b.WriteString(j.String())
+			b.WriteString("=")
+			b.WriteString(strconv.FormatFloat(a.factors[j], 'g', -1, 64))
+		}
+	}
+
+	a.config = b.String()
+	return a
+}
@@ -0,0 +1,266 @@
+package slots
+
+import (
+	"testing"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/encode/zjson"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/prng.git/rng"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/results"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	shw1   = NewSymbol(1, WithPayouts(0, 0, 0.5, 2, 4), WithWeights(90, 90, 90, 90, 90))
+	shw2   = NewSymbol(2, WithPayouts(0, 0, 0.5, 2, 5), WithWeights(90, 90, 90, 90, 90))
+	shw3   = NewSymbol(3, WithPayouts(0, 0, 1, 2.5, 5), WithWeights(70, 70, 70, 70, 70))
+	shw4   = NewSymbol(4, WithKind(Prize), WithWeights(40, 40, 40, 40, 40))
+	setHW1 = NewSymbolSet(shw1, shw2, shw3, shw4)
+)
+
+func TestNewHoldAndWinAction(t *testing.T) {
+	values := utils.AcquireWeighting().AddWeights(utils.Indexes{1, 2, 5, 10}, []float64{50, 30, 15, 5})
+	jackpots := utils.AcquireWeighting().AddWeights(utils.Indexes{0, 1, 2, 3}, []float64{90, 6, 3, 1})
+
+	t.Run("new", func(t *testing.T) {
+		a := NewHoldAndWinAction(4, 6, values)
+		require.NotNil(t, a)
+		assert.Equal(t, AwardBonuses, a.Stage())
+		assert.Equal(t, HoldAndWin, a.Result())
+		assert.Equal(t, utils.Index(4), a.symbol)
+		assert.Equal(t, uint8(6), a.count)
+		assert.Equal(t, uint8(3), a.Respins())
+		assert.Equal(t, HoldAndWinTransition, a.FeatureTransition())
+		assert.Equal(t, "stage=Bonuses,result=HoldAndWin,symbol=4,count=6,respins=3,values="+values.String(), a.Config())
+	})
+
+	t.Run("with options", func(t *testing.T) {
+		a := NewHoldAndWinAction(4, 5, values).WithRespins(4).WithJackpots(jackpots, 10, 25, 100, 1000)
+		require.NotNil(t, a)
+		assert.Equal(t, uint8(4), a.Respins())
+		assert.Equal(t, 10.0, a.JackpotFactor(MiniJackpot))
+		assert.Equal(t, 25.0, a.JackpotFactor(MinorJackpot))
+		assert.Equal(t, 100.0, a.JackpotFactor(MajorJackpot))
+		assert.Equal(t, 1000.0, a.JackpotFactor(GrandJackpot))
+		assert.Zero(t, a.JackpotFactor(NoJackpot))
+		assert.Contains(t, a.Config(), ",Mini=10,Minor=25,Major=100,Grand=1000")
+	})
+
+	t.Run("bad parameters", func(t *testing.T) {
+		assert.Panics(t, func() { NewHoldAndWinAction(0, 6, values) })
+		assert.Panics(t, func() { NewHoldAndWinAction(4, 0, values) })
+		assert.Panics(t, func() { NewHoldAndWinAction(4, 6, nil) })
+		assert.Panics(t, func() { NewHoldAndWinAction(4, 6, values).WithRespins(0) })
+
+		grand := utils.AcquireWeighting().AddWeights(utils.Indexes{0, 4}, []float64{90, 10})
+		assert.Panics(t, func() { NewHoldAndWinAction(4, 6, values).WithJackpots(grand, 1, 2, 3, 4) })
+	})
+}
+
+func TestHoldAndWinAction_Triggered(t *testing.T) {
+	values := utils.AcquireWeighting().AddWeights(utils.Indexes{1, 2, 5, 10}, []float64{50, 30, 15, 5})
+	a := NewHoldAndWinAction(4, 6, values)
+
+	prng := rng.NewRNG()
+	defer prng.ReturnToPool()
+
+	slots := NewSlots(Grid(5, 3), WithSymbols(setHW1))
+
+	testCases := []struct {
+		name    string
+		indexes utils.Indexes
+		want    bool
+		coins   uint8
+		respins uint8
+	}{
+		{
+			name:    "no coins",
+			indexes: utils.Indexes{1, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2, 3},
+		},
+		{
+			name:    "5 coins",
+			indexes: utils.Indexes{4, 2, 4, 1, 4, 3, 1, 2, 4, 1, 2, 3, 4, 2, 3},
+		},
+		{
+			name:    "6 coins",
+			indexes: utils.Indexes{4, 2, 4, 1, 4, 3, 1, 4, 4, 1, 2, 3, 4, 2, 3},
+			want:    true,
+			coins:   6,
+			respins: 3,
+		},
+		{
+			name:    "full grid",
+			indexes: utils.Indexes{4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4},
+			want:    true,
+			coins:   15,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			spin := AcquireSpin(slots, prng)
+			defer spin.Release()
+
+			spin.Debug(tc.indexes)
+
+			got := a.Triggered(spin)
+			if !tc.want {
+				assert.Nil(t, got)
+				assert.Empty(t, spin.Coins())
+				return
+			}
+
+			require.Equal(t, a, got)
+			assert.Equal(t, tc.coins, spin.CoinCount())
+			assert.Equal(t, tc.coins, spin.StickyCount())
+			assert.Equal(t, tc.respins, spin.Respins())
+			assert.Equal(t, tc.coins == 15, spin.CoinsFull())
+
+			for ix, id := range spin.indexes {
+				if id == 4 {
+					assert.Contains(t, []float64{1, 2, 5, 10}, spin.coins[ix])
+				} else {
+					assert.Zero(t, spin.coins[ix])
+				}
+			}
+
+			// the feature doesn't trigger again while it is in progress.
+			if tc.respins > 0 {
+				assert.Nil(t, a.Triggered(spin))
+			}
+		})
+	}
+}
+
+func TestHoldAndWinAction_Respins(t *testing.T) {
+	values := utils.AcquireWeighting().AddWeights(utils.Indexes{1, 2, 5, 10}, []float64{50, 30, 15, 5})
+	jackpots := utils.AcquireWeighting().AddWeights(utils.Indexes{0, 1, 2, 3}, []float64{70, 15, 10, 5})
+	a := NewHoldAndWinAction(4, 6, values).WithJackpots(jackpots, 10, 25, 100, 1000)
+
+	prng := rng.NewRNG()
+	defer prng.ReturnToPool()
+
+	slots := NewSlots(Grid(5, 3), WithSymbols(setHW1))
+	spin := AcquireSpin(slots, prng)
+	defer spin.Release()
+
+	var full int
+	for ix := 0; ix < 100; ix++ {
+		spin.ResetSpin()
+		spin.Debug(utils.Indexes{4, 2, 4, 1, 4, 3, 1, 4, 4, 1, 2, 3, 4, 2, 3})
+
+		require.Equal(t, a, a.Triggered(spin))
+		require.Equal(t, uint8(6), spin.CoinCount())
+
+		for spin.Respins() > 0 {
+			prev := append([]float64{}, spin.coins...)
+			count := spin.CoinCount()
+
+			spin.SetKind(Respin)
+			spin.Respin()
+			require.Equal(t, a, a.Triggered(spin))
+
+			// locked coins never change.
+			for iy := range prev {
+				if prev[iy] > 0 {
+					require.Equal(t, prev[iy], spin.coins[iy])
+					require.Equal(t, utils.Index(4), spin.indexes[iy])
+				}
+			}
+
+			switch {
+			case spin.CoinsFull():
+				require.Zero(t, spin.Respins())
+			case spin.CoinCount() > count:
+				require.Equal(t, uint8(3), spin.Respins())
+			}
+		}
+
+		res := results.AcquireResult(nil, 0)
+
+		var want float64
+		for iy := range spin.coins {
+			want += spin.coins[iy]
+		}
+		if spin.CoinsFull() {
+			want += 1000
+			full++
+		}
+
+		require.Equal(t, a, a.Payout(spin, res))
+		assert.InDelta(t, want, res.Total, 0.001)
+
+		for _, p := range res.Payouts {
+			switch p.Kind() {
+			case results.SlotHoldAndWin:
+				assert.Equal(t, utils.Index(4), p.(*SpinPayout).Symbol())
+			case results.SlotJackpot:
+				j := p.(*SpinPayout).Jackpot()
+				assert.NotEqual(t, NoJackpot, j)
+				assert.Equal(t, a.JackpotFactor(j), p.Total())
+			default:
+				t.Errorf("unexpected payout kind %v", p.Kind())
+			}
+		}
+
+		r := AcquireSpinResult(spin)
+		assert.Equal(t, spin.Coins(), r.Coins())
+		assert.Equal(t, spin.Jackpots(), r.Jackpots())
+		assert.Zero(t, r.Respins())
+		r.Release()
+
+		res.Release()
+
+		// the next spin ends the feature and releases the coins.
+		spin.SetKind(RegularSpin)
+		spin.Spin()
+		assert.Empty(t, spin.Coins())
+		assert.Zero(t, spin.StickyCount())
+	}
+	assert.Greater(t, full, 0)
+}
+
+func TestSpinResult_Coins(t *testing.T) {
+	values := utils.AcquireWeighting().AddWeights(utils.Indexes{1, 2, 5, 10}, []float64{50, 30, 15, 5})
+	jackpots := utils.AcquireWeighting().AddWeights(utils.Indexes{0, 1}, []float64{50, 50})
+	a := NewHoldAndWinAction(4, 3, values).WithJackpots(jackpots, 10, 25, 100, 1000)
+
+	prng := rng.NewRNG()
+	defer prng.ReturnToPool()
+
+	slots := NewSlots(Grid(5, 3), WithSymbols(setHW1))
+	spin := AcquireSpin(slots, prng)
+	defer spin.Release()
+
+	spin.Debug(utils.Indexes{4, 2, 4, 1, 4, 3, 1, 4, 4, 1, 2, 3, 4, 2, 3})
+	require.NotNil(t, a.Triggered(spin))
+
+	r := AcquireSpinResult(spin)
+	defer r.Release()
+
+	assert.Equal(t, uint8(3), r.Respins())
+	assert.Equal(t, 15, len(r.Coins()))
+	assert.Equal(t, 15, len(r.Jackpots()))
+
+	enc := zjson.AcquireEncoder(4096)
+	defer enc.Release()
+
+	enc.Object(r)
+	b := enc.Bytes()
+	assert.Contains(t, string(b), `"respins":3,"coins":[`)
+
+	dec := zjson.AcquireDecoder(b)
+	defer dec.Release()
+
+	r2 := AcquireSpinResult(nil)
+	defer r2.Release()
+
+	ok := dec.Object(r2)
+	require.True(t, ok)
+	assert.Equal(t, r.Coins(), r2.Coins())
+	assert.Equal(t, r.Jackpots(), r2.Jackpots())
+	assert.Equal(t, r.Respins(), r2.Respins())
+}
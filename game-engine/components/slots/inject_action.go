@@ -116,7 +116,7 @@ func (a *InjectAction) injectFromEdge(spin *Spin) SpinActioner {
 
 	options := make(utils.UInt8s, 0, 100)
 	for offset := range def.stepsOffGrid {
-		if def.stepsOffGrid[offset] == a.singleEdgeSteps {
+		if def.stepsOffGrid[offset] == a.singleEdgeSteps && spin.IsValidOffset(offset) {
 			options = append(options, uint8(offset))
 		}
 	}
@@ -166,7 +166,7 @@ func (a *InjectAction) injectSymbol(spin *Spin) SpinActioner {
 
 	var deadlock int
 	for {
-		if def.IsValidOffset(offset) && valid() {
+		if spin.IsValidOffset(offset) && valid() {
 			return a.injectSymbolAtOffset(spin, offset, a.symbol)
 		}
 
@@ -237,7 +237,7 @@ func (a *InjectAction) injectClusterAroundOffset(spin *Spin, offset uint8) SpinA
 		// if we can't find a suitable neighbor, we'll fall back into picking a random tile.
 		var deadlock int
 		for symbol == nil || symbol.kind != Standard {
-			list := def.withoutSelf[offset]
+			list := spin.neighborsWithoutSelf(offset)
 			offset = list.RandomNeighbor(spin.prng)
 			if !spin.sticky[offset] {
 				if spin.indexes[offset] == symbolID {
@@ -257,7 +257,7 @@ func (a *InjectAction) injectClusterAroundOffset(spin *Spin, offset uint8) SpinA
 				step := uint8(def.offsetStep)
 				size := uint8(def.gridSize)
 				for {
-					if def.IsValidOffset(int(offset)) {
+					if spin.IsValidOffset(int(offset)) {
 						if spin.indexes[offset] == symbolID {
 							break
 						}
@@ -297,7 +297,7 @@ func (a *InjectAction) injectClusterAroundOffset(spin *Spin, offset uint8) SpinA
 		// inject the selected symbol in every neighboring tile where possible.
 		// if we hit the requested symbol, we can keep its offset as another anchor point.
 		// if the counter hits zero, we're also done.
-		list := def.withoutSelf[offset]
+		list := spin.neighborsWithoutSelf(offset)
 		for offset = range list {
 			if !spin.sticky[offset] {
 				if spin.indexes[offset] == symbolID {
@@ -1,6 +1,8 @@
 package slots
 
 import (
+	"strings"
+
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/encode/zjson"
 
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/utils"
@@ -18,6 +20,8 @@ const (
 	PayBoth
 	PayCluster
 	PayScatter
+	// endPayDirections marks the end of the list; always add new directions above it!
+	endPayDirections
 )
 
 // String implements the Stringer interface.
@@ -38,6 +42,17 @@ func (d PayDirection) String() string {
 	}
 }
 
+// ParsePayDirection returns the pay direction with the given name, as returned by String.
+// The name is not case-sensitive. It returns false if there is no such direction.
+func ParsePayDirection(name string) (PayDirection, bool) {
+	for d := PayLTR; d < endPayDirections; d++ {
+		if strings.EqualFold(d.String(), name) {
+			return d, true
+		}
+	}
+	return 0, false
+}
+
 // Payline represents a payline for a slot machine.
 type Payline struct {
 	id      uint8
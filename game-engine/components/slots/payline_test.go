@@ -72,3 +72,18 @@ func TestPurgePaylines(t *testing.T) {
 		})
 	}
 }
+
+func TestParsePayDirection(t *testing.T) {
+	for d := PayLTR; d < endPayDirections; d++ {
+		got, ok := ParsePayDirection(d.String())
+		assert.True(t, ok, d.String())
+		assert.Equal(t, d, got)
+	}
+
+	got, ok := ParsePayDirection("RTL")
+	assert.True(t, ok)
+	assert.Equal(t, PayRTL, got)
+
+	_, ok = ParsePayDirection("diagonal")
+	assert.False(t, ok)
+}
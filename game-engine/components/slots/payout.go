@@ -82,6 +82,25 @@ func SuperSymbolPayout(factor, multiplier float64, symbol utils.Index, count uin
 	return p
 }
 
+// HoldAndWinPayout instantiates a payout for the coin values collected during a hold-and-win feature.
+func HoldAndWinPayout(factor float64, symbol utils.Index, count uint8, payMap utils.UInt8s) results.Payout {
+	p := initPayout(results.SlotHoldAndWin, PayScatter, count, symbol, factor, 1.0)
+	p.payMap = utils.CopyPurgeUInt8s(payMap, p.payMap, 16)
+	return p
+}
+
+// BonusGamePayout instantiates a payout for the prizes collected during a bonus game, with the multiplier of the game.
+func BonusGamePayout(factor, multiplier float64) results.Payout {
+	return initPayout(results.SlotBonusGame, PayScatter, 0, 0, factor, multiplier)
+}
+
+// JackpotPayout instantiates a payout for a fixed jackpot.
+func JackpotPayout(factor float64, symbol utils.Index, jackpot Jackpot) results.Payout {
+	p := initPayout(results.SlotJackpot, PayScatter, 1, symbol, factor, 1.0)
+	p.jackpot = jackpot
+	return p
+}
+
 // Kind returns the reward kind.
 func (p *SpinPayout) Kind() results.PayoutKind {
 	return p.kind
@@ -136,6 +155,11 @@ func (p *SpinPayout) Total() float64 {
 	return payout
 }
 
+// Jackpot returns the jackpot awarded by the reward, if any.
+func (p *SpinPayout) Jackpot() Jackpot {
+	return p.jackpot
+}
+
 // PayRows returns the row numbers of the symbol.
 func (p *SpinPayout) PayRows() utils.UInt8s {
 	return p.payRows
@@ -164,6 +188,7 @@ func (p *SpinPayout) EncodeFields(enc *zjson.Encoder) {
 	enc.Uint8FieldOpt("count", p.count)
 	enc.Uint8FieldOpt("direction", uint8(p.direction))
 	enc.Uint8FieldOpt("paylineID", p.paylineID)
+	enc.Uint8FieldOpt("jackpot", uint8(p.jackpot))
 	enc.StringFieldOpt("message", p.message)
 
 	if len(p.payRows) > 0 {
@@ -210,6 +235,10 @@ func (p *SpinPayout) DecodeField(dec *zjson.Decoder, key []byte) error {
 		}
 	} else if string(key) == "paylineID" {
 		p.paylineID, ok = dec.Uint8()
+	} else if string(key) == "jackpot" {
+		if i8, ok = dec.Uint8(); ok {
+			p.jackpot = Jackpot(i8)
+		}
 	} else if string(key) == "message" {
 		if b, esc, ok = dec.String(); ok {
 			if esc {
@@ -249,6 +278,7 @@ type SpinPayout struct {
 	direction  PayDirection
 	count      uint8
 	paylineID  uint8
+	jackpot    Jackpot
 	symbol     utils.Index
 	factor     float64
 	multiplier float64
@@ -287,6 +317,7 @@ func (p *SpinPayout) reset() {
 		p.count = 0
 		p.direction = PayLTR
 		p.paylineID = 0
+		p.jackpot = NoJackpot
 		p.symbol = utils.NullIndex
 		p.factor = 0.0
 		p.multiplier = 0.0
@@ -304,6 +335,7 @@ func (p *SpinPayout) Equals(other *SpinPayout) bool {
 		p.count == other.count &&
 		p.direction == other.direction &&
 		p.paylineID == other.paylineID &&
+		p.jackpot == other.jackpot &&
 		p.symbol == other.symbol &&
 		p.factor == other.factor &&
 		p.multiplier == other.multiplier &&
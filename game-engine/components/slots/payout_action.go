@@ -165,6 +165,11 @@ func (a *PayoutAction) HaveAllPaylines() bool {
 	return a.allPaylines
 }
 
+// HighestPayout returns true if the highest payout is used when a payline starts with wilds.
+func (a *PayoutAction) HighestPayout() bool {
+	return a.highestPayout
+}
+
 // HaveClusterPayouts returns true if cluster payouts are active.
 func (a *PayoutAction) HaveClusterPayouts() bool {
 	return a.cluster != nil
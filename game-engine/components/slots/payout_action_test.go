@@ -57,6 +57,7 @@ func TestNewAllPaylinesAction(t *testing.T) {
 		assert.False(t, a.paylines)
 		assert.True(t, a.allPaylines)
 		assert.Nil(t, a.cluster)
+		assert.True(t, a.HighestPayout())
 	})
 }
 
@@ -617,3 +618,56 @@ func TestNewRemovePayoutsAction(t *testing.T) {
 		})
 	}
 }
+
+func TestPayoutAction_WaysReelHeights(t *testing.T) {
+	prng := rng.NewRNG()
+	defer prng.ReturnToPool()
+
+	heights := make([]utils.WeightedGenerator, 4)
+	for ix := range heights {
+		heights[ix] = utils.AcquireWeighting().AddWeights(utils.Indexes{1, 2, 3, 4}, []float64{1, 1, 1, 1})
+	}
+
+	slots := NewSlots(Grid(4, 4), WithSymbols(setF1), WithReelHeights(heights...))
+
+	spin := AcquireSpin(slots, prng)
+	defer spin.Release()
+
+	testCases := []struct {
+		name    string
+		indexes utils.Indexes
+		heights utils.UInt8s
+		want    int
+	}{
+		{
+			name:    "2x3x1x4 ways",
+			indexes: utils.Indexes{1, 1, 0, 0, 1, 1, 1, 0, 1, 0, 0, 0, 1, 1, 1, 1},
+			heights: utils.UInt8s{2, 3, 1, 4},
+			want:    24,
+		},
+		{
+			name:    "2x1x2 ways",
+			indexes: utils.Indexes{1, 1, 0, 0, 1, 0, 0, 0, 1, 1, 0, 0, 2, 2, 2, 2},
+			heights: utils.UInt8s{2, 1, 2, 4},
+			want:    4,
+		},
+		{
+			name:    "short reel breaks ways",
+			indexes: utils.Indexes{1, 1, 1, 1, 2, 0, 0, 0, 1, 1, 1, 1, 1, 1, 1, 1},
+			heights: utils.UInt8s{4, 1, 4, 4},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			spin.Debug(tc.indexes)
+			require.Equal(t, tc.heights, spin.ReelHeights())
+
+			res := results.AcquireResult(nil, 0)
+			defer res.Release()
+
+			NewAllPaylinesAction(false).Payout(spin, res)
+			assert.Equal(t, tc.want, len(res.Payouts))
+		})
+	}
+}
@@ -58,6 +58,26 @@ func (a *PenaltyAction) Penalty(spin *Spin, res *results.Result) SpinActioner {
 	return a
 }
 
+// HaveReduction returns true if the action imposes a reduction penalty.
+func (a *PenaltyAction) HaveReduction() bool {
+	return a.reduce
+}
+
+// HaveDivision returns true if the action imposes a division penalty.
+func (a *PenaltyAction) HaveDivision() bool {
+	return a.divide
+}
+
+// Count returns the symbol count required to impose the penalty.
+func (a *PenaltyAction) Count() uint8 {
+	return a.count
+}
+
+// Factor returns the factor of the penalty.
+func (a *PenaltyAction) Factor() float64 {
+	return a.factor
+}
+
 func newPenaltyAction() *PenaltyAction {
 	a := &PenaltyAction{}
 	a.init(RegularPenalties, Penalty, reflect.TypeOf(a).String())
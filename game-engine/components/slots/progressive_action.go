@@ -0,0 +1,89 @@
+package slots
+
+import (
+	"bytes"
+	"reflect"
+	"strconv"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/consts"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/utils"
+)
+
+// ProgressiveAction is an action that triggers a progressive jackpot.
+// The engine only decides that the jackpot was triggered; the value of the shared jackpot pool is awarded by the
+// game manager when the round is booked, so the action has no payout.
+// The jackpot level identifies the pool, e.g. when a game has multiple progressive jackpots.
+type ProgressiveAction struct {
+	SpinAction
+	level  uint8
+	count  uint8
+	chance float64
+}
+
+// NewProgressiveAction instantiates a new progressive jackpot action.
+// The jackpot triggers randomly with the given chance, as a percentage with max 4 decimals.
+// Level must be greater than zero.
+func NewProgressiveAction(level uint8, chance float64) *ProgressiveAction {
+	if level == 0 || chance <= 0 || chance > 100 {
+		panic(consts.MsgInvalidProgressive)
+	}
+
+	a := &ProgressiveAction{level: level, chance: chance}
+	a.init(AwardBonuses, ProgressiveJackpot, reflect.TypeOf(a).String())
+	return a.finalizer()
+}
+
+// WithSymbols restricts the jackpot to spins with at least count occurrences of the given symbol on the grid.
+// The chance then applies to those spins only.
+func (a *ProgressiveAction) WithSymbols(symbol utils.Index, count uint8) *ProgressiveAction {
+	if symbol == 0 || count == 0 {
+		panic(consts.MsgInvalidProgressive)
+	}
+	a.symbol = symbol
+	a.count = count
+	return a.finalizer()
+}
+
+// Level returns the jackpot level triggered by the action.
+func (a *ProgressiveAction) Level() uint8 {
+	return a.level
+}
+
+// Triggered implements the SpinActioner.Triggered interface.
+// If multiple levels trigger in the same spin, the highest level is retained.
+func (a *ProgressiveAction) Triggered(spin *Spin) SpinActioner {
+	if a.count > 0 && spin.CountSymbol(a.symbol) < a.count {
+		return nil
+	}
+	if !spin.TestChance4(a.ModifyChance(a.chance, spin)) {
+		return nil
+	}
+
+	if a.level > spin.progressive {
+		spin.progressive = a.level
+	}
+	return a
+}
+
+func (a *ProgressiveAction) finalizer() *ProgressiveAction {
+	b := bytes.Buffer{}
+
+	b.WriteString("stage=")
+	b.WriteString(a.stage.String())
+	b.WriteString(",result=")
+	b.WriteString(a.result.String())
+	b.WriteString(",level=")
+	b.WriteString(strconv.Itoa(int(a.level)))
+	b.WriteString(",chance=")
+	b.WriteString(strconv.FormatFloat(a.chance, 'g', -1, 64))
+
+	if a.count > 0 {
+		b.WriteString(",symbol=")
+		b.WriteString(strconv.Itoa(int(a.symbol)))
+		b.WriteString(",count=")
+		b.WriteString(strconv.Itoa(int(a.count)))
+	}
+
+	a.config = b.String()
+	return a
+}
@@ -0,0 +1,139 @@
+package slots
+
+import (
+	"testing"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/encode/zjson"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/prng.git/rng"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProgressiveAction(t *testing.T) {
+	t.Run("new", func(t *testing.T) {
+		a := NewProgressiveAction(1, 0.05)
+		require.NotNil(t, a)
+		assert.Equal(t, AwardBonuses, a.Stage())
+		assert.Equal(t, ProgressiveJackpot, a.Result())
+		assert.Equal(t, uint8(1), a.Level())
+		assert.Equal(t, "stage=Bonuses,result=ProgressiveJackpot,level=1,chance=0.05", a.Config())
+	})
+
+	t.Run("with symbols", func(t *testing.T) {
+		a := NewProgressiveAction(2, 100).WithSymbols(4, 5)
+		require.NotNil(t, a)
+		assert.Equal(t, uint8(2), a.Level())
+		assert.Equal(t, "stage=Bonuses,result=ProgressiveJackpot,level=2,chance=100,symbol=4,count=5", a.Config())
+	})
+
+	t.Run("bad parameters", func(t *testing.T) {
+		assert.Panics(t, func() { NewProgressiveAction(0, 1) })
+		assert.Panics(t, func() { NewProgressiveAction(1, 0) })
+		assert.Panics(t, func() { NewProgressiveAction(1, 101) })
+		assert.Panics(t, func() { NewProgressiveAction(1, 1).WithSymbols(0, 3) })
+		assert.Panics(t, func() { NewProgressiveAction(1, 1).WithSymbols(4, 0) })
+	})
+}
+
+func TestProgressiveAction_Triggered(t *testing.T) {
+	prng := rng.NewRNG()
+	defer prng.ReturnToPool()
+
+	slots := NewSlots(Grid(5, 3), WithSymbols(setHW1))
+
+	testCases := []struct {
+		name    string
+		actions []*ProgressiveAction
+		indexes utils.Indexes
+		want    uint8
+	}{
+		{
+			name:    "always",
+			actions: []*ProgressiveAction{NewProgressiveAction(1, 100)},
+			indexes: utils.Indexes{1, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2, 3},
+			want:    1,
+		},
+		{
+			name:    "not enough symbols",
+			actions: []*ProgressiveAction{NewProgressiveAction(1, 100).WithSymbols(4, 5)},
+			indexes: utils.Indexes{4, 2, 3, 1, 4, 3, 1, 2, 4, 1, 2, 4, 1, 2, 3},
+		},
+		{
+			name:    "enough symbols",
+			actions: []*ProgressiveAction{NewProgressiveAction(1, 100).WithSymbols(4, 5)},
+			indexes: utils.Indexes{4, 2, 3, 1, 4, 3, 1, 2, 4, 1, 2, 4, 1, 4, 3},
+			want:    1,
+		},
+		{
+			name:    "highest level",
+			actions: []*ProgressiveAction{NewProgressiveAction(3, 100), NewProgressiveAction(2, 100)},
+			indexes: utils.Indexes{1, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2, 3},
+			want:    3,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			spin := AcquireSpin(slots, prng)
+			defer spin.Release()
+
+			spin.Debug(tc.indexes)
+
+			for _, a := range tc.actions {
+				got := a.Triggered(spin)
+				if tc.want == 0 {
+					assert.Nil(t, got)
+				} else {
+					assert.Equal(t, a, got)
+				}
+			}
+
+			assert.Equal(t, tc.want, spin.Progressive())
+
+			r := AcquireSpinResult(spin)
+			defer r.Release()
+			assert.Equal(t, tc.want, r.Progressive())
+
+			enc := zjson.AcquireEncoder(4096)
+			defer enc.Release()
+			enc.Object(r)
+
+			dec := zjson.AcquireDecoder(enc.Bytes())
+			defer dec.Release()
+
+			r2 := AcquireSpinResult(nil)
+			defer r2.Release()
+			require.True(t, dec.Object(r2))
+			assert.Equal(t, tc.want, r2.Progressive())
+
+			// the next spin resets the trigger.
+			spin.Spin()
+			assert.Zero(t, spin.Progressive())
+		})
+	}
+}
+
+func TestProgressiveAction_Chance(t *testing.T) {
+	prng := rng.NewRNG()
+	defer prng.ReturnToPool()
+
+	slots := NewSlots(Grid(5, 3), WithSymbols(setHW1))
+	spin := AcquireSpin(slots, prng)
+	defer spin.Release()
+
+	a := NewProgressiveAction(1, 10)
+
+	var count int
+	for ix := 0; ix < 10000; ix++ {
+		spin.Spin()
+		if a.Triggered(spin) != nil {
+			count++
+		}
+	}
+
+	assert.Greater(t, count, 800)
+	assert.Less(t, count, 1200)
+}
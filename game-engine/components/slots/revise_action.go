@@ -315,6 +315,61 @@ func (a *ReviseAction) Triggered(spin *Spin) SpinActioner {
 	return nil
 }
 
+// HaveGenerateSymbol returns true if the action generates a symbol.
+func (a *ReviseAction) HaveGenerateSymbol() bool {
+	return a.generateSymbol
+}
+
+// HaveGenerateShape returns true if the action generates a shape.
+func (a *ReviseAction) HaveGenerateShape() bool {
+	return a.generateShape
+}
+
+// SymbolChances returns the chances for 1,2,3,etc. symbols to be generated.
+func (a *ReviseAction) SymbolChances() []float64 {
+	return a.symbolChances
+}
+
+// GenerateReels returns the reels to generate symbols on (1-based).
+func (a *ReviseAction) GenerateReels() utils.UInt8s {
+	return a.generateReels
+}
+
+// DupesAllowed returns true if duplicate symbols may be generated across reels.
+func (a *ReviseAction) DupesAllowed() bool {
+	return a.genAllowDupes
+}
+
+// PreviousAllowed returns true if symbols may be generated when the symbol already appears in the grid.
+func (a *ReviseAction) PreviousAllowed() bool {
+	return a.genAllowOld
+}
+
+// SpinKinds returns the spin kinds the action is limited to.
+func (a *ReviseAction) SpinKinds() []SpinKind {
+	return a.spinKinds
+}
+
+// ShapeChance returns the chance of generating the shape.
+func (a *ReviseAction) ShapeChance() float64 {
+	return a.shapeChance
+}
+
+// ShapeGrid returns the shape to generate.
+func (a *ReviseAction) ShapeGrid() GridOffsets {
+	return a.shapeGrid
+}
+
+// ShapeCenters returns the center positions for the shape to choose from.
+func (a *ReviseAction) ShapeCenters() GridOffsets {
+	return a.shapeCenters
+}
+
+// ShapeWeights returns the weighting for the symbols of the shape.
+func (a *ReviseAction) ShapeWeights() utils.WeightedGenerator {
+	return a.shapeWeights
+}
+
 func (a *ReviseAction) kindAllowed(spin *Spin) bool {
 	for ix := range a.spinKinds {
 		if spin.kind == a.spinKinds[ix] {
@@ -94,7 +94,7 @@ func (v validator) validNoDupes2(offs int) bool {
 
 func (a *ReviseAction) doGenerateSymbol2(spin *Spin) bool {
 	v := validator{
-		testMask:   spin.gridDef.haveMask,
+		testMask:   spin.gridDef.haveMask || spin.gridDef.dynamic,
 		testReels:  len(a.generateReels) > 0,
 		testDupes1: !a.genAllowDupes && len(a.morphFor) == 0,
 		testDupes2: !a.genAllowDupes && len(a.morphFor) > 0,
@@ -215,7 +215,7 @@ func (a *ReviseAction) doGenerateReelSymbols(spin *Spin) bool {
 
 func (a *ReviseAction) doGenerateSymbols(spin *Spin) bool {
 	v := validator{
-		testMask:   spin.gridDef.haveMask,
+		testMask:   spin.gridDef.haveMask || spin.gridDef.dynamic,
 		testReels:  len(a.generateReels) > 0,
 		testDupes1: !a.genAllowDupes && len(a.morphFor) == 0,
 		testDupes2: !a.genAllowDupes && len(a.morphFor) > 0,
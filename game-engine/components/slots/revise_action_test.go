@@ -304,6 +304,12 @@ func TestNewGenerateSymbolAction(t *testing.T) {
 			require.NotNil(t, a)
 
 			assert.True(t, a.generateSymbol)
+			assert.True(t, a.HaveGenerateSymbol())
+			assert.Equal(t, tc.chances, a.SymbolChances())
+			assert.Empty(t, a.GenerateReels())
+			assert.True(t, a.DupesAllowed())
+			assert.False(t, a.PreviousAllowed())
+			assert.Empty(t, a.SpinKinds())
 
 			if tc.multipliers != nil {
 				a.WithMultipliers(tc.multipliers)
@@ -480,6 +486,11 @@ func TestNewGenerateShapeAction(t *testing.T) {
 			require.NotNil(t, a)
 
 			assert.True(t, a.generateShape)
+			assert.True(t, a.HaveGenerateShape())
+			assert.Equal(t, tc.chance, a.ShapeChance())
+			assert.Equal(t, tc.shape, a.ShapeGrid())
+			assert.Equal(t, centers, a.ShapeCenters())
+			assert.Equal(t, weights, a.ShapeWeights())
 
 			var count int
 
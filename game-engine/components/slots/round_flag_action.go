@@ -199,6 +199,31 @@ func (a *RoundFlagAction) Triggered(spin *Spin) SpinActioner {
 	return nil
 }
 
+// Flag returns the round flag updated by the action.
+func (a *RoundFlagAction) Flag() int {
+	return a.flag
+}
+
+// HaveWeights returns true if the action sets the round flag using a weighting.
+func (a *RoundFlagAction) HaveWeights() bool {
+	return a.weightedFlag
+}
+
+// Weights returns the weighting used to set the round flag.
+func (a *RoundFlagAction) Weights() utils.WeightedGenerator {
+	return a.weights
+}
+
+// HaveIncrease returns true if the action increases the round flag.
+func (a *RoundFlagAction) HaveIncrease() bool {
+	return a.increaseFlag
+}
+
+// HaveDecrease returns true if the action decreases the round flag.
+func (a *RoundFlagAction) HaveDecrease() bool {
+	return a.decreaseFlag
+}
+
 func newRoundFlagAction() *RoundFlagAction {
 	a := &RoundFlagAction{}
 	a.init(PreBonus, Processed, reflect.TypeOf(a).String())
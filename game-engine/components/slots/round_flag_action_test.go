@@ -81,6 +81,10 @@ func TestNewRoundFlagShapeDetect(t *testing.T) {
 			assert.Equal(t, tc.flag, a.flag)
 			assert.Equal(t, tc.symbol, a.symbol)
 			assert.Equal(t, tc.grid, a.shapeGrid)
+			assert.Equal(t, tc.flag, a.Flag())
+			assert.False(t, a.HaveWeights())
+			assert.False(t, a.HaveIncrease())
+			assert.False(t, a.HaveDecrease())
 
 			got := a.Triggered(spin)
 			if tc.want {
@@ -313,6 +313,26 @@ func (a *ScatterAction) CanPayout() bool {
 	return a.payout
 }
 
+// HaveFreeSpins returns true if the action awards free spins.
+func (a *ScatterAction) HaveFreeSpins() bool {
+	return a.freeSpins
+}
+
+// ScatterCount returns the minimum number of scatter symbols for the action to trigger.
+func (a *ScatterAction) ScatterCount() uint8 {
+	return a.scatterCount
+}
+
+// ScatterPayout returns the payout factor awarded by the action.
+func (a *ScatterAction) ScatterPayout() float64 {
+	return a.scatterPayout
+}
+
+// MultiSymbols returns the additional symbols counted as scatter symbols.
+func (a *ScatterAction) MultiSymbols() utils.Indexes {
+	return a.multiSymbols
+}
+
 // HaveAllScatterPayouts return true if all scatter payouts feature is active.
 func (a *ScatterAction) HaveAllScatterPayouts() bool {
 	return a.allScatters
@@ -86,10 +86,16 @@ func TestNewScatterAction(t *testing.T) {
 			assert.Equal(t, tc.scatterPayout, a.scatterPayout)
 			assert.Equal(t, tc.bonusSymbol, a.bonusSymbol)
 
+			assert.Equal(t, tc.kind == FreeSpins, a.HaveFreeSpins())
+			assert.Equal(t, tc.symbol, a.Symbol())
+			assert.Equal(t, tc.scatterCount, a.ScatterCount())
+			assert.Equal(t, tc.scatterPayout, a.ScatterPayout())
+
 			if tc.multi != nil {
 				a2 := a.WithMultiSymbols(tc.multi...)
 				require.Equal(t, a, a2)
 				assert.Equal(t, tc.multi, a.multiSymbols)
+				assert.Equal(t, tc.multi, a.MultiSymbols())
 			}
 
 			n := a.WithAlternate(alt)
@@ -6,6 +6,7 @@ import (
 
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/encode/zjson"
 
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/consts"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/utils"
 )
 
@@ -37,10 +38,12 @@ type Slots struct {
 	reverseWin            bool
 	directions            PayDirection
 	noRepeat              uint8
+	gambleSteps           uint8
 	reelCount             int
 	rowCount              int
 	flagBB                int
 	maxPayout             float64
+	gambleMaxWin          float64
 	targetRTP             float64
 	symbols               *SymbolSet
 	altSymbols            *SymbolSet
@@ -51,6 +54,7 @@ type Slots struct {
 	refiller              Spinner
 	configHash            string
 	mask                  utils.UInt8s
+	reelHeights           []utils.WeightedGenerator
 	actionsFirst          SpinActions
 	actionsFree           SpinActions
 	actionsFirstBB        SpinActions
@@ -80,7 +84,12 @@ func NewSlots(opts ...SlotOption) *Slots {
 		opts[ix](s)
 	}
 
-	s.gridDef = NewGridDefinition(s.reelCount, s.rowCount, s.mask)
+	if s.reelHeights != nil {
+		s.validateReelHeights()
+		s.gridDef = NewDynamicGridDefinition(s.reelCount, s.rowCount)
+	} else {
+		s.gridDef = NewGridDefinition(s.reelCount, s.rowCount, s.mask)
+	}
 
 	for ix := range s.roundFlags {
 		if s.roundFlags[ix].export {
@@ -119,6 +128,27 @@ func (s *Slots) ReelMask() utils.UInt8s {
 	return s.mask
 }
 
+// ReelHeights returns the weightings for the reel heights of grids with dynamic reel heights.
+// It returns nil if the grid has fixed reel heights.
+func (s *Slots) ReelHeights() []utils.WeightedGenerator {
+	return s.reelHeights
+}
+
+// NoRepeat returns the number of rows for which the PRNG prevents repeating symbols.
+func (s *Slots) NoRepeat() uint8 {
+	return s.noRepeat
+}
+
+// PayDirections returns the valid direction(s) for the paylines.
+func (s *Slots) PayDirections() PayDirection {
+	return s.directions
+}
+
+// HighestPayout returns whether the highest payout feature is turned on.
+func (s *Slots) HighestPayout() bool {
+	return s.highestPayout
+}
+
 // GridDefinition returns the definition for the grid.
 func (s *Slots) GridDefinition() *GridDefinition {
 	return s.gridDef
@@ -175,6 +205,37 @@ func (s *Slots) MaxPayout() float64 {
 	return s.maxPayout
 }
 
+// Gamble returns whether the player can gamble the win of a round.
+func (s *Slots) Gamble() bool {
+	return s.gambleSteps > 0
+}
+
+// GambleSteps returns the maximum number of gamble steps after a winning round.
+func (s *Slots) GambleSteps() uint8 {
+	return s.gambleSteps
+}
+
+// GambleMaxWin returns the maximum win of a gamble as a factor of the bet.
+// A value of 0 means the gamble is only limited by the maximum payout.
+func (s *Slots) GambleMaxWin() float64 {
+	return s.gambleMaxWin
+}
+
+// HotReelsAsBonusSymbol returns whether hot reels count as bonus symbol during free spins.
+func (s *Slots) HotReelsAsBonusSymbol() bool {
+	return s.hotReelsAsBonusSymbol
+}
+
+// MultiplierOnWildsOnly returns whether the round multiplier only applies to paylines with a wild.
+func (s *Slots) MultiplierOnWildsOnly() bool {
+	return s.multiplierOnWildsOnly
+}
+
+// BonusBuyFlag returns the round flag indicating a bonus buy was activated for a round.
+func (s *Slots) BonusBuyFlag() int {
+	return s.flagBB
+}
+
 // BonusBuy returns whether the game has a bonus buy feature.
 func (s *Slots) BonusBuy() bool {
 	return s.bonusBuy
@@ -231,6 +292,16 @@ func (s *Slots) ActionsFreeBB() SpinActions {
 	return s.actionsFreeBB
 }
 
+// Spinner returns the (optional) Spinner interface of the slot machine.
+func (s *Slots) Spinner() Spinner {
+	return s.spinner
+}
+
+// Refiller returns the (optional) Spinner interface for refilling the grid.
+func (s *Slots) Refiller() Spinner {
+	return s.refiller
+}
+
 // ScriptedRoundSelector returns the scripted round selector.
 func (s *Slots) ScriptedRoundSelector() *ScriptedRoundSelector {
 	return s.scriptedRoundSelector
@@ -244,10 +315,19 @@ func (s *Slots) IsEmpty() bool {
 // EncodeFields implements the zjson.Encoder interface.
 func (s *Slots) EncodeFields(enc *zjson.Encoder) {
 	enc.ObjectField("grid", s.gridDef)
+	if len(s.reelHeights) > 0 {
+		enc.StartArrayField("reelHeights")
+		for ix := range s.reelHeights {
+			enc.String(s.reelHeights[ix].String())
+		}
+		enc.EndArray()
+	}
 	enc.Uint8Field("noRepeat", s.noRepeat)
 	enc.StringField("paylineDirection", s.directions.String())
 	enc.FloatField("maxPayout", s.maxPayout, 'f', 2)
 	enc.FloatField("targetRTP", s.targetRTP, 'f', 2)
+	enc.Uint8FieldOpt("gambleSteps", s.gambleSteps)
+	enc.FloatFieldOpt("gambleMaxWin", s.gambleMaxWin, 'f', 2)
 	enc.IntBoolFieldOpt("highestPayout", s.highestPayout)
 	enc.IntBoolFieldOpt("cascadingReels", s.cascadingReels)
 	enc.IntBoolFieldOpt("doubleSpin", s.doubleSpin)
@@ -345,6 +425,19 @@ func WithMask(mask ...uint8) SlotOption {
 	}
 }
 
+// WithReelHeights changes the slot machine grid to a grid where the height of each reel is selected for every spin.
+// The weightings give the chance for each possible height of a reel, from 1 up to the row count of the grid.
+// The used positions of a reel are always at the top. During a spin the unused positions will remain zero.
+// This is typically used for "ways" games, where the number of ways changes with every spin,
+// e.g. a 6x7 grid with reels of 2 to 7 rows gives up to 117,649 ways.
+// NOTE: make sure to define the weighting for all reels, and don't combine this option with WithMask()!
+// The slot engine will panic if you ignore this rule!
+func WithReelHeights(weights ...utils.WeightedGenerator) SlotOption {
+	return func(s *Slots) {
+		s.reelHeights = weights
+	}
+}
+
 // WithSymbols initializes the symbols for the slot machine.
 func WithSymbols(symbols *SymbolSet) SlotOption {
 	return func(s *Slots) {
@@ -483,6 +576,16 @@ func MaxPayout(maxPayout float64) SlotOption {
 	}
 }
 
+// WithGamble allows the player to gamble the win of a round on the color or suit of a card, for up to maxSteps times.
+// A gamble is only offered if the potential win does not exceed maxWin, expressed as a factor of the bet.
+// The value 0 for maxWin means the gamble is only limited by the maximum payout.
+func WithGamble(maxWin float64, maxSteps uint8) SlotOption {
+	return func(s *Slots) {
+		s.gambleMaxWin = maxWin
+		s.gambleSteps = maxSteps
+	}
+}
+
 // WithRTP adds the official target RTP to the game.
 func WithRTP(target float64) SlotOption {
 	return func(s *Slots) {
@@ -534,3 +637,21 @@ func WithRefiller(refiller Spinner) SlotOption {
 		s.refiller = refiller
 	}
 }
+
+// validateReelHeights panics if the weightings for the reel heights are invalid.
+func (s *Slots) validateReelHeights() {
+	if len(s.mask) > 0 || len(s.reelHeights) != s.reelCount {
+		panic(consts.MsgInvalidReelHeights)
+	}
+	for ix := range s.reelHeights {
+		options := s.reelHeights[ix].Options()
+		if len(options) == 0 {
+			panic(consts.MsgInvalidReelHeights)
+		}
+		for _, h := range options {
+			if h == 0 || int(h) > s.rowCount {
+				panic(consts.MsgInvalidReelHeights)
+			}
+		}
+	}
+}
@@ -112,7 +112,10 @@ func TestNewSlots(t *testing.T) {
 			assert.Equal(t, tc.symbols, s.Symbols())
 
 			assert.Equal(t, tc.directions, s.directions)
+			assert.Equal(t, tc.directions, s.PayDirections())
 			assert.Equal(t, tc.highest, s.highestPayout)
+			assert.Equal(t, tc.highest, s.HighestPayout())
+			assert.Equal(t, tc.noRepeat, s.NoRepeat())
 			assert.Equal(t, tc.cascadingReels, s.cascadingReels)
 			assert.Equal(t, tc.maxPayout, s.maxPayout)
 
@@ -140,6 +143,60 @@ func TestNewSlotsFail(t *testing.T) {
 	})
 }
 
+func TestSlots_WithReelHeights(t *testing.T) {
+	weights := func(heights ...utils.Index) utils.WeightedGenerator {
+		w := utils.AcquireWeighting()
+		for _, h := range heights {
+			w.AddWeight(h, 10)
+		}
+		return w
+	}
+
+	t.Run("dynamic grid", func(t *testing.T) {
+		s := NewSlots(Grid(3, 4), WithSymbols(setF1), WithReelHeights(weights(2, 3), weights(1, 4), weights(3)))
+		require.NotNil(t, s)
+
+		assert.Equal(t, 3, len(s.ReelHeights()))
+		assert.True(t, s.GridDefinition().Dynamic())
+		assert.Equal(t, utils.UInt8s{4, 4, 4}, s.GridDefinition().GridMask())
+		assert.NotEqual(t, NewSlots(Grid(3, 4), WithSymbols(setF1)).ConfigHash(), s.ConfigHash())
+	})
+
+	testCases := []struct {
+		name string
+		opts []SlotOption
+	}{
+		{
+			name: "too few reels",
+			opts: []SlotOption{Grid(3, 4), WithReelHeights(weights(2, 3), weights(1, 4))},
+		},
+		{
+			name: "height too large",
+			opts: []SlotOption{Grid(3, 4), WithReelHeights(weights(2, 3), weights(1, 5), weights(3))},
+		},
+		{
+			name: "zero height",
+			opts: []SlotOption{Grid(3, 4), WithReelHeights(weights(2, 3), weights(0, 4), weights(3))},
+		},
+		{
+			name: "with mask",
+			opts: []SlotOption{Grid(3, 4), WithMask(2, 3, 4), WithReelHeights(weights(2, 3), weights(1, 4), weights(3))},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func() {
+				e := recover()
+				require.NotNil(t, e)
+			}()
+
+			s := NewSlots(append(tc.opts, WithSymbols(setF1))...)
+			require.Nil(t, s)
+		})
+	}
+}
+
 func TestSlots_GetBonusSymbol(t *testing.T) {
 	t.Run("get bonus symbol", func(t *testing.T) {
 		prng := rng.NewRNG()
@@ -5,6 +5,7 @@ import (
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/object-pool/pool"
 
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/consts"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/games/pickem"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/interfaces"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/utils"
 )
@@ -34,6 +35,8 @@ const (
 	FirstFreeSpin
 	// SecondFreeSpin represents the second spin of a free spin in a double-spin game.
 	SecondFreeSpin
+	// Respin represents a respin during a hold-and-win feature.
+	Respin
 )
 
 const (
@@ -67,6 +70,11 @@ func AcquireSpin(slots *Slots, prng interfaces.Generator) *Spin {
 	s.paylines = slots.paylines
 	s.gridDef = slots.gridDef
 	s.mask = s.gridDef.mask
+	if s.gridDef.dynamic {
+		// the spin owns the mask, as the reel heights are selected for each spin.
+		s.heights = utils.CopyPurgeUInt8s(s.gridDef.mask, s.heights, 8)
+		s.mask = s.heights
+	}
 	s.spinner = slots.spinner
 	s.refiller = slots.refiller
 
@@ -160,6 +168,73 @@ func (s *Spin) ReelSize(reel uint8) int {
 	return int(s.mask[reel])
 }
 
+// ReelHeights returns the number of rows for each reel.
+// For grids with dynamic reel heights, the result is only valid for the current spin.
+func (s *Spin) ReelHeights() utils.UInt8s {
+	return s.mask
+}
+
+// SetReelHeights sets the number of rows for each reel of a grid with dynamic reel heights.
+// This can be used by a custom Spinner to set up pre-determined heights before filling the grid.
+// Positions beyond the new height of a reel are cleared.
+// The function does nothing if the grid has fixed reel heights, or if the number of heights doesn't match the reels.
+func (s *Spin) SetReelHeights(heights ...uint8) {
+	if !s.gridDef.dynamic || len(heights) != len(s.heights) {
+		return
+	}
+
+	var offset int
+	for reel := range heights {
+		h := min(max(int(heights[reel]), 1), s.rowCount)
+		s.heights[reel] = uint8(h)
+		s.clearAbove(offset+h, offset+s.rowCount)
+		offset += s.rowCount
+	}
+}
+
+// Heights returns the reel heights for grids with dynamic reel heights.
+// The given slice is re-used if it is not nil and of sufficient capacity.
+// For grids with fixed reel heights the result is always empty.
+func (s *Spin) Heights(input utils.UInt8s) utils.UInt8s {
+	if s.gridDef == nil || !s.gridDef.dynamic {
+		return utils.PurgeUInt8s(input, 8)
+	}
+	return utils.CopyPurgeUInt8s(s.heights, input, 8)
+}
+
+// Neighbors returns a map with neighboring tiles for a given tile.
+// For grids with dynamic reel heights only the tiles valid for the current spin are included,
+// and the returned map is only valid until the next call.
+func (s *Spin) Neighbors(offset uint8) GridNeighbors {
+	if !s.gridDef.dynamic {
+		return s.gridDef.neighbors[offset]
+	}
+	if s.neighbors == nil {
+		s.neighbors = make(GridNeighbors, MaxNeighbors)
+	}
+	return s.gridDef.NeighborsIn(offset, s.heights, s.neighbors)
+}
+
+// IsValidOffset returns true if the given offset is a valid tile on the grid for the current spin.
+func (s *Spin) IsValidOffset(offset int) bool {
+	return s.gridDef.IsValidOffsetIn(offset, s.mask)
+}
+
+// IsOnTheEdge returns true if the given offset is on the edge of the grid for the current spin.
+func (s *Spin) IsOnTheEdge(offset int) bool {
+	return s.gridDef.IsOnTheEdgeIn(offset, s.mask)
+}
+
+// neighborsWithoutSelf returns a map with neighboring tiles for a given tile excluding the tile itself.
+func (s *Spin) neighborsWithoutSelf(offset uint8) GridNeighbors {
+	if !s.gridDef.dynamic {
+		return s.gridDef.withoutSelf[offset]
+	}
+	n := s.Neighbors(offset)
+	delete(n, offset)
+	return n
+}
+
 // PRNG returns the random number generator.
 func (s *Spin) PRNG() interfaces.Generator {
 	return s.prng
@@ -200,6 +275,7 @@ func (s *Spin) Debug(indexes utils.Indexes) {
 	s.resetPayouts()
 
 	copy(s.indexes, indexes)
+	s.heightsFromGrid()
 
 	s.CountSpecials()
 }
@@ -234,9 +310,12 @@ func (s *Spin) ResetSpin() {
 	s.freeSpins = 0
 	s.spinSeq = 0
 	s.progressLevel = 0
+	s.progressive = 0
 	s.multiplier = 0.0
 
 	s.resetHot()
+	s.resetCoins()
+	s.resetPickem()
 	s.ResetSticky()
 	s.ResetEffects()
 	s.resetMultipliers()
@@ -252,9 +331,18 @@ func (s *Spin) RestoreState(state *SpinState) {
 		copy(s.indexes, state.indexes)
 	}
 
+	if len(state.heights) == len(s.heights) && s.gridDef.dynamic {
+		copy(s.heights, state.heights)
+	}
+
 	if len(state.sticky) == len(s.sticky) {
 		copy(s.sticky, state.sticky)
 	}
+
+	s.resetPickem()
+	if state.pickem != nil {
+		s.pickem = state.pickem.DeepCopy()
+	}
 }
 
 // SetKind sets the kind of spin.
@@ -516,6 +604,114 @@ func (s *Spin) Sticky(input utils.UInt8s) utils.UInt8s {
 	return input
 }
 
+// Progressive returns the level of the progressive jackpot triggered by the spin, or zero if none was triggered.
+func (s *Spin) Progressive() uint8 {
+	return s.progressive
+}
+
+// Respins returns the number of remaining respins of a hold-and-win feature.
+func (s *Spin) Respins() uint8 {
+	return s.respins
+}
+
+// Coins returns the coin values of a hold-and-win feature, or an empty slice if the feature isn't active.
+// Tiles without a coin have a value of zero.
+func (s *Spin) Coins() []float64 {
+	return s.coins
+}
+
+// Jackpots returns the jackpot for each tile of a hold-and-win feature, or an empty slice if the feature isn't active.
+// Tiles without a jackpot are marked with NoJackpot.
+func (s *Spin) Jackpots() utils.UInt8s {
+	return s.jackpots
+}
+
+// CoinCount returns the number of tiles holding a coin during a hold-and-win feature.
+func (s *Spin) CoinCount() uint8 {
+	var count uint8
+	for ix := range s.coins {
+		if s.coins[ix] > 0 {
+			count++
+		}
+	}
+	return count
+}
+
+// CoinsFull returns true if every tile of the grid holds a coin during a hold-and-win feature.
+func (s *Spin) CoinsFull() bool {
+	if len(s.coins) == 0 {
+		return false
+	}
+
+	var offset int
+	for reel := range s.mask {
+		m := offset + int(s.mask[reel])
+		for ix := offset; ix < m; ix++ {
+			if s.coins[ix] == 0 {
+				return false
+			}
+		}
+		offset += s.rowCount
+	}
+	return true
+}
+
+// Respin performs a respin during a hold-and-win feature.
+// Tiles holding a coin remain in place and all other tiles are refilled.
+// It decreases the number of remaining respins.
+func (s *Spin) Respin() {
+	if s.respins > 0 {
+		s.respins--
+	}
+
+	s.resetPayouts()
+	s.clearNonSticky()
+	s.Refill()
+	s.CountSpecials()
+}
+
+// startCoins prepares the spin for a hold-and-win feature.
+func (s *Spin) startCoins(respins uint8) {
+	l := len(s.indexes)
+	s.coins = utils.PurgeFloats(s.coins, l)[:l]
+	s.jackpots = utils.PurgeUInt8s(s.jackpots, l)[:l]
+	clear(s.coins)
+	clear(s.jackpots)
+	s.respins = respins
+}
+
+// setCoin locks a coin with the given value and jackpot on the given tile.
+func (s *Spin) setCoin(offset int, value float64, jackpot Jackpot) {
+	s.coins[offset] = value
+	s.jackpots[offset] = uint8(jackpot)
+	s.sticky[offset] = true
+}
+
+// resetCoins ends a hold-and-win feature and releases the tiles holding a coin.
+func (s *Spin) resetCoins() {
+	for ix := range s.coins {
+		if s.coins[ix] > 0 {
+			s.sticky[ix] = false
+		}
+	}
+	s.respins = 0
+	s.coins = s.coins[:0]
+	s.jackpots = s.jackpots[:0]
+}
+
+// PickemPending returns true if a pick'em bonus game is waiting for the next pick of the player.
+func (s *Spin) PickemPending() bool {
+	return s.pickem != nil && !s.pickem.Done()
+}
+
+// resetPickem releases the state of a pick'em bonus game.
+func (s *Spin) resetPickem() {
+	if s.pickem != nil {
+		s.pickem.Release()
+		s.pickem = nil
+	}
+}
+
 // ResetEffects resets all the special effect indicators and symbol injections.
 func (s *Spin) ResetEffects() {
 	clear(s.effects)
@@ -846,6 +1042,16 @@ func (s *Spin) Spin(locks ...uint8) {
 func (s *Spin) initialSpin() {
 	s.resetPayouts()
 	s.ResetEffects()
+	s.progressive = 0
+
+	if s.respins == 0 && len(s.coins) > 0 {
+		// the hold-and-win feature has completed.
+		s.resetCoins()
+	}
+
+	if s.gridDef.dynamic {
+		s.selectHeights()
+	}
 
 	if s.spinner != nil {
 		s.spinSeq++
@@ -889,11 +1095,64 @@ func (s *Spin) spin(reels Reels) {
 	}
 }
 
+// selectHeights selects new heights for the unlocked reels of a grid with dynamic reel heights.
+// A reel is never shorter than needed to keep its sticky symbols on the grid.
+// Positions beyond the new height of a reel are cleared.
+func (s *Spin) selectHeights() {
+	weights := s.slots.reelHeights
+
+	var offset int
+	for reel := range s.heights {
+		if !s.locked[reel] {
+			h := int(weights[reel].RandomIndex(s.prng))
+			for row := h; row < s.rowCount; row++ {
+				if s.sticky[offset+row] {
+					h = row + 1
+				}
+			}
+			s.heights[reel] = uint8(h)
+			s.clearAbove(offset+h, offset+s.rowCount)
+		}
+		offset += s.rowCount
+	}
+}
+
+// heightsFromGrid derives the reel heights of a grid with dynamic reel heights from the symbols on the grid.
+// The height of a reel is determined by the last position holding a symbol.
+func (s *Spin) heightsFromGrid() {
+	if !s.gridDef.dynamic {
+		return
+	}
+
+	var offset int
+	for reel := range s.heights {
+		h := 1
+		for row := s.rowCount - 1; row > 0; row-- {
+			if s.indexes[offset+row] != 0 {
+				h = row + 1
+				break
+			}
+		}
+		s.heights[reel] = uint8(h)
+		offset += s.rowCount
+	}
+}
+
+// clearAbove clears the unused positions of a reel in a grid with dynamic reel heights.
+func (s *Spin) clearAbove(from, to int) {
+	clear(s.indexes[from:to])
+	clear(s.sticky[from:to])
+	if len(s.multipliers) > 0 {
+		clear(s.multipliers[from:to])
+	}
+}
+
 // Refill fills cleared locations with new random symbols, honouring the cascading reels feature.
 // It basically acts like a "free spin" but with some symbols locked in place,
 // and other symbols landing on empty locations lower down on the same reel if the cascading reels feature is active.
 func (s *Spin) Refill() {
 	s.ResetEffects()
+	s.progressive = 0
 
 	s.spinSeq++
 
@@ -1022,6 +1281,8 @@ type Spin struct {
 	newWilds            uint8                // count of new wild symbols, excluding locked reels.
 	newHeroes           uint8                // count of new hero symbols, excluding locked reels.
 	newScatters         uint8                // count of new scatter symbols, excluding locked reels.
+	respins             uint8                // remaining respins of a hold-and-win feature.
+	progressive         uint8                // level of the progressive jackpot triggered by the spin; zero if none.
 	bonusSymbol         utils.Index          // randomly selected bonus symbol during free spins.
 	superSymbol         utils.Index          // symbol which triggered a super-shape feature.
 	stickySymbol        utils.Index          // symbol which is marked as sticky in the grid.
@@ -1036,9 +1297,11 @@ type Spin struct {
 	altSymbols          *SymbolSet           // copy of alternate symbol set.
 	gridDef             *GridDefinition      // copy of the grid definition.
 	paylines            *PaylineSet          // copy of paylines.
+	pickem              *pickem.PickemResult // state of a pick'em bonus game waiting for the player.
 	spinner             Spinner              // spinner function.
 	refiller            Spinner              // refiller function.
 	gamer               interfaces.Gamer     // interface for a game round.
+	neighbors           GridNeighbors        // neighboring tiles for grids with dynamic reel heights.
 	prng                interfaces.Generator // local PRNG for all randomness.
 	reels               Reels                // primary reels.
 	altReels            Reels                // the alternate reels.
@@ -1048,10 +1311,13 @@ type Spin struct {
 	superShape          []bool               // marks super shape in the grid.
 	roundFlags          []int                // local flags carried across multiple spins of a round.
 	multipliers         []uint16             // optional multipliers for the symbols on the grid.
+	coins               []float64            // coin values for the tiles of a hold-and-win feature.
 	mask                utils.UInt8s         // copy of slot machine reels mask.
+	heights             utils.UInt8s         // reel heights for grids with dynamic reel heights; the mask points here.
 	payouts             utils.UInt8s         // marks payout symbols in the grid; 1=standard; 2+=wild.
 	effects             utils.UInt8s         // special effects indicators.
 	jumps               utils.UInt8s         // array of jumping symbol vectors: 0=no jump, 255=off grid, otherwise new offset+1.
+	jackpots            utils.UInt8s         // jackpots for the tiles of a hold-and-win feature.
 	indexes             utils.Indexes        // the symbol grid.
 	injections          utils.Indexes        // array of symbol injections.
 	pool.Object
@@ -1069,9 +1335,11 @@ var spinsProducer = pool.NewProducer(func() (pool.Objecter, func()) {
 		superShape:  make([]bool, 0, 24),
 		roundFlags:  make([]int, 16),
 		multipliers: make([]uint16, 0, 24),
+		coins:       make([]float64, 0, 24),
 		payouts:     make(utils.UInt8s, 0, 16),
 		effects:     make(utils.UInt8s, 0, 24),
 		jumps:       make(utils.UInt8s, 0, 24),
+		jackpots:    make(utils.UInt8s, 0, 24),
 		indexes:     make(utils.Indexes, 0, 24),
 		injections:  make(utils.Indexes, 0, 24),
 	}
@@ -1082,6 +1350,7 @@ var spinsProducer = pool.NewProducer(func() (pool.Objecter, func()) {
 func (s *Spin) reset() {
 	if s != nil {
 		s.debug = false
+		s.debugInitial = false
 		s.altActive = false
 		s.expanded = false
 		s.multiplierNeedsWild = false
@@ -1090,6 +1359,8 @@ func (s *Spin) reset() {
 		s.newWilds = 0
 		s.newHeroes = 0
 		s.newScatters = 0
+		s.respins = 0
+		s.progressive = 0
 		s.bonusSymbol = utils.NullIndex
 		s.superSymbol = utils.NullIndex
 		s.stickySymbol = utils.NullIndex
@@ -1110,6 +1381,8 @@ func (s *Spin) reset() {
 		s.gamer = nil
 		s.prng = nil
 		s.mask = nil
+		s.resetPickem()
+		s.heights = s.heights[:0]
 
 		s.reels = ReleaseReels(s.reels)
 		s.altReels = ReleaseReels(s.altReels)
@@ -1123,6 +1396,8 @@ func (s *Spin) reset() {
 		s.indexes = s.indexes[:0]
 		s.injections = s.injections[:0]
 		s.jumps = s.jumps[:0]
+		s.coins = s.coins[:0]
+		s.jackpots = s.jackpots[:0]
 
 		if len(s.multipliers) > 0 {
 			clear(s.multipliers)
@@ -129,6 +129,10 @@ const (
 	GridModified
 	// Penalty is the action result imposing a direct penalty.
 	Penalty
+	// HoldAndWin is the action result that indicates a hold-and-win feature was triggered or progressed.
+	HoldAndWin
+	// ProgressiveJackpot is the action result that indicates a progressive jackpot was triggered.
+	ProgressiveJackpot
 )
 
 // String implements the Stringer interface.
@@ -168,6 +172,10 @@ func (r SpinActionResult) String() string {
 		return "SymbolsInjected"
 	case Penalty:
 		return "Penalty"
+	case HoldAndWin:
+		return "HoldAndWin"
+	case ProgressiveJackpot:
+		return "ProgressiveJackpot"
 	default:
 		return "???"
 	}
@@ -262,6 +270,11 @@ func (a *SpinAction) Config() string {
 	return a.config
 }
 
+// Symbol returns the symbol of the action, if any.
+func (a *SpinAction) Symbol() utils.Index {
+	return a.symbol
+}
+
 // Stage returns the stage at which the spin action should be tested.
 func (a *SpinAction) Stage() SpinActionStage {
 	return a.stage
@@ -407,6 +420,12 @@ func (a *SpinAction) CanClear(spin *Spin) bool {
 	return true
 }
 
+// HasFilters returns true if the action has any filters or a chance modifier.
+func (a *SpinAction) HasFilters() bool {
+	return len(a.testChoicesFilters) > 0 || len(a.triggerFilters) > 0 || len(a.stickyFilters) > 0 ||
+		len(a.clearFilters) > 0 || a.chanceModifier != nil
+}
+
 // WithChanceModifier adds a chance modifier function to the action.
 func (a *SpinAction) WithChanceModifier(f ChanceModifier) {
 	a.chanceModifier = f
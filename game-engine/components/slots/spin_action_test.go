@@ -35,6 +35,10 @@ func TestSpinAction(t *testing.T) {
 			assert.False(t, a.AltSymbols())
 			assert.Nil(t, a.Triggered(nil))
 			assert.Equal(t, false, a.BonusSymbol())
+			assert.False(t, a.HasFilters())
+
+			a.WithTriggerFilters(OnFreeSpin)
+			assert.True(t, a.HasFilters())
 
 			a.Payout(nil, nil) // just for code coverage as the function is a no-op
 		})
@@ -5,6 +5,7 @@ import (
 	"strconv"
 	"strings"
 
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/games/pickem"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/games/wheel"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/results"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/utils"
@@ -33,6 +34,9 @@ func SpinResultsReport(in results.Results, f SpinDataFormatter, maxPayout float6
 		if data, ok := result.Data.(*wheel.BonusWheelResult); ok {
 			bonusWheelData(data, &b, ix)
 		}
+		if data, ok := result.Data.(*pickem.PickemResult); ok {
+			pickemData(data, &b, ix)
+		}
 	}
 
 	out := strings.Split(b.String(), "\n")
@@ -215,6 +219,26 @@ func bonusWheelData(data *wheel.BonusWheelResult, b *strings.Builder, ix int) {
 	b.WriteByte('\n')
 }
 
+func pickemData(data *pickem.PickemResult, b *strings.Builder, ix int) {
+	// header lines
+	b.WriteString("pick'em\n")
+	b.WriteString("spin    level  picks  prizes    multiplier  done\n")
+
+	// line 1
+	b.WriteString(fmt.Sprintf("%-6d", ix+1))
+	b.WriteString("  ")
+	b.WriteString(fmt.Sprintf("%-5d", data.LevelsReached()))
+	b.WriteString("  ")
+	b.WriteString(fmt.Sprintf("%-5d", len(data.Picks())))
+	b.WriteString("  ")
+	b.WriteString(fmt.Sprintf("%-8.2f", data.Prizes()))
+	b.WriteString("  ")
+	b.WriteString(fmt.Sprintf("%-10.2f", data.Multiplier()))
+	b.WriteString("  ")
+	b.WriteString(strconv.FormatBool(data.Done()))
+	b.WriteByte('\n')
+}
+
 type SpinDataFormatter func(data utils.Indexes) []string
 
 func SpinDataRectangle(reels, rows int) SpinDataFormatter {
@@ -25,12 +25,15 @@ func AcquireSpinResult(spin *Spin) *SpinResult {
 	r.multipliers = spin.CloneMultipliers(r.multipliers)
 	r.lockedReels = spin.Locked(r.lockedReels)
 	r.hotReels = spin.Hot(r.hotReels)
+	r.heights = spin.Heights(r.heights)
 	r.sticky = spin.Sticky(r.sticky)
 	r.effects = spin.Effects(r.effects)
+	r.setCoins(spin)
 	r.bonusSymbol = spin.bonusSymbol
 	r.stickySymbol = spin.stickySymbol
 	r.superSymbol = spin.superSymbol
 	r.progressLevel = spin.progressLevel
+	r.progressive = spin.progressive
 	r.multiplier = spin.multiplier
 
 	if spin.slots != nil {
@@ -117,6 +120,34 @@ func (r *SpinResult) Locked() utils.UInt8s {
 	return r.lockedReels
 }
 
+// Heights returns the reel heights for grids with dynamic reel heights.
+// The result is empty for grids with fixed reel heights.
+func (r *SpinResult) Heights() utils.UInt8s {
+	return r.heights
+}
+
+// Progressive returns the level of the progressive jackpot triggered by the spin, or zero if none was triggered.
+func (r *SpinResult) Progressive() uint8 {
+	return r.progressive
+}
+
+// Respins returns the number of remaining respins of a hold-and-win feature.
+func (r *SpinResult) Respins() uint8 {
+	return r.respins
+}
+
+// Coins returns the coin values of a hold-and-win feature.
+// The result is empty if the feature wasn't active.
+func (r *SpinResult) Coins() []float64 {
+	return r.coins
+}
+
+// Jackpots returns the jackpot for each tile of a hold-and-win feature.
+// The result is empty if the feature wasn't active.
+func (r *SpinResult) Jackpots() utils.UInt8s {
+	return r.jackpots
+}
+
 // IsHot returns whether the given reel index is for a hot reel.
 // Reel indexes are 1-based, so the first reel has the index 1.
 func (r *SpinResult) IsHot(reel uint8) bool {
@@ -198,6 +229,11 @@ func (r *SpinResult) SetDebug(debug bool) {
 	r.debug = debug
 }
 
+// ConfigHash returns the hash of the game config the spin result was created with.
+func (r *SpinResult) ConfigHash() string {
+	return r.configHash
+}
+
 // SetMaxPayout sets the spin to have reached max payout.
 func (r *SpinResult) SetMaxPayout() {
 	r.maxPayout = true
@@ -220,14 +256,24 @@ func (r *SpinResult) Update(spin *Spin) {
 	r.multipliers = spin.CloneMultipliers(r.multipliers)
 	r.lockedReels = spin.Locked(r.lockedReels)
 	r.hotReels = spin.Hot(r.hotReels)
+	r.heights = spin.Heights(r.heights)
 	r.sticky = spin.Sticky(r.sticky)
+	r.setCoins(spin)
 	r.bonusSymbol = spin.bonusSymbol
 	r.stickySymbol = spin.stickySymbol
 	r.superSymbol = spin.superSymbol
 	r.progressLevel = spin.progressLevel
+	r.progressive = spin.progressive
 	r.multiplier = spin.multiplier
 }
 
+// setCoins copies the state of a hold-and-win feature.
+func (r *SpinResult) setCoins(spin *Spin) {
+	r.respins = spin.respins
+	r.coins = append(utils.PurgeFloats(r.coins, len(spin.coins)), spin.coins...)
+	r.jackpots = utils.CopyUInt8s(spin.jackpots, r.jackpots)
+}
+
 // SetMultipliers refreshes the grid multipliers.
 func (r *SpinResult) SetMultipliers(spin *Spin) {
 	r.multipliers = spin.CloneMultipliers(r.multipliers)
@@ -562,6 +608,30 @@ func (r *SpinResult) encode(enc *zjson.Encoder, withLog bool) {
 		enc.EndArray()
 	}
 
+	if len(r.heights) > 0 {
+		enc.StartArrayField("heights")
+		for ix := range r.heights {
+			enc.Uint64(uint64(r.heights[ix]))
+		}
+		enc.EndArray()
+	}
+
+	enc.Uint8FieldOpt("progressive", r.progressive)
+
+	if len(r.coins) > 0 {
+		enc.Uint8Field("respins", r.respins)
+		enc.StartArrayField("coins")
+		for ix := range r.coins {
+			enc.Float(r.coins[ix], 'g', -1)
+		}
+		enc.EndArray()
+		enc.StartArrayField("jackpots")
+		for ix := range r.jackpots {
+			enc.Uint64(uint64(r.jackpots[ix]))
+		}
+		enc.EndArray()
+	}
+
 	if r.bonusSymbol != utils.MaxIndex {
 		enc.Uint16FieldOpt("bonusSymbol", uint16(r.bonusSymbol))
 	}
@@ -707,6 +777,19 @@ func (r *SpinResult) DecodeField(dec *zjson.Decoder, key []byte) error {
 	} else if string(key) == "hotReels" {
 		r.hotReels = utils.PurgeUInt8s(r.hotReels, 8)
 		ok = dec.Array(r.decodeHotReels)
+	} else if string(key) == "heights" {
+		r.heights = utils.PurgeUInt8s(r.heights, 8)
+		ok = dec.Array(r.decodeHeights)
+	} else if string(key) == "progressive" {
+		r.progressive, ok = dec.Uint8()
+	} else if string(key) == "respins" {
+		r.respins, ok = dec.Uint8()
+	} else if string(key) == "coins" {
+		r.coins = utils.PurgeFloats(r.coins, 24)
+		ok = dec.Array(r.decodeCoins)
+	} else if string(key) == "jackpots" {
+		r.jackpots = utils.PurgeUInt8s(r.jackpots, 24)
+		ok = dec.Array(r.decodeJackpots)
 	} else if string(key) == "sticky" {
 		r.sticky = utils.PurgeUInt8s(r.sticky, 24)
 		ok = dec.Array(r.decodeSticky)
@@ -840,6 +923,30 @@ func (r *SpinResult) decodeHotReels(dec *zjson.Decoder) error {
 	return dec.Error()
 }
 
+func (r *SpinResult) decodeHeights(dec *zjson.Decoder) error {
+	if i, ok := dec.Uint8(); ok {
+		r.heights = append(r.heights, i)
+		return nil
+	}
+	return dec.Error()
+}
+
+func (r *SpinResult) decodeCoins(dec *zjson.Decoder) error {
+	if f, ok := dec.Float(); ok {
+		r.coins = append(r.coins, f)
+		return nil
+	}
+	return dec.Error()
+}
+
+func (r *SpinResult) decodeJackpots(dec *zjson.Decoder) error {
+	if i, ok := dec.Uint8(); ok {
+		r.jackpots = append(r.jackpots, i)
+		return nil
+	}
+	return dec.Error()
+}
+
 func (r *SpinResult) decodeSticky(dec *zjson.Decoder) error {
 	if i, ok := dec.Uint8(); ok {
 		r.sticky = append(r.sticky, i)
@@ -912,6 +1019,8 @@ type SpinResult struct {
 	maxPayout     bool                  // indicates this spin reached the max payout.
 	bonusBuy      uint8                 // indicates a spin in bonus buy/bonus bet mode.
 	kind          SpinKind              // type of spin result.
+	respins       uint8                 // remaining respins of a hold-and-win feature.
+	progressive   uint8                 // level of the triggered progressive jackpot; zero if none.
 	transition    FeatureTransitionKind // optional type of transition.
 	bonusSymbol   utils.Index           // the selected bonus symbol for free spins.
 	stickySymbol  utils.Index           // the selected sticky symbol.
@@ -921,14 +1030,17 @@ type SpinResult struct {
 	buyFactor     float64               // bet multiplier for bonus buy/bonus bet.
 	initial       utils.Indexes         // initial symbol grid.
 	multipliers   []uint16              // optional multipliers for symbol grid.
+	coins         []float64             // coin values of a hold-and-win feature.
 	afterNudge    utils.Indexes         // symbol grid after nudge operations.
 	afterExpand   utils.Indexes         // symbol grid after expansions.
 	afterClear    utils.Indexes         // symbol grid after clear operations.
 	afterCascade  utils.Indexes         // symbol grid after cascading reels operation.
 	lockedReels   utils.UInt8s          // locked reels after wild expansions (1-based).
 	hotReels      utils.UInt8s          // hot reels during free games (1-based).
+	heights       utils.UInt8s          // reel heights for grids with dynamic reel heights.
 	sticky        utils.UInt8s          // sticky symbol indicators (same size as grid).
 	effects       utils.UInt8s          // special effect indicators (same size as grid).
+	jackpots      utils.UInt8s          // jackpot indicators of a hold-and-win feature (same size as grid).
 	injections    Tiles                 // list of injected symbols.
 	jumps         Tiles                 // list of jumped symbols.
 	nudges        ReelNudges            // list of nudged reels.
@@ -947,14 +1059,17 @@ var spinResultsProducer = pool.NewProducer(func() (pool.Objecter, func()) {
 	r := &SpinResult{
 		initial:       make(utils.Indexes, 0, 24),
 		multipliers:   make([]uint16, 0, 24),
+		coins:         make([]float64, 0, 24),
 		afterNudge:    make(utils.Indexes, 0, 24),
 		afterExpand:   make(utils.Indexes, 0, 24),
 		afterClear:    make(utils.Indexes, 0, 24),
 		afterCascade:  make(utils.Indexes, 0, 24),
 		lockedReels:   make(utils.UInt8s, 0, 8),
 		hotReels:      make(utils.UInt8s, 0, 8),
+		heights:       make(utils.UInt8s, 0, 8),
 		sticky:        make(utils.UInt8s, 0, 24),
 		effects:       make(utils.UInt8s, 0, 24),
+		jackpots:      make(utils.UInt8s, 0, 24),
 		injections:    make(Tiles, 0, 16),
 		jumps:         make(Tiles, 0, 4),
 		nudges:        make(ReelNudges, 0, 2),
@@ -973,6 +1088,8 @@ func (r *SpinResult) reset() {
 		r.maxPayout = false
 		r.bonusBuy = 0
 		r.kind = 0
+		r.respins = 0
+		r.progressive = 0
 		r.transition = 0
 		r.bonusSymbol = utils.NullIndex
 		r.stickySymbol = utils.NullIndex
@@ -989,8 +1106,11 @@ func (r *SpinResult) reset() {
 		r.afterCascade = r.afterCascade[:0]
 		r.lockedReels = r.lockedReels[:0]
 		r.hotReels = r.hotReels[:0]
+		r.heights = r.heights[:0]
 		r.sticky = r.sticky[:0]
 		r.effects = r.effects[:0]
+		r.coins = r.coins[:0]
+		r.jackpots = r.jackpots[:0]
 
 		r.injections = ReleaseTiles(r.injections)
 		r.jumps = ReleaseTiles(r.jumps)
@@ -1011,6 +1131,8 @@ func (r *SpinResult) Equals(other *SpinResult) bool {
 	if r.debug != other.debug ||
 		r.maxPayout != other.maxPayout ||
 		r.kind != other.kind ||
+		r.respins != other.respins ||
+		r.progressive != other.progressive ||
 		r.bonusBuy != other.bonusBuy ||
 		r.transition != other.transition ||
 		r.bonusSymbol != other.bonusSymbol ||
@@ -1034,8 +1156,11 @@ func (r *SpinResult) Equals(other *SpinResult) bool {
 		!reflect.DeepEqual(r.afterCascade, other.afterCascade) ||
 		!reflect.DeepEqual(r.lockedReels, other.lockedReels) ||
 		!reflect.DeepEqual(r.hotReels, other.hotReels) ||
+		!reflect.DeepEqual(r.heights, other.heights) ||
 		!reflect.DeepEqual(r.sticky, other.sticky) ||
 		!reflect.DeepEqual(r.effects, other.effects) ||
+		!reflect.DeepEqual(r.coins, other.coins) ||
+		!reflect.DeepEqual(r.jackpots, other.jackpots) ||
 		!reflect.DeepEqual(r.roundFlags, other.roundFlags) ||
 		!reflect.DeepEqual(r.exportFlags, other.exportFlags) {
 		return false
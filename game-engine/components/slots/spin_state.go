@@ -6,6 +6,7 @@ import (
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/encode/zjson"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/object-pool/pool"
 
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/games/pickem"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/utils"
 )
 
@@ -25,6 +26,12 @@ func AcquireSpinState(spin *Spin) *SpinState {
 	copy(s.indexes, spin.indexes)
 	copy(s.sticky, spin.sticky)
 
+	s.heights = spin.Heights(s.heights)
+
+	if spin.pickem != nil {
+		s.pickem = spin.pickem.DeepCopy()
+	}
+
 	return s
 }
 
@@ -82,6 +89,18 @@ func (s *SpinState) EncodeFields(enc *zjson.Encoder) {
 		}
 		enc.EndArray()
 	}
+
+	if len(s.heights) > 0 {
+		enc.StartArrayField("heights")
+		for ix := range s.heights {
+			enc.Uint64(uint64(s.heights[ix]))
+		}
+		enc.EndArray()
+	}
+
+	if s.pickem != nil {
+		enc.ObjectField("pickem", s.pickem)
+	}
 }
 
 // DecodeField implements the zjson decoder interface.
@@ -106,6 +125,11 @@ func (s *SpinState) DecodeField(dec *zjson.Decoder, key []byte) error {
 		ok = dec.Array(s.decodeIndexes)
 	} else if string(key) == "sticky" {
 		ok = dec.Array(s.decodeSticky)
+	} else if string(key) == "heights" {
+		ok = dec.Array(s.decodeHeights)
+	} else if string(key) == "pickem" {
+		s.pickem = pickem.AcquirePickemResult()
+		ok = dec.Object(s.pickem)
 	}
 
 	if ok {
@@ -130,14 +154,24 @@ func (s *SpinState) decodeSticky(dec *zjson.Decoder) error {
 	return dec.Error()
 }
 
+func (s *SpinState) decodeHeights(dec *zjson.Decoder) error {
+	if i, ok := dec.Uint8(); ok {
+		s.heights = append(s.heights, i)
+		return nil
+	}
+	return dec.Error()
+}
+
 // SpinState is used to keep the state of a spin alive across the session.
 // Keep fields ordered by ascending SizeOf().
 type SpinState struct {
 	stickySymbol utils.Index
 	startGrid    int
 	freeSpins    uint64
+	pickem       *pickem.PickemResult
 	indexes      utils.Indexes
 	sticky       []bool
+	heights      utils.UInt8s
 	pool.Object
 }
 
@@ -148,6 +182,7 @@ var spinStateProducer = pool.NewProducer(func() (pool.Objecter, func()) {
 		stickySymbol: utils.MaxIndex,
 		indexes:      make(utils.Indexes, 0, 16),
 		sticky:       make([]bool, 0, 16),
+		heights:      make(utils.UInt8s, 0, 8),
 	}
 	return s, s.reset
 })
@@ -160,5 +195,11 @@ func (s *SpinState) reset() {
 		s.freeSpins = 0
 		s.indexes = s.indexes[:0]
 		s.sticky = s.sticky[:0]
+		s.heights = s.heights[:0]
+
+		if s.pickem != nil {
+			s.pickem.Release()
+			s.pickem = nil
+		}
 	}
 }
@@ -1090,3 +1090,93 @@ func BenchmarkSpin_Result6x4Both(b *testing.B) {
 		s.Spin()
 	}
 }
+
+func TestSpin_ReelHeights(t *testing.T) {
+	prng := rng.NewRNG()
+	defer prng.ReturnToPool()
+
+	heights := make([]utils.WeightedGenerator, 6)
+	for ix := range heights {
+		heights[ix] = utils.AcquireWeighting().AddWeights(utils.Indexes{2, 3, 4, 5, 6, 7}, []float64{10, 20, 30, 20, 10, 10})
+	}
+
+	slots := NewSlots(Grid(6, 7), WithSymbols(setF1), WithReelHeights(heights...))
+
+	spin := AcquireSpin(slots, prng)
+	defer spin.Release()
+
+	t.Run("spin", func(t *testing.T) {
+		seen := make(map[uint8]bool)
+		for ix := 0; ix < 100; ix++ {
+			spin.Spin()
+
+			var offset int
+			for reel := 0; reel < 6; reel++ {
+				h := spin.ReelSize(uint8(reel))
+				require.GreaterOrEqual(t, h, 2)
+				require.LessOrEqual(t, h, 7)
+				seen[uint8(h)] = true
+
+				for row := 0; row < 7; row++ {
+					assert.Equal(t, row < h, spin.indexes[offset+row] != 0)
+					assert.Equal(t, row < h, spin.IsValidOffset(offset+row))
+				}
+				offset += 7
+			}
+
+			r := AcquireSpinResult(spin)
+			assert.Equal(t, spin.ReelHeights(), r.Heights())
+			r.Release()
+		}
+		assert.Equal(t, 6, len(seen))
+		assert.Equal(t, utils.UInt8s{7, 7, 7, 7, 7, 7}, slots.GridDefinition().GridMask())
+	})
+
+	t.Run("locked reels", func(t *testing.T) {
+		spin.Spin()
+		h := spin.ReelSize(2)
+		reel := utils.CopyIndexes(spin.indexes[14:21], nil)
+
+		for ix := 0; ix < 20; ix++ {
+			spin.Spin(3)
+			assert.Equal(t, h, spin.ReelSize(2))
+			assert.Equal(t, reel, spin.indexes[14:21])
+		}
+	})
+
+	t.Run("set heights", func(t *testing.T) {
+		spin.Spin()
+		spin.SetReelHeights(7, 2, 7, 7, 7, 7)
+		assert.Equal(t, utils.UInt8s{7, 2, 7, 7, 7, 7}, spin.ReelHeights())
+		assert.Equal(t, utils.Indexes{0, 0, 0, 0, 0}, spin.indexes[9:14])
+	})
+
+	t.Run("debug", func(t *testing.T) {
+		indexes := utils.Indexes{
+			1, 2, 0, 0, 0, 0, 0,
+			1, 2, 3, 0, 0, 0, 0,
+			1, 2, 3, 4, 5, 6, 1,
+			1, 2, 3, 4, 0, 0, 0,
+			1, 2, 3, 4, 5, 0, 0,
+			1, 2, 3, 4, 5, 6, 0,
+		}
+		spin.Debug(indexes)
+		assert.Equal(t, utils.UInt8s{2, 3, 7, 4, 5, 6}, spin.ReelHeights())
+
+		n := spin.Neighbors(8)
+		assert.Equal(t, 8, len(n))
+		_, ok := n[2]
+		assert.False(t, ok)
+	})
+
+	t.Run("state", func(t *testing.T) {
+		spin.Spin()
+		state := AcquireSpinState(spin)
+		defer state.Release()
+		want := utils.CopyUInt8s(spin.ReelHeights(), nil)
+
+		spin.SetReelHeights(1, 1, 1, 1, 1, 1)
+		spin.RestoreState(state)
+		assert.Equal(t, want, spin.ReelHeights())
+	})
+}
@@ -2,6 +2,7 @@ package slots
 
 import (
 	"math"
+	"strings"
 
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/encode/zjson"
 
@@ -43,6 +44,8 @@ const (
 	WildShooter
 	// ScatterShooter is a scatter and shooter symbol.
 	ScatterShooter
+	// endSymbolKinds marks the end of the list; always add new kinds above it!
+	endSymbolKinds
 )
 
 // String implements the Stringer interface.
@@ -79,6 +82,17 @@ func (k SymbolKind) String() string {
 	}
 }
 
+// ParseSymbolKind returns the symbol kind with the given name, as returned by String.
+// The name is not case-sensitive. It returns false if there is no such kind.
+func ParseSymbolKind(name string) (SymbolKind, bool) {
+	for k := Standard; k < endSymbolKinds; k++ {
+		if strings.EqualFold(k.String(), name) {
+			return k, true
+		}
+	}
+	return 0, false
+}
+
 func (k SymbolKind) isSplit() bool {
 	return k == Split
 }
@@ -259,11 +273,26 @@ func (s *Symbol) WildFor(index utils.Index) bool {
 	return s.wildFor.Contains(index)
 }
 
+// WildForSymbols returns the symbol indexes the split symbol can substitute for.
+func (s *Symbol) WildForSymbols() utils.Indexes {
+	return s.wildFor
+}
+
 // Multiplier returns the multiplier for the symbol.
 func (s *Symbol) Multiplier() float64 {
 	return s.multiplier
 }
 
+// MorphInto returns the symbol this symbol may morph into when it lands in the grid.
+func (s *Symbol) MorphInto() utils.Index {
+	return s.morphInto
+}
+
+// ClearPattern returns the pattern for a bomb symbol "explosion".
+func (s *Symbol) ClearPattern() []int {
+	return s.clearPattern
+}
+
 // IsEmpty implements the zjson.Encoder interface.
 func (s *Symbol) IsEmpty() bool {
 	return false
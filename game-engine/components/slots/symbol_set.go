@@ -35,11 +35,21 @@ func (s *SymbolSet) SetBonusWeights(weighting utils.WeightedGenerator) *SymbolSe
 	return s
 }
 
+// BonusWeights returns the weighting for selecting a bonus symbol.
+func (s *SymbolSet) BonusWeights() utils.WeightedGenerator {
+	return s.bonusWeights
+}
+
 // GetBonusSymbol returns a random bonus symbol using the bonus symbol weighting with the given PRNG.
 func (s *SymbolSet) GetBonusSymbol(prng interfaces.Generator) utils.Index {
 	return s.bonusWeights.RandomIndex(prng)
 }
 
+// Symbols returns the symbols in the order they were added to the set.
+func (s *SymbolSet) Symbols() Symbols {
+	return s.symbols
+}
+
 // GetSymbol returns the symbol matching the given index or nil if it doesn't exist.
 func (s *SymbolSet) GetSymbol(index utils.Index) *Symbol {
 	if index > s.maxID {
@@ -206,6 +206,7 @@ func TestSymbolSet_BonusWeights(t *testing.T) {
 		w.AddWeight(h1.id, 10)
 
 		s.SetBonusWeights(w)
+		assert.Equal(t, w, s.BonusWeights())
 
 		counts := make(map[utils.Index]int)
 		for ix := 0; ix < 10000; ix++ {
@@ -211,6 +211,7 @@ func TestNewSymbol(t *testing.T) {
 			assert.Equal(t, tc.varyMult, s.VaryMultiplier())
 			assert.Equal(t, tc.multiplier, s.Multiplier())
 			assert.Equal(t, tc.morphInto, s.morphInto)
+			assert.Equal(t, tc.morphInto, s.MorphInto())
 
 			if len(tc.weights) > 0 {
 				assert.EqualValues(t, tc.weights, s.Weights())
@@ -241,6 +242,7 @@ func TestNewSymbol(t *testing.T) {
 				for _, f := range tc.wildFor {
 					assert.True(t, s.WildFor(f))
 				}
+				assert.EqualValues(t, tc.wildFor, s.WildForSymbols())
 				assert.False(t, s.WildFor(99))
 			}
 		})
@@ -256,3 +258,18 @@ func TestNewSymbolFail(t *testing.T) {
 		NewSymbol(MaxSymbolID + 1)
 	})
 }
+
+func TestParseSymbolKind(t *testing.T) {
+	for k := Standard; k < endSymbolKinds; k++ {
+		got, ok := ParseSymbolKind(k.String())
+		assert.True(t, ok, k.String())
+		assert.Equal(t, k, got)
+	}
+
+	got, ok := ParseSymbolKind("wild-scatter")
+	assert.True(t, ok)
+	assert.Equal(t, WildScatter, got)
+
+	_, ok = ParseSymbolKind("[unknown]")
+	assert.False(t, ok)
+}
@@ -6,6 +6,7 @@ const (
 	MsgDuplicateSymbolIndex      = "duplicate symbol index"
 	MsgInvalidGridSize           = "invalid grid size"
 	MsgInvalidGridMask           = "invalid grid mask"
+	MsgInvalidReelHeights        = "invalid reel heights"
 	MsgNoSymbolsSlots            = "no symbols/slots defined for slot machine"
 	MsgInvalidSymbolID           = "invalid symbol id (max = 99)"
 	MsgInvalidSymbolCount        = "invalid symbol count"
@@ -14,4 +15,6 @@ const (
 	MsgAnalysisNonMatchingRounds = "trying to merge rounds with non-matching game configurations"
 	MsgInvalidDeduplication      = "Invalid deduplication action"
 	MsgInvalidJumpParameters     = "Invalid jump parameters"
+	MsgInvalidHoldAndWin         = "invalid hold-and-win parameters"
+	MsgInvalidProgressive        = "invalid progressive jackpot parameters"
 )
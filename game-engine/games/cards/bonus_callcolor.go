@@ -12,7 +12,11 @@ import (
 
 // AcquireBonusCallColor instantiates a bonus "call color" game from the memory pool.
 func AcquireBonusCallColor() *BonusCallColor {
-	return bonusCallColorPool.Acquire().(*BonusCallColor)
+	b := bonusCallColorPool.Acquire().(*BonusCallColor)
+	if b.deck == nil {
+		b.deck = cards.NewDeck(cards.StandardDeck())
+	}
+	return b
 }
 
 // RequireParams implements the BonusRunner interface.
@@ -37,6 +41,7 @@ func (b *BonusCallColor) Run(_ *results.Result, params ...interface{}) (int, *re
 		}
 	}
 
+	b.deck.Shuffle()
 	if cut > 0 {
 		b.deck.Cut(cut)
 	}
@@ -45,7 +50,8 @@ func (b *BonusCallColor) Run(_ *results.Result, params ...interface{}) (int, *re
 	data := bonusCallColorDataPool.Acquire().(*BonusCallColorData)
 	data.Choice = choice
 	data.Cut = cut
-	data.Card = card
+	data.Card = cards.NewCard(card.ID())
+	data.cardOwned = true
 
 	var payout float64
 	if card.Color() == choice {
@@ -53,7 +59,6 @@ func (b *BonusCallColor) Run(_ *results.Result, params ...interface{}) (int, *re
 	}
 
 	p := results.AcquirePlayerChoice(payout)
-	defer p.Release()
 	return int(math.Round(payout * 100)), results.AcquireResult(data, results.BonusCallColorData, p)
 }
 
@@ -67,7 +72,7 @@ type BonusCallColor struct {
 
 var bonusCallColorPool = pool.NewProducer(func() (pool.Objecter, func()) {
 	b := &BonusCallColor{
-		deck: cards.NewDeck(cards.StandardDeck(), cards.Shuffled()),
+		deck: cards.NewDeck(cards.StandardDeck()),
 	}
 	return b, b.reset
 })
@@ -80,6 +85,11 @@ func (b *BonusCallColor) reset() {
 	}
 }
 
+// AcquireBonusCallColorData instantiates empty bonus data from the memory pool, e.g. for decoding stored results.
+func AcquireBonusCallColorData() *BonusCallColorData {
+	return bonusCallColorDataPool.Acquire().(*BonusCallColorData)
+}
+
 // BonusCallColorData represents the details of a bonus "call color" game.
 type BonusCallColorData struct {
 	cardOwned bool
@@ -12,7 +12,11 @@ import (
 
 // AcquireBonusCallSuit instantiates a bonus "call suit" game from the memory pool.
 func AcquireBonusCallSuit() *BonusCallSuit {
-	return bonusCallSuitPool.Acquire().(*BonusCallSuit)
+	b := bonusCallSuitPool.Acquire().(*BonusCallSuit)
+	if b.deck == nil {
+		b.deck = cards.NewDeck(cards.StandardDeck())
+	}
+	return b
 }
 
 // RequireParams implements the BonusRunner interface.
@@ -44,6 +48,7 @@ func (b *BonusCallSuit) Run(_ *results.Result, params ...interface{}) (int, *res
 		}
 	}
 
+	b.deck.Shuffle()
 	if cut > 0 {
 		b.deck.Cut(cut)
 	}
@@ -52,7 +57,8 @@ func (b *BonusCallSuit) Run(_ *results.Result, params ...interface{}) (int, *res
 	data := bonusCallSuitDataPool.Acquire().(*BonusCallSuitData)
 	data.Choice = choice
 	data.Cut = cut
-	data.Card = card
+	data.Card = cards.NewCard(card.ID())
+	data.cardOwned = true
 
 	var payout float64
 	if card.Suit() == choice {
@@ -60,7 +66,6 @@ func (b *BonusCallSuit) Run(_ *results.Result, params ...interface{}) (int, *res
 	}
 
 	p := results.AcquirePlayerChoice(payout)
-	defer p.Release()
 	return int(math.Round(payout * 100)), results.AcquireResult(data, results.BonusCallSuitData, p)
 }
 
@@ -74,7 +79,7 @@ type BonusCallSuit struct {
 
 var bonusCallSuitPool = pool.NewProducer(func() (pool.Objecter, func()) {
 	b := &BonusCallSuit{
-		deck: cards.NewDeck(cards.StandardDeck(), cards.Shuffled()),
+		deck: cards.NewDeck(cards.StandardDeck()),
 	}
 	return b, b.reset
 })
@@ -87,6 +92,11 @@ func (b *BonusCallSuit) reset() {
 	}
 }
 
+// AcquireBonusCallSuitData instantiates empty bonus data from the memory pool, e.g. for decoding stored results.
+func AcquireBonusCallSuitData() *BonusCallSuitData {
+	return bonusCallSuitDataPool.Acquire().(*BonusCallSuitData)
+}
+
 // BonusCallSuitData represents the details of a bonus "call suit" game.
 type BonusCallSuitData struct {
 	cardOwned bool
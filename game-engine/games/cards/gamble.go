@@ -0,0 +1,86 @@
+package cards
+
+import (
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/results"
+)
+
+// GambleKind represents the choice of a player for the gamble (double-up) stage after a winning round.
+type GambleKind uint8
+
+// List of gamble kinds.
+// Always add new elements after the end of the list as FE depends on the values!
+const (
+	// GambleCollect ends the gamble stage and collects the win.
+	GambleCollect GambleKind = iota
+	// GambleColor gambles the win on the color of a card (x2).
+	GambleColor
+	// GambleSuit gambles the win on the suit of a card (x4).
+	GambleSuit
+)
+
+// String implements the Stringer interface.
+func (k GambleKind) String() string {
+	switch k {
+	case GambleCollect:
+		return "collect"
+	case GambleColor:
+		return "color"
+	case GambleSuit:
+		return "suit"
+	default:
+		return "[unknown]"
+	}
+}
+
+// Multiplier returns the multiplier for the stake if the gamble is won.
+// It returns 0 if the kind is not a gamble.
+func (k GambleKind) Multiplier() float64 {
+	switch k {
+	case GambleColor:
+		return 2
+	case GambleSuit:
+		return 4
+	default:
+		return 0
+	}
+}
+
+// Gamble plays a single step of a gamble on the win of a round.
+// The stake is the win at risk, expressed as a factor of the bet of the round.
+// The pick is the players choice of color or suit, as for BonusCallColor and BonusCallSuit.
+// The returned result contains the details of the drawn card, and a single player choice payout for the change of
+// the stake; the gain if the gamble was won, or minus the stake if it was lost. This way the grand total of the
+// round results, including the gamble steps, equals the win of the round.
+// The function returns nil if the kind is not a gamble.
+func Gamble(kind GambleKind, stake float64, pick int) (bool, *results.Result) {
+	var payout int
+	var result *results.Result
+
+	switch kind {
+	case GambleColor:
+		b := AcquireBonusCallColor()
+		payout, result = b.Run(nil, pick)
+		b.Release()
+	case GambleSuit:
+		b := AcquireBonusCallSuit()
+		payout, result = b.Run(nil, pick)
+		b.Release()
+	default:
+		return false, nil
+	}
+
+	won := payout > 0
+	change := -stake
+	if won {
+		change = stake * (kind.Multiplier() - 1)
+	}
+
+	result.ReleasePayouts()
+	result.AddPayouts(results.AcquirePlayerChoice(change))
+	return won, result
+}
+
+// IsGamble returns true if the result is a step of a gamble.
+func IsGamble(result *results.Result) bool {
+	return result != nil && (result.DataKind == results.BonusCallColorData || result.DataKind == results.BonusCallSuitData)
+}
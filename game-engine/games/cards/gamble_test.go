@@ -0,0 +1,89 @@
+package cards
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/components/cards"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/results"
+)
+
+func TestGambleKind(t *testing.T) {
+	testCases := []struct {
+		kind       GambleKind
+		name       string
+		multiplier float64
+	}{
+		{kind: GambleCollect, name: "collect"},
+		{kind: GambleColor, name: "color", multiplier: 2},
+		{kind: GambleSuit, name: "suit", multiplier: 4},
+		{kind: GambleKind(99), name: "[unknown]"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.name, tc.kind.String())
+			assert.Equal(t, tc.multiplier, tc.kind.Multiplier())
+		})
+	}
+}
+
+func TestGamble(t *testing.T) {
+	t.Run("collect", func(t *testing.T) {
+		won, res := Gamble(GambleCollect, 1, 0)
+		assert.False(t, won)
+		assert.Nil(t, res)
+	})
+
+	t.Run("color", func(t *testing.T) {
+		var wins int
+		for ix := 0; ix < 1000; ix++ {
+			won, res := Gamble(GambleColor, 1.5, int(cards.Black))
+			require.NotNil(t, res)
+			assert.True(t, IsGamble(res))
+			assert.Equal(t, results.BonusCallColorData, res.DataKind)
+			require.Len(t, res.Payouts, 1)
+
+			data, ok := res.Data.(*BonusCallColorData)
+			require.True(t, ok)
+			require.NotNil(t, data.Card)
+			assert.Equal(t, cards.Black, data.Choice)
+			assert.Equal(t, data.Card.Color() == cards.Black, won)
+
+			if won {
+				wins++
+				assert.Equal(t, 1.5, res.Total)
+			} else {
+				assert.Equal(t, -1.5, res.Total)
+			}
+			res.Release()
+		}
+		assert.Greater(t, wins, 400)
+		assert.Less(t, wins, 600)
+	})
+
+	t.Run("suit", func(t *testing.T) {
+		var wins int
+		for ix := 0; ix < 1000; ix++ {
+			won, res := Gamble(GambleSuit, 2, int(cards.Hearts))
+			require.NotNil(t, res)
+			assert.Equal(t, results.BonusCallSuitData, res.DataKind)
+
+			data, ok := res.Data.(*BonusCallSuitData)
+			require.True(t, ok)
+			assert.Equal(t, data.Card.Suit() == cards.Hearts, won)
+
+			if won {
+				wins++
+				assert.Equal(t, 6.0, res.Total)
+			} else {
+				assert.Equal(t, -2.0, res.Total)
+			}
+			res.Release()
+		}
+		assert.Greater(t, wins, 150)
+		assert.Less(t, wins, 350)
+	})
+}
@@ -0,0 +1,507 @@
+package pickem
+
+import (
+	"fmt"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/encode/zjson"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/object-pool/pool"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/interfaces"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/results"
+)
+
+// TileKind represents the content of a tile on a pick'em board.
+type TileKind uint8
+
+// List of tile kinds.
+// Always add new elements after the end of the list as FE depends on the values!
+const (
+	// Prize is a tile with a cash prize, expressed as a factor of the bet.
+	Prize TileKind = iota + 1
+	// Multiplier is a tile which multiplies the prizes collected during the game.
+	Multiplier
+	// Advance is a tile which ends the current level and advances to the next level.
+	Advance
+	// Collect is a tile which ends the game.
+	Collect
+)
+
+// String implements the Stringer interface.
+func (k TileKind) String() string {
+	switch k {
+	case Prize:
+		return "prize"
+	case Multiplier:
+		return "multiplier"
+	case Advance:
+		return "advance"
+	case Collect:
+		return "collect"
+	default:
+		return "[unknown]"
+	}
+}
+
+// Tile represents a single tile on a pick'em board.
+type Tile struct {
+	Kind  TileKind
+	Value float64 // the prize factor or the multiplier; unused for the other kinds.
+}
+
+// PrizeTile returns a tile with a cash prize.
+func PrizeTile(factor float64) Tile {
+	return Tile{Kind: Prize, Value: factor}
+}
+
+// MultiplierTile returns a tile with a multiplier.
+func MultiplierTile(multiplier float64) Tile {
+	return Tile{Kind: Multiplier, Value: multiplier}
+}
+
+// AdvanceTile returns a tile which advances the game to the next level.
+func AdvanceTile() Tile {
+	return Tile{Kind: Advance}
+}
+
+// CollectTile returns a tile which ends the game.
+func CollectTile() Tile {
+	return Tile{Kind: Collect}
+}
+
+func (t *Tile) encode(enc *zjson.Encoder) {
+	enc.Uint8Field("kind", uint8(t.Kind))
+	enc.FloatFieldOpt("value", t.Value, 'g', -1)
+}
+
+// DecodeField implements the zjson.DecodeField interface.
+func (t *Tile) DecodeField(dec *zjson.Decoder, key []byte) error {
+	var ok bool
+	if string(key) == "kind" {
+		var i uint8
+		if i, ok = dec.Uint8(); ok {
+			t.Kind = TileKind(i)
+		}
+	} else if string(key) == "value" {
+		t.Value, ok = dec.Float()
+	} else {
+		return fmt.Errorf("Tile: invalid field [%s]", key)
+	}
+
+	if ok {
+		return nil
+	}
+	return dec.Error()
+}
+
+// Level contains the tiles for a level of a pick'em game.
+// The tiles are shuffled onto the board when the game starts.
+type Level []Tile
+
+// Pick represents a tile revealed by the player.
+type Pick struct {
+	Level    uint8 // the level of the board.
+	Position uint8 // the position of the tile within the level.
+	Tile           // the revealed tile.
+}
+
+func (p *Pick) encode(enc *zjson.Encoder) {
+	enc.Uint8Field("level", p.Level)
+	enc.Uint8Field("position", p.Position)
+	p.Tile.encode(enc)
+}
+
+// DecodeField implements the zjson.DecodeField interface.
+func (p *Pick) DecodeField(dec *zjson.Decoder, key []byte) error {
+	var ok bool
+	if string(key) == "level" {
+		p.Level, ok = dec.Uint8()
+	} else if string(key) == "position" {
+		p.Position, ok = dec.Uint8()
+	} else {
+		return p.Tile.DecodeField(dec, key)
+	}
+
+	if ok {
+		return nil
+	}
+	return dec.Error()
+}
+
+// AcquireBonusPickem instantiates a new pick'em bonus game with the given levels.
+func AcquireBonusPickem(prng interfaces.Generator, levels ...Level) *BonusPickem {
+	b := bonusPickemProducer.Acquire().(*BonusPickem)
+	b.prng = prng
+	b.levels = levels
+	return b
+}
+
+// RequireParams implements the BonusRunner interface.
+func (b *BonusPickem) RequireParams() bool {
+	return true
+}
+
+// Run implements the BonusRunner interface.
+// It lays out a new board and plays the game to the end.
+// The parameters can be the positions (int) of the tiles picked by the player, in order.
+// An invalid position, or a missing one, picks the first hidden tile of the current level instead.
+// The function returns the number of levels reached.
+func (b *BonusPickem) Run(_ *results.Result, params ...interface{}) (int, interfaces.Objecter2) {
+	result := b.Start()
+
+	for ix := 0; !result.Done(); ix++ {
+		pick := -1
+		if ix < len(params) {
+			if n, ok := params[ix].(int); ok {
+				pick = n
+			}
+		}
+		result.PickOrFirst(pick)
+	}
+
+	return result.LevelsReached(), result
+}
+
+// Start lays out a new board and returns it as the initial result of the game.
+// The outcomes of all levels are determined here, so the picks of the player only reveal them.
+// Levels without tiles are skipped.
+func (b *BonusPickem) Start() *PickemResult {
+	r := pickemResultProducer.Acquire().(*PickemResult)
+
+	for ix := range b.levels {
+		if len(b.levels[ix]) == 0 {
+			continue
+		}
+
+		level := append(make(Level, 0, len(b.levels[ix])), b.levels[ix]...)
+		for iy := len(level) - 1; iy > 0; iy-- {
+			iz := b.prng.IntN(iy + 1)
+			level[iy], level[iz] = level[iz], level[iy]
+		}
+		r.board = append(r.board, level)
+	}
+
+	r.done = len(r.board) == 0
+	return r
+}
+
+// BonusPickem is a bonus game where the player reveals the tiles of a board one at a time.
+type BonusPickem struct {
+	levels []Level
+	prng   interfaces.Generator
+	pool.Object
+}
+
+// bonusPickemProducer is the memory pool for pick'em bonus games.
+var bonusPickemProducer = pool.NewProducer(func() (pool.Objecter, func()) {
+	b := &BonusPickem{}
+	return b, b.reset
+})
+
+// reset clears the pick'em bonus game.
+func (b *BonusPickem) reset() {
+	if b != nil {
+		b.prng = nil
+		b.levels = nil
+	}
+}
+
+// AcquirePickemResult instantiates an empty pick'em result, e.g. for decoding stored results.
+func AcquirePickemResult() *PickemResult {
+	return pickemResultProducer.Acquire().(*PickemResult)
+}
+
+// DeepCopy returns a deep copy of the board, the picks and the player choices.
+// The PRNG log is not copied.
+func (r *PickemResult) DeepCopy() *PickemResult {
+	n := pickemResultProducer.Acquire().(*PickemResult)
+	n.done = r.done
+	n.level = r.level
+	n.multiplier = r.multiplier
+	n.prizes = r.prizes
+	for ix := range r.board {
+		n.board = append(n.board, append(make(Level, 0, len(r.board[ix])), r.board[ix]...))
+	}
+	n.picks = append(n.picks, r.picks...)
+
+	var choices map[string]string
+	if c := r.Choices(); c != nil {
+		choices = make(map[string]string, len(c))
+		for k, v := range c {
+			choices[k] = v
+		}
+	}
+	n.SetChoices(choices)
+	return n
+}
+
+// Done returns true if the game has ended.
+func (r *PickemResult) Done() bool {
+	return r.done
+}
+
+// Level returns the current level (0-based).
+func (r *PickemResult) Level() int {
+	return int(r.level)
+}
+
+// LevelsReached returns the number of levels reached so far.
+func (r *PickemResult) LevelsReached() int {
+	if len(r.board) == 0 {
+		return 0
+	}
+	return int(r.level) + 1
+}
+
+// Board returns the tiles of all levels of the board.
+func (r *PickemResult) Board() []Level {
+	return r.board
+}
+
+// Picks returns the tiles revealed so far, in order.
+func (r *PickemResult) Picks() []Pick {
+	return r.picks
+}
+
+// Prizes returns the sum of the prizes collected so far.
+func (r *PickemResult) Prizes() float64 {
+	return r.prizes
+}
+
+// Multiplier returns the product of the multipliers collected so far.
+func (r *PickemResult) Multiplier() float64 {
+	return r.multiplier
+}
+
+// Total returns the total payout factor of the prizes and multipliers collected so far.
+func (r *PickemResult) Total() float64 {
+	return r.prizes * r.multiplier
+}
+
+// Revealed returns true if the tile at the given position of the level has been picked.
+func (r *PickemResult) Revealed(level, position int) bool {
+	for ix := range r.picks {
+		if p := r.picks[ix]; int(p.Level) == level && int(p.Position) == position {
+			return true
+		}
+	}
+	return false
+}
+
+// Pick reveals the tile at the given position (0-based) of the current level.
+// It returns false if the game has ended, or if the position is invalid or was picked before.
+//
+// A prize tile adds its prize, and a multiplier tile multiplies the multiplier of the game.
+// An advance tile moves the game to the next level, or ends it on the last level.
+// A collect tile ends the game, as does picking the last hidden tile of a level.
+func (r *PickemResult) Pick(position int) (Tile, bool) {
+	if r.done {
+		return Tile{}, false
+	}
+
+	level := r.board[r.level]
+	if position < 0 || position >= len(level) || r.Revealed(int(r.level), position) {
+		return Tile{}, false
+	}
+
+	tile := level[position]
+	r.picks = append(r.picks, Pick{Level: r.level, Position: uint8(position), Tile: tile})
+
+	switch tile.Kind {
+	case Prize:
+		r.prizes += tile.Value
+	case Multiplier:
+		r.multiplier *= tile.Value
+	case Advance:
+		if int(r.level)+1 < len(r.board) {
+			r.level++
+			return tile, true
+		}
+		r.done = true
+	case Collect:
+		r.done = true
+	}
+
+	if !r.done && r.hidden() == 0 {
+		r.done = true
+	}
+	return tile, true
+}
+
+// PickOrFirst reveals the tile at the given position (0-based) of the current level.
+// If the position is invalid, the first hidden tile of the current level is revealed instead.
+// It returns false if the game has ended.
+func (r *PickemResult) PickOrFirst(position int) (Tile, bool) {
+	if tile, ok := r.Pick(position); ok || r.done {
+		return tile, ok
+	}
+
+	for ix := range r.board[r.level] {
+		if !r.Revealed(int(r.level), ix) {
+			return r.Pick(ix)
+		}
+	}
+	return Tile{}, false
+}
+
+// hidden returns the number of hidden tiles on the current level.
+func (r *PickemResult) hidden() int {
+	n := len(r.board[r.level])
+	for ix := range r.picks {
+		if r.picks[ix].Level == r.level {
+			n--
+		}
+	}
+	return n
+}
+
+// EncodeFields implements the zjson.Encoder interface.
+func (r *PickemResult) EncodeFields(enc *zjson.Encoder) {
+	r.encode(enc, true)
+}
+
+// Encode2 implements the Objecter2 interface.
+// The hidden tiles are only revealed after the game has ended.
+func (r *PickemResult) Encode2(enc *zjson.Encoder) {
+	r.encode(enc, false)
+}
+
+func (r *PickemResult) encode(enc *zjson.Encoder, withLog bool) {
+	enc.IntBoolFieldOpt("done", r.done)
+	enc.Uint8FieldOpt("level", r.level)
+	enc.FloatFieldOpt("prizes", r.prizes, 'g', -1)
+	enc.FloatField("multiplier", r.multiplier, 'g', -1)
+
+	if withLog || r.done {
+		enc.StartArrayField("board")
+		for ix := range r.board {
+			enc.StartArray()
+			for iy := range r.board[ix] {
+				enc.StartObject()
+				r.board[ix][iy].encode(enc)
+				enc.EndObject()
+			}
+			enc.EndArray()
+		}
+		enc.EndArray()
+	} else {
+		enc.StartArrayField("sizes")
+		for ix := range r.board {
+			enc.Uint64(uint64(len(r.board[ix])))
+		}
+		enc.EndArray()
+	}
+
+	if len(r.picks) > 0 {
+		enc.StartArrayField("picks")
+		for ix := range r.picks {
+			enc.StartObject()
+			r.picks[ix].encode(enc)
+			enc.EndObject()
+		}
+		enc.EndArray()
+	}
+
+	r.PlayerChoices.EncodeChoices(enc)
+	if withLog {
+		r.PrngLog.EncodeEventLog(enc)
+	}
+}
+
+// DecodeField implements the zjson.DecodeField interface.
+func (r *PickemResult) DecodeField(dec *zjson.Decoder, key []byte) error {
+	var ok bool
+	if string(key) == "done" {
+		r.done, ok = dec.IntBool()
+	} else if string(key) == "level" {
+		r.level, ok = dec.Uint8()
+	} else if string(key) == "prizes" {
+		r.prizes, ok = dec.Float()
+	} else if string(key) == "multiplier" {
+		r.multiplier, ok = dec.Float()
+	} else if string(key) == "board" {
+		ok = dec.Array(r.decodeLevel)
+	} else if string(key) == "picks" {
+		ok = dec.Array(r.decodePick)
+	} else if string(key) == "playerChoices" {
+		ok = r.PlayerChoices.DecodeChoices(dec)
+	} else if string(key) == "events" {
+		ok = dec.Array(r.PrngLog.DecodeEventLog)
+	} else if string(key) == "rngIn" {
+		ok = dec.Array(r.PrngLog.DecodeRngIn)
+	} else if string(key) == "rngOut" {
+		ok = dec.Array(r.PrngLog.DecodeRngOut)
+	} else {
+		return fmt.Errorf("PickemResult: invalid field [%s]", key)
+	}
+
+	if ok {
+		return nil
+	}
+	return dec.Error()
+}
+
+func (r *PickemResult) decodeLevel(dec *zjson.Decoder) error {
+	r.board = append(r.board, make(Level, 0, 16))
+	if dec.Array(r.decodeTile) {
+		return nil
+	}
+	return dec.Error()
+}
+
+func (r *PickemResult) decodeTile(dec *zjson.Decoder) error {
+	var t Tile
+	if dec.Object(&t) {
+		l := len(r.board) - 1
+		r.board[l] = append(r.board[l], t)
+		return nil
+	}
+	return dec.Error()
+}
+
+func (r *PickemResult) decodePick(dec *zjson.Decoder) error {
+	var p Pick
+	if dec.Object(&p) {
+		r.picks = append(r.picks, p)
+		return nil
+	}
+	return dec.Error()
+}
+
+// PickemResult represents the state and result of a pick'em bonus game.
+type PickemResult struct {
+	done       bool
+	level      uint8
+	multiplier float64
+	prizes     float64
+	board      []Level
+	picks      []Pick
+	results.PlayerChoices
+	results.PrngLog
+	pool.Object
+}
+
+// pickemResultProducer is the memory pool for pick'em results.
+var pickemResultProducer = pool.NewProducer(func() (pool.Objecter, func()) {
+	r := &PickemResult{
+		multiplier: 1,
+		board:      make([]Level, 0, 4),
+		picks:      make([]Pick, 0, 16),
+	}
+	r.PrngLog.Initialize()
+	return r, r.reset
+})
+
+// reset clears the pick'em result.
+func (r *PickemResult) reset() {
+	if r != nil {
+		r.done = false
+		r.level = 0
+		r.multiplier = 1
+		r.prizes = 0
+		clear(r.board)
+		r.board = r.board[:0]
+		r.picks = r.picks[:0]
+		r.PlayerChoices.Reset()
+		r.PrngLog.Reset()
+	}
+}
@@ -0,0 +1,308 @@
+package pickem
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/encode/zjson"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/prng.git/rng"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	level1 = Level{PrizeTile(1), PrizeTile(2), PrizeTile(5), MultiplierTile(2), AdvanceTile(), CollectTile()}
+	level2 = Level{PrizeTile(10), PrizeTile(20), MultiplierTile(3), CollectTile()}
+)
+
+func TestTileKind(t *testing.T) {
+	testCases := []struct {
+		kind TileKind
+		name string
+	}{
+		{kind: Prize, name: "prize"},
+		{kind: Multiplier, name: "multiplier"},
+		{kind: Advance, name: "advance"},
+		{kind: Collect, name: "collect"},
+		{kind: TileKind(99), name: "[unknown]"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.name, tc.kind.String())
+		})
+	}
+}
+
+func TestBonusPickem_Start(t *testing.T) {
+	prng := rng.NewRNG()
+	defer prng.ReturnToPool()
+
+	b := AcquireBonusPickem(prng, level1, nil, level2)
+	require.NotNil(t, b)
+	defer b.Release()
+	assert.True(t, b.RequireParams())
+
+	counts := make(map[Tile]int, 8)
+	for ix := 0; ix < 1000; ix++ {
+		r := b.Start()
+		require.NotNil(t, r)
+
+		assert.False(t, r.Done())
+		assert.Zero(t, r.Level())
+		assert.Equal(t, 1, r.LevelsReached())
+		assert.Empty(t, r.Picks())
+		assert.Equal(t, 1.0, r.Multiplier())
+		require.Len(t, r.Board(), 2)
+
+		assert.ElementsMatch(t, level1, r.Board()[0])
+		assert.ElementsMatch(t, level2, r.Board()[1])
+		counts[r.Board()[0][0]]++
+
+		r.Release()
+	}
+
+	// every tile must be able to end up in the first position.
+	for _, tile := range level1 {
+		assert.NotZero(t, counts[tile])
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		b2 := AcquireBonusPickem(prng)
+		defer b2.Release()
+
+		r := b2.Start()
+		defer r.Release()
+		assert.True(t, r.Done())
+		assert.Zero(t, r.LevelsReached())
+	})
+}
+
+func TestPickemResult_Pick(t *testing.T) {
+	newResult := func(levels ...Level) *PickemResult {
+		r := AcquirePickemResult()
+		for ix := range levels {
+			r.board = append(r.board, append(Level{}, levels[ix]...))
+		}
+		return r
+	}
+
+	t.Run("prizes and collect", func(t *testing.T) {
+		r := newResult(level1, level2)
+		defer r.Release()
+
+		tile, ok := r.Pick(1)
+		assert.True(t, ok)
+		assert.Equal(t, PrizeTile(2), tile)
+		assert.True(t, r.Revealed(0, 1))
+
+		_, ok = r.Pick(1)
+		assert.False(t, ok)
+		_, ok = r.Pick(6)
+		assert.False(t, ok)
+		_, ok = r.Pick(-1)
+		assert.False(t, ok)
+
+		r.Pick(2)
+		r.Pick(3)
+		assert.Equal(t, 7.0, r.Prizes())
+		assert.Equal(t, 2.0, r.Multiplier())
+		assert.Equal(t, 14.0, r.Total())
+		assert.False(t, r.Done())
+
+		tile, ok = r.Pick(5)
+		assert.True(t, ok)
+		assert.Equal(t, Collect, tile.Kind)
+		assert.True(t, r.Done())
+		assert.Equal(t, 1, r.LevelsReached())
+		assert.Len(t, r.Picks(), 4)
+
+		_, ok = r.Pick(0)
+		assert.False(t, ok)
+	})
+
+	t.Run("advance", func(t *testing.T) {
+		r := newResult(level1, level2)
+		defer r.Release()
+
+		r.Pick(0)
+		r.Pick(4)
+		assert.False(t, r.Done())
+		assert.Equal(t, 1, r.Level())
+		assert.Equal(t, 2, r.LevelsReached())
+
+		r.Pick(0)
+		r.Pick(2)
+		assert.Equal(t, 11.0, r.Prizes())
+		assert.Equal(t, 33.0, r.Total())
+
+		r.Pick(3)
+		assert.True(t, r.Done())
+
+		want := []Pick{
+			{Level: 0, Position: 0, Tile: PrizeTile(1)},
+			{Level: 0, Position: 4, Tile: AdvanceTile()},
+			{Level: 1, Position: 0, Tile: PrizeTile(10)},
+			{Level: 1, Position: 2, Tile: MultiplierTile(3)},
+			{Level: 1, Position: 3, Tile: CollectTile()},
+		}
+		assert.Equal(t, want, r.Picks())
+	})
+
+	t.Run("advance last level", func(t *testing.T) {
+		r := newResult(Level{AdvanceTile(), PrizeTile(1)})
+		defer r.Release()
+
+		r.Pick(0)
+		assert.True(t, r.Done())
+		assert.Zero(t, r.Level())
+	})
+
+	t.Run("level exhausted", func(t *testing.T) {
+		r := newResult(Level{PrizeTile(1), PrizeTile(2)}, level2)
+		defer r.Release()
+
+		r.Pick(1)
+		r.Pick(0)
+		assert.True(t, r.Done())
+		assert.Equal(t, 3.0, r.Total())
+	})
+
+	t.Run("pick or first", func(t *testing.T) {
+		r := newResult(level1)
+		defer r.Release()
+
+		tile, ok := r.PickOrFirst(2)
+		assert.True(t, ok)
+		assert.Equal(t, PrizeTile(5), tile)
+
+		tile, ok = r.PickOrFirst(2)
+		assert.True(t, ok)
+		assert.Equal(t, PrizeTile(1), tile)
+
+		tile, ok = r.PickOrFirst(-1)
+		assert.True(t, ok)
+		assert.Equal(t, PrizeTile(2), tile)
+	})
+}
+
+func TestBonusPickem_Run(t *testing.T) {
+	prng := rng.NewRNG()
+	defer prng.ReturnToPool()
+
+	b := AcquireBonusPickem(prng, level1, level2)
+	defer b.Release()
+
+	levels := make(map[int]int, 2)
+	for ix := 0; ix < 1000; ix++ {
+		n, data := b.Run(nil, 5, 4, "bad")
+		require.NotNil(t, data)
+
+		r, ok := data.(*PickemResult)
+		require.True(t, ok)
+		assert.True(t, r.Done())
+		assert.Equal(t, r.LevelsReached(), n)
+		assert.NotEmpty(t, r.Picks())
+		assert.Equal(t, uint8(5), r.Picks()[0].Position)
+
+		levels[n]++
+		data.Release()
+	}
+
+	assert.NotZero(t, levels[1])
+	assert.NotZero(t, levels[2])
+}
+
+func TestPickemResult_Encode(t *testing.T) {
+	prng := rng.NewRNG()
+	defer prng.ReturnToPool()
+
+	b := AcquireBonusPickem(prng, level1, level2)
+	defer b.Release()
+
+	r := b.Start()
+	defer r.Release()
+
+	position := 0
+	for iy, tile := range r.Board()[0] {
+		if tile.Kind == Prize {
+			position = iy
+			break
+		}
+	}
+	r.Pick(position)
+	r.SetChoices(map[string]string{"pick": "1"})
+
+	t.Run("hidden", func(t *testing.T) {
+		enc := zjson.AcquireEncoder(1024)
+		defer enc.Release()
+
+		enc.StartObject()
+		r.Encode2(enc)
+		enc.EndObject()
+
+		got := string(enc.Bytes())
+		assert.True(t, strings.Contains(got, `"sizes":[6,4]`))
+		assert.False(t, strings.Contains(got, `"board"`))
+		assert.True(t, strings.Contains(got, `"picks":[{"level":0,"position":`))
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		enc := zjson.AcquireEncoder(1024)
+		defer enc.Release()
+
+		enc.Object(r)
+
+		r2 := AcquirePickemResult()
+		defer r2.Release()
+
+		dec := zjson.AcquireDecoder(enc.Bytes())
+		defer dec.Release()
+		require.True(t, dec.Object(r2))
+
+		assert.Equal(t, r.Done(), r2.Done())
+		assert.Equal(t, r.Level(), r2.Level())
+		assert.Equal(t, r.Prizes(), r2.Prizes())
+		assert.Equal(t, r.Multiplier(), r2.Multiplier())
+		assert.Equal(t, r.Board(), r2.Board())
+		assert.Equal(t, r.Picks(), r2.Picks())
+		assert.Equal(t, r.Choices(), r2.Choices())
+	})
+
+	t.Run("deep copy", func(t *testing.T) {
+		r2 := r.DeepCopy()
+		defer r2.Release()
+
+		assert.Equal(t, r.Board(), r2.Board())
+		assert.Equal(t, r.Picks(), r2.Picks())
+		assert.Equal(t, r.Choices(), r2.Choices())
+
+		r2.Choices()["pick"] = "2"
+		assert.Equal(t, "1", r.Choices()["pick"])
+
+		r2.PickOrFirst(-1)
+		assert.Len(t, r.Picks(), 1)
+		assert.Len(t, r2.Picks(), 2)
+
+		tiles := func(l Level) []int {
+			out := make([]int, len(l))
+			for ix := range l {
+				out[ix] = int(l[ix].Kind)*1000 + int(l[ix].Value)
+			}
+			sort.Ints(out)
+			return out
+		}
+		assert.Equal(t, tiles(level1), tiles(r2.Board()[0]))
+	})
+
+	t.Run("deep copy without choices", func(t *testing.T) {
+		r2 := b.Start()
+		defer r2.Release()
+		r2.SetChoices(nil)
+
+		r3 := r2.DeepCopy()
+		defer r3.Release()
+		assert.Nil(t, r3.Choices())
+	})
+}
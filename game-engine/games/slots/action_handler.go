@@ -93,6 +93,16 @@ func (h *actionHandler) testPreSpinActions() bool {
 	return h.r.makeChoice
 }
 
+// getPickemAction returns the pre-spin action which plays a pick'em bonus game, or nil if there isn't one.
+func (h *actionHandler) getPickemAction() slots.SpinActioner {
+	for ix := range h.preSpin {
+		if a, ok := h.preSpin[ix].(*slots.BonusAction); ok && a.IsPickem() {
+			return a
+		}
+	}
+	return nil
+}
+
 // testGridRevisements processes the revise grid actions.
 func (h *actionHandler) testGridRevisements() {
 	list, spin, spinData, logEvent := h.reviseGrid, h.r.spin, h.r.spinData, h.r.logEvent
@@ -405,6 +415,14 @@ func (h *actionHandler) testBonuses() {
 			case slots.GridModified:
 				spinData.Update(spin)
 
+			case slots.HoldAndWin:
+				spinData.SetTransition(t.FeatureTransition())
+				t.Payout(spin, currResult)
+				spinData.Update(spin)
+
+			case slots.ProgressiveJackpot:
+				spinData.Update(spin)
+
 			default:
 			}
 
@@ -427,6 +445,24 @@ func (h *actionHandler) testBonuses() {
 	awardFreeSpins(free)
 }
 
+// testRespin tests the hold-and-win actions during a respin.
+// New coins are locked, and the feature is paid out once it has completed.
+func (h *actionHandler) testRespin() {
+	list, spin, spinData, currResult, logEvent := h.bonuses, h.r.spin, h.r.spinData, h.r.currResult, h.r.logEvent
+
+	for ix := range list {
+		if a := list[ix]; a.Result() == slots.HoldAndWin && a.CanTrigger(spin) {
+			if t := a.Triggered(spin); t == nil {
+				logEvent(a, false)
+			} else {
+				t.Payout(spin, currResult)
+				spinData.Update(spin)
+				logEvent(t, true)
+			}
+		}
+	}
+}
+
 // testClearing tests the clearing actions on the grid result.
 func (h *actionHandler) testClearing() {
 	list, spin, spinData, logEvent := h.clearing, h.r.spin, h.r.spinData, h.r.logEvent
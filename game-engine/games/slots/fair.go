@@ -0,0 +1,70 @@
+package slots
+
+import (
+	"errors"
+	"math"
+	"slices"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/results"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/rng"
+)
+
+// Fair plays a provably-fair round, with the PRNG derived from the given seeds.
+// The game must be instantiated with a buffered PRNG (e.g. with PRNG logging); it returns nil otherwise.
+// Any spin state or symbols state must be restored before calling Fair, as it determines the results as well.
+func (r *Regular) Fair(seeds *rng.FairSeeds, bonusBuy uint8) results.Results {
+	if r.prngBuf == nil || seeds == nil {
+		return nil
+	}
+	r.prngBuf.WithPRNG(seeds.AcquireRNG())
+	return r.Round(bonusBuy)
+}
+
+// VerifyFair replays a provably-fair round and verifies it against the recorded results.
+// The game must be a newly instantiated game with PRNG logging, and have the same state as when the round was played.
+// It returns ErrFairCommitment if the server seed does not match the commitment made before the round,
+// and ErrFairMismatch if the replayed round differs in the number of results, the payouts or the PRNG log.
+// The PRNG logs are only compared for the recorded results which include one.
+func VerifyFair(g *Regular, seeds *rng.FairSeeds, commitment string, bonusBuy uint8, recorded results.Results) error {
+	if !seeds.Verify(commitment) {
+		return ErrFairCommitment
+	}
+
+	replayed := g.Fair(seeds, bonusBuy)
+	if replayed == nil {
+		return ErrFairNoLog
+	}
+	if len(replayed) != len(recorded) {
+		return ErrFairMismatch
+	}
+
+	for ix := range replayed {
+		r1, r2 := replayed[ix], recorded[ix]
+		if r1.DataKind != r2.DataKind || math.Abs(r1.Total-r2.Total) > 1e-9 {
+			return ErrFairMismatch
+		}
+
+		l1, ok1 := r1.Data.(prngLogger)
+		l2, ok2 := r2.Data.(prngLogger)
+		if ok1 && ok2 {
+			_, in1, out1 := l1.Log()
+			_, in2, out2 := l2.Log()
+			if len(in2) > 0 && (!slices.Equal(in1, in2) || !slices.Equal(out1, out2)) {
+				return ErrFairMismatch
+			}
+		}
+	}
+
+	return nil
+}
+
+// prngLogger is implemented by result data which embeds the PRNG log.
+type prngLogger interface {
+	Log() (results.Events, []int, []int)
+}
+
+var (
+	ErrFairCommitment = errors.New("server seed does not match the commitment")
+	ErrFairNoLog      = errors.New("game has no buffered PRNG")
+	ErrFairMismatch   = errors.New("replayed round does not match the recorded results")
+)
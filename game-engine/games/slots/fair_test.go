@@ -0,0 +1,76 @@
+package slots
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/components/slots"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/results"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/rng"
+)
+
+func TestRegular_Fair(t *testing.T) {
+	s := slots.NewSlots(
+		slots.Grid(5, 3),
+		slots.WithSymbols(set1),
+		slots.WithPaylines(slots.PayLTR, false, pl5x3x1, pl5x3x2, pl5x3x3),
+		slots.WithActions(slots.SpinActions{t1, t2, t14}, slots.SpinActions{t1, t14}, nil, nil),
+	)
+
+	seeds := &rng.FairSeeds{ServerSeed: rng.NewServerSeed(), ClientSeed: "player", Nonce: 1}
+	commitment := seeds.Commitment()
+
+	play := func(seeds *rng.FairSeeds) (*Regular, results.Results) {
+		r := AcquireRegular(RegularParams{Slots: s, PrngLog: true})
+		require.NotNil(t, r)
+		return r, r.Fair(seeds, 0)
+	}
+
+	t.Run("deterministic", func(t *testing.T) {
+		for ix := 0; ix < 25; ix++ {
+			seeds.Nonce = uint64(ix)
+			commitment = seeds.Commitment()
+
+			r1, res1 := play(seeds)
+			require.NotEmpty(t, res1)
+
+			r2 := AcquireRegular(RegularParams{Slots: s, PrngLog: true})
+			require.NotNil(t, r2)
+			require.NoError(t, VerifyFair(r2, seeds, commitment, 0, res1))
+
+			r1.Release()
+			r2.Release()
+		}
+	})
+
+	t.Run("wrong server seed", func(t *testing.T) {
+		r1, res1 := play(seeds)
+		defer r1.Release()
+
+		other := &rng.FairSeeds{ServerSeed: rng.NewServerSeed(), ClientSeed: seeds.ClientSeed, Nonce: seeds.Nonce}
+		r2 := AcquireRegular(RegularParams{Slots: s, PrngLog: true})
+		defer r2.Release()
+		assert.Equal(t, ErrFairCommitment, VerifyFair(r2, other, commitment, 0, res1))
+	})
+
+	t.Run("wrong nonce", func(t *testing.T) {
+		r1, res1 := play(seeds)
+		defer r1.Release()
+
+		other := &rng.FairSeeds{ServerSeed: seeds.ServerSeed, ClientSeed: seeds.ClientSeed, Nonce: seeds.Nonce + 1}
+		r2 := AcquireRegular(RegularParams{Slots: s, PrngLog: true})
+		defer r2.Release()
+		assert.Equal(t, ErrFairMismatch, VerifyFair(r2, other, commitment, 0, res1))
+	})
+
+	t.Run("no log", func(t *testing.T) {
+		r1, res1 := play(seeds)
+		defer r1.Release()
+
+		r2 := AcquireRegular(RegularParams{Slots: s})
+		defer r2.Release()
+		assert.Equal(t, ErrFairNoLog, VerifyFair(r2, seeds, commitment, 0, res1))
+	})
+}
@@ -0,0 +1,34 @@
+package slots
+
+import (
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/games/cards"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/results"
+)
+
+// CanGamble returns true if the player can gamble the given stake after the given number of gamble steps.
+// The stake is the win at risk, expressed as a factor of the bet of the round.
+// A gamble is only offered if the game allows it, the number of steps is below the maximum, and the potential win
+// does not exceed the maximum win of the gamble, or the maximum payout of the round.
+func (r *Regular) CanGamble(kind cards.GambleKind, stake float64, steps uint8) bool {
+	if stake <= 0 || steps >= r.slots.GambleSteps() || kind.Multiplier() == 0 {
+		return false
+	}
+
+	win := stake * kind.Multiplier()
+	if max := r.slots.GambleMaxWin(); max > 0 && win > max {
+		return false
+	}
+	return win <= r.maxPayout
+}
+
+// Gamble plays a single gamble step on the given stake, after the given number of gamble steps.
+// The pick is the players choice of color or suit for the drawn card.
+// It returns whether the gamble was won, and the result of the step; the payout of the result is the change of the stake.
+// The result is nil if the gamble is not allowed; see CanGamble.
+// Make sure to call Release() on the result if you are done with it.
+func (r *Regular) Gamble(kind cards.GambleKind, stake float64, steps uint8, pick int) (bool, *results.Result) {
+	if !r.CanGamble(kind, stake, steps) {
+		return false, nil
+	}
+	return cards.Gamble(kind, stake, pick)
+}
@@ -0,0 +1,83 @@
+package slots
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/components/slots"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/games/cards"
+)
+
+func TestRegular_CanGamble(t *testing.T) {
+	testCases := []struct {
+		name      string
+		maxWin    float64
+		maxSteps  uint8
+		maxPayout float64
+		limit     float64
+		kind      cards.GambleKind
+		stake     float64
+		steps     uint8
+		want      bool
+	}{
+		{name: "no gamble", kind: cards.GambleColor, stake: 1},
+		{name: "collect", maxSteps: 5, kind: cards.GambleCollect, stake: 1},
+		{name: "no stake", maxSteps: 5, kind: cards.GambleColor},
+		{name: "color", maxSteps: 5, kind: cards.GambleColor, stake: 1, want: true},
+		{name: "suit", maxSteps: 5, kind: cards.GambleSuit, stake: 1, want: true},
+		{name: "last step", maxSteps: 5, kind: cards.GambleColor, stake: 1, steps: 4, want: true},
+		{name: "max steps", maxSteps: 5, kind: cards.GambleColor, stake: 1, steps: 5},
+		{name: "max win color", maxWin: 100, maxSteps: 5, kind: cards.GambleColor, stake: 50, want: true},
+		{name: "max win suit", maxWin: 100, maxSteps: 5, kind: cards.GambleSuit, stake: 50},
+		{name: "max payout", maxSteps: 5, maxPayout: 80, kind: cards.GambleColor, stake: 50},
+		{name: "limit", maxWin: 1000, maxSteps: 5, limit: 80, kind: cards.GambleColor, stake: 50},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := slots.NewSlots(slots.Grid(5, 3), slots.WithSymbols(set1), slots.MaxPayout(tc.maxPayout), slots.WithGamble(tc.maxWin, tc.maxSteps))
+			assert.Equal(t, tc.maxSteps > 0, s.Gamble())
+
+			r := AcquireRegular(RegularParams{Slots: s})
+			require.NotNil(t, r)
+			defer r.Release()
+
+			r.LimitMaxPayout(tc.limit)
+			assert.Equal(t, tc.want, r.CanGamble(tc.kind, tc.stake, tc.steps))
+		})
+	}
+}
+
+func TestRegular_Gamble(t *testing.T) {
+	s := slots.NewSlots(slots.Grid(5, 3), slots.WithSymbols(set1), slots.WithGamble(1000, 3))
+	r := AcquireRegular(RegularParams{Slots: s})
+	require.NotNil(t, r)
+	defer r.Release()
+
+	t.Run("not allowed", func(t *testing.T) {
+		won, res := r.Gamble(cards.GambleColor, 2, 3, 1)
+		assert.False(t, won)
+		assert.Nil(t, res)
+	})
+
+	t.Run("steps", func(t *testing.T) {
+		var wins int
+		for ix := 0; ix < 100; ix++ {
+			won, res := r.Gamble(cards.GambleSuit, 2, 0, 1)
+			require.NotNil(t, res)
+			assert.True(t, cards.IsGamble(res))
+
+			if won {
+				wins++
+				assert.Equal(t, 6.0, res.Total)
+			} else {
+				assert.Equal(t, -2.0, res.Total)
+			}
+			res.Release()
+		}
+		assert.Greater(t, wins, 0)
+		assert.Less(t, wins, 100)
+	})
+}
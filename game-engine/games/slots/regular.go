@@ -7,6 +7,7 @@ import (
 
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/components/slots"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/consts"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/games/pickem"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/games/wheel"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/interfaces"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/results"
@@ -140,6 +141,15 @@ func (r *Regular) MaxPayout() float64 {
 	return r.maxPayout
 }
 
+// LimitMaxPayout lowers the maximum payout for a round, e.g. to apply the max win cap of a jurisdiction.
+// It is ignored if max is zero, or higher than the maximum payout of the slot machine.
+// The limit remains in effect until the game is released.
+func (r *Regular) LimitMaxPayout(max float64) {
+	if max > 0 && max < r.maxPayout {
+		r.maxPayout = max
+	}
+}
+
 // MaxPayoutReached indicates if the maximum payout has been reached during the round.
 func (r *Regular) MaxPayoutReached() bool {
 	return r.maxPayoutReached
@@ -328,6 +338,13 @@ func (r *Regular) prepareResume(choices map[string]string) {
 			r.spin.SetKind(slots.FreeSpin)
 			r.resuming = true
 		}
+
+		// continue an unfinished pick'em bonus game with the next pick.
+		if r.spin.PickemPending() {
+			if r.bonusGame = r.getHandler().getPickemAction(); r.bonusGame == nil {
+				r.bonusGame = r.actionsFirst.getPickemAction()
+			}
+		}
 	}
 
 	// test player choices.
@@ -410,7 +427,7 @@ func (r *Regular) doRound() results.Results {
 	}
 
 	handler := r.getHandler()
-	if handler.testPreSpinActions() {
+	if r.bonusGame == nil && handler.testPreSpinActions() {
 		return r.results
 	}
 
@@ -447,7 +464,7 @@ func (r *Regular) getResults() {
 		r.getResultCurrent()
 	}
 
-	for !r.makeChoice && !r.maxPayoutReached && !r.minPayoutReached && (r.bonusGame != nil || r.freeSpins > 0 || r.needRefill) {
+	for !r.makeChoice && !r.maxPayoutReached && !r.minPayoutReached && (r.bonusGame != nil || r.freeSpins > 0 || r.needRefill || r.spin.Respins() > 0) {
 		if r.bonusGame == nil {
 			r.playFreeSpin()
 		} else {
@@ -457,6 +474,9 @@ func (r *Regular) getResults() {
 
 	if r.makeChoice {
 		// when there's a player choice request, we need to remember the spin state.
+		if r.spinState != nil {
+			r.spinState.Release()
+		}
 		r.spinState = slots.AcquireSpinState(r.spin)
 	} else if !r.doubleSpin && r.spinState != nil {
 		r.spinState.Release()
@@ -506,7 +526,7 @@ func (r *Regular) getResultCurrent() {
 	}
 }
 
-// playFreeSpin plays and compiles the result for a free spin or refill spin.
+// playFreeSpin plays and compiles the result for a free spin, refill spin or hold-and-win respin.
 func (r *Regular) playFreeSpin() {
 	if r.needRefill {
 		if r.superSpin {
@@ -516,6 +536,10 @@ func (r *Regular) playFreeSpin() {
 		}
 		r.spin.Refill()
 		r.needRefill = false
+	} else if r.spin.Respins() > 0 {
+		// respins of a hold-and-win feature take precedence over free spins.
+		r.spin.SetKind(slots.Respin)
+		r.spin.Respin()
 	} else {
 		r.freeStarted = true
 
@@ -585,12 +609,46 @@ func (r *Regular) playBonusGame() {
 				r.eventLast = r.prngLast
 			}
 			r.results = append(r.results, results.AcquireResult(b, results.BonusWheelData))
+		} else if bp, ok2 := b.(*pickem.PickemResult); ok2 {
+			r.playPickem(t, bp)
 		}
 	}
 
 	r.testActionsBonusGame()
 }
 
+// playPickem compiles the result for a pick'em bonus game.
+// The round waits for a player choice until the game has ended; the payout is added when it ends.
+func (r *Regular) playPickem(t slots.SpinActioner, bp *pickem.PickemResult) {
+	bp.SetChoices(r.choices)
+	r.choices = nil
+	bp.LogEvent(r.getEvent(t, true))
+	if r.prngLog {
+		// add the PRNG log.
+		l1, l2 := r.prngBuf.Log()
+		bp.SetLog(l1[r.prngLast:], l2[r.prngLast:])
+		r.prngLast = len(l1)
+		r.eventLast = r.prngLast
+	}
+
+	if !bp.Done() {
+		r.makeChoice = true
+		r.results = append(r.results, results.AcquireResult(bp, results.PickemData))
+		return
+	}
+
+	result := results.AcquireResult(bp, results.PickemData)
+	if bp.Total() > 0 {
+		result.AddPayouts(slots.BonusGamePayout(bp.Prizes(), bp.Multiplier()))
+	}
+	r.results = append(r.results, result)
+
+	r.totalPayout += result.Total
+	if r.totalPayout >= r.maxPayout {
+		r.maxPayoutReached = true
+	}
+}
+
 // testActions tests all actions on the current state of the spin, establishes a spin result,
 // records awarded payouts/free spins/bonus games, performs wild expansions, performs symbol clearing or stickiness, etc.
 // For a "paid" round, grid revisements are skipped.
@@ -635,6 +693,13 @@ func (r *Regular) testActions() {
 
 	r.initCurrData()
 
+	if r.spin.Kind() == slots.Respin {
+		// only the hold-and-win feature is tested during a respin.
+		handler.testRespin()
+		r.completeActions(handler)
+		return
+	}
+
 	if (r.paidAction == nil || r.spin.Kind() != slots.FirstSpin) && !r.superSpin && !r.debugInitial {
 		handler.testGridRevisements()
 	}
@@ -683,6 +748,12 @@ func (r *Regular) testActions() {
 		r.locked = r.spin.Locked(r.locked)
 	}
 
+	r.completeActions(handler)
+}
+
+// completeActions records the state, free games and payout of the current spin result, checks the payout limits,
+// and tests the pre-bonus actions when more free spins follow.
+func (r *Regular) completeActions(handler *actionHandler) {
 	if r.symbolsState != nil {
 		r.currResult.AddState(r.symbolsState.DeepCopy())
 	}
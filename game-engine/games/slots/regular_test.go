@@ -7,6 +7,7 @@ import (
 	rng2 "git-codecommit.eu-central-1.amazonaws.com/v1/repos/prng.git/rng"
 
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/components/slots"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/games/pickem"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/interfaces"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/results"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/rng"
@@ -166,6 +167,33 @@ func TestNewRegular(t *testing.T) {
 	}
 }
 
+func TestRegular_LimitMaxPayout(t *testing.T) {
+	testCases := []struct {
+		name      string
+		maxPayout float64
+		limit     float64
+		want      float64
+	}{
+		{name: "no max, no limit", want: math.MaxFloat64},
+		{name: "no max, limit", limit: 500, want: 500},
+		{name: "max, no limit", maxPayout: 3000, want: 3000},
+		{name: "max, lower limit", maxPayout: 3000, limit: 500, want: 500},
+		{name: "max, higher limit", maxPayout: 3000, limit: 5000, want: 3000},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := slots.NewSlots(slots.Grid(5, 3), slots.WithSymbols(set1), slots.MaxPayout(tc.maxPayout))
+			r := AcquireRegular(RegularParams{Slots: s})
+			require.NotNil(t, r)
+			defer r.Release()
+
+			r.LimitMaxPayout(tc.limit)
+			assert.Equal(t, tc.want, r.MaxPayout())
+		})
+	}
+}
+
 func TestNewRegularFail(t *testing.T) {
 	t.Run("new regular fail", func(t *testing.T) {
 		defer func() {
@@ -890,6 +918,82 @@ func TestRegular_GetResult(t *testing.T) {
 	})
 }
 
+func TestRegular_HoldAndWin(t *testing.T) {
+	t.Run("regular hold and win", func(t *testing.T) {
+		coin := slots.NewSymbol(15, slots.WithKind(slots.Prize), slots.WithWeights(250, 250, 250, 250, 250, 250))
+		set2 := slots.NewSymbolSet(sf1, sf2, sf3, sf4, sf5, sf6, coin)
+
+		values := utils.AcquireWeighting().AddWeights(utils.Indexes{1, 2, 5}, []float64{60, 30, 10})
+		jackpots := utils.AcquireWeighting().AddWeights(utils.Indexes{0, 1, 2}, []float64{90, 7, 3})
+		a := slots.NewHoldAndWinAction(15, 6, values).WithJackpots(jackpots, 10, 20, 50, 500)
+
+		s := slots.NewSlots(
+			slots.Grid(5, 3),
+			slots.WithSymbols(set2),
+			slots.WithPaylines(slots.PayLTR, false, pl5x3x1, pl5x3x2, pl5x3x3),
+			slots.WithActions(slots.SpinActions{t14, a}, nil, nil, nil),
+		)
+
+		r := AcquireRegular(RegularParams{Slots: s})
+		require.NotNil(t, r)
+		defer r.Release()
+
+		var triggered, respins int
+		for ix := 0; ix < 200; ix++ {
+			r.prepareRound(0)
+			r.spin.Spin()
+			r.getResults()
+			require.NotEmpty(t, r.results)
+
+			first := r.results[0].Data.(*slots.SpinResult)
+			if len(first.Coins()) == 0 {
+				assert.Equal(t, 1, len(r.results))
+				continue
+			}
+
+			triggered++
+			respins += len(r.results) - 1
+			assert.Equal(t, slots.HoldAndWinTransition, first.Transition())
+
+			last := r.results[len(r.results)-1]
+			data := last.Data.(*slots.SpinResult)
+			assert.Zero(t, data.Respins())
+
+			var coins float64
+			full := true
+			for _, c := range data.Coins() {
+				coins += c
+				full = full && c > 0
+			}
+			if full {
+				coins += 500
+			}
+
+			var paid float64
+			for _, p := range last.Payouts {
+				switch p.Kind() {
+				case results.SlotHoldAndWin, results.SlotJackpot:
+					paid += p.Total()
+				}
+			}
+			assert.InDelta(t, coins, paid, 0.001)
+
+			for iy := 1; iy < len(r.results); iy++ {
+				res := r.results[iy]
+				require.Equal(t, slots.Respin, res.Data.(*slots.SpinResult).Kind())
+				for _, p := range res.Payouts {
+					assert.NotEqual(t, results.SlotWinline, p.Kind())
+				}
+				if iy < len(r.results)-1 {
+					assert.Zero(t, res.Total)
+				}
+			}
+		}
+		assert.Greater(t, triggered, 0)
+		assert.Greater(t, respins, triggered)
+	})
+}
+
 func TestRegular_PrngLog(t *testing.T) {
 	t.Run("regular get prng log", func(t *testing.T) {
 		w := utils.AcquireWeighting()
@@ -1177,3 +1281,96 @@ func init() {
 	// use internal RNG for unit-tests
 	rng.AcquireRNG = func() interfaces.Generator { return rng2.NewRNG() }
 }
+
+func TestRegular_Pickem(t *testing.T) {
+	level1 := pickem.Level{pickem.PrizeTile(1), pickem.PrizeTile(2), pickem.MultiplierTile(2), pickem.AdvanceTile(), pickem.CollectTile()}
+	level2 := pickem.Level{pickem.PrizeTile(10), pickem.PrizeTile(20), pickem.CollectTile()}
+
+	choice := slots.NewPlayerChoiceAction(1, "pick", []string{"1", "2", "3", "4", "5"}, []int{1, 2, 3, 4, 5})
+
+	t.Run("auto", func(t *testing.T) {
+		a := slots.NewPickemBonusAction(-1, 2, level1, level2)
+		s := slots.NewSlots(
+			slots.Grid(5, 3),
+			slots.WithSymbols(set1),
+			slots.WithPaylines(slots.PayLTR, false, pl5x3x1, pl5x3x2, pl5x3x3),
+			slots.WithActions(slots.SpinActions{a, t14}, nil, nil, nil),
+		)
+
+		r := AcquireRegular(RegularParams{Slots: s})
+		require.NotNil(t, r)
+		defer r.Release()
+
+		for ix := 0; ix < 100; ix++ {
+			res := r.Round(0)
+			require.Len(t, res, 1)
+			assert.False(t, r.NeedPlayerChoice())
+			assert.Nil(t, r.SpinState())
+
+			p, ok := res[0].Data.(*pickem.PickemResult)
+			require.True(t, ok)
+			assert.True(t, p.Done())
+			assert.Equal(t, p.Total(), r.TotalPayout())
+			assert.Equal(t, p.Total(), res[0].Total)
+		}
+	})
+
+	t.Run("player choice", func(t *testing.T) {
+		a := slots.NewPickemBonusAction(1, 2, level1, level2).WithPlayerChoice("pick")
+		s := slots.NewSlots(
+			slots.Grid(5, 3),
+			slots.WithSymbols(set1),
+			slots.WithPaylines(slots.PayLTR, false, pl5x3x1, pl5x3x2, pl5x3x3),
+			slots.WithActions(slots.SpinActions{choice, a, t14}, nil, nil, nil),
+			slots.WithPlayerChoice(),
+		)
+
+		for ix := 0; ix < 25; ix++ {
+			r := AcquireRegular(RegularParams{Slots: s})
+			require.NotNil(t, r)
+
+			res := r.Round(0)
+			require.Len(t, res, 1)
+			require.True(t, r.NeedPlayerChoice())
+			assert.Zero(t, r.TotalPayout())
+
+			p, ok := res[0].Data.(*pickem.PickemResult)
+			require.True(t, ok)
+			assert.False(t, p.Done())
+			assert.Empty(t, p.Picks())
+
+			var picks int
+			for r.NeedPlayerChoice() {
+				// a disconnected player resumes the game with a new instance.
+				state := r.SpinState()
+				require.NotNil(t, state)
+				r.Release()
+
+				r = AcquireRegular(RegularParams{Slots: s})
+				r.RestoreState(state, nil)
+				state.Release()
+
+				res = r.RoundResume(map[string]string{"pick": "1"})
+				require.Len(t, res, 1)
+				picks++
+
+				p, ok = res[0].Data.(*pickem.PickemResult)
+				require.True(t, ok)
+				require.Len(t, p.Picks(), picks)
+				assert.Equal(t, map[string]string{"pick": "1"}, p.Choices())
+				assert.Equal(t, !p.Done(), r.NeedPlayerChoice())
+			}
+
+			assert.True(t, p.Done())
+			assert.Equal(t, p.Total(), r.TotalPayout())
+			if p.Total() > 0 {
+				require.Len(t, res[0].Payouts, 1)
+				assert.Equal(t, results.SlotBonusGame, res[0].Payouts[0].Kind())
+			} else {
+				assert.Empty(t, res[0].Payouts)
+			}
+
+			r.Release()
+		}
+	})
+}
@@ -34,6 +34,10 @@ const (
 	SlotReducePenalty
 	// SlotDividePenalty is a penalty that divides the total payout factor by a percentage.
 	SlotDividePenalty
+	// SlotHoldAndWin is a payout for the coin values collected during a hold-and-win feature.
+	SlotHoldAndWin
+	// SlotJackpot is a payout for a fixed jackpot.
+	SlotJackpot
 )
 
 // String implements the Stringer interface.
@@ -63,6 +67,10 @@ func (k PayoutKind) String() string {
 		return "slot reduce penalty"
 	case SlotDividePenalty:
 		return "slot divide penalty"
+	case SlotHoldAndWin:
+		return "slot hold and win"
+	case SlotJackpot:
+		return "slot jackpot"
 	default:
 		return "[unknown]"
 	}
@@ -76,9 +76,10 @@ func (r *PrngLog) CloneEvent(e *Event) {
 }
 
 // SetLog overwrites the PRNG log.
+// The values are copied, as the given slices are usually part of a PRNG buffer which is reused.
 func (r *PrngLog) SetLog(l1, l2 []int) {
-	r.rngInLog = l1
-	r.rngOutLog = l2
+	r.rngInLog = append(r.rngInLog[:0], l1...)
+	r.rngOutLog = append(r.rngOutLog[:0], l2...)
 }
 
 // EncodeEventLog implements the Objecter2.Encode2 interface.
@@ -118,8 +119,8 @@ func (r *PrngLog) DecodeScriptID(dec *zjson.Decoder) bool {
 }
 
 // DecodeEventLog can be used to decode the event log.
+// It is called for each element of the array, and appends the event to the log.
 func (r *PrngLog) DecodeEventLog(dec *zjson.Decoder) error {
-	r.eventLog = PurgeEvents(r.eventLog, 32)
 	e := EventProducer.Acquire().(*Event)
 	if ok := dec.Object(e); ok {
 		r.eventLog = append(r.eventLog, e)
@@ -129,8 +130,8 @@ func (r *PrngLog) DecodeEventLog(dec *zjson.Decoder) error {
 }
 
 // DecodeRngIn can be used to decode the PRNG input log.
+// It is called for each element of the array, and appends the value to the log.
 func (r *PrngLog) DecodeRngIn(dec *zjson.Decoder) error {
-	r.rngInLog = utils.PurgeInts(r.rngInLog, 32)
 	if i, ok := dec.Int(); ok {
 		r.rngInLog = append(r.rngInLog, i)
 		return nil
@@ -139,8 +140,8 @@ func (r *PrngLog) DecodeRngIn(dec *zjson.Decoder) error {
 }
 
 // DecodeRngOut can be used to decode the PRNG output log.
+// It is called for each element of the array, and appends the value to the log.
 func (r *PrngLog) DecodeRngOut(dec *zjson.Decoder) error {
-	r.rngOutLog = utils.PurgeInts(r.rngOutLog, 32)
 	if i, ok := dec.Int(); ok {
 		r.rngOutLog = append(r.rngOutLog, i)
 		return nil
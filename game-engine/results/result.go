@@ -21,6 +21,7 @@ const (
 	BonusWheelData
 	BonusCallColorData
 	BonusCallSuitData
+	PickemData
 )
 
 // AcquireResult instantiates a new result from the memory pool.
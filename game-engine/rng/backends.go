@@ -0,0 +1,166 @@
+package rng
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	prng "git-codecommit.eu-central-1.amazonaws.com/v1/repos/prng.git/rng"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/interfaces"
+)
+
+// Backend contains the functions to instantiate the PRNGs and shufflers of a registered implementation.
+type Backend struct {
+	AcquireRNG        func() interfaces.Generator // required.
+	AcquireShuffler   func() interfaces.Shuffler  // optional; defaults to a Fisher-Yates shuffle using AcquireRNG.
+	EnableHealthTests func(enabled bool)          // optional; enables the online health tests of the backend.
+	HealthStatus      func() prng.Health          // optional; returns the counters of the online health tests.
+	ResetHealth       func()                      // optional; resets the counters of the online health tests.
+}
+
+const (
+	BackendSharedLib = "sharedlib" // the shared library "libprng.so"; not available when built with the nosharedlib tag.
+	BackendChaCha    = "chacha"    // the pure-Go ChaCha PRNG from the prng module.
+	BackendSeeded    = "seeded"    // a deterministic ChaCha PRNG; see UseSeed().
+)
+
+// Register adds a backend under the given name, or replaces the backend already registered under that name.
+// It panics if the backend has no AcquireRNG function.
+func Register(name string, b Backend) {
+	if b.AcquireRNG == nil {
+		panic("rng.Register: missing AcquireRNG for backend " + name)
+	}
+
+	backendsMutex.Lock()
+	backends[name] = b
+	backendsMutex.Unlock()
+}
+
+// Backends returns the names of all registered backends in alphabetical order.
+func Backends() []string {
+	backendsMutex.RLock()
+	names := make([]string, 0, len(backends))
+	for name := range backends {
+		names = append(names, name)
+	}
+	backendsMutex.RUnlock()
+
+	sort.Strings(names)
+	return names
+}
+
+// Current returns the name of the selected backend.
+func Current() string {
+	backendsMutex.RLock()
+	defer backendsMutex.RUnlock()
+	return current
+}
+
+// Use selects the backend with the given name for AcquireRNG and AcquireShuffler.
+// It is intended to be called during application startup or in unit tests, as it replaces the package level
+// functions without synchronization with the go-routines that may be calling them.
+func Use(name string) error {
+	backendsMutex.Lock()
+	defer backendsMutex.Unlock()
+
+	b, ok := backends[name]
+	if !ok {
+		return ErrUnknownBackend
+	}
+
+	selected = b
+	AcquireRNG = b.AcquireRNG
+	if b.AcquireShuffler != nil {
+		AcquireShuffler = b.AcquireShuffler
+	} else {
+		AcquireShuffler = func() interfaces.Shuffler { return AcquireFisherYates(b.AcquireRNG()) }
+	}
+	current = name
+	return nil
+}
+
+// UseSeed selects a deterministic ChaCha backend producing generators from the given seed.
+// All generators acquired after the call, and the sequence of numbers they produce, can be replayed
+// by calling UseSeed() again with the same seed, provided they are acquired in the same order.
+// The backend is registered as BackendSeeded, replacing the previous seeded backend.
+// It returns the seed source, so it can be reset in between rounds.
+// Never use the seeded backend in production!
+func UseSeed(seed []byte) *SeedSource {
+	s := NewSeedSource(seed)
+	Register(BackendSeeded, Backend{AcquireRNG: s.AcquireRNG})
+	_ = Use(BackendSeeded) // cannot fail.
+	return s
+}
+
+// EnableHealthTests enables or disables the online health tests of the selected backend.
+// It returns ErrNoHealthTests if the backend doesn't support health tests.
+func EnableHealthTests(enabled bool) error {
+	backendsMutex.RLock()
+	b := selected
+	backendsMutex.RUnlock()
+
+	if b.EnableHealthTests == nil {
+		return ErrNoHealthTests
+	}
+	b.EnableHealthTests(enabled)
+	return nil
+}
+
+// HealthStatus returns the counters of the online health tests of the selected backend.
+// It returns ErrNoHealthTests if the backend doesn't support health tests.
+func HealthStatus() (prng.Health, error) {
+	backendsMutex.RLock()
+	b := selected
+	backendsMutex.RUnlock()
+
+	if b.HealthStatus == nil {
+		return prng.Health{}, ErrNoHealthTests
+	}
+	return b.HealthStatus(), nil
+}
+
+// Healthy returns false if any of the online health tests of the selected backend failed.
+// It always returns true if the backend doesn't support health tests.
+func Healthy() bool {
+	h, err := HealthStatus()
+	return err != nil || h.Healthy()
+}
+
+// ResetHealth resets the counters of the online health tests of the selected backend.
+// It returns ErrNoHealthTests if the backend doesn't support health tests.
+func ResetHealth() error {
+	backendsMutex.RLock()
+	b := selected
+	backendsMutex.RUnlock()
+
+	if b.ResetHealth == nil {
+		return ErrNoHealthTests
+	}
+	b.ResetHealth()
+	return nil
+}
+
+func acquireChaCha() interfaces.Generator {
+	return prng.NewRNG()
+}
+
+var (
+	backendsMutex sync.RWMutex
+	current       string
+	selected      Backend
+	backends      = map[string]Backend{
+		BackendChaCha: {
+			AcquireRNG:        acquireChaCha,
+			EnableHealthTests: prng.EnableHealthTests,
+			HealthStatus:      prng.HealthStatus,
+			ResetHealth:       prng.ResetHealth,
+		},
+		BackendSeeded: {AcquireRNG: NewSeedSource(nil).AcquireRNG},
+	}
+)
+
+var (
+	ErrUnknownBackend = errors.New("unknown PRNG backend")
+	ErrNoHealthTests  = errors.New("PRNG backend does not support health tests")
+)
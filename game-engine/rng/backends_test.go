@@ -0,0 +1,189 @@
+package rng
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/interfaces"
+)
+
+func TestUse(t *testing.T) {
+	defer func() { require.NoError(t, Use(defaultBackend)) }()
+
+	assert.Equal(t, defaultBackend, Current())
+
+	names := Backends()
+	assert.Contains(t, names, BackendChaCha)
+	assert.Contains(t, names, BackendSeeded)
+	assert.True(t, sort.StringsAreSorted(names))
+
+	for _, name := range names {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, Use(name))
+			assert.Equal(t, name, Current())
+
+			prng := AcquireRNG()
+			require.NotNil(t, prng)
+			defer prng.ReturnToPool()
+
+			for ix := 0; ix < 1000; ix++ {
+				n := prng.IntN(6)
+				assert.GreaterOrEqual(t, n, 0)
+				assert.Less(t, n, 6)
+			}
+
+			s := AcquireShuffler()
+			require.NotNil(t, s)
+			defer s.Release()
+
+			c := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+			s.Shuffle(c)
+			sort.Ints(c)
+			assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, c)
+		})
+	}
+
+	last := Current()
+	err := Use("bad")
+	require.ErrorIs(t, err, ErrUnknownBackend)
+	assert.Equal(t, last, Current())
+}
+
+func TestRegister(t *testing.T) {
+	defer func() { require.NoError(t, Use(defaultBackend)) }()
+
+	var count int
+	Register("test", Backend{AcquireRNG: func() interfaces.Generator {
+		count++
+		return NewSeeded([]byte("test"))
+	}})
+	defer func() {
+		backendsMutex.Lock()
+		delete(backends, "test")
+		backendsMutex.Unlock()
+	}()
+
+	require.NoError(t, Use("test"))
+	AcquireRNG().ReturnToPool()
+	s := AcquireShuffler()
+	s.Release()
+	assert.Equal(t, 2, count)
+
+	assert.Panics(t, func() { Register("bad", Backend{}) })
+}
+
+func TestUseSeed(t *testing.T) {
+	defer func() { require.NoError(t, Use(defaultBackend)) }()
+
+	draw := func() []uint64 {
+		out := make([]uint64, 0, 16)
+		for ix := 0; ix < 4; ix++ {
+			prng := AcquireRNG()
+			out = append(out, prng.Uint64(), uint64(prng.Uint32()), uint64(prng.IntN(100)), uint64(prng.IntN(1<<40)))
+			prng.ReturnToPool()
+		}
+		return out
+	}
+
+	s := UseSeed([]byte("round 12345"))
+	assert.Equal(t, BackendSeeded, Current())
+	first := draw()
+	assert.Equal(t, uint64(4), s.Count())
+
+	s.Reset()
+	assert.Equal(t, first, draw())
+
+	UseSeed([]byte("round 12345"))
+	assert.Equal(t, first, draw())
+
+	UseSeed([]byte("round 12346"))
+	assert.NotEqual(t, first, draw())
+}
+
+func TestNewSeeded(t *testing.T) {
+	r1 := NewSeeded([]byte{1, 2, 3})
+	defer r1.ReturnToPool()
+	r2 := NewSeeded([]byte{1, 2, 3})
+	defer r2.ReturnToPool()
+
+	out1 := make([]int, 5000)
+	out2 := make([]int, 5000)
+	r1.IntsN(37, out1)
+	r2.IntsN(37, out2)
+	assert.Equal(t, out1, out2)
+
+	counts := make([]int, 37)
+	for _, n := range out1 {
+		counts[n]++
+	}
+	for n, c := range counts {
+		assert.Greater(t, c, 70, n)
+		assert.Less(t, c, 210, n)
+	}
+
+	assert.Panics(t, func() { r1.IntN(0) })
+}
+
+func TestFisherYates(t *testing.T) {
+	s := AcquireFisherYates(NewSeeded([]byte("deck")))
+	defer s.Release()
+
+	counts := make([][]int, 4)
+	for ix := range counts {
+		counts[ix] = make([]int, 4)
+	}
+
+	for ix := 0; ix < 40000; ix++ {
+		c := []int{0, 1, 2, 3}
+		s.Shuffle(c)
+		for pos, v := range c {
+			counts[pos][v]++
+		}
+	}
+
+	for pos := range counts {
+		for v := range counts[pos] {
+			assert.InDelta(t, 10000, counts[pos][v], 400, "%d-%d", pos, v)
+		}
+	}
+}
+
+func TestHealth(t *testing.T) {
+	defer func() { require.NoError(t, Use(defaultBackend)) }()
+
+	for _, name := range Backends() {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, Use(name))
+
+			if name == BackendSeeded {
+				require.ErrorIs(t, EnableHealthTests(true), ErrNoHealthTests)
+				_, err := HealthStatus()
+				require.ErrorIs(t, err, ErrNoHealthTests)
+				require.ErrorIs(t, ResetHealth(), ErrNoHealthTests)
+				assert.True(t, Healthy())
+				return
+			}
+
+			require.NoError(t, ResetHealth())
+			require.NoError(t, EnableHealthTests(true))
+			defer func() { require.NoError(t, EnableHealthTests(false)) }()
+
+			prng := AcquireRNG()
+			for ix := 0; ix < 10000; ix++ {
+				prng.Uint64()
+			}
+			prng.ReturnToPool()
+
+			h, err := HealthStatus()
+			require.NoError(t, err)
+			assert.True(t, h.Enabled)
+			assert.Greater(t, h.Buffers, uint64(0))
+			assert.True(t, Healthy())
+
+			require.NoError(t, ResetHealth())
+		})
+	}
+}
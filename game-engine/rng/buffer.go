@@ -85,6 +85,22 @@ func (b *Buffer) WithCache(cache []int) *Buffer {
 	return b
 }
 
+// WithPRNG replaces the supplied prng, e.g. to play a provably-fair round with a PRNG derived from its seeds.
+// It takes ownership of the new prng and returns the old one to the memory pool. The internal buffers are
+// cleared, so all following random numbers are produced by the new prng.
+func (b *Buffer) WithPRNG(prng interfaces.Generator) *Buffer {
+	if b.prng != nil {
+		b.prng.ReturnToPool()
+	}
+	b.prng = prng
+
+	for n := range b.buffers {
+		b.buffers[n].Release()
+		delete(b.buffers, n)
+	}
+	return b
+}
+
 // Uint32 implements the Generator interface.
 func (b *Buffer) Uint32() uint32 {
 	return b.prng.Uint32()
@@ -149,6 +165,7 @@ func (b *Buffer) getLogged(n int) int {
 // getCached retrieves a random number from the cache, if the input matches,
 // otherwise, it uses the normal retrieval from the appropriate buffer.
 // If the cache becomes empty it sets the get function to the appropriate non-cached retrieval function.
+// Numbers retrieved from the cache are logged as well if logging was turned on.
 func (b *Buffer) getCached(n int) int {
 	l := len(b.cache)
 
@@ -174,6 +191,10 @@ func (b *Buffer) getCached(n int) int {
 		}
 	}
 
+	if b.withLog {
+		b.inputs = append(b.inputs, n)
+		b.outputs = append(b.outputs, out)
+	}
 	return out
 }
 
@@ -186,3 +186,20 @@ func TestBuffer_WithCache(t *testing.T) {
 		})
 	}
 }
+
+func TestBuffer_WithCacheLog(t *testing.T) {
+	cache := []int{10000, 9000, 11000, 8000, 12000, 7000}
+
+	buf := AcquireBuffer(AcquireRNG(), true).WithCache(cache)
+	require.NotNil(t, buf)
+	defer buf.Release()
+
+	assert.Equal(t, 9000, buf.IntN(10000))
+	assert.Equal(t, 8000, buf.IntN(11000))
+	assert.Equal(t, 7000, buf.IntN(12000))
+	buf.IntN(13000)
+
+	in, out := buf.Log()
+	assert.Equal(t, []int{10000, 11000, 12000, 13000}, in)
+	assert.Equal(t, []int{9000, 8000, 7000}, out[:3])
+}
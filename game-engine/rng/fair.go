@@ -0,0 +1,59 @@
+package rng
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/interfaces"
+)
+
+// FairSeeds contains the seeds for a provably-fair round.
+// Before the round the server commits to the hash of the server seed (see Commitment()).
+// The player supplies the client seed and nonce, and the PRNG for the round is derived deterministically from all three,
+// so the player can replay the round offline once the server seed is revealed.
+type FairSeeds struct {
+	ServerSeed []byte
+	ClientSeed string
+	Nonce      uint64
+}
+
+// NewServerSeed generates a new random server seed from the crypto/rand source.
+func NewServerSeed() []byte {
+	b := make([]byte, ServerSeedSize)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// FairCommitment returns the hex encoded SHA-256 hash of the server seed.
+func FairCommitment(serverSeed []byte) string {
+	h := sha256.Sum256(serverSeed)
+	return hex.EncodeToString(h[:])
+}
+
+// Commitment returns the hex encoded SHA-256 hash of the server seed.
+func (s *FairSeeds) Commitment() string {
+	return FairCommitment(s.ServerSeed)
+}
+
+// Verify returns true if the server seed matches the given commitment.
+func (s *FairSeeds) Verify(commitment string) bool {
+	return hmac.Equal([]byte(s.Commitment()), []byte(commitment))
+}
+
+// AcquireRNG instantiates the deterministic ChaCha PRNG for the round.
+// The PRNG is the seeded CPRNG of the prng module, seeded with HMAC-SHA256(serverSeed, clientSeed + ":" + nonce).
+func (s *FairSeeds) AcquireRNG() interfaces.Generator {
+	m := hmac.New(sha256.New, s.ServerSeed)
+	m.Write([]byte(s.ClientSeed))
+	m.Write([]byte{':'})
+	m.Write(strconv.AppendUint(nil, s.Nonce, 10))
+	return NewSeeded(m.Sum(nil))
+}
+
+// ServerSeedSize is the size in bytes of a generated server seed.
+const ServerSeedSize = 32
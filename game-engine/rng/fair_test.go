@@ -0,0 +1,54 @@
+package rng
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFairSeeds(t *testing.T) {
+	seed := NewServerSeed()
+	require.Len(t, seed, ServerSeedSize)
+	assert.NotEqual(t, seed, NewServerSeed())
+
+	s1 := &FairSeeds{ServerSeed: seed, ClientSeed: "client", Nonce: 7}
+	assert.Len(t, s1.Commitment(), 64)
+	assert.Equal(t, FairCommitment(seed), s1.Commitment())
+	assert.True(t, s1.Verify(s1.Commitment()))
+	assert.False(t, s1.Verify(FairCommitment(NewServerSeed())))
+
+	draw := func(s *FairSeeds) []int {
+		prng := s.AcquireRNG()
+		defer prng.ReturnToPool()
+
+		out := make([]int, 100)
+		prng.IntsN(1000, out)
+		return out
+	}
+
+	want := draw(s1)
+	assert.Equal(t, want, draw(&FairSeeds{ServerSeed: seed, ClientSeed: "client", Nonce: 7}))
+	assert.NotEqual(t, want, draw(&FairSeeds{ServerSeed: seed, ClientSeed: "client", Nonce: 8}))
+	assert.NotEqual(t, want, draw(&FairSeeds{ServerSeed: seed, ClientSeed: "other", Nonce: 7}))
+	assert.NotEqual(t, want, draw(&FairSeeds{ServerSeed: NewServerSeed(), ClientSeed: "client", Nonce: 7}))
+}
+
+func TestBuffer_WithPRNG(t *testing.T) {
+	s := &FairSeeds{ServerSeed: []byte("server"), ClientSeed: "client", Nonce: 1}
+
+	draw := func(b *Buffer) []int {
+		out := make([]int, 50)
+		b.IntsN(36, out)
+		return out
+	}
+
+	b1 := AcquireBuffer(s.AcquireRNG(), false)
+	defer b1.Release()
+	want := draw(b1)
+
+	b2 := AcquireBuffer(AcquireRNG(), false)
+	defer b2.Release()
+	draw(b2)
+	assert.Equal(t, want, draw(b2.WithPRNG(s.AcquireRNG())))
+}
@@ -0,0 +1,8 @@
+//go:build nosharedlib
+
+package rng
+
+// defaultBackend is the pure-Go ChaCha PRNG, as the package is built without the shared library.
+const defaultBackend = BackendChaCha
+
+var sharedLib *Backend
@@ -2,11 +2,20 @@ package rng
 
 import (
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/interfaces"
-	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/sharedlib"
 )
 
+// AcquireRNG instantiates a new PRNG from the selected backend.
+// Use Use() or UseSeed() to select a different backend.
 var AcquireRNG func() interfaces.Generator
 
+// AcquireShuffler instantiates a new shuffler from the selected backend.
+var AcquireShuffler func() interfaces.Shuffler
+
 func init() {
-	AcquireRNG = sharedlib.AcquireRNG
+	if sharedLib != nil {
+		Register(BackendSharedLib, *sharedLib)
+	}
+	if err := Use(defaultBackend); err != nil {
+		panic(err)
+	}
 }
@@ -0,0 +1,68 @@
+package rng
+
+import (
+	"encoding/binary"
+	"sync"
+
+	prng "git-codecommit.eu-central-1.amazonaws.com/v1/repos/prng.git/rng"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/interfaces"
+)
+
+// SeedSource produces deterministic ChaCha PRNGs derived from a master seed.
+// The PRNGs are the seeded CPRNGs of the prng module (see NewRNGFromSeed()).
+// The n-th PRNG acquired from the source is keyed with the SHA-256 hash of the seed and n,
+// so a sequence of rounds can be replayed exactly from the seed.
+// A SeedSource is safe for use across multiple go-routines, but the order in which the PRNGs are acquired
+// determines their output, so replays are only deterministic when the PRNGs are acquired in the same order.
+type SeedSource struct {
+	mutex sync.Mutex
+	seed  []byte
+	count uint64
+}
+
+// NewSeedSource instantiates a new seed source from the given seed.
+// The seed is copied, so the caller can reuse the slice.
+func NewSeedSource(seed []byte) *SeedSource {
+	s := &SeedSource{seed: make([]byte, len(seed))}
+	copy(s.seed, seed)
+	return s
+}
+
+// AcquireRNG instantiates the next deterministic PRNG from the source.
+func (s *SeedSource) AcquireRNG() interfaces.Generator {
+	s.mutex.Lock()
+	n := s.count
+	s.count++
+	s.mutex.Unlock()
+
+	b := make([]byte, len(s.seed)+8)
+	copy(b, s.seed)
+	binary.LittleEndian.PutUint64(b[len(s.seed):], n)
+
+	return prng.NewRNGFromSeed(b, seededRounds)
+}
+
+// Count returns the number of PRNGs acquired since the source was created or reset.
+func (s *SeedSource) Count() uint64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.count
+}
+
+// Reset restarts the sequence of PRNGs, so the next acquired PRNG is the same as the first one.
+func (s *SeedSource) Reset() {
+	s.mutex.Lock()
+	s.count = 0
+	s.mutex.Unlock()
+}
+
+// NewSeeded instantiates a deterministic ChaCha PRNG keyed with the SHA-256 hash of the given seed.
+// It produces the same sequence of numbers for the same seed, which makes it suitable for unit tests
+// and bug reproductions. Never use it in production!
+func NewSeeded(seed []byte) interfaces.Generator {
+	return prng.NewRNGFromSeed(seed, seededRounds)
+}
+
+// seededRounds is the number of rounds of the ChaCha cipher used for the seeded PRNGs.
+const seededRounds = 20
@@ -0,0 +1,18 @@
+//go:build !nosharedlib
+
+package rng
+
+import (
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/sharedlib"
+)
+
+// defaultBackend is the shared library, unless the package is built with the nosharedlib tag.
+const defaultBackend = BackendSharedLib
+
+var sharedLib = &Backend{
+	AcquireRNG:        sharedlib.AcquireRNG,
+	AcquireShuffler:   sharedlib.AcquireShuffler,
+	EnableHealthTests: sharedlib.EnableHealthTests,
+	HealthStatus:      sharedlib.HealthStatus,
+	ResetHealth:       sharedlib.ResetHealth,
+}
@@ -0,0 +1,41 @@
+package rng
+
+import (
+	"sync"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/interfaces"
+)
+
+// FisherYates implements the Shuffler interface using a PRNG.
+type FisherYates struct {
+	prng interfaces.Generator
+}
+
+// AcquireFisherYates instantiates a new shuffler from the memory pool.
+// It takes ownership of the supplied prng. *DO NOT* call ReturnToPool on the supplied prng!
+func AcquireFisherYates(prng interfaces.Generator) interfaces.Shuffler {
+	s := fisherYatesPool.Get().(*FisherYates)
+	s.prng = prng
+	return s
+}
+
+// Release implements the Objecter interface.
+func (s *FisherYates) Release() {
+	if s != nil {
+		s.prng.ReturnToPool()
+		s.prng = nil
+		fisherYatesPool.Put(s)
+	}
+}
+
+// Shuffle shuffles the slice into a random order using the FisherYates algorithm.
+// See https://en.wikipedia.org/wiki/Fisher%E2%80%93Yates_shuffle
+func (s *FisherYates) Shuffle(c []int) {
+	for ix := len(c) - 1; ix > 0; ix-- {
+		iy := s.prng.IntN(ix + 1)
+		c[ix], c[iy] = c[iy], c[ix]
+	}
+}
+
+// fisherYatesPool is the memory pool for FisherYates shufflers.
+var fisherYatesPool = sync.Pool{New: func() any { return &FisherYates{} }}
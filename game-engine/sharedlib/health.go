@@ -0,0 +1,42 @@
+//go:build !nosharedlib
+
+package sharedlib
+
+// #cgo CFLAGS: -I/usr/local/include
+// #cgo LDFLAGS: -L/usr/local/lib -lprng -Wl,-rpath=/usr/local/lib
+// #include "libprng.h"
+import "C"
+
+import (
+	"unsafe"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/prng.git/rng"
+)
+
+// EnableHealthTests enables or disables the online health tests of the shared library.
+func EnableHealthTests(enabled bool) {
+	var v C.GoInt
+	if enabled {
+		v = 1
+	}
+	C.EnableHealthTests(v)
+}
+
+// HealthStatus returns the counters of the online health tests of the shared library.
+func HealthStatus() rng.Health {
+	out := make([]uint64, 5)
+	C.GetHealth((*C.GoUint64)(unsafe.Pointer(&out[0])), C.GoInt(len(out)))
+
+	return rng.Health{
+		Enabled:            out[0] != 0,
+		Buffers:            out[1],
+		Samples:            out[2],
+		RepetitionFailures: out[3],
+		ProportionFailures: out[4],
+	}
+}
+
+// ResetHealth resets the counters of the online health tests of the shared library.
+func ResetHealth() {
+	C.ResetHealth()
+}
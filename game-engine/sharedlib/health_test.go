@@ -0,0 +1,35 @@
+//go:build !nosharedlib
+
+package sharedlib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthStatus(t *testing.T) {
+	defer func() {
+		EnableHealthTests(false)
+		ResetHealth()
+	}()
+
+	ResetHealth()
+	assert.False(t, HealthStatus().Enabled)
+
+	EnableHealthTests(true)
+	r := AcquireRNG()
+	for ix := 0; ix < 10000; ix++ {
+		r.Uint64()
+	}
+	r.ReturnToPool()
+
+	h := HealthStatus()
+	assert.True(t, h.Enabled)
+	assert.Greater(t, h.Buffers, uint64(0))
+	assert.Greater(t, h.Samples, uint64(0))
+	assert.True(t, h.Healthy())
+
+	ResetHealth()
+	assert.Zero(t, HealthStatus().Buffers)
+}
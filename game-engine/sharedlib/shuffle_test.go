@@ -1,3 +1,5 @@
+//go:build !nosharedlib
+
 package sharedlib
 
 import (
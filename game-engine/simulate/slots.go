@@ -0,0 +1,386 @@
+package simulate
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/tg"
+
+	analysis "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/analysis/game/slots"
+	metrics "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/analysis/metrics/slots"
+	comp "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/components/slots"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/games/cards"
+	game "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/games/slots"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/results"
+)
+
+// SlotsParams contains the parameters for a multi-core slot machine simulation.
+type SlotsParams struct {
+	GameNR   tg.GameNR                // game number; used to determine bonus kinds.
+	NewGame  func() *game.Regular     // instantiates a game with its own PRNG for each worker (required).
+	Symbols  *comp.SymbolSet          // complete symbol set; defaults to the symbols of the game.
+	Actions  comp.SpinActions         // all spin actions of the game; defaults to the actions of all spin stages.
+	Rounds   uint64                   // total number of rounds to play (required).
+	Workers  int                      // number of workers; defaults to the number of CPUs.
+	Bet      int64                    // bet per round; defaults to 100.
+	BonusBuy uint8                    // bonus buy kind; 0 for regular rounds.
+	Balance  int64                    // start balance for each worker.
+	Choices  func() map[string]string // returns player choices for games which need them (optional).
+	Progress func(done, total uint64) // called periodically with the progress (optional).
+	Interval time.Duration            // interval for progress reporting; defaults to 10s.
+	Options  func(r *analysis.Rounds) // sets analysis options for each worker (optional).
+
+	// Jackpots are the progressive jackpot pools the bets contribute to (optional).
+	// Each worker has its own pools; the amounts awarded from the pools are reported as the jackpot RTP.
+	Jackpots []analysis.JackpotPool
+
+	// Gamble is the gamble strategy for the winning rounds of games with a gamble feature (optional).
+	// It is called with the win at risk, as a factor of the bet, and the number of gamble steps played so far.
+	// It returns the kind of gamble and the pick for the next step; GambleCollect ends the gamble.
+	Gamble func(stake float64, steps uint8) (cards.GambleKind, int)
+
+	// Resume contains the metrics of an earlier, partial simulation of the same game (optional).
+	// Only the remaining rounds are played, and the metrics are merged into Resume, which is returned by Slots().
+	// Slots() takes ownership of Resume, and releases it if the simulation fails.
+	Resume *analysis.Rounds
+	// Checkpoint is called with the merged metrics after every batch of rounds,
+	// and when the simulation completes or is cancelled (optional).
+	// The simulation is aborted if it returns an error.
+	Checkpoint func(r *analysis.Rounds) error
+	// StopWidth stops the simulation early once the width of the confidence interval of the RTP, in percentage points,
+	// drops below the given target (optional). The width is verified after every batch of rounds.
+	StopWidth float64
+	// StopLevel is the confidence level for StopWidth; defaults to 95%.
+	StopLevel metrics.Confidence
+	// Batch is the number of rounds between checkpoints and convergence checks; defaults to 10 million.
+	Batch uint64
+}
+
+// Slots runs a slot machine simulation across multiple workers, each with its own game and PRNG.
+// The metrics of the workers are merged into the returned rounds metrics.
+// The simulation stops early if the context is cancelled, in which case the metrics of the completed rounds are returned.
+// If a checkpoint function is given, the rounds are played in batches, and the merged metrics are passed to the
+// checkpoint function after each batch, e.g. to save a snapshot so the simulation can be resumed later.
+// If a stop width is given, the simulation stops after the batch where the RTP confidence interval becomes narrow enough.
+// The caller must call Release() on the returned metrics if done with it.
+func Slots(ctx context.Context, params SlotsParams) (*analysis.Rounds, error) {
+	if params.NewGame == nil || params.Rounds == 0 {
+		return nil, ErrInvalidParams
+	}
+
+	g := params.NewGame()
+	if g == nil {
+		return nil, ErrNoGame
+	}
+
+	cost := params.bet()
+	if params.BonusBuy > 0 {
+		paid := g.ForSale(params.BonusBuy)
+		if paid == nil {
+			g.Release()
+			return nil, ErrNoBonusBuy
+		}
+		cost *= int64(paid.BetMultiplier())
+	}
+	g.Release()
+
+	var done atomic.Uint64
+	rounds := params.Resume
+	if rounds != nil {
+		if rounds.RoundCount >= params.Rounds {
+			return rounds, nil
+		}
+		done.Store(rounds.RoundCount)
+	}
+
+	stop := make(chan struct{})
+	if params.Progress != nil {
+		go params.progress(&done, stop)
+	}
+	defer close(stop)
+
+	every := params.Rounds
+	if params.Checkpoint != nil || params.StopWidth > 0 {
+		every = params.batchSize()
+	}
+
+	for remaining := params.Rounds - done.Load(); remaining > 0; remaining = params.Rounds - done.Load() {
+		count := min(remaining, every)
+
+		batch, err := params.batch(ctx, count, cost, &done)
+		if err != nil {
+			if rounds != nil {
+				rounds.Release()
+			}
+			return nil, err
+		}
+
+		if rounds == nil {
+			rounds = batch
+		} else if batch != nil {
+			rounds.Merge(batch)
+			batch.Release()
+		}
+
+		if params.Checkpoint != nil && rounds != nil {
+			if err = params.Checkpoint(rounds); err != nil {
+				rounds.Release()
+				return nil, err
+			}
+		}
+
+		if ctx.Err() != nil || params.converged(rounds) {
+			break
+		}
+	}
+
+	return rounds, nil
+}
+
+// NewRounds instantiates the rounds metrics for the given game.
+// If symbols is nil, the symbol set of the game is used.
+// If actions is empty, the actions of all spin stages of the game are used. Games with nested or alternative actions
+// must provide the complete list, as the metrics are indexed by action id.
+func NewRounds(gameNR tg.GameNR, playerID string, balance int64, g *game.Regular, symbols *comp.SymbolSet, actions comp.SpinActions) *analysis.Rounds {
+	s := g.Slots()
+	if symbols == nil {
+		symbols = s.Symbols()
+	}
+
+	if len(actions) == 0 {
+		actions = make(comp.SpinActions, 0, 64)
+		actions = append(actions, s.ActionsFirst()...)
+		actions = append(actions, s.ActionsFree()...)
+		actions = append(actions, s.ActionsFirstBB()...)
+		actions = append(actions, s.ActionsFreeBB()...)
+	}
+
+	var paylines comp.Paylines
+	if set := s.Paylines(); set != nil {
+		paylines = set.Paylines()
+	}
+
+	return analysis.AcquireRounds(gameNR, playerID, balance, s.ReelCount(), s.RowCount(), s.DoubleSpin(), s.MaxPayout(),
+		symbols, actions, paylines, s.RoundFlags())
+}
+
+// batch plays the given number of rounds across the workers, and returns the merged metrics.
+func (p *SlotsParams) batch(ctx context.Context, rounds uint64, cost int64, done *atomic.Uint64) (*analysis.Rounds, error) {
+	workers := p.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if uint64(workers) > rounds {
+		workers = int(rounds)
+	}
+
+	out := make([]*analysis.Rounds, workers)
+	errs := make([]error, workers)
+
+	wg := sync.WaitGroup{}
+	for ix := 0; ix < workers; ix++ {
+		count := rounds / uint64(workers)
+		if ix == 0 {
+			count += rounds % uint64(workers)
+		}
+
+		wg.Add(1)
+		go func(ix int, count uint64) {
+			defer wg.Done()
+			out[ix], errs[ix] = p.worker(ctx, count, cost, done)
+		}(ix, count)
+	}
+	wg.Wait()
+
+	var merged *analysis.Rounds
+	var err error
+	for ix := range out {
+		if errs[ix] != nil && err == nil {
+			err = errs[ix]
+		}
+		switch {
+		case out[ix] == nil:
+		case merged == nil:
+			merged = out[ix]
+		default:
+			merged.Merge(out[ix])
+			out[ix].Release()
+		}
+	}
+
+	if err != nil {
+		if merged != nil {
+			merged.Release()
+		}
+		return nil, err
+	}
+	return merged, nil
+}
+
+func (p *SlotsParams) worker(ctx context.Context, count uint64, cost int64, done *atomic.Uint64) (*analysis.Rounds, error) {
+	g := p.NewGame()
+	if g == nil {
+		return nil, ErrNoGame
+	}
+	defer g.Release()
+
+	rounds := NewRounds(p.GameNR, "simulate", p.Balance, g, p.Symbols, p.Actions)
+	if p.Options != nil {
+		p.Options(rounds)
+	}
+	if len(p.Jackpots) > 0 {
+		rounds.SetJackpotPools(p.Jackpots...)
+	}
+
+	bet := p.bet()
+	buf := make(results.Results, 0, 64)
+
+	for ix := uint64(0); ix < count; ix++ {
+		if ix%1000 == 0 {
+			select {
+			case <-ctx.Done():
+				return rounds, nil
+			default:
+			}
+		}
+
+		res, cloned, err := p.round(g, buf[:0])
+		if err != nil {
+			rounds.Release()
+			return nil, err
+		}
+
+		if p.Gamble != nil && g.Slots().Gamble() {
+			res, cloned = p.gamble(g, res, buf[:0], cloned)
+		}
+
+		rounds.Analyse(bet, cost, res)
+
+		if cloned {
+			buf = results.ReleaseResults(res)
+		}
+		done.Add(1)
+	}
+
+	return rounds, nil
+}
+
+// round plays a complete round including any player choices or second spins.
+// A round is resumed with new player choices for as long as the game needs them, e.g. for each pick of a pick'em game.
+// The results of a single call are owned by the game and remain valid until the next round.
+// If the round needs multiple calls, the results are cloned into out, and must be released by the caller.
+func (p *SlotsParams) round(g *game.Regular, out results.Results) (results.Results, bool, error) {
+	res := g.Round(p.BonusBuy)
+
+	switch {
+	case g.NeedPlayerChoice():
+		if p.Choices == nil {
+			return nil, false, ErrNeedChoices
+		}
+		out = cloneResults(out, res)
+		for ix := 0; ix < maxResumes && g.NeedPlayerChoice(); ix++ {
+			out = cloneResults(out, g.RoundResume(p.Choices()))
+		}
+		return out, true, nil
+
+	case g.IsDoubleSpin() && len(res) == 1:
+		out = cloneResults(out, res)
+		return cloneResults(out, g.Round(0)), true, nil
+
+	default:
+		return res, false, nil
+	}
+}
+
+// gamble plays the gamble steps of the strategy after a winning round, and appends them to the results.
+// The results are cloned into out before the first step is appended, unless they were cloned already.
+func (p *SlotsParams) gamble(g *game.Regular, res, out results.Results, cloned bool) (results.Results, bool) {
+	stake := results.GrandTotal2(res, g.MaxPayout())
+
+	// the step cap is checked before the strategy is consulted.
+	var steps uint8
+	limit := g.Slots().GambleSteps()
+	for stake > 0 && steps < limit {
+		kind, pick := p.Gamble(stake, steps)
+		_, step := g.Gamble(kind, stake, steps, pick)
+		if step == nil {
+			break
+		}
+
+		if !cloned {
+			res, cloned = cloneResults(out, res), true
+		}
+		res = append(res, step)
+
+		stake += step.Total
+		steps++
+	}
+	return res, cloned
+}
+
+func (p *SlotsParams) batchSize() uint64 {
+	if p.Batch > 0 {
+		return p.Batch
+	}
+	return 10000000
+}
+
+// converged returns true if the width of the RTP confidence interval dropped below the stop width.
+func (p *SlotsParams) converged(r *analysis.Rounds) bool {
+	if p.StopWidth <= 0 || r == nil {
+		return false
+	}
+
+	level := p.StopLevel
+	if level == 0 {
+		level = metrics.Confidence95
+	}
+	return r.RTPConfidenceWidth(level) < p.StopWidth
+}
+
+func (p *SlotsParams) bet() int64 {
+	if p.Bet > 0 {
+		return p.Bet
+	}
+	return 100
+}
+
+func (p *SlotsParams) progress(done *atomic.Uint64, stop chan struct{}) {
+	interval := p.Interval
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			p.Progress(done.Load(), p.Rounds)
+		}
+	}
+}
+
+func cloneResults(out, in results.Results) results.Results {
+	for ix := range in {
+		out = append(out, in[ix].Clone().(*results.Result))
+	}
+	return out
+}
+
+// maxResumes is the maximum number of times a round is resumed with player choices.
+const maxResumes = 100
+
+var (
+	ErrInvalidParams = errors.New("invalid simulation parameters")
+	ErrNoGame        = errors.New("simulation failed to instantiate the game")
+	ErrNoBonusBuy    = errors.New("game does not support the bonus buy kind")
+	ErrNeedChoices   = errors.New("game needs player choices")
+)
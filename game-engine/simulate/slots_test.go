@@ -0,0 +1,309 @@
+package simulate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/tg"
+
+	analysis "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/analysis/game/slots"
+	metrics "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/analysis/metrics/slots"
+	comp "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/components/slots"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/games/cards"
+	game "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/games/slots"
+)
+
+var (
+	sym1 = comp.NewSymbol(1, comp.WithPayouts(0, 0, 3, 6, 12), comp.WithWeights(90, 70, 90, 70, 90))
+	sym2 = comp.NewSymbol(2, comp.WithPayouts(0, 0, 3, 6, 15), comp.WithWeights(90, 70, 90, 70, 90))
+	sym3 = comp.NewSymbol(3, comp.WithPayouts(0, 0, 6, 7.5, 15), comp.WithWeights(70, 90, 70, 90, 70))
+	sym4 = comp.NewSymbol(4, comp.WithPayouts(0, 0, 6, 12, 21), comp.WithWeights(70, 90, 70, 90, 70))
+	wild = comp.NewSymbol(5, comp.WithKind(comp.Wild), comp.WithPayouts(0, 0, 6, 15, 45), comp.WithWeights(0, 8, 8, 8, 0))
+	scat = comp.NewSymbol(6, comp.WithKind(comp.Scatter), comp.WithPayouts(0, 3, 6, 24, 60), comp.WithWeights(3, 3, 3, 3, 3))
+
+	symbols = comp.NewSymbolSet(sym1, sym2, sym3, sym4, wild, scat)
+
+	pl1 = comp.NewPayline(1, 3, 1, 1, 1, 1, 1)
+	pl2 = comp.NewPayline(2, 3, 0, 0, 0, 0, 0)
+	pl3 = comp.NewPayline(3, 3, 2, 2, 2, 2, 2)
+
+	linePays  = comp.NewPaylinesAction()
+	freeSpins = comp.NewScatterFreeSpinsAction(5, false, 6, 3, false)
+	bonusBuy  = comp.NewPaidAction(comp.FreeSpins, 5, 50, scat.ID(), 3).WithBonusKind(5)
+
+	actions   = comp.SpinActions{linePays, freeSpins}
+	actionsBB = comp.SpinActions{bonusBuy, linePays, freeSpins}
+)
+
+func newSlots() *comp.Slots {
+	return comp.NewSlots(comp.Grid(5, 3), comp.WithSymbols(symbols), comp.WithPaylines(comp.PayLTR, false, pl1, pl2, pl3),
+		comp.WithActions(actions, actions, actionsBB, actions), comp.MaxPayout(5000))
+}
+
+func newParams(rounds uint64, workers int) SlotsParams {
+	s := newSlots()
+	return SlotsParams{
+		GameNR:  tg.BOTnr,
+		NewGame: func() *game.Regular { return game.AcquireRegular(game.RegularParams{Slots: s}) },
+		Rounds:  rounds,
+		Workers: workers,
+	}
+}
+
+func TestSlots(t *testing.T) {
+	testCases := []struct {
+		name    string
+		rounds  uint64
+		workers int
+	}{
+		{name: "single worker", rounds: 1000, workers: 1},
+		{name: "multiple workers", rounds: 10001, workers: 4},
+		{name: "more workers than rounds", rounds: 3, workers: 8},
+		{name: "default workers", rounds: 5000},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := Slots(context.Background(), newParams(tc.rounds, tc.workers))
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			defer r.Release()
+
+			assert.Equal(t, tc.rounds, r.RoundCount)
+			assert.Equal(t, tc.rounds, r.AllRounds.Count)
+			assert.Equal(t, int64(tc.rounds)*100, r.AllRounds.Bets.Total)
+		})
+	}
+}
+
+func TestSlotsBonusBuy(t *testing.T) {
+	t.Run("supported", func(t *testing.T) {
+		params := newParams(1000, 2)
+		params.BonusBuy = 5
+		params.Bet = 200
+		params.Balance = 1000000
+
+		r, err := Slots(context.Background(), params)
+		require.NoError(t, err)
+		require.NotNil(t, r)
+		defer r.Release()
+
+		assert.Equal(t, uint64(1000), r.RoundCount)
+		assert.Equal(t, int64(1000*200), r.AllRounds.Bets.Total)
+		assert.NotZero(t, r.FreeSpins)
+	})
+
+	t.Run("not supported", func(t *testing.T) {
+		s := comp.NewSlots(comp.Grid(5, 3), comp.WithSymbols(symbols), comp.WithActions(actions, actions, nil, nil))
+		params := newParams(1000, 2)
+		params.NewGame = func() *game.Regular { return game.AcquireRegular(game.RegularParams{Slots: s}) }
+		params.BonusBuy = 1
+
+		r, err := Slots(context.Background(), params)
+		require.ErrorIs(t, err, ErrNoBonusBuy)
+		assert.Nil(t, r)
+	})
+}
+
+func TestSlotsOptions(t *testing.T) {
+	var count int
+	params := newParams(100, 3)
+	params.Symbols = symbols
+	params.Options = func(r *analysis.Rounds) { count++ }
+
+	r, err := Slots(context.Background(), params)
+	require.NoError(t, err)
+	require.NotNil(t, r)
+	defer r.Release()
+
+	assert.Equal(t, 3, count)
+}
+
+func TestSlotsJackpots(t *testing.T) {
+	progressive := comp.NewProgressiveAction(1, 5)
+	withJackpot := comp.SpinActions{linePays, progressive}
+	s := comp.NewSlots(comp.Grid(5, 3), comp.WithSymbols(symbols), comp.WithPaylines(comp.PayLTR, false, pl1, pl2, pl3),
+		comp.WithActions(withJackpot, nil, nil, nil), comp.MaxPayout(5000))
+
+	params := newParams(2000, 2)
+	params.NewGame = func() *game.Regular { return game.AcquireRegular(game.RegularParams{Slots: s}) }
+	params.Jackpots = []analysis.JackpotPool{{Level: 1, Seed: 1000, Rate: 0.01}}
+
+	r, err := Slots(context.Background(), params)
+	require.NoError(t, err)
+	require.NotNil(t, r)
+	defer r.Release()
+
+	// each worker seeds its own pool, and reseeds it after every hit; without free spins every hit is a round.
+	hits := int64(r.Progressives["1"])
+	require.NotZero(t, hits)
+	assert.Equal(t, (2+hits)*1000, r.JackpotSeeds)
+
+	// the awarded amounts can't exceed the seeds and contributions.
+	bets := r.AllRounds.Bets.Total
+	assert.Greater(t, r.JackpotWins, hits*1000)
+	assert.LessOrEqual(t, r.JackpotWins, r.JackpotSeeds+bets/100)
+	assert.InDelta(t, float64(r.JackpotWins)*100/float64(bets), r.JackpotRTP(), 1e-9)
+	assert.InDelta(t, r.RTP()+r.JackpotRTP(), r.TotalRTP(), 1e-9)
+}
+
+func TestSlotsGamble(t *testing.T) {
+	s := comp.NewSlots(comp.Grid(5, 3), comp.WithSymbols(symbols), comp.WithPaylines(comp.PayLTR, false, pl1, pl2, pl3),
+		comp.WithActions(actions, actions, actionsBB, actions), comp.MaxPayout(5000), comp.WithGamble(100, 2))
+
+	params := newParams(2000, 2)
+	params.NewGame = func() *game.Regular { return game.AcquireRegular(game.RegularParams{Slots: s}) }
+	params.Gamble = func(_ float64, steps uint8) (cards.GambleKind, int) {
+		assert.Less(t, steps, uint8(2))
+		return cards.GambleColor, 1
+	}
+
+	r, err := Slots(context.Background(), params)
+	require.NoError(t, err)
+	require.NotNil(t, r)
+	defer r.Release()
+
+	assert.Equal(t, uint64(2000), r.RoundCount)
+	assert.NotZero(t, r.GambleSteps)
+	assert.NotZero(t, r.GambleWins)
+	assert.Less(t, r.GambleWins, r.GambleSteps)
+}
+
+func TestSlotsCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	r, err := Slots(ctx, newParams(100000, 2))
+	require.NoError(t, err)
+	require.NotNil(t, r)
+	defer r.Release()
+
+	assert.Zero(t, r.RoundCount)
+}
+
+func TestSlotsCheckpoint(t *testing.T) {
+	var counts []uint64
+
+	params := newParams(1000, 2)
+	params.Batch = 400
+	params.Checkpoint = func(r *analysis.Rounds) error {
+		counts = append(counts, r.RoundCount)
+		return nil
+	}
+
+	r, err := Slots(context.Background(), params)
+	require.NoError(t, err)
+	require.NotNil(t, r)
+	defer r.Release()
+
+	assert.Equal(t, uint64(1000), r.RoundCount)
+	assert.Equal(t, []uint64{400, 800, 1000}, counts)
+}
+
+func TestSlotsCheckpointFail(t *testing.T) {
+	fail := errors.New("disk full")
+
+	params := newParams(1000, 2)
+	params.Batch = 400
+	params.Checkpoint = func(r *analysis.Rounds) error { return fail }
+
+	r, err := Slots(context.Background(), params)
+	require.ErrorIs(t, err, fail)
+	assert.Nil(t, r)
+}
+
+func TestSlotsResume(t *testing.T) {
+	var snapshot []byte
+
+	params := newParams(600, 2)
+	params.Checkpoint = func(r *analysis.Rounds) error {
+		snapshot = r.Snapshot()
+		return nil
+	}
+
+	r, err := Slots(context.Background(), params)
+	require.NoError(t, err)
+	require.NotNil(t, r)
+	r.Release()
+
+	g := params.NewGame()
+	require.NotNil(t, g)
+	defer g.Release()
+
+	resume := NewRounds(params.GameNR, "simulate", 0, g, nil, nil)
+	require.NotNil(t, resume)
+	require.NoError(t, resume.ReadSnapshot(snapshot))
+	assert.Equal(t, uint64(600), resume.RoundCount)
+
+	params = newParams(1000, 2)
+	params.Resume = resume
+
+	r, err = Slots(context.Background(), params)
+	require.NoError(t, err)
+	require.NotNil(t, r)
+	defer r.Release()
+
+	assert.Equal(t, uint64(1000), r.RoundCount)
+	assert.Equal(t, uint64(1000), r.AllRounds.Count)
+	assert.Equal(t, int64(100000), r.AllRounds.Bets.Total)
+}
+
+func TestSlotsStopWidth(t *testing.T) {
+	testCases := []struct {
+		name  string
+		width float64
+		level metrics.Confidence
+		max   uint64
+	}{
+		{name: "converges early", width: 1000, max: 1000},
+		{name: "converges early 99%", width: 1000, level: metrics.Confidence99, max: 1000},
+		{name: "never converges", width: 0.0001, max: 5000},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			params := newParams(5000, 2)
+			params.Batch = 1000
+			params.StopWidth = tc.width
+			params.StopLevel = tc.level
+
+			r, err := Slots(context.Background(), params)
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			defer r.Release()
+
+			assert.Equal(t, tc.max, r.RoundCount)
+			if tc.max < params.Rounds {
+				level := tc.level
+				if level == 0 {
+					level = metrics.Confidence95
+				}
+				assert.Less(t, r.RTPConfidenceWidth(level), tc.width)
+			}
+		})
+	}
+}
+
+func TestSlotsInvalid(t *testing.T) {
+	t.Run("no game", func(t *testing.T) {
+		r, err := Slots(context.Background(), SlotsParams{Rounds: 100})
+		require.ErrorIs(t, err, ErrInvalidParams)
+		assert.Nil(t, r)
+	})
+
+	t.Run("no rounds", func(t *testing.T) {
+		r, err := Slots(context.Background(), newParams(0, 1))
+		require.ErrorIs(t, err, ErrInvalidParams)
+		assert.Nil(t, r)
+	})
+
+	t.Run("nil game", func(t *testing.T) {
+		r, err := Slots(context.Background(), SlotsParams{Rounds: 100, NewGame: func() *game.Regular { return nil }})
+		require.ErrorIs(t, err, ErrNoGame)
+		assert.Nil(t, r)
+	})
+}
@@ -79,6 +79,17 @@ func (w *WeightingNoDedup) Options() Indexes {
 	return w.indexes
 }
 
+// Weights returns the weights of the item indexes, as rounded to 3 decimals when they were added.
+func (w *WeightingNoDedup) Weights() []float64 {
+	out := make([]float64, len(w.weights))
+	var prev int
+	for ix := range w.weights {
+		out[ix] = float64(w.weights[ix]-prev) / 1000
+		prev = w.weights[ix]
+	}
+	return out
+}
+
 // RandomIndex calculates a random item index using the given PRNG if needed.
 // The function panics if the Weighting hasn't been initialized properly.
 // Even if the no-repeat feature is set, it cannot be used here,
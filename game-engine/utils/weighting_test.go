@@ -100,6 +100,14 @@ func TestWeighting_AddWeights(t *testing.T) {
 			assert.Equal(t, tc.length, len(w.weights))
 			assert.Equal(t, tc.total, w.total)
 
+			weights := make([]float64, 0, len(tc.weights))
+			for ix := range tc.weights {
+				if tc.weights[ix] > 0 {
+					weights = append(weights, tc.weights[ix])
+				}
+			}
+			assert.Equal(t, weights, w.Weights())
+
 			max := w.total / 25
 			counts := make(map[Index]int, tc.length)
 			for ix := 0; ix < max; ix++ {
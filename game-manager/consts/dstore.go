@@ -9,14 +9,24 @@ const (
 	MsgDsComplexBetFailed      = "ds complex round bet failed"
 	MsgDsComplexWinFailed      = "ds complex round win failed"
 	MsgDsComplexCompleteFailed = "ds complex round complete failed"
+	MsgDsGambleRoundFailed     = "ds gamble round failed"
 	MsgDsRoundNextFailed       = "ds round next failed"
 	MsgDsGetRoundStateFailed   = "ds get round state failed"
+	MsgDsGetRoundFailed        = "ds get round failed"
 	MsgDsPutSessionStateFailed = "ds put session state failed"
 	MsgDsGetSessionStateFailed = "ds get session state failed"
 	MsgDsPutGamePrefsFailed    = "ds put game prefs failed"
 	MsgDsGetGamePrefsFailed    = "ds get game prefs failed"
 	MsgDsPutPlayerPrefsFailed  = "ds put player prefs failed"
 	MsgDsGetPlayerPrefsFailed  = "ds get player prefs failed"
+	MsgDsPutCampaignFailed     = "ds put campaign failed"
+	MsgDsGetCampaignsFailed    = "ds get campaigns failed"
+	MsgDsPutFairSeedFailed     = "ds put fair seed failed"
+	MsgDsGetFairSeedFailed     = "ds get fair seed failed"
+	MsgDsPutJackpotFailed      = "ds put jackpot failed"
+	MsgDsGetJackpotsFailed     = "ds get jackpots failed"
+	MsgDsAcquireLeaseFailed    = "ds acquire session lease failed"
+	MsgDsReleaseLeaseFailed    = "ds release session lease failed"
 	MsgDsInvalidStatus         = "ds invalid HTTP status %d from API call"
 
 	DefaultContentType   = "application/json"
@@ -28,8 +38,13 @@ const (
 	DsRoundInitURI       = "/v1/init-round"
 	DsRoundCompleteURI   = "/v1/complete-round"
 	DsRoundNextURI       = "/v1/round/next"
+	DsRoundGambleURI     = "/v1/round/gamble"
 	DsRoundStateURI      = "/v1/round-state"
 	DsSessionStateURI    = "/v1/session-state"
 	DsGameStateURI       = "/v1/player-game-state"
 	DsPlayerStateURI     = "/v1/player-global-state"
+	DsCampaignsURI       = "/v1/player-campaigns"
+	DsFairSeedURI        = "/v1/fair-seed"
+	DsJackpotsURI        = "/v1/jackpots"
+	DsSessionLeaseURI    = "/v1/session-lease"
 )
@@ -5,4 +5,6 @@ const (
 	FieldError   = "error"
 	FieldRound   = "round"
 	FieldSession = "session"
+	FieldOwner   = "owner"
+	FieldGame    = "game"
 )
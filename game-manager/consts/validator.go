@@ -7,6 +7,8 @@ import (
 
 const (
 	DefaultSessionExpire = 2 * time.Hour
+	DefaultStartBalance  = 1000000
+	MaxEmbeddedRounds    = 100
 )
 
 var (
@@ -14,4 +16,8 @@ var (
 	ErrSessionNotFound   = fmt.Errorf("session not found")
 	ErrSpinSeqNotFound   = fmt.Errorf("spin sequence not found")
 	ErrGameStateNotFound = fmt.Errorf("game state not found")
+	ErrDuplicateRequest  = fmt.Errorf("duplicate request")
+	ErrRoundNotFound     = fmt.Errorf("round not found")
+	ErrInsufficientFunds = fmt.Errorf("insufficient funds")
+	ErrCampaignInactive  = fmt.Errorf("campaign not active")
 )
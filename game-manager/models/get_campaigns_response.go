@@ -0,0 +1,71 @@
+package models
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/encode/zjson"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/object-pool/pool"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-manager.git/state/slots"
+)
+
+func UnmarshallGetCampaignsResponse(resp *http.Response) ([]*slots.Campaign, error) {
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	r := campaignsResponsePool.Acquire().(*campaignsResponse)
+	defer r.Release()
+
+	dec := zjson.AcquireDecoder(b)
+	defer dec.Release()
+
+	if !dec.Object(r) || !r.found {
+		if err = dec.Error(); err == nil {
+			err = fmt.Errorf("empty response")
+		}
+		return nil, err
+	}
+
+	return append([]*slots.Campaign{}, r.campaigns...), nil
+}
+
+type campaignsResponse struct {
+	found     bool
+	campaigns []*slots.Campaign
+	pool.Object
+}
+
+var campaignsResponsePool = pool.NewProducer(func() (pool.Objecter, func()) {
+	r := &campaignsResponse{campaigns: make([]*slots.Campaign, 0, 4)}
+	return r, r.reset
+})
+
+func (r *campaignsResponse) reset() {
+	r.found = false
+	clear(r.campaigns)
+	r.campaigns = r.campaigns[:0]
+}
+
+func (r *campaignsResponse) DecodeField(dec *zjson.Decoder, key []byte) error {
+	if string(key) == "campaigns" {
+		r.found = true
+		if dec.Array(r.decodeCampaign) {
+			return nil
+		}
+		return dec.Error()
+	}
+	return nil // ignore unknown fields
+}
+
+func (r *campaignsResponse) decodeCampaign(dec *zjson.Decoder) error {
+	c := &slots.Campaign{}
+	if dec.Object(c) {
+		r.campaigns = append(r.campaigns, c)
+		return nil
+	}
+	return dec.Error()
+}
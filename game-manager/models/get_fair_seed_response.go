@@ -0,0 +1,61 @@
+package models
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/encode/zjson"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/object-pool/pool"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-manager.git/state/slots"
+)
+
+func UnmarshallGetFairSeedResponse(resp *http.Response) (*slots.FairSeed, error) {
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	r := fairSeedResponsePool.Acquire().(*fairSeedResponse)
+	defer r.Release()
+
+	dec := zjson.AcquireDecoder(b)
+	defer dec.Release()
+
+	if !dec.Object(r) || !r.found {
+		if err = dec.Error(); err == nil {
+			err = fmt.Errorf("empty response")
+		}
+		return nil, err
+	}
+
+	return r.seed.Clone(), nil
+}
+
+type fairSeedResponse struct {
+	found bool
+	seed  slots.FairSeed
+	pool.Object
+}
+
+var fairSeedResponsePool = pool.NewProducer(func() (pool.Objecter, func()) {
+	r := &fairSeedResponse{}
+	return r, r.reset
+})
+
+func (r *fairSeedResponse) reset() {
+	r.found = false
+	r.seed = slots.FairSeed{}
+}
+
+func (r *fairSeedResponse) DecodeField(dec *zjson.Decoder, key []byte) error {
+	if string(key) == "fairSeed" {
+		r.found = true
+		if dec.Object(&r.seed) {
+			return nil
+		}
+		return dec.Error()
+	}
+	return nil // ignore unknown fields
+}
@@ -0,0 +1,71 @@
+package models
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/encode/zjson"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/object-pool/pool"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-manager.git/state/slots"
+)
+
+func UnmarshallGetJackpotsResponse(resp *http.Response) ([]*slots.Jackpot, error) {
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	r := jackpotsResponsePool.Acquire().(*jackpotsResponse)
+	defer r.Release()
+
+	dec := zjson.AcquireDecoder(b)
+	defer dec.Release()
+
+	if !dec.Object(r) || !r.found {
+		if err = dec.Error(); err == nil {
+			err = fmt.Errorf("empty response")
+		}
+		return nil, err
+	}
+
+	return append([]*slots.Jackpot{}, r.jackpots...), nil
+}
+
+type jackpotsResponse struct {
+	found    bool
+	jackpots []*slots.Jackpot
+	pool.Object
+}
+
+var jackpotsResponsePool = pool.NewProducer(func() (pool.Objecter, func()) {
+	r := &jackpotsResponse{jackpots: make([]*slots.Jackpot, 0, 4)}
+	return r, r.reset
+})
+
+func (r *jackpotsResponse) reset() {
+	r.found = false
+	clear(r.jackpots)
+	r.jackpots = r.jackpots[:0]
+}
+
+func (r *jackpotsResponse) DecodeField(dec *zjson.Decoder, key []byte) error {
+	if string(key) == "jackpots" {
+		r.found = true
+		if dec.Array(r.decodeJackpot) {
+			return nil
+		}
+		return dec.Error()
+	}
+	return nil // ignore unknown fields
+}
+
+func (r *jackpotsResponse) decodeJackpot(dec *zjson.Decoder) error {
+	j := &slots.Jackpot{}
+	if dec.Object(j) {
+		r.jackpots = append(r.jackpots, j)
+		return nil
+	}
+	return dec.Error()
+}
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/encode/zjson"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/object-pool/pool"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-manager.git/state/slots"
+)
+
+func UnmarshallGetRoundResponse(sessionID, roundID string, resp *http.Response) (*slots.StoredRound, error) {
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	r := storedRoundResponsePool.Acquire().(*storedRoundResponse)
+	defer r.Release()
+
+	dec := zjson.AcquireDecoder(b)
+	defer dec.Release()
+
+	if !dec.Object(r) || len(r.results) == 0 {
+		if err = dec.Error(); err == nil {
+			err = fmt.Errorf("empty response")
+		}
+		return nil, err
+	}
+
+	return slots.NewStoredRound(sessionID, roundID, r.bet, r.win, r.results), nil
+}
+
+type storedRoundResponse struct {
+	bet     int64
+	win     int64
+	results slots.RoundResults
+	pool.Object
+}
+
+var storedRoundResponsePool = pool.NewProducer(func() (pool.Objecter, func()) {
+	r := &storedRoundResponse{}
+	return r, r.reset
+})
+
+func (r *storedRoundResponse) reset() {
+	r.bet = 0
+	r.win = 0
+	for ix := range r.results {
+		r.results[ix].Release()
+		r.results[ix] = nil
+	}
+	r.results = nil
+}
+
+func (r *storedRoundResponse) DecodeField(dec *zjson.Decoder, key []byte) error {
+	ok := true
+
+	switch string(key) {
+	case "bet":
+		r.bet, ok = dec.Int64()
+	case "win":
+		r.win, ok = dec.Int64()
+	case "result":
+		var b []byte
+		if b, _, ok = dec.String(); ok {
+			var err error
+			r.results, err = slots.AcquireRoundResultsFromJSON(dec.Unescaped(b))
+			return err
+		}
+	default:
+		return nil // ignore unknown fields
+	}
+
+	if ok {
+		return nil
+	}
+	return dec.Error()
+}
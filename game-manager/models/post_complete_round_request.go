@@ -10,6 +10,7 @@ func MarshallCompleteRoundRequest(r *slots.Round, rs *slots.RoundState, debug bo
 	enc := zjson.AcquireEncoder(4096)
 	enc.StartObject()
 	enc.StringField("sessionId", r.SessionID())
+	enc.StringFieldOpt("requestId", r.RequestID())
 	enc.StringField("roundId", r.RoundID())
 	enc.BoolFieldOpt("debug", debug)
 	enc.Int64Field("win", r.TotalWin())
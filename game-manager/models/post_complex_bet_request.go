@@ -10,9 +10,11 @@ func MarshallComplexBetRequest(roundID string, r *slots.Round, debug, withData b
 	enc := zjson.AcquireEncoder(4096)
 	enc.StartObject()
 	enc.StringField("sessionId", r.SessionID())
+	enc.StringFieldOpt("requestId", r.RequestID())
 	enc.StringField("roundId", roundID)
 	enc.Int64Field("bet", r.TotalBet())
 	enc.BoolFieldOpt("debug", debug)
+	encodeJackpot(enc, r)
 
 	if withData {
 		enc2 := zjson.AcquireEncoder(4096)
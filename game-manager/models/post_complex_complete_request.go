@@ -10,8 +10,12 @@ func MarshallComplexCompleteRequest(r *slots.Round, rs *slots.RoundState, debug,
 	enc := zjson.AcquireEncoder(4096)
 	enc.StartObject()
 	enc.StringField("sessionId", r.SessionID())
+	enc.StringFieldOpt("requestId", r.RequestID())
 	enc.StringField("roundId", r.RoundID())
 	enc.BoolFieldOpt("debug", debug)
+	if c := r.Campaign(); c != nil {
+		enc.StringField("campaignId", c.ID)
+	}
 
 	if withData {
 		enc2 := zjson.AcquireEncoder(4096)
@@ -10,7 +10,11 @@ func MarshallComplexInitRequest(r *slots.Round, debug, withData bool) (*zjson.En
 	enc := zjson.AcquireEncoder(4096)
 	enc.StartObject()
 	enc.StringField("sessionId", r.SessionID())
+	enc.StringFieldOpt("requestId", r.RequestID())
 	enc.BoolFieldOpt("debug", debug)
+	if c := r.Campaign(); c != nil {
+		enc.StringField("campaignId", c.ID)
+	}
 
 	if withData {
 		enc2 := zjson.AcquireEncoder(4096)
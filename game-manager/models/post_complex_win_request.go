@@ -10,6 +10,7 @@ func MarshallComplexWinRequest(roundID string, r *slots.Round, debug, withData b
 	enc := zjson.AcquireEncoder(4096)
 	enc.StartObject()
 	enc.StringField("sessionId", r.SessionID())
+	enc.StringFieldOpt("requestId", r.RequestID())
 	enc.StringField("roundId", roundID)
 	enc.Int64Field("win", r.TotalWin())
 	enc.BoolFieldOpt("debug", debug)
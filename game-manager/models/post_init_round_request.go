@@ -10,6 +10,7 @@ func MarshallInitRoundRequest(r *slots.Round, debug bool) (*zjson.Encoder, error
 	enc := zjson.AcquireEncoder(4096)
 	enc.StartObject()
 	enc.StringField("sessionId", r.SessionID())
+	enc.StringFieldOpt("requestId", r.RequestID())
 	enc.Int64Field("bet", r.TotalBet())
 	enc.BoolFieldOpt("debug", debug)
 
@@ -10,9 +10,14 @@ func MarshallRoundRequest(r *slots.Round, debug bool) (*zjson.Encoder, error) {
 	enc := zjson.AcquireEncoder(4096)
 	enc.StartObject()
 	enc.StringField("sessionId", r.SessionID())
+	enc.StringFieldOpt("requestId", r.RequestID())
 	enc.Int64Field("bet", r.TotalBet())
 	enc.Int64Field("win", r.TotalWin())
 	enc.BoolFieldOpt("debug", debug)
+	if c := r.Campaign(); c != nil {
+		enc.StringField("campaignId", c.ID)
+	}
+	encodeJackpot(enc, r)
 
 	enc2 := zjson.AcquireEncoder(4096)
 	enc2.StartArray()
@@ -40,3 +45,13 @@ func MarshallRoundRequest(r *slots.Round, debug bool) (*zjson.Encoder, error) {
 	enc.EndObject()
 	return enc, nil
 }
+
+// encodeJackpot adds the fields D-store needs to contribute the bet of the round to the jackpot pools.
+func encodeJackpot(enc *zjson.Encoder, r *slots.Round) {
+	if r.GameID() == "" || r.Currency() == "" {
+		return
+	}
+	enc.StringField("gameId", r.GameID())
+	enc.StringField("currency", r.Currency())
+	enc.Uint8FieldOpt("jackpotLevel", r.JackpotLevel())
+}
@@ -7,9 +7,12 @@ import (
 
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/encode/zjson"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/object-pool/pool"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-manager.git/consts"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-manager.git/state/slots"
 )
 
-func UnmarshallRoundResponse(resp *http.Response) (string, int64, error) {
+func UnmarshallRoundResponse(resp *http.Response, round *slots.Round) (string, int64, error) {
 	b, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return "", 0, err
@@ -28,13 +31,22 @@ func UnmarshallRoundResponse(resp *http.Response) (string, int64, error) {
 		return "", 0, err
 	}
 
+	if len(r.jackpots) > 0 {
+		round.SetJackpotHits(r.jackpots)
+	}
+
+	if r.duplicate {
+		return r.roundID, r.playerData.balance, consts.ErrDuplicateRequest
+	}
 	return r.roundID, r.playerData.balance, nil
 }
 
 type roundResponse struct {
 	success    bool
+	duplicate  bool
 	roundID    string
 	playerData playerData
+	jackpots   []slots.JackpotHit
 	pool.Object
 }
 
@@ -45,8 +57,10 @@ var roundResponsePool = pool.NewProducer(func() (pool.Objecter, func()) {
 
 func (r *roundResponse) reset() {
 	r.success = false
+	r.duplicate = false
 	r.roundID = ""
 	r.playerData.balance = 0
+	r.jackpots = r.jackpots[:0]
 }
 
 func (r *roundResponse) DecodeField(dec *zjson.Decoder, key []byte) error {
@@ -68,6 +82,14 @@ func (r *roundResponse) DecodeField(dec *zjson.Decoder, key []byte) error {
 		if r.success, ok = dec.Bool(); ok {
 			return nil
 		}
+	} else if string(key) == "duplicate" {
+		if r.duplicate, ok = dec.Bool(); ok {
+			return nil
+		}
+	} else if string(key) == "jackpots" {
+		if ok = dec.Array(r.decodeJackpot); ok {
+			return nil
+		}
 	} else {
 		return nil // ignore unknown fields
 	}
@@ -75,6 +97,15 @@ func (r *roundResponse) DecodeField(dec *zjson.Decoder, key []byte) error {
 	return dec.Error()
 }
 
+func (r *roundResponse) decodeJackpot(dec *zjson.Decoder) error {
+	h := slots.JackpotHit{}
+	if dec.Object(&h) {
+		r.jackpots = append(r.jackpots, h)
+		return nil
+	}
+	return dec.Error()
+}
+
 type playerData struct {
 	balance int64
 }
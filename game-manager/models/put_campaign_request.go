@@ -0,0 +1,16 @@
+package models
+
+import (
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/encode/zjson"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-manager.git/state/slots"
+)
+
+func MarshallCampaignRequest(playerID string, campaign *slots.Campaign) (*zjson.Encoder, error) {
+	enc := zjson.AcquireEncoder(512)
+	enc.StartObject()
+	enc.StringField("playerId", playerID)
+	enc.ObjectField("campaign", campaign)
+	enc.EndObject()
+	return enc, nil
+}
@@ -0,0 +1,16 @@
+package models
+
+import (
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/encode/zjson"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-manager.git/state/slots"
+)
+
+func MarshallFairSeedRequest(sessionID string, seed *slots.FairSeed) (*zjson.Encoder, error) {
+	enc := zjson.AcquireEncoder(256)
+	enc.StartObject()
+	enc.StringField("sessionId", sessionID)
+	enc.ObjectField("fairSeed", seed)
+	enc.EndObject()
+	return enc, nil
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/encode/zjson"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/object-pool/pool"
+)
+
+func UnmarshallPutFairSeedResponse(resp *http.Response) (bool, error) {
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return false, err
+	}
+
+	r := putFairSeedResponsePool.Acquire().(*putFairSeedResponse)
+	defer r.Release()
+
+	dec := zjson.AcquireDecoder(b)
+	defer dec.Release()
+
+	if !dec.Object(r) || !r.success {
+		if err = dec.Error(); err == nil {
+			err = fmt.Errorf("invalid response or success==false")
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+type putFairSeedResponse struct {
+	success bool
+	pool.Object
+}
+
+var putFairSeedResponsePool = pool.NewProducer(func() (pool.Objecter, func()) {
+	r := &putFairSeedResponse{}
+	return r, r.reset
+})
+
+func (r *putFairSeedResponse) reset() {
+	r.success = false
+}
+
+func (r *putFairSeedResponse) DecodeField(dec *zjson.Decoder, key []byte) error {
+	if string(key) == "success" {
+		if success, ok := dec.Bool(); ok {
+			r.success = success
+			return nil
+		}
+		return dec.Error()
+	}
+	return nil // ignore unknown fields
+}
@@ -0,0 +1,15 @@
+package models
+
+import (
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/encode/zjson"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-manager.git/state/slots"
+)
+
+func MarshallJackpotRequest(jackpot *slots.Jackpot) (*zjson.Encoder, error) {
+	enc := zjson.AcquireEncoder(512)
+	enc.StartObject()
+	enc.ObjectField("jackpot", jackpot)
+	enc.EndObject()
+	return enc, nil
+}
@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/encode/zjson"
+)
+
+func MarshallSessionLeaseRequest(sessionID, owner string, ttl time.Duration) (*zjson.Encoder, error) {
+	enc := zjson.AcquireEncoder(256)
+	enc.StartObject()
+	enc.StringField("sessionId", sessionID)
+	enc.StringField("owner", owner)
+	enc.Int64Field("ttl", ttl.Milliseconds())
+	enc.EndObject()
+	return enc, nil
+}
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/encode/zjson"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/object-pool/pool"
+)
+
+func UnmarshallSessionLeaseResponse(resp *http.Response) (bool, error) {
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return false, err
+	}
+
+	r := sessionLeaseResponsePool.Acquire().(*sessionLeaseResponse)
+	defer r.Release()
+
+	dec := zjson.AcquireDecoder(b)
+	defer dec.Release()
+
+	if !dec.Object(r) || !r.success {
+		if err = dec.Error(); err == nil {
+			err = fmt.Errorf("invalid response or success==false")
+		}
+		return false, err
+	}
+
+	return r.acquired, nil
+}
+
+type sessionLeaseResponse struct {
+	success  bool
+	acquired bool
+	pool.Object
+}
+
+var sessionLeaseResponsePool = pool.NewProducer(func() (pool.Objecter, func()) {
+	r := &sessionLeaseResponse{}
+	return r, r.reset
+})
+
+func (r *sessionLeaseResponse) reset() {
+	r.success = false
+	r.acquired = false
+}
+
+func (r *sessionLeaseResponse) DecodeField(dec *zjson.Decoder, key []byte) error {
+	ok := true
+
+	switch string(key) {
+	case "success":
+		r.success, ok = dec.Bool()
+	case "acquired":
+		r.acquired, ok = dec.Bool()
+	default:
+		return nil // ignore unknown fields
+	}
+
+	if ok {
+		return nil
+	}
+	return dec.Error()
+}
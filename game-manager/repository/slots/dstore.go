@@ -2,6 +2,7 @@ package slots
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -35,10 +36,15 @@ type dstore struct {
 	roundInitURI       string
 	roundCompleteURI   string
 	roundNextURI       string
+	roundGambleURI     string
 	roundStateURI      string
 	sessionStateURI    string
 	gamePrefsURI       string
 	playerPrefsURI     string
+	campaignsURI       string
+	fairSeedURI        string
+	jackpotsURI        string
+	sessionLeaseURI    string
 	logger             log.Logger
 }
 
@@ -76,10 +82,15 @@ func NewDStore(uri, apikey string, timeout time.Duration, transport http.RoundTr
 		roundInitURI:       prefix + consts.DsRoundInitURI,
 		roundCompleteURI:   prefix + consts.DsRoundCompleteURI,
 		roundNextURI:       prefix + consts.DsRoundNextURI,
+		roundGambleURI:     prefix + consts.DsRoundGambleURI,
 		roundStateURI:      prefix + consts.DsRoundStateURI,
 		sessionStateURI:    prefix + consts.DsSessionStateURI,
 		gamePrefsURI:       prefix + consts.DsGameStateURI,
 		playerPrefsURI:     prefix + consts.DsPlayerStateURI,
+		campaignsURI:       prefix + consts.DsCampaignsURI,
+		fairSeedURI:        prefix + consts.DsFairSeedURI,
+		jackpotsURI:        prefix + consts.DsJackpotsURI,
+		sessionLeaseURI:    prefix + consts.DsSessionLeaseURI,
 		logger:             logger,
 		logReqResp:         reqResp,
 	}
@@ -92,7 +103,7 @@ func (m *dstore) PostRound(r *slots.Round, debug bool) (string, int64, error) {
 	if err != nil {
 		return m.roundFailed(consts.MsgDsRoundFailed, enc, nil, err)
 	}
-	return m.postRound(consts.MsgDsRoundFailed, m.roundURI, enc)
+	return m.postRound(consts.MsgDsRoundFailed, m.roundURI, enc, r)
 }
 
 // PostInitRound posts the initial results of a round to D-store, and returns the new balance if the round was accepted.
@@ -101,14 +112,24 @@ func (m *dstore) PostInitRound(r *slots.Round, debug bool) (string, int64, error
 	haveBet := r.TotalBet() > 0
 	haveWin := r.TotalWin() > 0
 
+	// with a request id each step is deduplicated by D-store, so a retry completes the steps which were not booked.
+	dup := true
+
 	roundID, balance, err := m.postComplexInit(r, debug, !haveBet && !haveWin)
+	err = duplicate(err, &dup)
 
 	if err == nil && haveBet {
 		_, balance, err = m.postComplexBet(roundID, r, debug, !haveWin)
+		err = duplicate(err, &dup)
 	}
 
 	if err == nil && haveWin {
 		_, balance, err = m.postComplexWin(roundID, r, debug, true)
+		err = duplicate(err, &dup)
+	}
+
+	if err == nil && dup {
+		err = consts.ErrDuplicateRequest
 	}
 	return roundID, balance, err
 }
@@ -120,13 +141,41 @@ func (m *dstore) PostCompleteRound(r *slots.Round, rs *slots.RoundState, debug b
 		rs.ResumePlay(len(r.Results()) + 1)
 	}
 
+	dup := true
+
 	haveWin := r.TotalWin() > 0
 	if haveWin {
-		if _, _, err := m.postComplexWin(r.RoundID(), r, debug, true); err != nil {
+		if _, _, err := m.postComplexWin(r.RoundID(), r, debug, true); duplicate(err, &dup) != nil {
 			return "", 0, err
 		}
 	}
-	return m.postComplexComplete(r, rs, debug, !haveWin)
+
+	roundID, balance, err := m.postComplexComplete(r, rs, debug, !haveWin)
+	if err = duplicate(err, &dup); err == nil && dup {
+		err = consts.ErrDuplicateRequest
+	}
+	return roundID, balance, err
+}
+
+// PostGambleRound posts a gamble step on the win of a round to D-store, and returns the new balance if the step was accepted.
+// The stake of the step was booked as win of the round before, so D-store only books the change of the stake.
+// If the API call fails the function will return false.
+func (m *dstore) PostGambleRound(r *slots.Round, debug bool) (string, int64, error) {
+	enc, err := models2.MarshallGambleRoundRequest(r, debug)
+	if err != nil {
+		return m.roundFailed(consts.MsgDsGambleRoundFailed, enc, nil, err)
+	}
+	return m.postRound(consts.MsgDsGambleRoundFailed, m.roundGambleURI, enc, r)
+}
+
+// duplicate clears the error of a step of a complex round if it was a duplicate request.
+// The flag is cleared if the step was booked.
+func duplicate(err error, dup *bool) error {
+	if errors.Is(err, consts.ErrDuplicateRequest) {
+		return nil
+	}
+	*dup = false
+	return err
 }
 
 // postComplexInit initializes a new round in D-store, and returns the unqiue round id or an error.
@@ -136,7 +185,7 @@ func (m *dstore) postComplexInit(r *slots.Round, debug, withData bool) (string,
 	if err != nil {
 		return m.roundFailed(consts.MsgDsComplexInitFailed, enc, nil, err)
 	}
-	return m.postRound(consts.MsgDsComplexInitFailed, m.complexInitURI, enc)
+	return m.postRound(consts.MsgDsComplexInitFailed, m.complexInitURI, enc, r)
 }
 
 // postComplexBet posts a bet for a complex round in D-store, and returns the new balance or an error.
@@ -146,7 +195,7 @@ func (m *dstore) postComplexBet(roundID string, r *slots.Round, debug, withData
 	if err != nil {
 		return m.roundFailed(consts.MsgDsComplexBetFailed, enc, nil, err)
 	}
-	return m.postRound(consts.MsgDsComplexBetFailed, m.complexBetURI, enc)
+	return m.postRound(consts.MsgDsComplexBetFailed, m.complexBetURI, enc, r)
 }
 
 // postComplexWin posts a win for a complex round to D-store, and returns the new balance or an error.
@@ -156,7 +205,7 @@ func (m *dstore) postComplexWin(roundID string, r *slots.Round, debug, withData
 	if err != nil {
 		return m.roundFailed(consts.MsgDsComplexWinFailed, enc, nil, err)
 	}
-	return m.postRound(consts.MsgDsComplexWinFailed, m.complexWinURI, enc)
+	return m.postRound(consts.MsgDsComplexWinFailed, m.complexWinURI, enc, r)
 }
 
 // postComplexComplete completes a complex round in D-store, and returns the new balance or an error.
@@ -166,10 +215,10 @@ func (m *dstore) postComplexComplete(r *slots.Round, rs *slots.RoundState, debug
 	if err != nil {
 		return m.roundFailed(consts.MsgDsComplexCompleteFailed, enc, nil, err)
 	}
-	return m.postRound(consts.MsgDsComplexCompleteFailed, m.complexCompleteURI, enc)
+	return m.postRound(consts.MsgDsComplexCompleteFailed, m.complexCompleteURI, enc, r)
 }
 
-func (m *dstore) postRound(msg, uri string, enc *zjson.Encoder) (string, int64, error) {
+func (m *dstore) postRound(msg, uri string, enc *zjson.Encoder, r *slots.Round) (string, int64, error) {
 	defer enc.Release()
 
 	req, err := m.newRequest(http.MethodPost, uri, bytes.NewReader(enc.Bytes()))
@@ -183,8 +232,11 @@ func (m *dstore) postRound(msg, uri string, enc *zjson.Encoder) (string, int64,
 	}
 	defer resp.Body.Close()
 
-	roundID, balance, err3 := models2.UnmarshallRoundResponse(resp)
+	roundID, balance, err3 := models2.UnmarshallRoundResponse(resp, r)
 	if err3 != nil {
+		if errors.Is(err3, consts.ErrDuplicateRequest) {
+			return roundID, balance, err3
+		}
 		return m.roundFailed(msg, enc, nil, err3)
 	}
 
@@ -242,6 +294,29 @@ func (m *dstore) GetRoundState(sessionID, roundID string) (*slots.RoundState, er
 	return state, nil
 }
 
+// GetRound retrieves a completed round with its results from D-Store.
+// If the API call fails the function will return an error.
+func (m *dstore) GetRound(sessionID, roundID string) (*slots.StoredRound, error) {
+	uri := fmt.Sprintf("%s?session=%s&round=%s", m.roundURI, sessionID, roundID)
+	req, err := m.newRequest(http.MethodGet, uri, nil)
+	if err != nil {
+		return m.getRoundFailed(sessionID, roundID, nil, err)
+	}
+
+	resp, err2 := m.httpRequest(req)
+	if err2 != nil || resp == nil {
+		return m.getRoundFailed(sessionID, roundID, resp, err2)
+	}
+	defer resp.Body.Close()
+
+	round, err3 := models2.UnmarshallGetRoundResponse(sessionID, roundID, resp)
+	if err3 != nil {
+		return m.getRoundFailed(sessionID, roundID, nil, err3)
+	}
+
+	return round, nil
+}
+
 // PutGameState updates the game state for the current session in D-Store.
 // If the API call fails the function will return false.
 func (m *dstore) PutGameState(sessionID string, state *slots.GameState) error {
@@ -395,6 +470,215 @@ func (m *dstore) GetPlayerPrefs(sessionID string) (map[string]string, error) {
 	return state, nil
 }
 
+// PutCampaign stores a free-round campaign of the player in D-Store.
+// D-Store retains the play of an existing campaign, and consumes the free rounds when the rounds with the campaign id are posted.
+// If the API call fails the function will return false.
+func (m *dstore) PutCampaign(playerID string, campaign *slots.Campaign) error {
+	enc, err := models2.MarshallCampaignRequest(playerID, campaign)
+	defer enc.Release()
+	if err != nil {
+		return m.putCampaignFailed(enc, nil, err)
+	}
+
+	req, err2 := m.newRequest(http.MethodPut, m.campaignsURI, bytes.NewReader(enc.Bytes()))
+	if err2 != nil {
+		return m.putCampaignFailed(enc, nil, err2)
+	}
+
+	resp, err3 := m.httpRequest(req)
+	if err3 != nil || resp == nil {
+		return m.putCampaignFailed(enc, resp, err3)
+	}
+	defer resp.Body.Close()
+
+	if _, err = models2.UnmarshallPutCampaignResponse(resp); err != nil {
+		return m.putCampaignFailed(enc, nil, err)
+	}
+	return nil
+}
+
+// GetCampaigns retrieves the free-round campaigns of the player of the session from D-Store.
+// If the API call fails the function will return false.
+func (m *dstore) GetCampaigns(sessionID string) ([]*slots.Campaign, error) {
+	uri := fmt.Sprintf("%s?session=%s", m.campaignsURI, sessionID)
+	req, err := m.newRequest(http.MethodGet, uri, nil)
+	if err != nil {
+		return m.getCampaignsFailed(sessionID, nil, err)
+	}
+
+	resp, err2 := m.httpRequest(req)
+	if err2 != nil || resp == nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return m.getCampaignsFailed(sessionID, resp, err2)
+	}
+	defer resp.Body.Close()
+
+	campaigns, err3 := models2.UnmarshallGetCampaignsResponse(resp)
+	if err3 != nil {
+		return m.getCampaignsFailed(sessionID, nil, err3)
+	}
+	return campaigns, nil
+}
+
+// PutFairSeed stores the provably-fair server seed of the player session in D-Store.
+// If the API call fails the function will return an error.
+func (m *dstore) PutFairSeed(sessionID string, seed *slots.FairSeed) error {
+	enc, err := models2.MarshallFairSeedRequest(sessionID, seed)
+	defer enc.Release()
+	if err != nil {
+		return m.putFairSeedFailed(sessionID, nil, err)
+	}
+
+	req, err2 := m.newRequest(http.MethodPut, m.fairSeedURI, bytes.NewReader(enc.Bytes()))
+	if err2 != nil {
+		return m.putFairSeedFailed(sessionID, nil, err2)
+	}
+
+	resp, err3 := m.httpRequest(req)
+	if err3 != nil || resp == nil {
+		return m.putFairSeedFailed(sessionID, resp, err3)
+	}
+	defer resp.Body.Close()
+
+	if _, err = models2.UnmarshallPutFairSeedResponse(resp); err != nil {
+		return m.putFairSeedFailed(sessionID, nil, err)
+	}
+	return nil
+}
+
+// GetFairSeed retrieves the provably-fair server seed of the player session from D-Store.
+// It returns nil if the session has no server seed yet.
+// If the API call fails the function will return an error.
+func (m *dstore) GetFairSeed(sessionID string) (*slots.FairSeed, error) {
+	uri := fmt.Sprintf("%s?session=%s", m.fairSeedURI, sessionID)
+	req, err := m.newRequest(http.MethodGet, uri, nil)
+	if err != nil {
+		return m.getFairSeedFailed(sessionID, nil, err)
+	}
+
+	resp, err2 := m.httpRequest(req)
+	if err2 != nil || resp == nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return m.getFairSeedFailed(sessionID, resp, err2)
+	}
+	defer resp.Body.Close()
+
+	seed, err3 := models2.UnmarshallGetFairSeedResponse(resp)
+	if err3 != nil {
+		return m.getFairSeedFailed(sessionID, nil, err3)
+	}
+	return seed, nil
+}
+
+// PutJackpot stores the definition of a progressive jackpot pool in D-Store.
+// D-Store keeps the state of the pool, and adds the contributions of rounds to it.
+// If the API call fails the function will return an error.
+func (m *dstore) PutJackpot(jackpot *slots.Jackpot) error {
+	enc, err := models2.MarshallJackpotRequest(jackpot)
+	defer enc.Release()
+	if err != nil {
+		return m.putJackpotFailed(enc, nil, err)
+	}
+
+	req, err2 := m.newRequest(http.MethodPut, m.jackpotsURI, bytes.NewReader(enc.Bytes()))
+	if err2 != nil {
+		return m.putJackpotFailed(enc, nil, err2)
+	}
+
+	resp, err3 := m.httpRequest(req)
+	if err3 != nil || resp == nil {
+		return m.putJackpotFailed(enc, resp, err3)
+	}
+	defer resp.Body.Close()
+
+	if _, err = models2.UnmarshallPutJackpotResponse(resp); err != nil {
+		return m.putJackpotFailed(enc, nil, err)
+	}
+	return nil
+}
+
+// GetJackpots retrieves the progressive jackpot pools for the game and currency from D-Store.
+// If the API call fails the function will return an error.
+func (m *dstore) GetJackpots(gameID, currency string) ([]*slots.Jackpot, error) {
+	uri := fmt.Sprintf("%s?game=%s&currency=%s", m.jackpotsURI, url.QueryEscape(gameID), url.QueryEscape(currency))
+	req, err := m.newRequest(http.MethodGet, uri, nil)
+	if err != nil {
+		return m.getJackpotsFailed(gameID, nil, err)
+	}
+
+	resp, err2 := m.httpRequest(req)
+	if err2 != nil || resp == nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return m.getJackpotsFailed(gameID, resp, err2)
+	}
+	defer resp.Body.Close()
+
+	jackpots, err3 := models2.UnmarshallGetJackpotsResponse(resp)
+	if err3 != nil {
+		return m.getJackpotsFailed(gameID, nil, err3)
+	}
+	return jackpots, nil
+}
+
+// AcquireLease acquires or extends the lease on the session for the given owner in D-Store.
+// It returns false if the session is leased by another owner.
+// If the API call fails the function will return an error.
+func (m *dstore) AcquireLease(sessionID, owner string, ttl time.Duration) (bool, error) {
+	enc, err := models2.MarshallSessionLeaseRequest(sessionID, owner, ttl)
+	defer enc.Release()
+	if err != nil {
+		return false, m.leaseFailed(consts.MsgDsAcquireLeaseFailed, sessionID, owner, nil, err)
+	}
+
+	req, err2 := m.newRequest(http.MethodPut, m.sessionLeaseURI, bytes.NewReader(enc.Bytes()))
+	if err2 != nil {
+		return false, m.leaseFailed(consts.MsgDsAcquireLeaseFailed, sessionID, owner, nil, err2)
+	}
+
+	resp, err3 := m.httpRequest(req)
+	if err3 != nil || resp == nil {
+		if resp != nil && resp.StatusCode == http.StatusConflict {
+			resp.Body.Close()
+			return false, nil
+		}
+		return false, m.leaseFailed(consts.MsgDsAcquireLeaseFailed, sessionID, owner, resp, err3)
+	}
+	defer resp.Body.Close()
+
+	acquired, err4 := models2.UnmarshallSessionLeaseResponse(resp)
+	if err4 != nil {
+		return false, m.leaseFailed(consts.MsgDsAcquireLeaseFailed, sessionID, owner, nil, err4)
+	}
+	return acquired, nil
+}
+
+// ReleaseLease releases the lease on the session in D-Store, if it is held by the given owner.
+// If the API call fails the function will return an error.
+func (m *dstore) ReleaseLease(sessionID, owner string) error {
+	uri := fmt.Sprintf("%s?session=%s&owner=%s", m.sessionLeaseURI, sessionID, url.QueryEscape(owner))
+	req, err := m.newRequest(http.MethodDelete, uri, nil)
+	if err != nil {
+		return m.leaseFailed(consts.MsgDsReleaseLeaseFailed, sessionID, owner, nil, err)
+	}
+
+	resp, err2 := m.httpRequest(req)
+	if err2 != nil || resp == nil {
+		return m.leaseFailed(consts.MsgDsReleaseLeaseFailed, sessionID, owner, resp, err2)
+	}
+	defer resp.Body.Close()
+
+	if _, err = models2.UnmarshallSessionLeaseResponse(resp); err != nil {
+		return m.leaseFailed(consts.MsgDsReleaseLeaseFailed, sessionID, owner, nil, err)
+	}
+	return nil
+}
+
 func (m *dstore) newRequest(method string, uri string, body *bytes.Reader) (*http.Request, error) {
 	var req *http.Request
 	var err error
@@ -475,6 +759,14 @@ func (m *dstore) getRoundStateFailed(sessionID, roundID string, resp *http.Respo
 	return nil, err
 }
 
+func (m *dstore) getRoundFailed(sessionID, roundID string, resp *http.Response, err error) (*slots.StoredRound, error) {
+	err = m.errorFromResponse(err, resp)
+	if m.logger != nil {
+		m.logger.Error(consts.MsgDsGetRoundFailed, consts.FieldSession, sessionID, consts.FieldRound, roundID, consts.FieldError, err)
+	}
+	return nil, err
+}
+
 func (m *dstore) putGameStateFailed(enc *zjson.Encoder, resp *http.Response, err error) error {
 	err = m.errorFromResponse(err, resp)
 	if m.logger != nil {
@@ -523,6 +815,63 @@ func (m *dstore) getPlayerPrefsFailed(sessionID string, resp *http.Response, err
 	return nil, err
 }
 
+func (m *dstore) putCampaignFailed(enc *zjson.Encoder, resp *http.Response, err error) error {
+	err = m.errorFromResponse(err, resp)
+	if m.logger != nil {
+		m.logger.Error(consts.MsgDsPutCampaignFailed, consts.FieldRequest, string(enc.Bytes()), consts.FieldError, err)
+	}
+	return err
+}
+
+func (m *dstore) getCampaignsFailed(sessionID string, resp *http.Response, err error) ([]*slots.Campaign, error) {
+	err = m.errorFromResponse(err, resp)
+	if m.logger != nil {
+		m.logger.Error(consts.MsgDsGetCampaignsFailed, consts.FieldSession, sessionID, consts.FieldError, err)
+	}
+	return nil, err
+}
+
+// putFairSeedFailed does not log the request, as it contains the secret server seed.
+func (m *dstore) putFairSeedFailed(sessionID string, resp *http.Response, err error) error {
+	err = m.errorFromResponse(err, resp)
+	if m.logger != nil {
+		m.logger.Error(consts.MsgDsPutFairSeedFailed, consts.FieldSession, sessionID, consts.FieldError, err)
+	}
+	return err
+}
+
+func (m *dstore) getFairSeedFailed(sessionID string, resp *http.Response, err error) (*slots.FairSeed, error) {
+	err = m.errorFromResponse(err, resp)
+	if m.logger != nil {
+		m.logger.Error(consts.MsgDsGetFairSeedFailed, consts.FieldSession, sessionID, consts.FieldError, err)
+	}
+	return nil, err
+}
+
+func (m *dstore) putJackpotFailed(enc *zjson.Encoder, resp *http.Response, err error) error {
+	err = m.errorFromResponse(err, resp)
+	if m.logger != nil {
+		m.logger.Error(consts.MsgDsPutJackpotFailed, consts.FieldRequest, string(enc.Bytes()), consts.FieldError, err)
+	}
+	return err
+}
+
+func (m *dstore) getJackpotsFailed(gameID string, resp *http.Response, err error) ([]*slots.Jackpot, error) {
+	err = m.errorFromResponse(err, resp)
+	if m.logger != nil {
+		m.logger.Error(consts.MsgDsGetJackpotsFailed, consts.FieldGame, gameID, consts.FieldError, err)
+	}
+	return nil, err
+}
+
+func (m *dstore) leaseFailed(label, sessionID, owner string, resp *http.Response, err error) error {
+	err = m.errorFromResponse(err, resp)
+	if m.logger != nil {
+		m.logger.Error(label, consts.FieldSession, sessionID, consts.FieldOwner, owner, consts.FieldError, err)
+	}
+	return err
+}
+
 func (m *dstore) errorFromResponse(err error, resp *http.Response) error {
 	out := &slots.APIerror{Err: err, Level: "F"}
 
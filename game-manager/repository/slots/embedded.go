@@ -0,0 +1,776 @@
+package slots
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/goccy/go-json"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/encode/zjson"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-manager.git/consts"
+	state "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-manager.git/state/slots"
+)
+
+// embedded represents a game bet&state manager with an embedded store, persisted to a local file.
+// It implements the semantics of D-store for balances, rounds, round states, game & player preferences, campaigns, jackpot pools,
+// idempotent requests and session expiry, so the game-service can run end-to-end without D-store.
+// The state is written to the file after every change, so it should only be used for development and tests.
+type embedded struct {
+	mu      sync.Mutex
+	file    string
+	balance int64
+	expire  time.Duration
+	data    embeddedData
+	pools   *state.JackpotPools // persisted as part of data.
+	leases  map[string]lease    // leases are not persisted.
+}
+
+// embeddedData is the persisted state of the embedded store.
+// Objects from the state package are kept in their JSON encoding, so every retrieval returns a deep copy.
+type embeddedData struct {
+	NextRound uint64                       `json:"nextRound"`
+	Sessions  map[string]*embeddedSession  `json:"sessions"`
+	Campaigns map[string][]json.RawMessage `json:"campaigns,omitempty"` // by player id; they outlive the sessions.
+	Jackpots  []json.RawMessage            `json:"jackpots,omitempty"`
+}
+
+// embeddedSession is the persisted state of a player session.
+type embeddedSession struct {
+	Balance     int64                      `json:"balance"`
+	Expires     time.Time                  `json:"expires"`
+	GameState   json.RawMessage            `json:"gameState,omitempty"`
+	GamePrefs   json.RawMessage            `json:"gamePrefs,omitempty"`
+	PlayerPrefs map[string]string          `json:"playerPrefs,omitempty"`
+	FairSeed    json.RawMessage            `json:"fairSeed,omitempty"`
+	Rounds      map[string]*embeddedRound  `json:"rounds,omitempty"`
+	RoundIDs    []string                   `json:"roundIds,omitempty"` // oldest first.
+	Requests    map[string]embeddedRequest `json:"requests,omitempty"`
+}
+
+// embeddedRound is the persisted state of a round.
+type embeddedRound struct {
+	Bet        int64           `json:"bet"`
+	Win        int64           `json:"win"`
+	Results    json.RawMessage `json:"results"`
+	RoundState json.RawMessage `json:"roundState"`
+}
+
+// embeddedRequest is the stored response for the idempotency key of a round.
+type embeddedRequest struct {
+	RoundID string `json:"roundId"`
+	Balance int64  `json:"balance"`
+}
+
+// NewEmbedded instantiates a new game round manager using an embedded store.
+// The state is loaded from the given file if it exists, and written to it after every change.
+// With an empty file name the state is only kept in memory.
+// New sessions start with the given balance, and sessions are removed after being idle for the expire duration.
+// The expiry of sessions stops when the given context is done.
+func NewEmbedded(ctx context.Context, file string, startBalance int64, expire time.Duration) (state.RoundManager, error) {
+	if startBalance <= 0 {
+		startBalance = consts.DefaultStartBalance
+	}
+	if expire <= 0 {
+		expire = consts.DefaultSessionExpire
+	}
+
+	m := &embedded{
+		file:    file,
+		balance: startBalance,
+		expire:  expire,
+		data:    embeddedData{Sessions: make(map[string]*embeddedSession, 256), Campaigns: make(map[string][]json.RawMessage, 16)},
+		pools:   state.NewJackpotPools(),
+		leases:  make(map[string]lease, 256),
+	}
+
+	if err := m.load(); err != nil {
+		return nil, err
+	}
+
+	go func(m *embedded) {
+		t := time.NewTicker(time.Minute)
+		defer t.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.C:
+				m.checkExpired()
+			}
+		}
+	}(m)
+
+	return m, nil
+}
+
+// PostRound implements the RoundManager interface.
+func (m *embedded) PostRound(r *state.Round, _ bool) (string, int64, error) {
+	return m.newRound("round:", r)
+}
+
+// PostInitRound implements the RoundManager interface.
+func (m *embedded) PostInitRound(r *state.Round, _ bool) (string, int64, error) {
+	return m.newRound("init:", r)
+}
+
+// PostCompleteRound implements the RoundManager interface.
+// It books the win of the completed round, and appends the results to the stored round.
+func (m *embedded) PostCompleteRound(r *state.Round, rs *state.RoundState, _ bool) (string, int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s := m.session(r.SessionID())
+	if req, ok := s.Requests["complete:"+r.RequestID()]; ok && r.RequestID() != "" {
+		return req.RoundID, req.Balance, consts.ErrDuplicateRequest
+	}
+
+	roundID := r.RoundID()
+	round := s.Rounds[roundID]
+	if round == nil {
+		return "", 0, consts.ErrRoundNotFound
+	}
+
+	held, err := m.bookCampaigns(r, true)
+	if err != nil {
+		return "", 0, err
+	}
+
+	stored, err := state.AcquireRoundResultsFromJSON(round.Results)
+	if err != nil {
+		return "", 0, err
+	}
+	list := append(stored, r.RoundResults()...)
+	round.Results = encodeRoundResults(list)
+	for ix := range stored {
+		stored[ix].Release()
+	}
+
+	if rs != nil {
+		rs.ResumePlay(len(list))
+		round.RoundState = encodeObject(rs)
+	}
+
+	win := r.TotalWin()
+	round.Win += win
+	s.Balance += win - held
+
+	if gs := r.GameState(); gs != nil {
+		s.GameState = encodeObject(gs)
+	}
+
+	s.addRequest("complete:", r.RequestID(), roundID)
+	return roundID, s.Balance, m.save()
+}
+
+// PostGambleRound implements the RoundManager interface.
+// It books the outcome of a gamble step on the win of the round, and appends the results to the stored round.
+// The stake of the step was booked as win of the round before, so only the change of the stake is booked.
+func (m *embedded) PostGambleRound(r *state.Round, _ bool) (string, int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s := m.session(r.SessionID())
+	if req, ok := s.Requests["gamble:"+r.RequestID()]; ok && r.RequestID() != "" {
+		return req.RoundID, req.Balance, consts.ErrDuplicateRequest
+	}
+
+	roundID := r.RoundID()
+	round := s.Rounds[roundID]
+	if round == nil {
+		return "", 0, consts.ErrRoundNotFound
+	}
+
+	stored, err := state.AcquireRoundResultsFromJSON(round.Results)
+	if err != nil {
+		return "", 0, err
+	}
+	steps := r.RoundResults()
+	for ix := range steps {
+		steps[ix].SpinSeq = len(stored) + ix + 1
+	}
+	round.Results = encodeRoundResults(append(stored, steps...))
+	for ix := range stored {
+		stored[ix].Release()
+	}
+
+	change := r.TotalWin() - r.TotalBet()
+	round.Win += change
+	s.Balance += change
+
+	if gs := r.GameState(); gs != nil {
+		s.GameState = encodeObject(gs)
+	}
+
+	s.addRequest("gamble:", r.RequestID(), roundID)
+	return roundID, s.Balance, m.save()
+}
+
+// newRound books the bet and win of a new round, and stores its results and round state.
+// The bet contributes to the jackpot pools, and jackpot hits are booked on top of the win.
+// Request ids are scoped per kind of post, like the D-store endpoints.
+func (m *embedded) newRound(kind string, r *state.Round) (string, int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s := m.session(r.SessionID())
+	if req, ok := s.Requests[kind+r.RequestID()]; ok && r.RequestID() != "" {
+		return req.RoundID, req.Balance, consts.ErrDuplicateRequest
+	}
+
+	bet, win := r.TotalBet(), r.TotalWin()
+	if bet > s.Balance {
+		return "", 0, consts.ErrInsufficientFunds
+	}
+
+	held, err := m.bookCampaigns(r, false)
+	if err != nil {
+		return "", 0, err
+	}
+
+	m.pools.Contribute(r)
+	win += r.JackpotWin()
+
+	m.data.NextRound++
+	roundID := strconv.FormatUint(m.data.NextRound, 10)
+
+	rs := state.AcquireRoundState(r.SessionID(), roundID, len(r.RoundResults()))
+	s.addRound(roundID, &embeddedRound{Bet: bet, Win: win, Results: encodeRoundResults(r.RoundResults()), RoundState: encodeObject(rs)})
+	rs.Release()
+
+	s.Balance += win - bet - held
+
+	if gs := r.GameState(); gs != nil {
+		s.GameState = encodeObject(gs)
+	}
+
+	s.addRequest(kind, r.RequestID(), roundID)
+	return roundID, s.Balance, m.save()
+}
+
+// PostRoundNext implements the RoundManager interface.
+// It stores the round state if given, and returns the result with the given spin sequence.
+func (m *embedded) PostRoundNext(sessionID, roundID string, rs *state.RoundState, spinSeq int) (*state.RoundResult, int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, round, err := m.round(sessionID, roundID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	list, err2 := state.AcquireRoundResultsFromJSON(round.Results)
+	if err2 != nil {
+		return nil, 0, err2
+	}
+	defer func() {
+		for ix := range list {
+			list[ix].Release()
+		}
+	}()
+
+	spinSeq--
+	if spinSeq < 0 || spinSeq >= len(list) {
+		return nil, 0, consts.ErrSpinSeqNotFound
+	}
+
+	if rs != nil {
+		round.RoundState = encodeObject(rs)
+		if err = m.save(); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return list[spinSeq].Clone().(*state.RoundResult), s.Balance, nil
+}
+
+// GetRoundState implements the RoundManager interface.
+func (m *embedded) GetRoundState(sessionID, roundID string) (*state.RoundState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, round, err := m.round(sessionID, roundID)
+	if err != nil {
+		return nil, err
+	}
+	return state.AcquireRoundStateFromJSON(sessionID, roundID, round.RoundState)
+}
+
+// GetRound implements the RoundManager interface.
+func (m *embedded) GetRound(sessionID, roundID string) (*state.StoredRound, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, round, err := m.round(sessionID, roundID)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err2 := state.AcquireRoundResultsFromJSON(round.Results)
+	if err2 != nil {
+		return nil, err2
+	}
+
+	out := state.NewStoredRound(sessionID, roundID, round.Bet, round.Win, list)
+	for ix := range list {
+		list[ix].Release()
+	}
+	return out, nil
+}
+
+// PutGameState implements the RoundManager interface.
+func (m *embedded) PutGameState(sessionID string, gs *state.GameState) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s := m.data.Sessions[sessionID]
+	if s == nil {
+		return consts.ErrSessionNotFound
+	}
+
+	s.touch(m.expire)
+	s.GameState = encodeObject(gs)
+	return m.save()
+}
+
+// GetGameState implements the RoundManager interface.
+func (m *embedded) GetGameState(sessionID string) (*state.GameState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s := m.data.Sessions[sessionID]
+	if s == nil {
+		return nil, consts.ErrSessionNotFound
+	}
+
+	s.touch(m.expire)
+	if len(s.GameState) == 0 {
+		return nil, consts.ErrGameStateNotFound
+	}
+	return state.AcquireGameStateFromJSON(s.GameState)
+}
+
+// GetGamePrefs implements the RoundManager interface.
+// The embedded store does not know the casino and player, so their ids are empty.
+func (m *embedded) GetGamePrefs(sessionID string) (string, string, *state.GamePrefs, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	data := []byte{'{', '}'}
+	if s := m.data.Sessions[sessionID]; s != nil {
+		s.touch(m.expire)
+		if len(s.GamePrefs) > 0 {
+			data = s.GamePrefs
+		}
+	}
+
+	gp, err := state.AcquireGamePrefsFromJSON(data)
+	return "", "", gp, err
+}
+
+// PutGamePrefs implements the RoundManager interface.
+func (m *embedded) PutGamePrefs(sessionID string, gp *state.GamePrefs) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s := m.session(sessionID)
+	s.GamePrefs = encodeObject(gp)
+	return m.save()
+}
+
+// GetPlayerPrefs implements the RoundManager interface.
+func (m *embedded) GetPlayerPrefs(sessionID string) (map[string]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	out := make(map[string]string, 8)
+	if s := m.data.Sessions[sessionID]; s != nil {
+		s.touch(m.expire)
+		for k, v := range s.PlayerPrefs {
+			out[k] = v
+		}
+	}
+	return out, nil
+}
+
+// PutPlayerPrefs implements the RoundManager interface.
+func (m *embedded) PutPlayerPrefs(sessionID string, prefs map[string]string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s := m.session(sessionID)
+	s.PlayerPrefs = make(map[string]string, len(prefs))
+	for k, v := range prefs {
+		s.PlayerPrefs[k] = v
+	}
+	return m.save()
+}
+
+// GetCampaigns implements the RoundManager interface.
+// The embedded store does not know the player of a session, so the session id is used as the player id.
+func (m *embedded) GetCampaigns(sessionID string) ([]*state.Campaign, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return decodeCampaigns(m.data.Campaigns[sessionID])
+}
+
+// PutCampaign implements the RoundManager interface.
+// It retains the play of an existing campaign with the same id.
+func (m *embedded) PutCampaign(playerID string, campaign *state.Campaign) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	list, err := decodeCampaigns(m.data.Campaigns[playerID])
+	if err != nil {
+		return err
+	}
+
+	c := campaign.Clone()
+	for ix := range list {
+		if list[ix].ID == c.ID {
+			c.Retain(list[ix])
+			m.data.Campaigns[playerID][ix] = encodeObject(c)
+			return m.save()
+		}
+	}
+
+	m.data.Campaigns[playerID] = append(m.data.Campaigns[playerID], encodeObject(c))
+	return m.save()
+}
+
+// bookCampaigns books the round against the campaigns of the player, and returns the win held back from the balance.
+// The caller must hold the lock.
+func (m *embedded) bookCampaigns(r *state.Round, complete bool) (int64, error) {
+	playerID := r.SessionID()
+	list, err := decodeCampaigns(m.data.Campaigns[playerID])
+	if err != nil {
+		return 0, err
+	}
+
+	held, changed, err2 := bookCampaigns(list, r, complete, time.Now())
+	if err2 != nil || !changed {
+		return held, err2
+	}
+
+	for ix := range list {
+		m.data.Campaigns[playerID][ix] = encodeObject(list[ix])
+	}
+	return held, nil
+}
+
+// GetFairSeed implements the RoundManager interface.
+func (m *embedded) GetFairSeed(sessionID string) (*state.FairSeed, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s := m.data.Sessions[sessionID]
+	if s == nil || len(s.FairSeed) == 0 {
+		return nil, nil
+	}
+
+	s.touch(m.expire)
+	seed := &state.FairSeed{}
+
+	dec := zjson.AcquireDecoder(s.FairSeed)
+	defer dec.Release()
+
+	if !dec.Object(seed) {
+		return nil, dec.Error()
+	}
+	return seed, nil
+}
+
+// PutFairSeed implements the RoundManager interface.
+func (m *embedded) PutFairSeed(sessionID string, seed *state.FairSeed) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s := m.session(sessionID)
+	s.FairSeed = encodeObject(seed)
+	return m.save()
+}
+
+// GetJackpots implements the RoundManager interface.
+func (m *embedded) GetJackpots(gameID, currency string) ([]*state.Jackpot, error) {
+	return m.pools.Get(gameID, currency), nil
+}
+
+// PutJackpot implements the RoundManager interface.
+func (m *embedded) PutJackpot(jackpot *state.Jackpot) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.pools.Put(jackpot); err != nil {
+		return err
+	}
+	return m.save()
+}
+
+// AcquireLease implements the RoundManager interface.
+func (m *embedded) AcquireLease(sessionID, owner string, ttl time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	if l, ok := m.leases[sessionID]; ok && l.owner != owner && l.expires.After(now) {
+		return false, nil
+	}
+
+	m.leases[sessionID] = lease{owner: owner, expires: now.Add(ttl)}
+	return true, nil
+}
+
+// ReleaseLease implements the RoundManager interface.
+func (m *embedded) ReleaseLease(sessionID, owner string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if l, ok := m.leases[sessionID]; ok && l.owner == owner {
+		delete(m.leases, sessionID)
+	}
+	return nil
+}
+
+// session returns the session, and creates it with the start balance if it doesn't exist yet.
+// The caller must hold the lock.
+func (m *embedded) session(sessionID string) *embeddedSession {
+	s := m.data.Sessions[sessionID]
+	if s == nil {
+		s = &embeddedSession{Balance: m.balance}
+		m.data.Sessions[sessionID] = s
+	}
+	s.touch(m.expire)
+	return s
+}
+
+// round returns the session and the round with the given id.
+// The caller must hold the lock.
+func (m *embedded) round(sessionID, roundID string) (*embeddedSession, *embeddedRound, error) {
+	s := m.data.Sessions[sessionID]
+	if s == nil {
+		return nil, nil, consts.ErrSessionNotFound
+	}
+
+	s.touch(m.expire)
+	round := s.Rounds[roundID]
+	if round == nil {
+		return nil, nil, consts.ErrRoundNotFound
+	}
+	return s, round, nil
+}
+
+// checkExpired removes expired sessions and session leases.
+func (m *embedded) checkExpired() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+
+	var changed bool
+	for key, s := range m.data.Sessions {
+		if s.Expires.Before(now) {
+			delete(m.data.Sessions, key)
+			changed = true
+		}
+	}
+
+	for key, l := range m.leases {
+		if !l.expires.After(now) {
+			delete(m.leases, key)
+		}
+	}
+
+	if changed {
+		_ = m.save()
+	}
+}
+
+// load reads the state from the file, if it exists.
+func (m *embedded) load() error {
+	if m.file == "" {
+		return nil
+	}
+
+	b, err := os.ReadFile(m.file)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if err = json.Unmarshal(b, &m.data); err != nil {
+		return err
+	}
+	if m.data.Sessions == nil {
+		m.data.Sessions = make(map[string]*embeddedSession, 256)
+	}
+	if m.data.Campaigns == nil {
+		m.data.Campaigns = make(map[string][]json.RawMessage, 16)
+	}
+
+	for ix := range m.data.Jackpots {
+		j := &state.Jackpot{}
+
+		dec := zjson.AcquireDecoder(m.data.Jackpots[ix])
+		ok := dec.Object(j)
+		err = dec.Error()
+		dec.Release()
+
+		if !ok {
+			return err
+		}
+		m.pools.Restore(j)
+	}
+	return nil
+}
+
+// save writes the state to the file, through a temporary file so it is never left half-written.
+// The caller must hold the lock.
+func (m *embedded) save() error {
+	if m.file == "" {
+		return nil
+	}
+
+	pools := m.pools.List()
+	m.data.Jackpots = m.data.Jackpots[:0]
+	for ix := range pools {
+		m.data.Jackpots = append(m.data.Jackpots, encodeObject(pools[ix]))
+	}
+
+	b, err := json.Marshal(&m.data)
+	if err != nil {
+		return err
+	}
+
+	tmp := m.file + ".tmp"
+	if err = os.WriteFile(tmp, b, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, m.file)
+}
+
+// touch sets a new expiration timestamp.
+func (s *embeddedSession) touch(expire time.Duration) {
+	s.Expires = time.Now().Add(expire)
+}
+
+// addRound stores the round; only the last MaxEmbeddedRounds rounds are kept.
+func (s *embeddedSession) addRound(roundID string, round *embeddedRound) {
+	if s.Rounds == nil {
+		s.Rounds = make(map[string]*embeddedRound, 16)
+	}
+
+	if len(s.RoundIDs) >= consts.MaxEmbeddedRounds {
+		delete(s.Rounds, s.RoundIDs[0])
+		s.RoundIDs = s.RoundIDs[1:]
+	}
+
+	s.Rounds[roundID] = round
+	s.RoundIDs = append(s.RoundIDs, roundID)
+}
+
+// addRequest stores the response for the request id.
+// Requests are kept as long as the round they booked.
+func (s *embeddedSession) addRequest(kind, requestID, roundID string) {
+	if requestID == "" {
+		return
+	}
+
+	if s.Requests == nil {
+		s.Requests = make(map[string]embeddedRequest, 16)
+	}
+
+	for k, r := range s.Requests {
+		if s.Rounds[r.RoundID] == nil {
+			delete(s.Requests, k)
+		}
+	}
+
+	s.Requests[kind+requestID] = embeddedRequest{RoundID: roundID, Balance: s.Balance}
+}
+
+func encodeObject(o zjson.ObjectEncoder) json.RawMessage {
+	enc := zjson.AcquireEncoder(1024)
+	defer enc.Release()
+
+	enc.Object(o)
+	return append(json.RawMessage(nil), enc.Bytes()...)
+}
+
+func encodeRoundResults(list state.RoundResults) json.RawMessage {
+	enc := zjson.AcquireEncoder(4096)
+	defer enc.Release()
+
+	enc.StartArray()
+	for ix := range list {
+		enc.Object(list[ix])
+	}
+	enc.EndArray()
+	return append(json.RawMessage(nil), enc.Bytes()...)
+}
+
+// bookCampaigns books a round against the campaigns of a player, like D-store does when a round is posted.
+// A free round uses up a round of its campaign, or adds its win if it completes a free round,
+// and the win is held back from the balance while the campaign has a wagering requirement.
+// The bet of a real-money round counts towards the wagering requirements, and the winnings are released once met.
+// It returns the amount held back from the balance, which is negative for winnings released, and whether the campaigns changed.
+func bookCampaigns(list []*state.Campaign, r *state.Round, complete bool, now time.Time) (int64, bool, error) {
+	if c := r.Campaign(); c != nil {
+		for _, stored := range list {
+			if stored.ID != c.ID {
+				continue
+			}
+
+			win := r.TotalWin()
+			if complete {
+				stored.Complete(win)
+			} else if stored.Remaining() > 0 && !stored.Expired(now) {
+				stored.Use(win)
+			} else {
+				return 0, false, consts.ErrCampaignInactive
+			}
+
+			if stored.Wagering > 0 {
+				return win, true, nil
+			}
+			return 0, true, nil
+		}
+		return 0, false, consts.ErrCampaignInactive
+	}
+
+	var held int64
+	var changed bool
+	if bet := r.TotalBet(); bet > 0 && !complete {
+		for _, c := range list {
+			if c.Wagering > 0 && !c.Released {
+				held -= c.Wager(bet, now)
+				changed = true
+			}
+		}
+	}
+	return held, changed, nil
+}
+
+func decodeCampaigns(list []json.RawMessage) ([]*state.Campaign, error) {
+	out := make([]*state.Campaign, len(list))
+	for ix := range list {
+		c := &state.Campaign{}
+
+		dec := zjson.AcquireDecoder(list[ix])
+		ok := dec.Object(c)
+		err := dec.Error()
+		dec.Release()
+
+		if !ok {
+			return nil, err
+		}
+		out[ix] = c
+	}
+	return out, nil
+}
@@ -11,13 +11,37 @@ import (
 // memory represents a game bet&state manager utilizing local memory as the backing store.
 // It should be created once during app initialization, and only for development sessions.
 type memory struct {
-	mu       sync.RWMutex
-	sessions map[string]*state.SessionState
+	mu        sync.RWMutex
+	sessions  map[string]*state.SessionState
+	campaigns map[string][]*state.Campaign // by player id; they outlive the sessions.
+	seeds     map[string]*state.FairSeed
+	jackpots  *state.JackpotPools
+	leases    map[string]lease
+	requests  map[string]map[string]request
+}
+
+// lease is the lease on a session.
+type lease struct {
+	owner   string
+	expires time.Time
+}
+
+// request is the stored response for the idempotency key of a round.
+type request struct {
+	roundID string
+	balance int64
 }
 
 // NewMemory instantiates a new game round manager using local memory.
 func NewMemory() state.RoundManager {
-	m := &memory{sessions: make(map[string]*state.SessionState, 256)}
+	m := &memory{
+		sessions:  make(map[string]*state.SessionState, 256),
+		campaigns: make(map[string][]*state.Campaign, 16),
+		seeds:     make(map[string]*state.FairSeed, 16),
+		jackpots:  state.NewJackpotPools(),
+		leases:    make(map[string]lease, 256),
+		requests:  make(map[string]map[string]request, 256),
+	}
 
 	go func(m *memory) {
 		t := time.NewTicker(time.Minute)
@@ -34,34 +58,79 @@ func NewMemory() state.RoundManager {
 
 // PostRound implements the RoundManager interface.
 func (m *memory) PostRound(round *state.Round, _ bool) (string, int64, error) {
-	return m.newRound(round)
+	return m.newRound("round:", round)
 }
 
 // PostInitRound implements the RoundManager interface.
 func (m *memory) PostInitRound(round *state.Round, _ bool) (string, int64, error) {
-	return m.newRound(round)
+	return m.newRound("init:", round)
 }
 
 // PostCompleteRound implements the RoundManager interface.
 func (m *memory) PostCompleteRound(round *state.Round, _ *state.RoundState, _ bool) (string, int64, error) {
-	return m.newRound(round)
+	return m.newRound("complete:", round)
 }
 
-func (m *memory) newRound(round *state.Round) (string, int64, error) {
+// PostGambleRound implements the RoundManager interface.
+func (m *memory) PostGambleRound(round *state.Round, _ bool) (string, int64, error) {
+	return m.newRound("gamble:", round)
+}
+
+// newRound stores the round, or returns the stored response if the request id of the round was seen before.
+// Request ids are scoped per kind of post, like the D-store endpoints.
+// The bet of a new round contributes to the jackpot pools; jackpot hits are recorded in the round only.
+// A gamble step is added to the last round of the session.
+func (m *memory) newRound(kind string, round *state.Round) (string, int64, error) {
 	sessionID := round.SessionID()
 
 	m.mu.Lock()
 
+	var key string
+	if id := round.RequestID(); id != "" {
+		key = kind + id
+		if r, ok := m.requests[sessionID][key]; ok {
+			m.mu.Unlock()
+			return r.roundID, r.balance, consts.ErrDuplicateRequest
+		}
+	}
+
+	// the memory store does not keep real balances, so winnings held back by a campaign are not booked.
+	if kind != "gamble:" {
+		if _, _, err := bookCampaigns(m.campaigns[sessionID], round, kind == "complete:", time.Now()); err != nil {
+			m.mu.Unlock()
+			return "", 0, err
+		}
+	}
+
+	if kind == "round:" || kind == "init:" {
+		m.jackpots.Contribute(round)
+	}
+
 	s := m.sessions[sessionID]
-	if s == nil {
+	switch {
+	case kind == "gamble:":
+		if s == nil {
+			m.mu.Unlock()
+			return "", 0, consts.ErrSessionNotFound
+		}
+		s.AddGamble(round)
+	case s == nil:
 		s = state.AcquireSessionState(round)
-	} else {
+	default:
 		s.SetRound(round)
 	}
 	m.sessions[sessionID] = s
 
+	balance := s.Balance()
+	if key != "" {
+		if m.requests[sessionID] == nil {
+			m.requests[sessionID] = make(map[string]request, 16)
+		}
+		m.requests[sessionID][key] = request{roundID: "1", balance: balance}
+	}
+
 	m.mu.Unlock()
-	return "1", s.Balance(), nil
+	return "1", balance, nil
 }
 
 // PostRoundNext implements the RoundManager interface.
@@ -89,6 +158,19 @@ func (m *memory) GetRoundState(_, _ string) (*state.RoundState, error) {
 	return nil, nil
 }
 
+// GetRound implements the RoundManager interface.
+// Only the last round of the session is kept in memory, so the round id is ignored.
+func (m *memory) GetRound(sessionID, roundID string) (*state.StoredRound, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	s := m.sessions[sessionID]
+	if s == nil {
+		return nil, consts.ErrSessionNotFound
+	}
+	return s.StoredRound(roundID), nil
+}
+
 // PutGameState implements the RoundManager interface.
 func (m *memory) PutGameState(sessionID string, state *state.GameState) error {
 	m.mu.Lock()
@@ -141,7 +223,95 @@ func (m *memory) PutPlayerPrefs(_ string, _ map[string]string) error {
 	return nil
 }
 
-// checkExpired removes expired sessions from memory.
+// GetCampaigns implements the RoundManager interface.
+// The memory store does not know the player of a session, so the session id is used as the player id.
+func (m *memory) GetCampaigns(sessionID string) ([]*state.Campaign, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	list := m.campaigns[sessionID]
+	out := make([]*state.Campaign, len(list))
+	for ix := range list {
+		out[ix] = list[ix].Clone()
+	}
+	return out, nil
+}
+
+// PutCampaign implements the RoundManager interface.
+// It retains the play of an existing campaign with the same id.
+func (m *memory) PutCampaign(playerID string, campaign *state.Campaign) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	c := campaign.Clone()
+	list := m.campaigns[playerID]
+	for ix := range list {
+		if list[ix].ID == c.ID {
+			c.Retain(list[ix])
+			list[ix] = c
+			return nil
+		}
+	}
+	m.campaigns[playerID] = append(list, c)
+	return nil
+}
+
+// GetFairSeed implements the RoundManager interface.
+func (m *memory) GetFairSeed(sessionID string) (*state.FairSeed, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if seed := m.seeds[sessionID]; seed != nil {
+		return seed.Clone(), nil
+	}
+	return nil, nil
+}
+
+// PutFairSeed implements the RoundManager interface.
+func (m *memory) PutFairSeed(sessionID string, seed *state.FairSeed) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.seeds[sessionID] = seed.Clone()
+	return nil
+}
+
+// GetJackpots implements the RoundManager interface.
+func (m *memory) GetJackpots(gameID, currency string) ([]*state.Jackpot, error) {
+	return m.jackpots.Get(gameID, currency), nil
+}
+
+// PutJackpot implements the RoundManager interface.
+func (m *memory) PutJackpot(jackpot *state.Jackpot) error {
+	return m.jackpots.Put(jackpot)
+}
+
+// AcquireLease implements the RoundManager interface.
+func (m *memory) AcquireLease(sessionID, owner string, ttl time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	if l, ok := m.leases[sessionID]; ok && l.owner != owner && l.expires.After(now) {
+		return false, nil
+	}
+
+	m.leases[sessionID] = lease{owner: owner, expires: now.Add(ttl)}
+	return true, nil
+}
+
+// ReleaseLease implements the RoundManager interface.
+func (m *memory) ReleaseLease(sessionID, owner string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if l, ok := m.leases[sessionID]; ok && l.owner == owner {
+		delete(m.leases, sessionID)
+	}
+	return nil
+}
+
+// checkExpired removes expired sessions and session leases from memory.
 func (m *memory) checkExpired() {
 	var keys []string
 	if l := len(m.sessions); l > 4096 {
@@ -163,9 +333,20 @@ func (m *memory) checkExpired() {
 		if s := m.sessions[key]; s != nil {
 			if s.Expired() {
 				delete(m.sessions, key)
+				delete(m.seeds, key)
+				delete(m.requests, key)
 				s.Release()
 			}
 		}
 		m.mu.Unlock()
 	}
+
+	now := time.Now()
+	m.mu.Lock()
+	for key, l := range m.leases {
+		if !l.expires.After(now) {
+			delete(m.leases, key)
+		}
+	}
+	m.mu.Unlock()
 }
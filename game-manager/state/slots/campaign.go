@@ -0,0 +1,180 @@
+package slots
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/encode/zjson"
+)
+
+// Remaining returns the number of free rounds left in the campaign.
+func (c *Campaign) Remaining() int {
+	if n := c.Rounds - c.Used; n > 0 {
+		return n
+	}
+	return 0
+}
+
+// Expired returns true if the campaign has expired at the given time.
+func (c *Campaign) Expired(now time.Time) bool {
+	return !c.Expires.IsZero() && !now.Before(c.Expires)
+}
+
+// Active returns true if the campaign has free rounds left for the game at the given time.
+func (c *Campaign) Active(gameID string, now time.Time) bool {
+	return c.Remaining() > 0 && !c.Expired(now) && (c.Game == "" || c.Game == gameID)
+}
+
+// CapWin returns the win limited to what is left of the winnings cap of the campaign.
+func (c *Campaign) CapWin(win int64) int64 {
+	if c.MaxWin <= 0 {
+		return win
+	}
+	if left := c.MaxWin - c.Won; win > left {
+		if left < 0 {
+			return 0
+		}
+		return left
+	}
+	return win
+}
+
+// Use records a free round with the given win for the campaign.
+func (c *Campaign) Use(win int64) {
+	c.Used++
+	c.Won += win
+}
+
+// Complete records the win of the completion of a free round, e.g. after a player choice.
+func (c *Campaign) Complete(win int64) {
+	c.Won += win
+}
+
+// WageringRequired returns the amount the player must wager before the winnings of the campaign can be withdrawn.
+func (c *Campaign) WageringRequired() int64 {
+	return int64(math.Round(float64(c.Won) * c.Wagering))
+}
+
+// Held returns the winnings of the campaign which are held back from the balance of the player,
+// until the wagering requirement has been met.
+func (c *Campaign) Held() int64 {
+	if c.Wagering <= 0 || c.Released {
+		return 0
+	}
+	return c.Won
+}
+
+// Wager counts a real-money bet of the player towards the wagering requirement of the campaign.
+// Once all free rounds have been played and the requirement is met, the winnings are released;
+// it returns the amount to credit to the balance of the player.
+func (c *Campaign) Wager(bet int64, now time.Time) int64 {
+	if c.Wagering <= 0 || c.Released {
+		return 0
+	}
+
+	c.Wagered += bet
+	if (c.Remaining() == 0 || c.Expired(now)) && c.Wagered >= c.WageringRequired() {
+		c.Released = true
+		return c.Won
+	}
+	return 0
+}
+
+// Retain keeps the play of the given campaign, when the campaign is granted again by the operator.
+func (c *Campaign) Retain(old *Campaign) {
+	c.Used, c.Won, c.Wagered, c.Released = old.Used, old.Won, old.Wagered, old.Released
+}
+
+// Clone returns a copy of the campaign.
+func (c *Campaign) Clone() *Campaign {
+	out := *c
+	return &out
+}
+
+// IsEmpty implements the zjson.Encoder.IsEmpty interface.
+func (c *Campaign) IsEmpty() bool {
+	return c.ID == ""
+}
+
+// EncodeFields implements the zjson.Encoder.EncodeFields interface.
+func (c *Campaign) EncodeFields(enc *zjson.Encoder) {
+	enc.StringField("id", c.ID)
+	enc.StringFieldOpt("game", c.Game)
+	enc.Int64Field("bet", c.Bet)
+	enc.IntField("rounds", c.Rounds)
+	enc.IntFieldOpt("used", c.Used)
+	if !c.Expires.IsZero() {
+		enc.TimestampField("expires", c.Expires)
+	}
+	enc.Int64FieldOpt("maxWin", c.MaxWin)
+	enc.Int64FieldOpt("won", c.Won)
+	enc.FloatFieldOpt("wagering", c.Wagering, 'g', -1)
+	enc.Int64FieldOpt("wagered", c.Wagered)
+	enc.BoolFieldOpt("released", c.Released)
+}
+
+// DecodeField implements the zjson.Decoder.DecodeField interface.
+func (c *Campaign) DecodeField(dec *zjson.Decoder, key []byte) error {
+	var ok bool
+
+	if string(key) == "id" {
+		c.ID, ok = decodeString(dec)
+	} else if string(key) == "game" {
+		c.Game, ok = decodeString(dec)
+	} else if string(key) == "bet" {
+		c.Bet, ok = dec.Int64()
+	} else if string(key) == "rounds" {
+		c.Rounds, ok = dec.Int()
+	} else if string(key) == "used" {
+		c.Used, ok = dec.Int()
+	} else if string(key) == "expires" {
+		c.Expires, ok = dec.Timestamp()
+	} else if string(key) == "maxWin" {
+		c.MaxWin, ok = dec.Int64()
+	} else if string(key) == "won" {
+		c.Won, ok = dec.Int64()
+	} else if string(key) == "wagering" {
+		c.Wagering, ok = dec.Float()
+	} else if string(key) == "wagered" {
+		c.Wagered, ok = dec.Int64()
+	} else if string(key) == "released" {
+		c.Released, ok = dec.Bool()
+	} else {
+		return fmt.Errorf("Campaign.DecodeField: invalid field '%s'", string(key))
+	}
+
+	if ok {
+		return nil
+	}
+	return dec.Error()
+}
+
+func decodeString(dec *zjson.Decoder) (string, bool) {
+	s, escaped, ok := dec.String()
+	if !ok {
+		return "", false
+	}
+	if escaped {
+		return string(dec.Unescaped(s)), true
+	}
+	return string(s), true
+}
+
+// Campaign contains an operator-granted free-round campaign for a player.
+// Free rounds are played at the fixed bet of the campaign, without debiting the player.
+// With a wagering requirement the winnings are held back, until the player has wagered the required amount with real money.
+// Amounts are in cents.
+type Campaign struct {
+	ID       string    // unique id of the campaign.
+	Game     string    // game id the free rounds are granted for; empty for any game.
+	Bet      int64     // fixed bet for each free round.
+	Rounds   int       // number of free rounds granted.
+	Used     int       // number of free rounds played.
+	Expires  time.Time // the free rounds expire at this time; zero if they never expire.
+	MaxWin   int64     // cap on the total winnings of the campaign; zero if there is no cap.
+	Won      int64     // total winnings of the campaign so far.
+	Wagering float64   // wagering requirement as a multiple of the winnings; zero if the winnings are paid as cash.
+	Wagered  int64     // real-money bets towards the wagering requirement so far.
+	Released bool      // the winnings were released after the wagering requirement was met.
+}
@@ -0,0 +1,65 @@
+package slots
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/encode/zjson"
+)
+
+// Clone returns a copy of the server seed.
+func (s *FairSeed) Clone() *FairSeed {
+	out := *s
+	out.Seed = append([]byte(nil), s.Seed...)
+	return &out
+}
+
+// IsEmpty implements the zjson.Encoder.IsEmpty interface.
+func (s *FairSeed) IsEmpty() bool {
+	return len(s.Seed) == 0
+}
+
+// EncodeFields implements the zjson.Encoder.EncodeFields interface.
+func (s *FairSeed) EncodeFields(enc *zjson.Encoder) {
+	enc.StringField("seed", hex.EncodeToString(s.Seed))
+	enc.StringField("hash", s.Hash)
+	enc.Uint64FieldOpt("nonce", s.Nonce)
+	enc.IntFieldOpt("rounds", s.Rounds)
+}
+
+// DecodeField implements the zjson.Decoder.DecodeField interface.
+func (s *FairSeed) DecodeField(dec *zjson.Decoder, key []byte) error {
+	var ok bool
+
+	if string(key) == "seed" {
+		var seed string
+		if seed, ok = decodeString(dec); ok {
+			var err error
+			if s.Seed, err = hex.DecodeString(seed); err != nil {
+				return fmt.Errorf("FairSeed.DecodeField: invalid seed: %w", err)
+			}
+		}
+	} else if string(key) == "hash" {
+		s.Hash, ok = decodeString(dec)
+	} else if string(key) == "nonce" {
+		s.Nonce, ok = dec.Uint64()
+	} else if string(key) == "rounds" {
+		s.Rounds, ok = dec.Int()
+	} else {
+		return fmt.Errorf("FairSeed.DecodeField: invalid field '%s'", string(key))
+	}
+
+	if ok {
+		return nil
+	}
+	return dec.Error()
+}
+
+// FairSeed contains the secret server seed for the provably-fair rounds of a player session.
+// The player only knows the hash of the seed until it is revealed; a seed is kept until it has been revealed.
+type FairSeed struct {
+	Seed   []byte // secret server seed.
+	Hash   string // SHA-256 hash of the server seed, as committed to the player.
+	Nonce  uint64 // nonce of the last round played with the server seed.
+	Rounds int    // number of rounds played with the server seed.
+}
@@ -70,6 +70,51 @@ func (s *GameState) SetSymbolsState(state *slots.SymbolsState) {
 	s.symbols = state.Clone().(*slots.SymbolsState)
 }
 
+// GambleRoundID returns the id of the round with an open gamble stage.
+// It returns an empty string if there is no open gamble stage.
+func (s *GameState) GambleRoundID() string {
+	return s.gambleRound
+}
+
+// GambleWin returns the win at risk in the open gamble stage.
+func (s *GameState) GambleWin() int64 {
+	return s.gambleWin
+}
+
+// GambleSteps returns the number of gamble steps played in the open gamble stage.
+func (s *GameState) GambleSteps() uint8 {
+	return s.gambleSteps
+}
+
+// SetGamble sets the open gamble stage for the given round.
+// Use an empty round id to close the gamble stage.
+func (s *GameState) SetGamble(roundID string, win int64, steps uint8) {
+	s.gambleRound = roundID
+	s.gambleWin = win
+	s.gambleSteps = steps
+}
+
+// Play returns the play of the session so far.
+func (s *GameState) Play() PlayTotals {
+	return s.play
+}
+
+// SetPlay sets the play of the session so far.
+func (s *GameState) SetPlay(play PlayTotals) {
+	s.play = play
+}
+
+// Campaign returns the id of the campaign of the current free round, or an empty string if it is not a free round.
+// It is kept so the completion of a free round, e.g. after a player choice, is booked against the same campaign.
+func (s *GameState) Campaign() string {
+	return s.campaign
+}
+
+// SetCampaign sets the id of the campaign of the current free round.
+func (s *GameState) SetCampaign(campaignID string) {
+	s.campaign = campaignID
+}
+
 // SetRoundID sets the current round identifier.
 func (s *GameState) SetRoundID(roundID string) {
 	s.roundID = roundID
@@ -91,6 +136,11 @@ func (s *GameState) EncodeFields(enc *zjson.Encoder) {
 	enc.Int64FieldOpt("roundSeq", s.roundSeq)
 	enc.Int64FieldOpt("nextOffset", s.nextOffset)
 	enc.Int64FieldOpt("bet", s.bet)
+	enc.StringFieldOpt("gambleRound", s.gambleRound)
+	enc.Int64FieldOpt("gambleWin", s.gambleWin)
+	enc.Uint8FieldOpt("gambleSteps", s.gambleSteps)
+	enc.StringFieldOpt("campaign", s.campaign)
+	enc.ObjectFieldOpt("play", &s.play)
 	if s.spin != nil {
 		enc.ObjectField("spin", s.spin)
 	}
@@ -133,6 +183,30 @@ func (s *GameState) DecodeField(dec *zjson.Decoder, key []byte) error {
 		if i, ok = dec.Int64(); ok {
 			s.bet = i
 		}
+	} else if string(key) == "gambleRound" {
+		if b, escaped, ok = dec.String(); ok {
+			if escaped {
+				s.gambleRound = string(dec.Unescaped(b))
+			} else {
+				s.gambleRound = string(b)
+			}
+		}
+	} else if string(key) == "gambleWin" {
+		if i, ok = dec.Int64(); ok {
+			s.gambleWin = i
+		}
+	} else if string(key) == "gambleSteps" {
+		s.gambleSteps, ok = dec.Uint8()
+	} else if string(key) == "campaign" {
+		if b, escaped, ok = dec.String(); ok {
+			if escaped {
+				s.campaign = string(dec.Unescaped(b))
+			} else {
+				s.campaign = string(b)
+			}
+		}
+	} else if string(key) == "play" {
+		ok = dec.Object(&s.play)
 	} else if string(key) == "spin" {
 		s.spin = slots.AcquireSpinState(nil)
 		ok = dec.Object(s.spin)
@@ -153,12 +227,17 @@ func (s *GameState) DecodeField(dec *zjson.Decoder, key []byte) error {
 // It does not take ownership of the state objects.
 // GameState is not safe for use across multiple go-routines.
 type GameState struct {
-	roundSeq   int64
-	nextOffset int64
-	bet        int64
-	spin       *slots.SpinState
-	symbols    *slots.SymbolsState
-	roundID    string
+	roundSeq    int64
+	nextOffset  int64
+	bet         int64
+	gambleWin   int64
+	gambleSteps uint8
+	play        PlayTotals
+	spin        *slots.SpinState
+	symbols     *slots.SymbolsState
+	roundID     string
+	gambleRound string
+	campaign    string
 	pool.Object
 }
 
@@ -183,4 +262,9 @@ func (s *GameState) reset() {
 	s.spin = nil
 	s.symbols = nil
 	s.roundID = ""
+	s.gambleWin = 0
+	s.gambleSteps = 0
+	s.gambleRound = ""
+	s.campaign = ""
+	s.play = PlayTotals{}
 }
@@ -0,0 +1,297 @@
+package slots
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"sync"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/encode/zjson"
+)
+
+// Key returns the unique key of the jackpot pool.
+func (j *Jackpot) Key() string {
+	return jackpotKey(j.Game, j.Currency, j.Level)
+}
+
+// Validate returns an error if the definition of the pool is invalid.
+func (j *Jackpot) Validate() error {
+	switch {
+	case j.Game == "" || j.Currency == "" || j.Level == 0:
+		return fmt.Errorf("jackpot: game, currency and level are required")
+	case j.Seed < 0 || j.Rate < 0 || j.ReserveRate < 0 || j.Rate+j.ReserveRate >= 1:
+		return fmt.Errorf("jackpot: invalid seed or contribution rates")
+	case j.MustDropBy != 0 && j.MustDropBy <= j.Seed:
+		return fmt.Errorf("jackpot: must-drop value must exceed the seed")
+	default:
+		return nil
+	}
+}
+
+// Amount returns the current value of the pool in whole cents, as it would be awarded.
+func (j *Jackpot) Amount() int64 {
+	return int64(math.Floor(j.Value))
+}
+
+// Init (re)seeds the pool if it has no value yet, and selects a new drop value for a must-drop pool.
+// It is called when the definition of a pool is stored, so it can be used to update the definition of a running pool.
+func (j *Jackpot) Init() {
+	if j.Value < float64(j.Seed) {
+		j.Value = float64(j.Seed)
+	}
+	if j.MustDropBy > 0 && (j.DropAt <= 0 || j.DropAt > float64(j.MustDropBy)) {
+		j.DropAt = j.dropValue()
+	}
+}
+
+// Contribute adds the contributions for the given bet to the pool and the hidden reserve.
+// It returns true if the pool reached its drop value and must be awarded.
+func (j *Jackpot) Contribute(bet int64) bool {
+	if bet > 0 {
+		j.Value += float64(bet) * j.Rate
+		j.Reserve += float64(bet) * j.ReserveRate
+	}
+	return j.MustDropBy > 0 && j.Value >= j.DropAt
+}
+
+// Award returns the value of the pool and reseeds it.
+// The pool restarts at the seed value plus the hidden reserve, and the reserve is cleared.
+func (j *Jackpot) Award() int64 {
+	amount := j.Amount()
+	j.Value = float64(j.Seed) + j.Reserve + (j.Value - float64(amount))
+	j.Reserve = 0
+	j.Hits++
+	if j.MustDropBy > 0 {
+		j.DropAt = j.dropValue()
+	}
+	return amount
+}
+
+// dropValue returns a random drop value between the current value and the must-drop value.
+func (j *Jackpot) dropValue() float64 {
+	low := math.Max(j.Value, float64(j.Seed))
+	if high := float64(j.MustDropBy); high > low {
+		return low + rand.Float64()*(high-low)
+	}
+	return low
+}
+
+// Clone returns a copy of the jackpot pool.
+func (j *Jackpot) Clone() *Jackpot {
+	out := *j
+	return &out
+}
+
+// IsEmpty implements the zjson.Encoder.IsEmpty interface.
+func (j *Jackpot) IsEmpty() bool {
+	return j.Game == ""
+}
+
+// EncodeFields implements the zjson.Encoder.EncodeFields interface.
+func (j *Jackpot) EncodeFields(enc *zjson.Encoder) {
+	enc.StringField("game", j.Game)
+	enc.StringField("currency", j.Currency)
+	enc.Uint8Field("level", j.Level)
+	enc.Int64Field("seed", j.Seed)
+	enc.FloatField("rate", j.Rate, 'g', -1)
+	enc.FloatFieldOpt("reserveRate", j.ReserveRate, 'g', -1)
+	enc.Int64FieldOpt("mustDropBy", j.MustDropBy)
+	enc.FloatField("value", j.Value, 'g', -1)
+	enc.FloatFieldOpt("reserve", j.Reserve, 'g', -1)
+	enc.FloatFieldOpt("dropAt", j.DropAt, 'g', -1)
+	enc.IntFieldOpt("hits", j.Hits)
+}
+
+// DecodeField implements the zjson.Decoder.DecodeField interface.
+func (j *Jackpot) DecodeField(dec *zjson.Decoder, key []byte) error {
+	var ok bool
+
+	if string(key) == "game" {
+		j.Game, ok = decodeString(dec)
+	} else if string(key) == "currency" {
+		j.Currency, ok = decodeString(dec)
+	} else if string(key) == "level" {
+		j.Level, ok = dec.Uint8()
+	} else if string(key) == "seed" {
+		j.Seed, ok = dec.Int64()
+	} else if string(key) == "rate" {
+		j.Rate, ok = dec.Float()
+	} else if string(key) == "reserveRate" {
+		j.ReserveRate, ok = dec.Float()
+	} else if string(key) == "mustDropBy" {
+		j.MustDropBy, ok = dec.Int64()
+	} else if string(key) == "value" {
+		j.Value, ok = dec.Float()
+	} else if string(key) == "reserve" {
+		j.Reserve, ok = dec.Float()
+	} else if string(key) == "dropAt" {
+		j.DropAt, ok = dec.Float()
+	} else if string(key) == "hits" {
+		j.Hits, ok = dec.Int()
+	} else {
+		return fmt.Errorf("Jackpot.DecodeField: invalid field '%s'", string(key))
+	}
+
+	if ok {
+		return nil
+	}
+	return dec.Error()
+}
+
+// Jackpot contains the definition and the state of a progressive jackpot pool.
+// A pool is shared by all sessions playing the game in the same currency.
+// A game can have multiple pools, identified by their level; the level matches the level of the progressive
+// jackpot action in the game engine which triggers the pool.
+// Amounts are in cents; the pool and reserve values keep fractions of cents, so small bets are not lost.
+type Jackpot struct {
+	Game        string  // game id of the pool.
+	Currency    string  // currency of the pool.
+	Level       uint8   // level of the pool.
+	Seed        int64   // value the pool starts at, and is reseeded to after a hit.
+	Rate        float64 // fraction of each bet added to the pool.
+	ReserveRate float64 // fraction of each bet added to the hidden reserve, which is added to the seed after a hit.
+	MustDropBy  int64   // the pool is awarded before it exceeds this value; zero if the pool has no must-drop value.
+	Value       float64 // current value of the pool.
+	Reserve     float64 // current value of the hidden reserve.
+	DropAt      float64 // randomly selected value at which a must-drop pool is awarded.
+	Hits        int     // number of times the pool was awarded.
+}
+
+// JackpotHit contains the amount awarded from a jackpot pool in a round.
+type JackpotHit struct {
+	Level  uint8 // level of the pool.
+	Amount int64 // amount awarded in cents.
+}
+
+// IsEmpty implements the zjson.Encoder.IsEmpty interface.
+func (h *JackpotHit) IsEmpty() bool {
+	return h.Level == 0
+}
+
+// EncodeFields implements the zjson.Encoder.EncodeFields interface.
+func (h *JackpotHit) EncodeFields(enc *zjson.Encoder) {
+	enc.Uint8Field("level", h.Level)
+	enc.Int64Field("amount", h.Amount)
+}
+
+// DecodeField implements the zjson.Decoder.DecodeField interface.
+func (h *JackpotHit) DecodeField(dec *zjson.Decoder, key []byte) error {
+	var ok bool
+
+	if string(key) == "level" {
+		h.Level, ok = dec.Uint8()
+	} else if string(key) == "amount" {
+		h.Amount, ok = dec.Int64()
+	} else {
+		return nil // ignore unknown fields
+	}
+
+	if ok {
+		return nil
+	}
+	return dec.Error()
+}
+
+// JackpotPools is a store for progressive jackpot pools, which adds the contributions of rounds atomically.
+// It is used by the round managers which keep their state locally; with D-store the pools are kept by D-store.
+type JackpotPools struct {
+	mu    sync.Mutex
+	pools map[string]*Jackpot
+}
+
+// NewJackpotPools instantiates a new, empty jackpot pools store.
+func NewJackpotPools() *JackpotPools {
+	return &JackpotPools{pools: make(map[string]*Jackpot, 16)}
+}
+
+// Get returns copies of the pools for the game and currency, ordered by level.
+func (p *JackpotPools) Get(game, currency string) []*Jackpot {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	out := make([]*Jackpot, 0, 4)
+	for _, j := range p.pools {
+		if j.Game == game && j.Currency == currency {
+			out = append(out, j.Clone())
+		}
+	}
+	slices.SortFunc(out, func(a, b *Jackpot) int { return int(a.Level) - int(b.Level) })
+	return out
+}
+
+// List returns copies of all pools.
+func (p *JackpotPools) List() []*Jackpot {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	out := make([]*Jackpot, 0, len(p.pools))
+	for _, j := range p.pools {
+		out = append(out, j.Clone())
+	}
+	slices.SortFunc(out, func(a, b *Jackpot) int { return strings.Compare(a.Key(), b.Key()) })
+	return out
+}
+
+// Put stores the definition of a pool.
+// If the pool exists its state is retained, so the definition of a running pool can be changed without losing its value.
+func (p *JackpotPools) Put(jackpot *Jackpot) error {
+	if err := jackpot.Validate(); err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	j := jackpot.Clone()
+	if old := p.pools[j.Key()]; old != nil {
+		j.Value, j.Reserve, j.DropAt, j.Hits = old.Value, old.Reserve, old.DropAt, old.Hits
+	}
+	j.Init()
+
+	p.pools[j.Key()] = j
+	return nil
+}
+
+// Restore stores a pool including its state, e.g. when it was loaded from a file.
+func (p *JackpotPools) Restore(jackpot *Jackpot) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	j := jackpot.Clone()
+	j.Init()
+	p.pools[j.Key()] = j
+}
+
+// Contribute adds the contributions for the total bet of the round to the pools of its game and currency.
+// A pool is awarded if the round triggered its level in the game engine, or if it reached its must-drop value.
+// The hits are recorded in the round, and returned.
+func (p *JackpotPools) Contribute(r *Round) []JackpotHit {
+	if r.GameID() == "" || r.Currency() == "" {
+		return nil
+	}
+
+	level := r.JackpotLevel()
+	bet := r.TotalBet()
+
+	p.mu.Lock()
+	var hits []JackpotHit
+	for _, j := range p.pools {
+		if j.Game != r.GameID() || j.Currency != r.Currency() {
+			continue
+		}
+		if j.Contribute(bet) || j.Level == level {
+			hits = append(hits, JackpotHit{Level: j.Level, Amount: j.Award()})
+		}
+	}
+	p.mu.Unlock()
+
+	slices.SortFunc(hits, func(a, b JackpotHit) int { return int(a.Level) - int(b.Level) })
+	r.SetJackpotHits(hits)
+	return hits
+}
+
+func jackpotKey(game, currency string, level uint8) string {
+	return fmt.Sprintf("%s:%s:%d", game, currency, level)
+}
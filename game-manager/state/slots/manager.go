@@ -2,6 +2,7 @@ package slots
 
 import (
 	"fmt"
+	"time"
 )
 
 // RoundManager is the interface for bet validation and state management for slots games.
@@ -9,9 +10,11 @@ type RoundManager interface {
 	PostRound(r *Round, debug bool) (string, int64, error)
 	PostInitRound(r *Round, debug bool) (string, int64, error)
 	PostCompleteRound(r *Round, state *RoundState, debug bool) (string, int64, error)
+	PostGambleRound(r *Round, debug bool) (string, int64, error)
 	PostRoundNext(sessionID, roundID string, roundState *RoundState, spinSeq int) (*RoundResult, int64, error)
 
 	GetRoundState(sessionID, roundID string) (*RoundState, error)
+	GetRound(sessionID, roundID string) (*StoredRound, error)
 
 	PutGameState(sessionID string, state *GameState) error
 	GetGameState(sessionID string) (*GameState, error)
@@ -21,6 +24,20 @@ type RoundManager interface {
 
 	GetPlayerPrefs(sessionID string) (map[string]string, error)
 	PutPlayerPrefs(sessionID string, state map[string]string) error
+
+	// campaigns are granted per player; GetCampaigns returns the campaigns of the player of the session.
+	// Free rounds are consumed when the round is posted, and PutCampaign retains the play of an existing campaign.
+	GetCampaigns(sessionID string) ([]*Campaign, error)
+	PutCampaign(playerID string, campaign *Campaign) error
+
+	GetFairSeed(sessionID string) (*FairSeed, error)
+	PutFairSeed(sessionID string, seed *FairSeed) error
+
+	GetJackpots(gameID, currency string) ([]*Jackpot, error)
+	PutJackpot(jackpot *Jackpot) error
+
+	AcquireLease(sessionID, owner string, ttl time.Duration) (bool, error)
+	ReleaseLease(sessionID, owner string) error
 }
 
 type APIerror struct {
@@ -0,0 +1,91 @@
+package slots
+
+import (
+	"fmt"
+	"time"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/encode/zjson"
+)
+
+// Add adds a round with the given total bet and win to the play of the session.
+func (p *PlayTotals) Add(bet, win int64, now time.Time) {
+	if p.Started.IsZero() {
+		p.Started = now
+		p.Checked = now
+	}
+	p.Rounds++
+	p.Bets += bet
+	p.Wins += win
+}
+
+// Complete adds the win of the remaining part of a round to the play of the session.
+// The round and its bet were added when it was started.
+func (p *PlayTotals) Complete(win int64) {
+	p.Wins += win
+}
+
+// NetLoss returns the net loss of the session; it is negative if the player is winning.
+func (p *PlayTotals) NetLoss() int64 {
+	return p.Bets - p.Wins
+}
+
+// Elapsed returns the play time of the session.
+func (p *PlayTotals) Elapsed(now time.Time) time.Duration {
+	if p.Started.IsZero() {
+		return 0
+	}
+	return now.Sub(p.Started)
+}
+
+// IsEmpty implements the zjson.Encoder.IsEmpty interface.
+func (p *PlayTotals) IsEmpty() bool {
+	return p.Started.IsZero() && p.Rounds == 0
+}
+
+// EncodeFields implements the zjson.Encoder.EncodeFields interface.
+func (p *PlayTotals) EncodeFields(enc *zjson.Encoder) {
+	if !p.Started.IsZero() {
+		enc.TimestampField("started", p.Started)
+	}
+	if !p.Checked.IsZero() {
+		enc.TimestampField("checked", p.Checked)
+	}
+	enc.Int64FieldOpt("rounds", p.Rounds)
+	enc.Int64FieldOpt("bets", p.Bets)
+	enc.Int64FieldOpt("wins", p.Wins)
+}
+
+// DecodeField implements the zjson.Decoder.DecodeField interface.
+func (p *PlayTotals) DecodeField(dec *zjson.Decoder, key []byte) error {
+	var ok bool
+
+	if string(key) == "started" {
+		p.Started, ok = dec.Timestamp()
+	} else if string(key) == "checked" {
+		p.Checked, ok = dec.Timestamp()
+	} else if string(key) == "rounds" {
+		p.Rounds, ok = dec.Int64()
+	} else if string(key) == "bets" {
+		p.Bets, ok = dec.Int64()
+	} else if string(key) == "wins" {
+		p.Wins, ok = dec.Int64()
+	} else {
+		return fmt.Errorf("PlayTotals.DecodeField: invalid field '%s'", string(key))
+	}
+
+	if ok {
+		return nil
+	}
+	return dec.Error()
+}
+
+// PlayTotals contains the play of a player session so far, e.g. for responsible gambling limits and reality checks.
+// It is kept in the game state, so it is stored together with the rounds it counts.
+// Amounts are in cents.
+type PlayTotals struct {
+	Started time.Time // start of the first round; zero if no round was played.
+	Checked time.Time // time of the last reality check.
+	Rounds  int64     // number of rounds played.
+	Bets    int64     // total bets.
+	Wins    int64     // total wins.
+}
@@ -1,7 +1,9 @@
 package slots
 
 import (
+	"errors"
 	"math"
+	"time"
 
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/components/slots"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/results"
@@ -13,6 +15,7 @@ import (
 // RoundParams contains the parameters to set up a new bet round.
 type RoundParams struct {
 	Paid         bool
+	Gamble       bool // gamble step on the win of a round; the total bet is the stake and the total win is the outcome.
 	BuyFeature   uint8
 	StartBalance int64 // only if no validator is set up!
 	Bet          int64
@@ -21,8 +24,12 @@ type RoundParams struct {
 	MaxPayout    float64
 	GameState    *GameState
 	PlayerState  *GamePrefs
+	Campaign     *Campaign // free round, or completion of a free round, from a campaign; the total bet is ignored.
 	SessionID    string
 	RoundID      string
+	GameID       string          // game id for the progressive jackpot pools; optional.
+	Currency     string          // currency for the progressive jackpot pools; optional.
+	RequestID    string          // idempotency key of the client request; optional.
 	Results      results.Results // results and totalWin are mutually exclusive!
 }
 
@@ -34,8 +41,12 @@ func AcquireRound(v RoundManager, params RoundParams) *Round {
 	r.validator = v
 	r.sessionID = params.SessionID
 	r.roundID = params.RoundID
+	r.requestID = params.RequestID
+	r.gameID = params.GameID
+	r.currency = params.Currency
 	r.startBalance = params.StartBalance
 	r.paid = params.Paid
+	r.gamble = params.Gamble
 	r.buyFeature = params.BuyFeature
 	r.bet = params.Bet
 	r.totalBet = params.TotalBet
@@ -48,6 +59,10 @@ func AcquireRound(v RoundManager, params RoundParams) *Round {
 	if params.PlayerState != nil {
 		r.gamePrefs = params.PlayerState.Clone().(*GamePrefs)
 	}
+	if params.Campaign != nil {
+		r.campaign = params.Campaign.Clone()
+		r.totalBet = 0
+	}
 
 	if len(params.Results) > 0 {
 		for ix := range params.Results {
@@ -67,6 +82,7 @@ func (r *Round) Validate(debug, reverse bool) *Round {
 		return r
 	}
 	r.calculate(reverse)
+	r.addPlay()
 	r.roundID, r.playerBalance, r.valid = r.validator.PostRound(r, debug)
 	return r
 }
@@ -78,6 +94,7 @@ func (r *Round) ValidateInit(debug, reverse bool) *Round {
 		return r
 	}
 	r.calculate(reverse)
+	r.addPlay()
 	r.roundID, r.playerBalance, r.valid = r.validator.PostInitRound(r, debug)
 	return r
 }
@@ -89,16 +106,37 @@ func (r *Round) ValidateComplete(rs *RoundState, debug, reverse bool) *Round {
 		return r
 	}
 	r.calculate(reverse)
+	if r.gameState != nil {
+		r.gameState.play.Complete(r.totalWin)
+	}
 	r.roundID, r.playerBalance, r.valid = r.validator.PostCompleteRound(r, rs, debug)
 	return r
 }
 
+// ValidateGamble calls the D-store API to validate a gamble step on the win of the round with the casino and to store the result in the DB.
+// It does nothing if no validator has been set up for the round.
+func (r *Round) ValidateGamble(debug bool) *Round {
+	if r.validator == nil {
+		return r
+	}
+	r.calculate(false)
+	r.addPlay()
+	r.roundID, r.playerBalance, r.valid = r.validator.PostGambleRound(r, debug)
+	return r
+}
+
 // IsValid returns true if the round was accepted by D-store.
 // This will always return false if no validator was set up.
 func (r *Round) IsValid() bool {
 	return r.valid == nil
 }
 
+// IsDuplicate returns true if the round was posted before with the same request id.
+// The round id and player balance are then those of the stored round.
+func (r *Round) IsDuplicate() bool {
+	return errors.Is(r.valid, consts.ErrDuplicateRequest)
+}
+
 // Error returns the last error if the round is not valid.
 // This will always return nil if no validator was set up.
 func (r *Round) Error() error {
@@ -115,6 +153,55 @@ func (r *Round) RoundID() string {
 	return r.roundID
 }
 
+// RequestID returns the idempotency key of the client request for the round.
+// A round posted again with the same key is not booked again; the round manager then returns the stored round id and balance with ErrDuplicateRequest.
+func (r *Round) RequestID() string {
+	return r.requestID
+}
+
+// GameID returns the game id of the round.
+func (r *Round) GameID() string {
+	return r.gameID
+}
+
+// Currency returns the currency of the round.
+func (r *Round) Currency() string {
+	return r.currency
+}
+
+// JackpotLevel returns the highest progressive jackpot level triggered by the game engine in the round.
+// It returns zero if no progressive jackpot was triggered.
+func (r *Round) JackpotLevel() uint8 {
+	var level uint8
+	for ix := range r.roundResults {
+		if res := r.roundResults[ix].SpinData; res != nil && res.Progressive() > level {
+			level = res.Progressive()
+		}
+	}
+	return level
+}
+
+// JackpotHits returns the progressive jackpots awarded in the round.
+func (r *Round) JackpotHits() []JackpotHit {
+	return r.jackpotHits
+}
+
+// SetJackpotHits sets the progressive jackpots awarded in the round.
+// It is called by the round manager when the contributions of the round are added to the jackpot pools.
+func (r *Round) SetJackpotHits(hits []JackpotHit) {
+	r.jackpotHits = append(r.jackpotHits[:0], hits...)
+}
+
+// JackpotWin returns the total amount of the progressive jackpots awarded in the round.
+// The amount is booked by the round manager on top of the total win of the round.
+func (r *Round) JackpotWin() int64 {
+	var win int64
+	for ix := range r.jackpotHits {
+		win += r.jackpotHits[ix].Amount
+	}
+	return win
+}
+
 // Bet returns the stored bet.
 func (r *Round) Bet() int64 {
 	return r.bet
@@ -125,6 +212,16 @@ func (r *Round) TotalBet() int64 {
 	return r.totalBet
 }
 
+// IsGamble returns true if the round is a gamble step on the win of a round.
+func (r *Round) IsGamble() bool {
+	return r.gamble
+}
+
+// Campaign returns the campaign if the round is a free round, or nil otherwise.
+func (r *Round) Campaign() *Campaign {
+	return r.campaign
+}
+
 // MaxPayout returns if the maximum payout was reached.
 func (r *Round) MaxPayout() bool {
 	return r.maxPayout > 0.0
@@ -226,12 +323,18 @@ func (r *Round) PrependFirstSpin(first *RoundResult) {
 
 func (r *Round) calculate(reverse bool) {
 	switch {
+	case r.gamble:
+		// the outcome of a gamble step is decided by the caller.
 	case r.maxPayout > 0.0:
 		r.totalWin = int64(math.Round(float64(r.bet) * r.maxPayout))
 	case len(r.results) > 0:
 		r.totalWin = int64(math.Round(float64(r.bet) * results.GrandTotal(r.results)))
 	}
 
+	if r.campaign != nil {
+		r.totalWin = r.campaign.CapWin(r.totalWin)
+	}
+
 	before := r.startBalance
 	if r.newBalance > 0 && before == 0 {
 		before = r.newBalance - r.totalWin
@@ -247,6 +350,9 @@ func (r *Round) calculate(reverse bool) {
 		rr.BalanceBefore = before
 
 		win := int64(math.Round(float64(r.bet) * rr.TotalPayout))
+		if r.gamble {
+			win = r.totalWin - r.totalBet
+		}
 
 		if !reverse && progressive+win > r.totalWin {
 			// progressive cannot exceed the totalWin, so we must've hit maxPayout!
@@ -300,10 +406,19 @@ func (r *Round) calculate(reverse bool) {
 	}
 }
 
+// addPlay adds the round to the play of the session, which is kept in the game state so it is stored together with the round.
+// The remaining part of a round is added by ValidateComplete, as it only books the additional win.
+func (r *Round) addPlay() {
+	if r.gameState != nil {
+		r.gameState.play.Add(r.totalBet, r.totalWin, time.Now())
+	}
+}
+
 // Round contains all details for a bet round.
 // A round should only be kept in memory for the duration of a round, as its details are fleeting.
 type Round struct {
 	paid          bool            // indicates the results were generated from a bonus buy feature.
+	gamble        bool            // indicates a gamble step on the win of a round.
 	buyFeature    uint8           // indicates the unique id of the bonus buy feature.
 	startBalance  int64           // balance at the start of the round.
 	bet           int64           // bet amount for the spins in the round (e.g. the stake).
@@ -314,8 +429,13 @@ type Round struct {
 	maxPayout     float64         // zero or the max payout if it was reached.
 	gameState     *GameState      // game state for the round.
 	gamePrefs     *GamePrefs      // game preferences for the round.
+	campaign      *Campaign       // campaign for a free round.
 	sessionID     string          // related session id for the round.
 	roundID       string          // unique id for the round.
+	requestID     string          // idempotency key of the client request.
+	gameID        string          // game id for the progressive jackpot pools.
+	currency      string          // currency for the progressive jackpot pools.
+	jackpotHits   []JackpotHit    // progressive jackpots awarded in the round.
 	valid         error           // indicates if the complete round is valid or not.
 	validator     RoundManager    // validation interface.
 	results       results.Results // slice of results from the game engine.
@@ -356,6 +476,7 @@ func (r *Round) reset() {
 		}
 
 		r.paid = false
+		r.gamble = false
 		r.buyFeature = 0
 		r.startBalance = 0
 		r.bet = 0
@@ -364,8 +485,13 @@ func (r *Round) reset() {
 		r.newBalance = 0
 		r.playerBalance = 0
 		r.maxPayout = 0
+		r.campaign = nil
 		r.sessionID = ""
 		r.roundID = ""
+		r.requestID = ""
+		r.gameID = ""
+		r.currency = ""
+		r.jackpotHits = r.jackpotHits[:0]
 		r.valid = consts.ErrNotValidated
 		r.validator = nil
 		r.results = r.results[:0]
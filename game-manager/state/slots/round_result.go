@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/components/slots"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/games/cards"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/games/pickem"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/games/wheel"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/results"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/encode/zjson"
@@ -32,6 +34,12 @@ func AcquireRoundResult(spinSeq int, result *results.Result) *RoundResult {
 			r.BonusSelector = d.Clone().(*results.BonusSelector)
 		case *wheel.BonusWheelResult:
 			r.BonusWheel = d.Clone().(*wheel.BonusWheelResult)
+		case *cards.BonusCallColorData:
+			r.CallColor = d.Clone().(*cards.BonusCallColorData)
+		case *cards.BonusCallSuitData:
+			r.CallSuit = d.Clone().(*cards.BonusCallSuitData)
+		case *pickem.PickemResult:
+			r.Pickem = d.Clone().(*pickem.PickemResult)
 		}
 	}
 
@@ -76,6 +84,32 @@ func AcquireRoundResultFromJSON(data []byte) *RoundResult {
 	return nil
 }
 
+// AcquireRoundResultsFromJSON instantiates the spin results from the memory pool using the given json array.
+func AcquireRoundResultsFromJSON(data []byte) (RoundResults, error) {
+	out := make(RoundResults, 0, 8)
+
+	dec := zjson.AcquireDecoder(data)
+	defer dec.Release()
+
+	ok := dec.Array(func(dec *zjson.Decoder) error {
+		r := roundResultPool.Acquire().(*RoundResult)
+		if dec.Object(r) {
+			out = append(out, r)
+			return nil
+		}
+		r.Release()
+		return dec.Error()
+	})
+
+	if ok {
+		return out, nil
+	}
+	for ix := range out {
+		out[ix].Release()
+	}
+	return nil, dec.Error()
+}
+
 // Result returns the round result as a game engine result.
 func (r *RoundResult) Result() *results.Result {
 	switch {
@@ -100,6 +134,15 @@ func (r *RoundResult) Result() *results.Result {
 	case r.BonusWheel != nil:
 		return results.AcquireResult(r.BonusWheel, results.BonusWheelData, r.Payouts...)
 
+	case r.CallColor != nil:
+		return results.AcquireResult(r.CallColor, results.BonusCallColorData, r.Payouts...)
+
+	case r.CallSuit != nil:
+		return results.AcquireResult(r.CallSuit, results.BonusCallSuitData, r.Payouts...)
+
+	case r.Pickem != nil:
+		return results.AcquireResult(r.Pickem, results.PickemData, r.Payouts...)
+
 	default:
 		return results.AcquireResult(nil, 0)
 
@@ -119,8 +162,8 @@ func (r *RoundResult) EncodeFields(enc *zjson.Encoder) {
 	enc.Int64FieldOpt("spinWin", r.SpinWin)
 	enc.Uint64FieldOpt("awardedFreeGames", r.AwardedFreeGames)
 	enc.Uint64FieldOpt("freeGames", r.FreeGames)
-	enc.FloatFieldOpt("totalPayout", r.TotalPayout, 'g', 2)
-	enc.FloatFieldOpt("maxPayout", r.MaxPayout, 'g', 2)
+	enc.FloatFieldOpt("totalPayout", r.TotalPayout, 'g', -1)
+	enc.FloatFieldOpt("maxPayout", r.MaxPayout, 'g', -1)
 
 	if r.SpinData != nil {
 		enc.ObjectField("spinData", r.SpinData)
@@ -134,6 +177,15 @@ func (r *RoundResult) EncodeFields(enc *zjson.Encoder) {
 	if r.BonusWheel != nil {
 		enc.ObjectField("bonusWheel", r.BonusWheel)
 	}
+	if r.CallColor != nil {
+		enc.ObjectField("callColor", r.CallColor)
+	}
+	if r.CallSuit != nil {
+		enc.ObjectField("callSuit", r.CallSuit)
+	}
+	if r.Pickem != nil {
+		enc.ObjectField("pickem", r.Pickem)
+	}
 	if r.SymbolsState != nil {
 		enc.ObjectField("symbolsState", r.SymbolsState)
 	}
@@ -199,6 +251,15 @@ func (r *RoundResult) DecodeField(dec *zjson.Decoder, key []byte) error {
 	} else if string(key) == "bonusWheel" {
 		r.BonusWheel = wheel.AcquireBonusWheelResult(0, nil)
 		ok = dec.Object(r.BonusWheel)
+	} else if string(key) == "callColor" {
+		r.CallColor = cards.AcquireBonusCallColorData()
+		ok = dec.Object(r.CallColor)
+	} else if string(key) == "callSuit" {
+		r.CallSuit = cards.AcquireBonusCallSuitData()
+		ok = dec.Object(r.CallSuit)
+	} else if string(key) == "pickem" {
+		r.Pickem = pickem.AcquirePickemResult()
+		ok = dec.Object(r.Pickem)
 	} else if string(key) == "symbolsState" {
 		r.SymbolsState = slots.AcquireSymbolsState(nil)
 		ok = dec.Object(r.SymbolsState)
@@ -241,27 +302,30 @@ func (r *RoundResult) decodePenalty(dec *zjson.Decoder) error {
 // RoundResult contains the data for a single spin result with (re)calculated balances, progressive win amount.
 // It also contains pointers to the actual spin result, instant bonus, bonus wheel, etc.
 type RoundResult struct {
-	SpinSeq          int                     `json:"spinSeq,omitempty"`
-	BalanceBefore    int64                   `json:"balanceBefore,omitempty"`
-	BalanceAfter     int64                   `json:"balanceAfter,omitempty"`
-	Bet              int64                   `json:"bet,omitempty"`
-	Win              int64                   `json:"win,omitempty"`
-	TotalWin         int64                   `json:"totalWin,omitempty"`
-	ProgressiveWin   int64                   `json:"progressiveWin,omitempty"`
-	BonusWin         int64                   `json:"bonusWin,omitempty"`
-	SpinWin          int64                   `json:"spinWin,omitempty"`
-	AwardedFreeGames uint64                  `json:"awardedFreeGames,omitempty"`
-	FreeGames        uint64                  `json:"freeGames,omitempty"`
-	TotalPayout      float64                 `json:"totalPayout,omitempty"`
-	MaxPayout        float64                 `json:"maxPayout,omitempty"`
-	SpinData         *slots.SpinResult       `json:"spinData,omitempty"`
-	InstantBonus     *results.InstantBonus   `json:"instantBonus,omitempty"`
-	BonusSelector    *results.BonusSelector  `json:"bonusSelector,omitempty"`
-	BonusWheel       *wheel.BonusWheelResult `json:"bonusWheel,omitempty"`
-	SymbolsState     *slots.SymbolsState     `json:"symbolsState,omitempty"`
-	Payouts          results.Payouts         `json:"payouts,omitempty"`
-	Penalties        results.Penalties       `json:"penalties,omitempty"`
-	Created          time.Time               `json:"created,omitempty"`
+	SpinSeq          int                       `json:"spinSeq,omitempty"`
+	BalanceBefore    int64                     `json:"balanceBefore,omitempty"`
+	BalanceAfter     int64                     `json:"balanceAfter,omitempty"`
+	Bet              int64                     `json:"bet,omitempty"`
+	Win              int64                     `json:"win,omitempty"`
+	TotalWin         int64                     `json:"totalWin,omitempty"`
+	ProgressiveWin   int64                     `json:"progressiveWin,omitempty"`
+	BonusWin         int64                     `json:"bonusWin,omitempty"`
+	SpinWin          int64                     `json:"spinWin,omitempty"`
+	AwardedFreeGames uint64                    `json:"awardedFreeGames,omitempty"`
+	FreeGames        uint64                    `json:"freeGames,omitempty"`
+	TotalPayout      float64                   `json:"totalPayout,omitempty"`
+	MaxPayout        float64                   `json:"maxPayout,omitempty"`
+	SpinData         *slots.SpinResult         `json:"spinData,omitempty"`
+	InstantBonus     *results.InstantBonus     `json:"instantBonus,omitempty"`
+	BonusSelector    *results.BonusSelector    `json:"bonusSelector,omitempty"`
+	BonusWheel       *wheel.BonusWheelResult   `json:"bonusWheel,omitempty"`
+	CallColor        *cards.BonusCallColorData `json:"callColor,omitempty"`
+	CallSuit         *cards.BonusCallSuitData  `json:"callSuit,omitempty"`
+	Pickem           *pickem.PickemResult      `json:"pickem,omitempty"`
+	SymbolsState     *slots.SymbolsState       `json:"symbolsState,omitempty"`
+	Payouts          results.Payouts           `json:"payouts,omitempty"`
+	Penalties        results.Penalties         `json:"penalties,omitempty"`
+	Created          time.Time                 `json:"created,omitempty"`
 	pool.Object
 }
 
@@ -292,6 +356,18 @@ func (r *RoundResult) reset() {
 			r.BonusWheel.Release()
 			r.BonusWheel = nil
 		}
+		if r.CallColor != nil {
+			r.CallColor.Release()
+			r.CallColor = nil
+		}
+		if r.CallSuit != nil {
+			r.CallSuit.Release()
+			r.CallSuit = nil
+		}
+		if r.Pickem != nil {
+			r.Pickem.Release()
+			r.Pickem = nil
+		}
 		if r.SymbolsState != nil {
 			r.SymbolsState.Release()
 			r.SymbolsState = nil
@@ -14,7 +14,7 @@ import (
 func AcquireSessionState(round *Round) *SessionState {
 	s := sessionStatePool.Acquire().(*SessionState)
 	s.round = round.Clone().(*Round)
-	s.balance = 1000000 + s.round.TotalWin() - s.round.Bet()
+	s.balance = 1000000 + s.round.TotalWin() - s.round.TotalBet()
 	s.created = time.Now()
 	s.expires = s.created.Add(consts.DefaultSessionExpire)
 	return s
@@ -32,6 +32,13 @@ func (s *SessionState) RoundResults() RoundResults {
 	return s.round.roundResults
 }
 
+// StoredRound returns a deep copy of the last round in the session with the given round id.
+func (s *SessionState) StoredRound(roundID string) *StoredRound {
+	s.Touch()
+	r := s.round
+	return NewStoredRound(r.sessionID, roundID, r.totalBet, r.totalWin, r.roundResults)
+}
+
 // Expired returns true if the session state has expired.
 func (s *SessionState) Expired() bool {
 	return s.expires.Before(time.Now())
@@ -58,6 +65,19 @@ func (s *SessionState) SetRound(round *Round) {
 	s.balance += s.round.TotalWin()
 }
 
+// AddGamble adds the results of a gamble step to the last round of the session and updates the balance.
+// The total bet of the gamble step is the stake at risk, and the total win is the outcome of the step.
+func (s *SessionState) AddGamble(round *Round) {
+	s.Touch()
+	for ix := range round.roundResults {
+		rr := round.roundResults[ix].Clone().(*RoundResult)
+		rr.SpinSeq = len(s.round.roundResults) + 1
+		s.round.roundResults = append(s.round.roundResults, rr)
+	}
+	s.round.totalWin = round.TotalWin()
+	s.balance += round.TotalWin() - round.TotalBet()
+}
+
 // GetState returns a deep copy of the last game state for the session.
 func (s *SessionState) GetState() *GameState {
 	s.Touch()
@@ -0,0 +1,104 @@
+package slots
+
+// StoredRound contains a completed round as it was stored by the round manager.
+// It is used to replay the round for an audit, and is never posted back to the round manager.
+type StoredRound struct {
+	SessionID string
+	RoundID   string
+	Bet       int64
+	Win       int64
+	Results   RoundResults
+}
+
+// NewStoredRound instantiates a stored round.
+// It makes a deep copy of the results, so it's safe to call Release() on them.
+func NewStoredRound(sessionID, roundID string, bet, win int64, results RoundResults) *StoredRound {
+	r := &StoredRound{
+		SessionID: sessionID,
+		RoundID:   roundID,
+		Bet:       bet,
+		Win:       win,
+		Results:   make(RoundResults, len(results)),
+	}
+	for ix := range results {
+		r.Results[ix] = results[ix].Clone().(*RoundResult)
+	}
+	return r
+}
+
+// Release returns the round results to the memory pool.
+func (r *StoredRound) Release() {
+	if r != nil {
+		for ix := range r.Results {
+			r.Results[ix].Release()
+			r.Results[ix] = nil
+		}
+		r.Results = nil
+	}
+}
+
+// ConfigHash returns the hash of the game config the round was played with.
+// It returns an empty string if the round has no spin results.
+func (r *StoredRound) ConfigHash() string {
+	for ix := range r.Results {
+		if s := r.Results[ix].SpinData; s != nil {
+			if h := s.ConfigHash(); h != "" {
+				return h
+			}
+		}
+	}
+	return ""
+}
+
+// BonusBuy returns the bonus buy feature the round was played with, or 0 if it was not a bonus buy round.
+func (r *StoredRound) BonusBuy() uint8 {
+	for ix := range r.Results {
+		if s := r.Results[ix].SpinData; s != nil {
+			if bb, _ := s.BonusBuy(); bb > 0 {
+				return bb
+			}
+		}
+	}
+	return 0
+}
+
+// MaxPayout returns the total payout factor at which the round was capped, or 0 if the maximum payout was not reached.
+func (r *StoredRound) MaxPayout() float64 {
+	for ix := range r.Results {
+		if m := r.Results[ix].MaxPayout; m > 0 {
+			return m
+		}
+	}
+	return 0
+}
+
+// PrngCache returns the PRNG log of the round as a cache of interleaved input/output pairs.
+// The cache can be used to replay the round with the game engine (see Regular.PrngCache()).
+// It returns nil if the round was stored without a PRNG log.
+func (r *StoredRound) PrngCache() []int {
+	var out []int
+	for ix := range r.Results {
+		res := r.Results[ix]
+
+		var in, outs []int
+		switch {
+		case res.SpinData != nil:
+			_, in, outs = res.SpinData.Log()
+		case res.InstantBonus != nil:
+			_, in, outs = res.InstantBonus.Log()
+		case res.BonusSelector != nil:
+			_, in, outs = res.BonusSelector.Log()
+		case res.BonusWheel != nil:
+			_, in, outs = res.BonusWheel.Log()
+		case res.Pickem != nil:
+			_, in, outs = res.Pickem.Log()
+		}
+
+		for iy := range in {
+			if iy < len(outs) {
+				out = append(out, in[iy], outs[iy])
+			}
+		}
+	}
+	return out
+}
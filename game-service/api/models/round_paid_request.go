@@ -29,6 +29,10 @@ type RoundPaidRequest struct {
 	// Example: {"stickySymbol":5,"wing":"north"}
 	PlayerChoice interface{} `json:"playerChoice,omitempty"`
 
+	// Idempotency key of the request (optional); a retry with the same key returns the response of the first request.
+	// Example: 0f8fad5b-d9cb-469f-a165-70867728950e
+	RequestID string `json:"requestId,omitempty"`
+
 	// Player session ID.
 	// Example: bot9897cc03f5d7b43923a73bfaffc2d7dd43
 	// Required: true
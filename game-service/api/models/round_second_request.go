@@ -19,6 +19,10 @@ type RoundSecondRequest struct {
 	// Example: {"stickySymbol":5,"wing":"north"}
 	PlayerChoice interface{} `json:"playerChoice,omitempty"`
 
+	// Idempotency key of the request (optional); a retry with the same key returns the response of the first request.
+	// Example: 0f8fad5b-d9cb-469f-a165-70867728950e
+	RequestID string `json:"requestId,omitempty"`
+
 	// First round identification.
 	// Example: 5418324dc7884ad7b7d6e0fff31e4d1a
 	// Required: true
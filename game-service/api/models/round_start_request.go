@@ -17,9 +17,21 @@ type RoundStartRequest struct {
 	// Required: true
 	Bet int64 `json:"bet"`
 
+	// Client seed for a provably-fair round (optional).
+	// Example: 5f2b9c
+	ClientSeed string `json:"clientSeed,omitempty"`
+
 	// i18n
 	I18n *PrefetchI18n `json:"i18n,omitempty"`
 
+	// Nonce for a provably-fair round; must be higher than the nonce of the previous provably-fair round.
+	// Example: 1
+	Nonce uint64 `json:"nonce,omitempty"`
+
+	// Idempotency key of the request (optional); a retry with the same key returns the response of the first request.
+	// Example: 0f8fad5b-d9cb-469f-a165-70867728950e
+	RequestID string `json:"requestId,omitempty"`
+
 	// Player session ID.
 	// Example: bot9897cc03f5d7b43923a73bfaffc2d7dd43
 	// Required: true
@@ -15,6 +15,9 @@ type RoundStartResponse struct {
 	// Details of the requested result (if it is not a spin).
 	Data interface{} `json:"data,omitempty"`
 
+	// fair
+	Fair *RoundStartResponseFair `json:"fair,omitempty"`
+
 	// round data
 	// Required: true
 	RoundData *RoundStartResponseRoundData `json:"roundData"`
@@ -27,6 +30,28 @@ type RoundStartResponse struct {
 	// Required: true
 	Success bool `json:"success"`
 }
+
+// RoundStartResponseFair Provably-fair seeds of the round.
+//
+// swagger:model RoundStartResponseFair
+type RoundStartResponseFair struct {
+
+	// Client seed supplied by the player.
+	// Example: 5f2b9c
+	// Required: true
+	ClientSeed string `json:"clientSeed"`
+
+	// Nonce supplied by the player.
+	// Example: 1
+	// Required: true
+	Nonce uint64 `json:"nonce"`
+
+	// SHA-256 hash of the server seed (hex), committed to before the round.
+	// Example: 9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08
+	// Required: true
+	ServerSeedHash string `json:"serverSeedHash"`
+}
+
 type RoundStartResponseRoundData struct {
 
 	// Balance in cents after the spin.
@@ -48,6 +73,10 @@ type RoundStartResponseRoundData struct {
 	// Example: 1
 	DataKind int64 `json:"dataKind,omitempty"`
 
+	// Indicates that the win of the round can be gambled with /round/gamble.
+	// Example: 1
+	Gamble int8 `json:"gamble,omitempty"`
+
 	// Indicates that this spin result hit the max payout limit.
 	// Example: 1
 	MaxPayout int8 `json:"maxPayout,omitempty"`
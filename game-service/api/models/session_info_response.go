@@ -12,6 +12,10 @@ package models
 // swagger:model SessionInfoResponse
 type SessionInfoResponse struct {
 
+	// Number of free rounds left from operator campaigns.
+	// Example: 10
+	FreeRounds int64 `json:"freeRounds,omitempty"`
+
 	// Unique ID of the game played.
 	// Example: mgd
 	// Required: true
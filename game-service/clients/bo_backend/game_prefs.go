@@ -6,7 +6,6 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
-	"strings"
 	"time"
 
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-manager.git/models"
@@ -18,18 +17,23 @@ import (
 
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/consts"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/config"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/jurisdiction"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/limits"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/log"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/metrics"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/state"
 )
 
 // GetGamePrefs returns the preferences for the player, casino & jurisdiction based on the given session.
-func GetGamePrefs(loc string, sess *tg.SessionKey) (*slots.GamePrefs, map[string]any, map[string]any) {
+// The jurisdiction profile is cached for the session, so it can be enforced for the game rounds.
+func GetGamePrefs(loc string, sess *tg.SessionKey) (*slots.GamePrefs, map[string]any, *jurisdiction.Profile) {
 	sessionID := sess.SessionID()
 
 	if len(sessionID) <= 5 {
 		prefs := models.EmptyGamePrefs()
-		return prefs, defaultCasino(sess), defaultJurisdiction(prefs)
+		juris := defaultJurisdiction("", prefs)
+		setSession(sessionID, juris, limits.Limits{})
+		return prefs, defaultCasino(sess), juris
 	}
 
 	started2 := time.Now()
@@ -46,10 +50,52 @@ func GetGamePrefs(loc string, sess *tg.SessionKey) (*slots.GamePrefs, map[string
 		addCcbFlags(sessionID, prefs)
 	}
 
-	casino, juris := loadBackOffice(casinoID, playerID, sess, prefs)
+	casino, juris, lim := loadBackOffice(casinoID, playerID, sess, prefs)
+	setSession(sessionID, juris, lim)
 	return prefs, casino, juris
 }
 
+// Jurisdiction returns the jurisdiction profile for the given session.
+// It returns the profile cached by GetGamePrefs, or retrieves it from the back-office if it is not cached,
+// e.g. after a restart of the service. The responsible gambling limits are retrieved with it, so they are reloaded as well.
+func Jurisdiction(sess *tg.SessionKey) *jurisdiction.Profile {
+	sessionID := sess.SessionID()
+	if juris := jurisdiction.Session(sessionID); juris != nil {
+		if _, ok := limits.Get(sessionID); ok {
+			return juris
+		}
+	}
+
+	if len(sessionID) <= 5 {
+		juris := defaultJurisdiction("", models.EmptyGamePrefs())
+		setSession(sessionID, juris, limits.Limits{})
+		return juris
+	}
+
+	started := time.Now()
+	casinoID, playerID, prefs, err := state.Manager.GetGamePrefs(sessionID)
+	metrics.Metrics.AddDuration(metrics.DsGamePrefsGet, started)
+	if err != nil || prefs == nil {
+		prefs = models.EmptyGamePrefs()
+	}
+	defer prefs.Release()
+
+	addPlayerPrefs(sessionID, prefs)
+
+	_, juris, lim := loadBackOffice(casinoID, playerID, sess, prefs)
+	setSession(sessionID, juris, lim)
+	return juris
+}
+
+// setSession caches the jurisdiction profile, and sets the responsible gambling limits for the session.
+func setSession(sessionID string, juris *jurisdiction.Profile, lim limits.Limits) {
+	if lim.RealityCheck == 0 {
+		lim.RealityCheck = juris.RealityCheck
+	}
+	jurisdiction.SetSession(sessionID, juris)
+	limits.SetLimits(sessionID, lim)
+}
+
 func addPlayerPrefs(sessionID string, prefs *slots.GamePrefs) {
 	started2 := time.Now()
 	m, err := state.Manager.GetPlayerPrefs(sessionID)
@@ -108,25 +154,8 @@ func defaultCasino(sess *tg.SessionKey) map[string]any {
 	return m
 }
 
-func defaultJurisdiction(prefs *slots.GamePrefs) map[string]any {
-	m := make(map[string]any)
-
-	jurisdiction := codeUKGC
-	if strings.HasPrefix(prefs.GamePref(consts.PrefLocale), "it") {
-		jurisdiction = codeADM
-	}
-	m[keyCode] = jurisdiction
-
-	switch jurisdiction {
-	case codeADM:
-		m[keySpinWait] = waitADM
-	case codeUKGC:
-		m[keySpinWait] = waitUKGC
-	default:
-		m[keySpinWait] = waitDefault
-	}
-
-	return m
+func defaultJurisdiction(casinoID string, prefs *slots.GamePrefs) *jurisdiction.Profile {
+	return jurisdiction.Resolve(casinoID, "", prefs.GamePref(consts.PrefLocale))
 }
 
 func fixDefaultPrefs(loc string, prefs *slots.GamePrefs) {
@@ -156,8 +185,8 @@ func fixDefaultPrefs(loc string, prefs *slots.GamePrefs) {
 	}
 }
 
-func loadBackOffice(casinoID, playerID string, sess *tg.SessionKey, prefs *slots.GamePrefs) (casino map[string]any, juris map[string]any) {
-	casino, juris = defaultCasino(sess), defaultJurisdiction(prefs)
+func loadBackOffice(casinoID, playerID string, sess *tg.SessionKey, prefs *slots.GamePrefs) (casino map[string]any, juris *jurisdiction.Profile, lim limits.Limits) {
+	casino, juris = defaultCasino(sess), defaultJurisdiction(casinoID, prefs)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -207,6 +236,7 @@ func loadBackOffice(casinoID, playerID string, sess *tg.SessionKey, prefs *slots
 	}
 
 	if m2, ok := m["object"].(map[string]any); ok {
+		rules := make(map[string]any)
 		for k, v := range m2 {
 			switch k {
 			case "bets":
@@ -219,23 +249,32 @@ func loadBackOffice(casinoID, playerID string, sess *tg.SessionKey, prefs *slots
 				}
 
 			case "jurisdictionId":
-				if s, ok2 := v.(string); ok2 {
-					juris[keyCode] = s
+				if s, ok2 := v.(string); ok2 && s != "" {
+					juris = jurisdiction.Resolve(casinoID, s, prefs.GamePref(consts.PrefLocale))
 				}
 
 			case "spinWait":
 				if f, ok2 := v.(float64); ok2 {
-					juris[keySpinWait] = int64(f)
+					rules[keySpinWait] = int64(f)
 				}
 
+			case "lossLimit":
+				lim.LossLimit = int64(conv.IntFromAny(v))
+
+			case "timeLimit":
+				lim.TimeLimit = int64(conv.IntFromAny(v))
+
 			case "prefs":
 				if m3, ok3 := v.(map[string]any); ok3 {
 					for k3, v3 := range m3 {
-						juris[k3] = v3
+						rules[k3] = v3
 					}
 				}
 			}
 		}
+
+		// the jurisdiction must be known before the casino specific rules can be applied.
+		juris.Apply(rules)
 	}
 
 	return
@@ -262,16 +301,10 @@ var (
 )
 
 const (
-	dfltMusic         = "50"  // 50% volume/toggled on.
-	dfltEffects       = "50"  // 50% volume/toggled on.
-	dfltVolume        = "50"  // 50% audio volume.
-	dfltBet           = "100" // 100 cents.
-	waitADM     int64 = 1000  // 1 second
-	waitUKGC    int64 = 2500  // 2.5 seconds.
-	waitDefault int64 = 1000  // 1 second.
-	keyBets           = "bets"
-	keyCode           = "code"
-	keySpinWait       = "spinWait"
-	codeADM           = "ADM"
-	codeUKGC          = "UKGC"
+	dfltMusic   = "50"  // 50% volume/toggled on.
+	dfltEffects = "50"  // 50% volume/toggled on.
+	dfltVolume  = "50"  // 50% audio volume.
+	dfltBet     = "100" // 100 cents.
+	keyBets     = "bets"
+	keySpinWait = "spinWait"
 )
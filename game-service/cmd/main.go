@@ -14,13 +14,20 @@ import (
 
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/net/kafka"
 
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/rng"
+
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/consts"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/handlers"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/autoplay"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/config"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/events"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/hashes"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/idempotency"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/jurisdiction"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/limits"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/log"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/metrics"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/roundlock"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/state"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/utils"
 )
@@ -44,6 +51,27 @@ func main() {
 	log.Logger.Info(consts.MsgBinaryFileSize, hashes.MainFile, hashes.MainFileSize, hashes.RngLib, hashes.RngLibFileSize)
 	log.Logger.Info(consts.MsgGoModules, consts.FieldModules, hashes.Modules)
 
+	// select the PRNG backend.
+	initRNG()
+
+	// load the jurisdiction profiles.
+	initJurisdictions()
+
+	// expire the cached responsible gambling limits.
+	initLimits()
+
+	// expire the status of stopped autoplay sequences.
+	initAutoplay()
+
+	// set up the round manager; D-store, the embedded store or memory.
+	state.Setup()
+
+	// select the lock serialising rounds per session.
+	initRoundLock()
+
+	// expire the responses kept for idempotent rounds.
+	initIdempotency()
+
 	// init global metrics.
 	metrics.InitMetrics()
 
@@ -85,7 +113,7 @@ func main() {
 	time.AfterFunc(250*time.Millisecond, func() { log.Logger.Info(consts.MsgServerStarted) })
 
 	// shutdown on SIGINT.
-	intChan := make(chan os.Signal)
+	intChan := make(chan os.Signal, 1)
 	signal.Notify(intChan, syscall.SIGINT, syscall.SIGTERM)
 	<-intChan
 
@@ -107,6 +135,68 @@ func main() {
 	os.Exit(0)
 }
 
+func initRNG() {
+	switch {
+	case config.RngSeed != nil:
+		rng.UseSeed(config.RngSeed)
+	case config.RngBackend != "":
+		if err := rng.Use(config.RngBackend); err != nil {
+			log.Logger.Panic(consts.MsgRngFailed, consts.FieldBackend, config.RngBackend, consts.FieldError, err)
+		}
+	}
+
+	if rng.Current() == rng.BackendSeeded && !config.DebugMode {
+		log.Logger.Panic(consts.MsgRngSeededProd, consts.FieldBackend, rng.Current())
+	}
+
+	if !config.RngNoHealth {
+		if err := rng.EnableHealthTests(true); err != nil {
+			log.Logger.Warn(consts.MsgRngNoHealth, consts.FieldBackend, rng.Current())
+		}
+	}
+
+	log.Logger.Info(consts.MsgRngBackend, consts.FieldBackend, rng.Current())
+}
+
+func initJurisdictions() {
+	if config.Jurisdictions != "" {
+		if err := jurisdiction.Load(config.Jurisdictions); err != nil {
+			log.Logger.Panic(consts.MsgJurisdictionsFailed, consts.FieldFile, config.Jurisdictions, consts.FieldError, err)
+		}
+	}
+
+	go utils.Cleanup(utils.Final().Done(), config.JurisdictionIdle, jurisdiction.Expire)
+
+	log.Logger.Info(consts.MsgJurisdictions, consts.FieldCodes, jurisdiction.Codes())
+}
+
+func initAutoplay() {
+	go autoplay.Cleanup(utils.Final().Done(), config.AutoplayIdle)
+	log.Logger.Info(consts.MsgAutoplay, consts.FieldTTL, config.AutoplayIdle)
+}
+
+func initLimits() {
+	go utils.Cleanup(utils.Final().Done(), config.LimitsIdle, limits.Expire)
+	log.Logger.Info(consts.MsgLimits, consts.FieldTTL, config.LimitsIdle)
+}
+
+func initRoundLock() {
+	switch config.RoundLock {
+	case consts.ValueLockLease:
+		leaser := func() roundlock.Leaser { return state.Manager }
+		roundlock.Use(roundlock.NewLease(leaser, config.ClientID, config.RoundLockTTL, config.RoundLockWait, 0))
+		log.Logger.Info(consts.MsgRoundLock, consts.FieldMode, config.RoundLock, consts.FieldWait, config.RoundLockWait, consts.FieldTTL, config.RoundLockTTL)
+	default:
+		roundlock.Use(roundlock.NewLocal(config.RoundLockWait))
+		log.Logger.Info(consts.MsgRoundLock, consts.FieldMode, config.RoundLock, consts.FieldWait, config.RoundLockWait)
+	}
+}
+
+func initIdempotency() {
+	go utils.Cleanup(utils.Final().Done(), config.IdempotencyTTL, idempotency.Expire)
+	log.Logger.Info(consts.MsgIdempotency, consts.FieldTTL, config.IdempotencyTTL)
+}
+
 func initFiber() *fiber.App {
 	// initialize the fast http server.
 	app := fiber.New(fiber.Config{
@@ -143,7 +233,11 @@ func initFiber() *fiber.App {
 
 	app.Get(consts.PathPing, handlers.Ping)
 	app.Get(consts.PathBinHashes, handlers.GetBinHashes)
+	app.Get(consts.PathRngHealth, handlers.GetRngHealth)
+	app.Put(consts.PathCampaign, handlers.PutCampaign)
+	app.Put(consts.PathJackpot, handlers.PutJackpot)
 	app.Get(consts.PathGameHash, handlers.GameHash)
+	app.Get(consts.PathGames, handlers.GetGames)
 	app.Get(consts.PathGameInfo, handlers.GetGameInfo)
 	app.Post(consts.PathPreferences, handlers.PostPreferences)
 	app.Get(consts.PathCcbFlags, handlers.GetCcbFlags)
@@ -158,9 +252,19 @@ func initFiber() *fiber.App {
 	app.Post(consts.PathRoundResume, handlers.PostRoundResume)
 	app.Post(consts.PathRoundNext, handlers.PostRoundNext)
 	app.Post(consts.PathRoundFinish, handlers.PostRoundFinish)
+	app.Post(consts.PathRoundGamble, handlers.PostRoundGamble)
 
 	app.Get(consts.PathSessionInfo, handlers.GetSessionInfo)
 
+	app.Post(consts.PathAutoplay, handlers.PostAutoplay)
+	app.Post(consts.PathAutoplayStop, handlers.PostAutoplayStop)
+	app.Get(consts.PathAutoplay, handlers.GetAutoplay)
+
+	app.Get(consts.PathFair, handlers.GetFair)
+	app.Post(consts.PathFairRotate, handlers.PostFairRotate)
+
+	app.Get(consts.PathAudit, handlers.GetAudit)
+
 	// SUPERVISED-BUILD-REMOVE-START
 	if config.DebugMode {
 		app.Post(consts.PathRoundDebug, handlers.PostRoundDebug)
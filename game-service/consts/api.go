@@ -13,6 +13,7 @@ const (
 	PathPlurals     = "/v1/plurals"
 	PathBinHashes   = "/v1/bin-hashes"
 	PathGameHash    = "/v1/game-hash/:game"
+	PathGames       = "/v1/games"
 	PathGameInfo    = "/v1/game-info"
 	PathPreferences = "/v1/preferences"
 	PathCcbFlags    = "/v1/ccb-flags"
@@ -28,10 +29,19 @@ const (
 	// SUPERVISED-BUILD-REMOVE-END
 	PathRoundNext       = "/v1/round/next"
 	PathRoundFinish     = "/v1/round/finish"
+	PathRoundGamble     = "/v1/round/gamble"
 	PathSessionInfo     = "/v1/session/:session"
 	PathRngConditionsLU = "/v1/rng-conditions-lu/:game"
 	PathRngMagicTest    = "/v1/rng-magic/test"
 	PathRngMagic        = "/v1/rng-magic"
+	PathRngHealth       = "/v1/rng-health"
+	PathCampaign        = "/v1/campaign"
+	PathJackpot         = "/v1/jackpot"
+	PathAutoplay        = "/v1/autoplay"
+	PathAutoplayStop    = "/v1/autoplay/stop"
+	PathFair            = "/v1/fair"
+	PathFairRotate      = "/v1/fair/rotate"
+	PathAudit           = "/v1/audit"
 
 	AcceptLanguage  = "Accept-Language"
 	ContentType     = "Content-Type"
@@ -61,6 +71,23 @@ const (
 	ErrCdNotFound
 	ErrCdUpdateFailed
 	ErrCdRngFunctionInvalid
+	ErrCdRngHealth
+	ErrCdJurisdictionRTP
+	ErrCdJurisdictionBet
+	ErrCdJurisdictionBonusBuy
+	ErrCdJurisdictionSpinSpeed
+	ErrCdLossLimit
+	ErrCdTimeLimit
+	ErrCdJurisdictionAutoplay
+	ErrCdAutoplayRunning
+	ErrCdFairDisabled
+	ErrCdFairUnsupported
+	ErrCdFairSeeds
+	ErrCdAuditUnsupported
+	ErrCdAuditConfig
+	ErrCdSessionBusy
+	ErrCdDuplicateRequest
+	ErrCdGambleInvalid
 )
 
 const (
@@ -70,6 +97,15 @@ const (
 	ErrorInvalidSession = "invalid sessionID, code or RTP"
 	ErrorInvalidApiKey  = "invalid API key"
 	ErrorInvalidStatus  = "invalid status or roundID"
+	ErrorRngHealth      = "PRNG health test failed"
+	ErrorJurisdiction   = "not allowed in jurisdiction"
+	ErrorLimitReached   = "responsible gambling limit reached"
+	ErrorAutoplay       = "autoplay already running"
+	ErrorFair           = "provably-fair round not possible"
+	ErrorAudit          = "round cannot be audited"
+	ErrorSessionBusy    = "another round is in progress for the session"
+	ErrorDuplicate      = "round was already played for the request id"
+	ErrorGamble         = "gamble not possible"
 	ErrorCallFailed     = "%s failed: %v; request: %v"
 	ErrorDstoreError    = "D-store returned error: [%d] %s"
 )
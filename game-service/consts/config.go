@@ -31,7 +31,22 @@ const (
 	EnvNoDefaultHeaders   = "GS_HTTP_NO_DEFAULT_HEADERS"
 	EnvNoCors             = "GS_HTTP_NO_CORS"
 	EnvNoCompression      = "GS_HTTP_NO_COMPRESS"
+	EnvRngBackend         = "GS_RNG_BACKEND"
+	EnvRngSeed            = "GS_RNG_SEED"
+	EnvRngNoHealth        = "GS_RNG_NO_HEALTH"
+	EnvJurisdictions      = "GS_JURISDICTIONS"
+	EnvProvablyFair       = "GS_PROVABLY_FAIR"
+	EnvRoundLock          = "GS_ROUND_LOCK"
+	EnvRoundLockWait      = "GS_ROUND_LOCK_WAIT"
+	EnvRoundLockTTL       = "GS_ROUND_LOCK_TTL"
+	EnvIdempotencyTTL     = "GS_IDEMPOTENCY_TTL"
+	EnvLimitsIdle         = "GS_LIMITS_IDLE"
+	EnvAutoplayIdle       = "GS_AUTOPLAY_IDLE"
+	EnvEmbeddedStore      = "GS_EMBEDDED_STORE"
+	EnvEmbeddedBalance    = "GS_EMBEDDED_BALANCE"
 
-	ValueDev  = "DEV"
-	ValueTrue = "1"
+	ValueDev       = "DEV"
+	ValueTrue      = "1"
+	ValueLockLocal = "local"
+	ValueLockLease = "lease"
 )
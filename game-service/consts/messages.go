@@ -8,6 +8,8 @@ const (
 	MsgHttp                = "http"
 	MsgNoValidation        = "no validation set up; using memory for sessions"
 	MsgValidationSetup     = "d-store validation set up"
+	MsgEmbeddedStore       = "no validation set up; using embedded store for sessions"
+	MsgEmbeddedFailed      = "failed to load embedded store"
 	MsgServiceFailed       = "failed to start server"
 	MsgBigWin              = "big win (>=100x)"
 	MsgFreeSpins           = "free spins"
@@ -25,6 +27,19 @@ const (
 	MsgGameConfigs         = "game configs"
 	MsgMonitorFailed       = "failed to report error to monitoring service"
 	MsgInvalidStatusCode   = "invalid status code returned"
+	MsgRngBackend          = "PRNG backend"
+	MsgRngFailed           = "failed to select PRNG backend"
+	MsgRngSeededProd       = "seeded PRNG backend is not allowed in PROD mode"
+	MsgRngNoHealth         = "PRNG backend does not support health tests"
+	MsgRngHealthFailed     = "PRNG health test failed; refusing to play rounds"
+	MsgJurisdictions       = "jurisdiction profiles"
+	MsgJurisdictionsFailed = "failed to load jurisdiction profiles"
+	MsgJackpotsFailed      = "failed to load jackpot pools"
+	MsgAutoplayStopped     = "autoplay stopped"
+	MsgRoundLock           = "round lock"
+	MsgIdempotency         = "idempotent responses"
+	MsgLimits              = "responsible gambling limits"
+	MsgAutoplay            = "autoplay sequences"
 
 	FieldURI           = "uri"
 	FieldRequest       = "request"
@@ -51,4 +66,14 @@ const (
 	FieldCreatedFrom   = "createdFrom"
 	FieldCreatedTo     = "createdTo"
 	FieldLimit         = "limit"
+	FieldBackend       = "backend"
+	FieldHealth        = "health"
+	FieldFile          = "file"
+	FieldCodes         = "codes"
+	FieldGame          = "game"
+	FieldReason        = "reason"
+	FieldPlayed        = "played"
+	FieldMode          = "mode"
+	FieldWait          = "wait"
+	FieldTTL           = "ttl"
 )
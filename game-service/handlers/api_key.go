@@ -0,0 +1,16 @@
+package handlers
+
+import (
+	"crypto/subtle"
+
+	"github.com/gofiber/fiber/v2"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/config"
+)
+
+// checkAdminKey returns true if the request has the API key of the service, and the key was set with GS_API_KEY.
+// It guards the admin endpoints that grant free rounds and set jackpot pools, so these refuse the built-in default key.
+// The key is compared in constant time.
+func checkAdminKey(req *fiber.Ctx) bool {
+	return config.ApiKeySet && subtle.ConstantTimeCompare(req.Request().Header.Peek("X-API-KEY"), config.ApiKeyBytes) == 1
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"bytes"
+	"math"
+	"runtime/debug"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/gofiber/fiber/v2"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/tg"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/api/models"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/consts"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/audit"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/config"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/game"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/metrics"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/state"
+)
+
+// GetAudit replays a stored round from its PRNG log and reports any differences with the stored results.
+// The round is replayed with a new instance of the game; it is never validated or stored, so balances are not affected.
+// Rounds which span multiple requests (double-spin, player choices) cannot be replayed.
+func GetAudit(req *fiber.Ctx) (err error) {
+	started := time.Now()
+	defer func() { metrics.Metrics.AddDuration(metrics.ApiAudit, started) }()
+
+	params := &struct {
+		SessionID string `json:"sessionId"`
+		RoundID   string `json:"roundId"`
+	}{
+		SessionID: req.Query("sessionId"),
+		RoundID:   req.Query("roundId"),
+	}
+
+	defer func() {
+		if e := recover(); e != nil {
+			err = sendErrorStack(req, consts.PathAudit, e, params, fiber.StatusInternalServerError, BodyInternalError(consts.ErrCdPanic, consts.ErrLvlFatal), debug.Stack())
+		}
+	}()
+
+	// check api key.
+	if !bytes.Equal(req.Request().Header.Peek("X-API-KEY"), config.ApiKeyBytes) {
+		return sendError(req, consts.PathAudit, consts.ErrorInvalidApiKey, nil, fiber.StatusBadRequest, BodyBadRequest(consts.ErrCdApiKey, consts.ErrLvlFatal))
+	}
+
+	sess, err2 := tg.VerifySessionID(params.SessionID)
+	if params.SessionID == "" || err2 != nil {
+		return sendError(req, consts.PathAudit, FmtInvalidSession("verification", err2), params, fiber.StatusBadRequest, BodyInvalidSession(consts.ErrCdSessionInvalid, consts.ErrLvlFatal))
+	}
+	if params.RoundID == "" {
+		return sendError(req, consts.PathAudit, consts.ErrorBadRequest, params, fiber.StatusBadRequest, BodyBadRequest(consts.ErrCdParams, consts.ErrLvlFatal))
+	}
+
+	// load the stored round.
+	started2 := time.Now()
+	stored, err3 := state.Manager.GetRound(params.SessionID, params.RoundID)
+	metrics.Metrics.AddDuration(metrics.DsRoundGet, started2)
+	if err3 != nil || stored == nil || len(stored.Results) == 0 {
+		return sendError(req, consts.PathAudit, consts.ErrorNotFound, params, fiber.StatusNotFound, BodyNotFound(consts.ErrCdNotFound, consts.ErrLvlFatal))
+	}
+	defer stored.Release()
+
+	// init the game with the RTP of the session.
+	g := game.NewGame(sess.GameNr(), int(sess.RTP()))
+	if g == nil {
+		return sendError(req, consts.PathAudit, FmtInvalidSession("game", nil), params, fiber.StatusBadRequest, BodyInvalidSession(consts.ErrCdGameInvalid, consts.ErrLvlFatal))
+	}
+	defer g.Release()
+
+	if math.Abs(g.Slots().RTP()-float64(sess.RTP())) > 0.005 {
+		return sendError(req, consts.PathAudit, FmtInvalidSession("game", nil), params, fiber.StatusBadRequest, BodyInvalidSession(consts.ErrCdGameInvalid, consts.ErrLvlFatal))
+	}
+
+	// the round must be complete in a single request, and must include the PRNG log.
+	cache := stored.PrngCache()
+	if g.IsDoubleSpin() || g.AllowPlayerChoices() || len(cache) == 0 {
+		return sendError(req, consts.PathAudit, consts.ErrorAudit, params, fiber.StatusBadRequest, BodyAudit(consts.ErrCdAuditUnsupported, consts.ErrLvlFatal))
+	}
+
+	// the round must be replayed with the same game config; a round stored without its config hash cannot be verified.
+	hash := g.ConfigHash()
+	if stored.ConfigHash() != hash {
+		return sendError(req, consts.PathAudit, consts.ErrorAudit, params, fiber.StatusConflict, BodyAudit(consts.ErrCdAuditConfig, consts.ErrLvlFatal))
+	}
+
+	// replay the round from the PRNG log.
+	g.LimitMaxPayout(stored.MaxPayout())
+
+	started3 := time.Now()
+	replayed := g.PrngCache(cache, stored.BonusBuy(), false, nil)
+	metrics.Metrics.AddDuration(metrics.GeRoundDebug, started3)
+	if replayed == nil {
+		return sendError(req, consts.PathAudit, consts.ErrorInternalError, params, fiber.StatusInternalServerError, BodyInternalError(consts.ErrCdGameFailed, consts.ErrLvlRetry))
+	}
+
+	diffs := audit.Compare(stored.Results, replayed)
+
+	resp := &models.AuditResponse{
+		ConfigHash: hash,
+		Diffs:      make([]*models.AuditResponseDiff, len(diffs)),
+		Match:      len(diffs) == 0,
+		RoundID:    params.RoundID,
+		SessionID:  params.SessionID,
+		Success:    true,
+	}
+	for ix, d := range diffs {
+		resp.Diffs[ix] = &models.AuditResponseDiff{Field: d.Field, Replayed: d.Replayed, SpinSeq: int64(d.SpinSeq), Stored: d.Stored}
+	}
+
+	b, _ := json.Marshal(resp)
+	req.Set(consts.ContentType, consts.ApplicationJSON)
+	_, err = req.Write(b)
+	return err
+}
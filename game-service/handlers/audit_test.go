@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"bytes"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	store "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-manager.git/repository/slots"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/tg"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/api/models"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/config"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/log"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/state"
+)
+
+func TestGetAudit(t *testing.T) {
+	log.Init()
+	state.Manager = store.NewMemory()
+
+	app := fiber.New()
+	require.NotNil(t, app)
+
+	app.Get("/v1/audit", GetAudit)
+	app.Post("/v1/round", PostRound)
+
+	sessionID, err := tg.MakeSessionID("bot", 92, 1)
+	require.NoError(t, err)
+	setTestSession(sessionID)
+
+	call := func(method, path, body string, apiKey bool, out any) int {
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		require.NotNil(t, req)
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		if apiKey {
+			req.Header.Set("X-API-KEY", config.ApiKey)
+		}
+
+		resp, err2 := app.Test(req, 1000)
+		require.NoError(t, err2)
+		require.NotNil(t, resp)
+
+		if out != nil && resp.StatusCode == fiber.StatusOK {
+			b, err3 := io.ReadAll(resp.Body)
+			require.NoError(t, err3)
+			require.NoError(t, json.Unmarshal(b, out))
+		}
+		return resp.StatusCode
+	}
+
+	path := "/v1/audit?sessionId=" + sessionID + "&roundId=1"
+
+	t.Run("no api key", func(t *testing.T) {
+		assert.Equal(t, fiber.StatusBadRequest, call(fiber.MethodGet, path, "", false, nil))
+	})
+
+	t.Run("bad params", func(t *testing.T) {
+		assert.Equal(t, fiber.StatusBadRequest, call(fiber.MethodGet, "/v1/audit?sessionId=x&roundId=1", "", true, nil))
+		assert.Equal(t, fiber.StatusBadRequest, call(fiber.MethodGet, "/v1/audit?sessionId="+sessionID, "", true, nil))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		assert.Equal(t, fiber.StatusNotFound, call(fiber.MethodGet, path, "", true, nil))
+	})
+
+	t.Run("replay", func(t *testing.T) {
+		for ix := 0; ix < 25; ix++ {
+			var round models.RoundStartResponse
+			require.Equal(t, fiber.StatusOK, call(fiber.MethodPost, "/v1/round", `{"sessionId":"`+sessionID+`","bet":100}`, false, &round))
+
+			var resp models.AuditResponse
+			require.Equal(t, fiber.StatusOK, call(fiber.MethodGet, path, "", true, &resp))
+			assert.True(t, resp.Success)
+			assert.True(t, resp.Match)
+			assert.Empty(t, resp.Diffs)
+			assert.NotEmpty(t, resp.ConfigHash)
+			assert.Equal(t, sessionID, resp.SessionID)
+		}
+	})
+}
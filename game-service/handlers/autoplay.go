@@ -0,0 +1,236 @@
+package handlers
+
+import (
+	"runtime/debug"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/tg"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/api/models"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/clients/bo_backend"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/consts"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/autoplay"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/events"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/log"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/metrics"
+)
+
+// PostAutoplay starts a server-side autoplay sequence for a player session.
+// The rounds are played at the minimum spin interval of the jurisdiction, and each round response is sent to the client
+// as a message through GET /v1/messages. The sequence keeps running if the client disconnects.
+// The sequence and its status are kept by the instance of the service which started it,
+// so with multiple instances the requests of a session must be routed to the same instance to stop it or get its status.
+func PostAutoplay(req *fiber.Ctx) (err error) {
+	started := time.Now()
+	defer func() { metrics.Metrics.AddDuration(metrics.ApiAutoplay, started) }()
+
+	params := &struct {
+		SessionID string `json:"sessionId"`
+		Bet       int64  `json:"bet"`
+		autoplay.Settings
+	}{}
+
+	defer func() {
+		if e := recover(); e != nil {
+			err = sendErrorStack(req, consts.PathAutoplay, e, params, fiber.StatusInternalServerError, BodyInternalError(consts.ErrCdPanic, consts.ErrLvlFatal), debug.Stack())
+		}
+	}()
+
+	// decode request.
+	if err = req.BodyParser(params); err != nil {
+		return sendError(req, consts.PathAutoplay, err, params, fiber.StatusBadRequest, BodyBadRequest(consts.ErrCdParams, consts.ErrLvlFatal))
+	}
+
+	sess, err2 := tg.VerifySessionID(params.SessionID)
+	if params.SessionID == "" || params.Bet <= 0 || err2 != nil || sess.DSF() {
+		return sendError(req, consts.PathAutoplay, FmtInvalidSession("verification", err2), params, fiber.StatusBadRequest, BodyInvalidSession(consts.ErrCdSessionInvalid, consts.ErrLvlFatal))
+	}
+	if s := &params.Settings; s.Rounds <= 0 || s.WinAbove < 0 || s.BalanceBelow < 0 || s.BalanceAbove < 0 || s.LossLimit < 0 {
+		return sendError(req, consts.PathAutoplay, consts.ErrorBadRequest, params, fiber.StatusBadRequest, BodyBadRequest(consts.ErrCdParams, consts.ErrLvlFatal))
+	}
+
+	// mark active session.
+	metrics.MarkSession(params.SessionID)
+
+	// enforce the autoplay rules of the jurisdiction.
+	juris := bo_backend.Jurisdiction(sess)
+	if !juris.Autoplay || (juris.AutoplayMax > 0 && params.Rounds > juris.AutoplayMax) || (juris.AutoplayLossLimit && params.LossLimit <= 0) {
+		return sendError(req, consts.PathAutoplay, consts.ErrorJurisdiction, params, fiber.StatusForbidden, BodyJurisdiction(consts.ErrCdJurisdictionAutoplay, consts.ErrLvlFatal))
+	}
+
+	run, err3 := autoplay.Start(params.SessionID, params.Bet, params.Settings)
+	if err3 != nil {
+		return sendError(req, consts.PathAutoplay, err3, params, fiber.StatusConflict, BodyAutoplay(consts.ErrCdAutoplayRunning, consts.ErrLvlFatal))
+	}
+
+	wait := time.Duration(juris.MinSpinDuration) * time.Millisecond
+	go runAutoplay(run, params.SessionID, params.Bet, wait)
+
+	return sendAutoplayStatus(req, run.Status())
+}
+
+// PostAutoplayStop cancels the running autoplay sequence of a player session.
+// The round in progress is completed before the sequence stops.
+func PostAutoplayStop(req *fiber.Ctx) (err error) {
+	started := time.Now()
+	defer func() { metrics.Metrics.AddDuration(metrics.ApiAutoplayStop, started) }()
+
+	params := &struct {
+		SessionID string `json:"sessionId"`
+	}{}
+
+	defer func() {
+		if e := recover(); e != nil {
+			err = sendErrorStack(req, consts.PathAutoplayStop, e, params, fiber.StatusInternalServerError, BodyInternalError(consts.ErrCdPanic, consts.ErrLvlFatal), debug.Stack())
+		}
+	}()
+
+	// decode request.
+	if err = req.BodyParser(params); err != nil {
+		return sendError(req, consts.PathAutoplayStop, err, params, fiber.StatusBadRequest, BodyBadRequest(consts.ErrCdParams, consts.ErrLvlFatal))
+	}
+
+	if _, err2 := tg.VerifySessionID(params.SessionID); params.SessionID == "" || err2 != nil {
+		return sendError(req, consts.PathAutoplayStop, FmtInvalidSession("verification", err2), params, fiber.StatusBadRequest, BodyInvalidSession(consts.ErrCdSessionInvalid, consts.ErrLvlFatal))
+	}
+
+	if !autoplay.Stop(params.SessionID) {
+		return sendError(req, consts.PathAutoplayStop, consts.ErrorNotFound, params, fiber.StatusNotFound, BodyNotFound(consts.ErrCdNotFound, consts.ErrLvlFatal))
+	}
+
+	status, _ := autoplay.Get(params.SessionID)
+	return sendAutoplayStatus(req, status)
+}
+
+// GetAutoplay returns the status of the last autoplay sequence of a player session.
+// Clients use it to pick up a running sequence after reconnecting.
+func GetAutoplay(req *fiber.Ctx) (err error) {
+	started := time.Now()
+	defer func() { metrics.Metrics.AddDuration(metrics.ApiAutoplayStatus, started) }()
+
+	sessionID := req.Query("sessionId")
+	if _, err2 := tg.VerifySessionID(sessionID); sessionID == "" || err2 != nil {
+		return sendError(req, consts.PathAutoplay, FmtInvalidSession("verification", err2), sessionID, fiber.StatusBadRequest, BodyInvalidSession(consts.ErrCdSessionInvalid, consts.ErrLvlFatal))
+	}
+
+	status, ok := autoplay.Get(sessionID)
+	if !ok {
+		return sendError(req, consts.PathAutoplay, consts.ErrorNotFound, sessionID, fiber.StatusNotFound, BodyNotFound(consts.ErrCdNotFound, consts.ErrLvlFatal))
+	}
+	return sendAutoplayStatus(req, status)
+}
+
+func sendAutoplayStatus(req *fiber.Ctx, status autoplay.Status) error {
+	b, _ := json.Marshal(&struct {
+		autoplay.Status
+		Success bool `json:"success"`
+	}{Status: status, Success: true})
+
+	req.Set(consts.ContentType, consts.ApplicationJSON)
+	_, err := req.Write(b)
+	return err
+}
+
+// runAutoplay plays the rounds of an autoplay sequence until a stop condition is met, or the sequence is cancelled.
+func runAutoplay(run *autoplay.Run, sessionID string, bet int64, wait time.Duration) {
+	reason := autoplay.StopFailed
+	defer func() {
+		run.Finish(reason)
+		s := run.Status()
+
+		msg := events.NewMessage(tg.MsgAutoplayStopped, tg.DisplayInline, time.Now(), autoplayMessageTTL).
+			WithData(consts.FieldPlayed, int64(s.Played)).
+			WithData("bets", s.Bets).
+			WithData("wins", s.Wins).
+			WithData(consts.FieldReason, int64(reason))
+		events.AddMessage(sessionID, msg)
+
+		log.Logger.Info(consts.MsgAutoplayStopped, consts.FieldSession, sessionID, consts.FieldPlayed, s.Played, consts.FieldReason, reason.String())
+	}()
+
+	body, _ := json.Marshal(&models.RoundStartRequest{SessionID: sessionID, Bet: bet})
+
+	var last time.Time
+	for {
+		// wait for the minimum spin interval of the jurisdiction.
+		var delay <-chan time.Time
+		if d := wait - time.Since(last); !last.IsZero() && d > 0 {
+			delay = time.After(d)
+		} else {
+			delay = time.After(0)
+		}
+
+		select {
+		case <-run.Done():
+			reason = autoplay.StopCancelled
+			return
+		case <-delay:
+		}
+
+		last = time.Now()
+		status, resp, outcome := playAutoplayRound(body)
+		if status != fiber.StatusOK {
+			// e.g. a jurisdiction rule or responsible gambling limit; the error has already been logged.
+			events.AddMessage(sessionID, events.NewMessage(tg.MsgAutoplayStopped, tg.DisplayInline, last, autoplayMessageTTL).WithJSON("error", resp))
+			return
+		}
+
+		s := run.Status()
+		msg := events.NewMessage(tg.MsgAutoplayRound, tg.DisplayInline, last, autoplayMessageTTL).
+			WithData("seq", int64(s.Played+1)).
+			WithData("rounds", int64(s.Settings.Rounds)).
+			WithJSON("round", resp)
+		events.AddMessage(sessionID, msg)
+
+		if reason = run.Record(autoplay.Result{
+			Bet:     outcome.bet,
+			Win:     outcome.win,
+			Balance: outcome.balance,
+			Feature: outcome.feature,
+			Choice:  outcome.choice,
+		}); reason != autoplay.StopNone {
+			return
+		}
+	}
+}
+
+// playAutoplayRound plays a round through the PostRound handler, detached from any client connection.
+// It returns the HTTP status, the response body and the outcome of the round.
+func playAutoplayRound(body []byte) (int, []byte, *roundOutcome) {
+	fctx := &fasthttp.RequestCtx{}
+	fctx.Request.Header.SetMethod(fiber.MethodPost)
+	fctx.Request.Header.SetContentType(fiber.MIMEApplicationJSON)
+	fctx.Request.SetRequestURI(consts.PathRound)
+	fctx.Request.SetBody(body)
+
+	req := autoplayApp.AcquireCtx(fctx)
+	defer autoplayApp.ReleaseCtx(req)
+
+	outcome := &roundOutcome{}
+	req.Locals(localRoundOutcome, outcome)
+
+	_ = PostRound(req)
+
+	return fctx.Response.StatusCode(), append([]byte{}, fctx.Response.Body()...), outcome
+}
+
+// roundOutcome receives the outcome of a round played through execRound, e.g. for autoplay.
+type roundOutcome struct {
+	bet     int64
+	win     int64
+	balance int64
+	feature bool
+	choice  bool
+}
+
+// localRoundOutcome is the key of the fiber.Ctx local which receives the round outcome.
+const localRoundOutcome = "roundOutcome"
+
+const autoplayMessageTTL = 300 // seconds.
+
+// autoplayApp provides the contexts for the autoplay rounds; it is never started.
+var autoplayApp = fiber.New()
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/tg"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/log"
+)
+
+func TestAutoplaySession(t *testing.T) {
+	log.Init()
+
+	app := fiber.New()
+	require.NotNil(t, app)
+
+	app.Post("/v1/autoplay/stop", PostAutoplayStop)
+	app.Get("/v1/autoplay", GetAutoplay)
+
+	sessionID, err := tg.MakeSessionID("bot", 92, 1)
+	require.NoError(t, err)
+
+	call := func(method, path, body string) int {
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		require.NotNil(t, req)
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+
+		resp, err2 := app.Test(req, 100)
+		require.NoError(t, err2)
+		require.NotNil(t, resp)
+		return resp.StatusCode
+	}
+
+	t.Run("invalid session", func(t *testing.T) {
+		assert.Equal(t, fiber.StatusBadRequest, call(fiber.MethodGet, "/v1/autoplay?sessionId=x", ""))
+		assert.Equal(t, fiber.StatusBadRequest, call(fiber.MethodGet, "/v1/autoplay", ""))
+		assert.Equal(t, fiber.StatusBadRequest, call(fiber.MethodPost, "/v1/autoplay/stop", `{"sessionId":"x"}`))
+		assert.Equal(t, fiber.StatusBadRequest, call(fiber.MethodPost, "/v1/autoplay/stop", `{}`))
+	})
+
+	t.Run("not running", func(t *testing.T) {
+		assert.Equal(t, fiber.StatusNotFound, call(fiber.MethodGet, "/v1/autoplay?sessionId="+sessionID, ""))
+		assert.Equal(t, fiber.StatusNotFound, call(fiber.MethodPost, "/v1/autoplay/stop", `{"sessionId":"`+sessionID+`"}`))
+	})
+}
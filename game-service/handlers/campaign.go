@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	mngr "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-manager.git/state/slots"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/consts"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/metrics"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/state"
+)
+
+// PutCampaign grants or updates a free-round campaign for a player.
+// Rounds already played and winnings so far are retained by the RoundManager when a campaign is updated.
+func PutCampaign(req *fiber.Ctx) (err error) {
+	started := time.Now()
+	defer func() { metrics.Metrics.AddDuration(metrics.ApiCampaign, started) }()
+
+	params := struct {
+		PlayerID   string    `json:"playerId"`
+		CampaignID string    `json:"campaignId"`
+		GameID     string    `json:"gameId,omitempty"`
+		Bet        int64     `json:"bet"`
+		Rounds     int       `json:"rounds"`
+		Expires    time.Time `json:"expires,omitempty"`
+		MaxWin     int64     `json:"maxWin,omitempty"`
+		Wagering   float64   `json:"wagering,omitempty"`
+	}{}
+
+	defer func() {
+		if e := recover(); e != nil {
+			err = sendErrorStack(req, consts.PathCampaign, e, params, fiber.StatusInternalServerError, BodyInternalError(consts.ErrCdPanic, consts.ErrLvlFatal), debug.Stack())
+		}
+	}()
+
+	// check api key; it must have been configured for the service.
+	if !checkAdminKey(req) {
+		return sendError(req, consts.PathCampaign, consts.ErrorInvalidApiKey, nil, http.StatusBadRequest, BodyBadRequest(consts.ErrCdApiKey, consts.ErrLvlFatal))
+	}
+
+	// decode request.
+	if err = req.BodyParser(&params); err != nil {
+		return sendError(req, consts.PathCampaign, err, params, fiber.StatusBadRequest, BodyBadRequest(consts.ErrCdParams, consts.ErrLvlFatal))
+	}
+
+	if params.PlayerID == "" || params.CampaignID == "" || params.Bet <= 0 || params.Rounds <= 0 || params.MaxWin < 0 || params.Wagering < 0 {
+		return sendError(req, consts.PathCampaign, consts.ErrorBadRequest, params, fiber.StatusBadRequest, BodyBadRequest(consts.ErrCdParams, consts.ErrLvlFatal))
+	}
+
+	c := &mngr.Campaign{
+		ID:       params.CampaignID,
+		Game:     params.GameID,
+		Bet:      params.Bet,
+		Rounds:   params.Rounds,
+		Expires:  params.Expires,
+		MaxWin:   params.MaxWin,
+		Wagering: params.Wagering,
+	}
+
+	started2 := time.Now()
+	err = state.Manager.PutCampaign(params.PlayerID, c)
+	metrics.Metrics.AddDuration(metrics.DsCampaignPut, started2)
+
+	if err != nil {
+		return sendError(req, consts.PathCampaign, FmtDstoreError(err), params, fiber.StatusInternalServerError, BodyDstoreError(err))
+	}
+
+	req.Set(consts.ContentType, consts.ApplicationJSON)
+	_, err = req.Write(consts.SuccessResponse)
+	return err
+}
+
+// activeCampaign returns the campaign with free rounds left for the game in a player session, or nil if there are none.
+// If there are multiple campaigns, the one expiring first is returned.
+func activeCampaign(sessionID, gameID string) *mngr.Campaign {
+	var out *mngr.Campaign
+
+	list, _ := getCampaigns(sessionID)
+	now := time.Now()
+	for _, c := range list {
+		if !c.Active(gameID, now) {
+			continue
+		}
+		if out == nil || (!c.Expires.IsZero() && (out.Expires.IsZero() || c.Expires.Before(out.Expires))) {
+			out = c
+		}
+	}
+	return out
+}
+
+// freeRounds returns the total number of free rounds left for the game in a player session.
+func freeRounds(sessionID, gameID string) int64 {
+	var out int64
+
+	list, _ := getCampaigns(sessionID)
+	now := time.Now()
+	for _, c := range list {
+		if c.Active(gameID, now) {
+			out += int64(c.Remaining())
+		}
+	}
+	return out
+}
+
+// roundCampaign returns the campaign of the free round in the game state, or nil if it is not a free round.
+// The completion of a free round, e.g. after a player choice, must be capped and booked against the same campaign,
+// even if its free rounds have been used up since.
+func roundCampaign(sessionID string, gs *mngr.GameState) (*mngr.Campaign, error) {
+	id := gs.Campaign()
+	if id == "" {
+		return nil, nil
+	}
+
+	list, err := getCampaigns(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range list {
+		if c.ID == id {
+			return c, nil
+		}
+	}
+	return nil, errCampaignNotFound
+}
+
+func getCampaigns(sessionID string) ([]*mngr.Campaign, error) {
+	started := time.Now()
+	list, err := state.Manager.GetCampaigns(sessionID)
+	metrics.Metrics.AddDuration(metrics.DsCampaignsGet, started)
+	return list, err
+}
+
+var errCampaignNotFound = errors.New("campaign of the free round not found")
@@ -0,0 +1,220 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	store "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-manager.git/repository/slots"
+	mngr "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-manager.git/state/slots"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/tg"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/api/models"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/config"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/log"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/state"
+)
+
+func TestPutCampaign(t *testing.T) {
+	log.Init()
+	state.Manager = store.NewMemory()
+
+	app := fiber.New()
+	require.NotNil(t, app)
+
+	app.Put("/v1/campaign", PutCampaign)
+
+	// the memory store uses the session id as player id.
+	sessionID, err := tg.MakeSessionID("bot", 92, 1)
+	require.NoError(t, err)
+
+	put := func(body string, apiKey bool) int {
+		req := httptest.NewRequest(fiber.MethodPut, "/v1/campaign", bytes.NewBufferString(body))
+		require.NotNil(t, req)
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		if apiKey {
+			req.Header.Set("X-API-KEY", config.ApiKey)
+		}
+
+		resp, err2 := app.Test(req, 100)
+		require.NoError(t, err2)
+		require.NotNil(t, resp)
+		return resp.StatusCode
+	}
+
+	t.Run("default api key", func(t *testing.T) {
+		assert.Equal(t, fiber.StatusBadRequest, put(`{"playerId":"`+sessionID+`","campaignId":"c1","bet":100,"rounds":10}`, true))
+	})
+
+	config.ApiKeySet = true
+	defer func() { config.ApiKeySet = false }()
+
+	t.Run("no api key", func(t *testing.T) {
+		assert.Equal(t, fiber.StatusBadRequest, put(`{"playerId":"`+sessionID+`","campaignId":"c1","bet":100,"rounds":10}`, false))
+	})
+
+	t.Run("no player", func(t *testing.T) {
+		assert.Equal(t, fiber.StatusBadRequest, put(`{"campaignId":"c1","bet":100,"rounds":10}`, true))
+	})
+
+	t.Run("no rounds", func(t *testing.T) {
+		assert.Equal(t, fiber.StatusBadRequest, put(`{"playerId":"`+sessionID+`","campaignId":"c1","bet":100}`, true))
+		assert.Nil(t, activeCampaign(sessionID, "bot"))
+	})
+
+	t.Run("grant and update", func(t *testing.T) {
+		assert.Equal(t, fiber.StatusOK, put(`{"playerId":"`+sessionID+`","campaignId":"c1","gameId":"bot","bet":100,"rounds":3,"maxWin":1000}`, true))
+
+		c := activeCampaign(sessionID, "bot")
+		require.NotNil(t, c)
+		assert.Equal(t, "c1", c.ID)
+		assert.Equal(t, int64(100), c.Bet)
+		assert.Equal(t, 3, c.Remaining())
+		assert.Nil(t, activeCampaign(sessionID, "mgd"))
+		assert.Equal(t, int64(3), freeRounds(sessionID, "bot"))
+
+		assert.Equal(t, fiber.StatusOK, put(`{"playerId":"`+sessionID+`","campaignId":"c1","gameId":"bot","bet":100,"rounds":5,"maxWin":1000}`, true))
+		assert.Equal(t, int64(5), freeRounds(sessionID, "bot"))
+	})
+
+	t.Run("expiring first", func(t *testing.T) {
+		expires := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+		assert.Equal(t, fiber.StatusOK, put(`{"playerId":"`+sessionID+`","campaignId":"c2","bet":50,"rounds":2,"expires":"`+expires+`"}`, true))
+
+		c := activeCampaign(sessionID, "bot")
+		require.NotNil(t, c)
+		assert.Equal(t, "c2", c.ID)
+		assert.Equal(t, int64(7), freeRounds(sessionID, "bot"))
+	})
+}
+
+func TestCampaignRounds(t *testing.T) {
+	log.Init()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const startBalance = 1000000
+	m, err := store.NewEmbedded(ctx, "", startBalance, 0)
+	require.NoError(t, err)
+	state.Manager = m
+
+	app := fiber.New()
+	require.NotNil(t, app)
+
+	app.Post("/v1/round", PostRound)
+
+	sessionID, err := tg.MakeSessionID("bot", 92, 1)
+	require.NoError(t, err)
+	setTestSession(sessionID)
+
+	round := func() (int, *models.RoundStartResponse) {
+		req := httptest.NewRequest(fiber.MethodPost, "/v1/round", bytes.NewBufferString(`{"sessionId":"`+sessionID+`","bet":100}`))
+		require.NotNil(t, req)
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+
+		resp, err2 := app.Test(req, 5000)
+		require.NoError(t, err2)
+		require.NotNil(t, resp)
+
+		if resp.StatusCode != fiber.StatusOK {
+			return resp.StatusCode, nil
+		}
+
+		b, err3 := io.ReadAll(resp.Body)
+		require.NoError(t, err3)
+		out := &models.RoundStartResponse{}
+		require.NoError(t, json.Unmarshal(b, out))
+		return resp.StatusCode, out
+	}
+
+	campaign := func() *mngr.Campaign {
+		list, err2 := state.Manager.GetCampaigns(sessionID)
+		require.NoError(t, err2)
+		require.Len(t, list, 1)
+		return list[0]
+	}
+
+	require.NoError(t, state.Manager.PutCampaign(sessionID, &mngr.Campaign{ID: "c1", Game: "bot", Bet: 50, Rounds: 3, Wagering: 2}))
+
+	t.Run("free rounds", func(t *testing.T) {
+		for ix := 0; ix < 3; ix++ {
+			status, resp := round()
+			require.Equal(t, fiber.StatusOK, status)
+
+			// the winnings are held back until the wagering requirement is met.
+			assert.Equal(t, int64(startBalance), resp.RoundData.BalanceAfter)
+		}
+
+		c := campaign()
+		assert.Equal(t, 3, c.Used)
+		assert.Equal(t, c.Won, c.Held())
+		assert.Nil(t, activeCampaign(sessionID, "bot"))
+
+		// granting the campaign again retains the play so far.
+		require.NoError(t, state.Manager.PutCampaign(sessionID, &mngr.Campaign{ID: "c1", Game: "bot", Bet: 50, Rounds: 3, Wagering: 2}))
+		c2 := campaign()
+		assert.Equal(t, 3, c2.Used)
+		assert.Equal(t, c.Won, c2.Won)
+	})
+
+	t.Run("wagering", func(t *testing.T) {
+		var bets, wins int64
+		for ix := 0; ix < 1000 && !campaign().Released; ix++ {
+			status, resp := round()
+			require.Equal(t, fiber.StatusOK, status)
+			stored, err2 := state.Manager.GetRound(sessionID, resp.RoundData.RoundID)
+			require.NoError(t, err2)
+			bets += stored.Bet
+			wins += stored.Win
+			stored.Release()
+		}
+
+		c := campaign()
+		require.True(t, c.Released)
+		assert.GreaterOrEqual(t, c.Wagered, c.WageringRequired())
+		assert.Zero(t, c.Held())
+
+		status, resp := round()
+		require.Equal(t, fiber.StatusOK, status)
+		stored, err2 := state.Manager.GetRound(sessionID, resp.RoundData.RoundID)
+		require.NoError(t, err2)
+		bets += stored.Bet
+		wins += stored.Win
+		stored.Release()
+
+		assert.Equal(t, startBalance-bets+wins+c.Won, resp.RoundData.BalanceAfter)
+	})
+
+	t.Run("consumed elsewhere", func(t *testing.T) {
+		// the campaign appears active, but its free rounds have been used up by another session of the player.
+		state.Manager = staleCampaigns{RoundManager: m, campaign: &mngr.Campaign{ID: "c1", Game: "bot", Bet: 50, Rounds: 10}}
+		defer func() { state.Manager = m }()
+
+		status, _ := round()
+		assert.Equal(t, fiber.StatusBadRequest, status)
+
+		list, err2 := m.GetCampaigns(sessionID)
+		require.NoError(t, err2)
+		require.Len(t, list, 1)
+		assert.Equal(t, 3, list[0].Used)
+	})
+}
+
+// staleCampaigns returns a campaign which is no longer active in the store.
+type staleCampaigns struct {
+	mngr.RoundManager
+	campaign *mngr.Campaign
+}
+
+func (m staleCampaigns) GetCampaigns(_ string) ([]*mngr.Campaign, error) {
+	return []*mngr.Campaign{m.campaign.Clone()}, nil
+}
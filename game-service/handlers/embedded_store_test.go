@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	store "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-manager.git/repository/slots"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/tg"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/api/models"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/config"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/log"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/state"
+)
+
+func TestEmbeddedStore(t *testing.T) {
+	log.Init()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	file := filepath.Join(t.TempDir(), "store.json")
+	m, err := store.NewEmbedded(ctx, file, 1000000, 0)
+	require.NoError(t, err)
+	state.Manager = m
+
+	app := fiber.New()
+	require.NotNil(t, app)
+
+	app.Post("/v1/round", PostRound)
+	app.Get("/v1/audit", GetAudit)
+
+	sessionID, err := tg.MakeSessionID("bot", 92, 1)
+	require.NoError(t, err)
+	setTestSession(sessionID)
+
+	call := func(method, path, body string, out any) int {
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		require.NotNil(t, req)
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		req.Header.Set("X-API-KEY", config.ApiKey)
+
+		resp, err2 := app.Test(req, 5000)
+		require.NoError(t, err2)
+		require.NotNil(t, resp)
+
+		if resp.StatusCode == fiber.StatusOK {
+			b, err3 := io.ReadAll(resp.Body)
+			require.NoError(t, err3)
+			require.NoError(t, json.Unmarshal(b, out))
+		}
+		return resp.StatusCode
+	}
+
+	var bets, wins int64
+	var roundID string
+	seen := make(map[string]bool)
+
+	for ix := 0; ix < 10; ix++ {
+		var resp models.RoundStartResponse
+		require.Equal(t, fiber.StatusOK, call(fiber.MethodPost, "/v1/round", `{"sessionId":"`+sessionID+`","bet":100}`, &resp))
+		require.NotNil(t, resp.RoundData)
+
+		roundID = resp.RoundData.RoundID
+		assert.False(t, seen[roundID])
+		seen[roundID] = true
+
+		round, err2 := state.Manager.GetRound(sessionID, roundID)
+		require.NoError(t, err2)
+		require.NotNil(t, round)
+		bets += round.Bet
+		wins += round.Win
+		round.Release()
+	}
+
+	_, balance, err := state.Manager.PostRoundNext(sessionID, roundID, nil, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1000000-bets+wins, balance)
+
+	rs, err := state.Manager.GetRoundState(sessionID, roundID)
+	require.NoError(t, err)
+	require.NotNil(t, rs)
+	rs.Release()
+
+	// the state survives a restart.
+	m2, err := store.NewEmbedded(ctx, file, 1000000, 0)
+	require.NoError(t, err)
+	state.Manager = m2
+
+	_, balance2, err := state.Manager.PostRoundNext(sessionID, roundID, nil, 1)
+	require.NoError(t, err)
+	assert.Equal(t, balance, balance2)
+
+	// the play for the responsible gambling limits is stored with the game state.
+	gs, err := state.Manager.GetGameState(sessionID)
+	require.NoError(t, err)
+	play := gs.Play()
+	gs.Release()
+	assert.Equal(t, int64(10), play.Rounds)
+	assert.Equal(t, bets, play.Bets)
+	assert.Equal(t, wins, play.Wins)
+	assert.False(t, play.Started.IsZero())
+
+	var audit models.AuditResponse
+	require.Equal(t, fiber.StatusOK, call(fiber.MethodGet, "/v1/audit?sessionId="+sessionID+"&roundId="+roundID, "", &audit))
+	assert.True(t, audit.Match)
+	assert.Empty(t, audit.Diffs)
+
+	_, err = state.Manager.GetRound(sessionID, "unknown")
+	assert.Error(t, err)
+}
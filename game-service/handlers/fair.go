@@ -0,0 +1,185 @@
+package handlers
+
+import (
+	"runtime/debug"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/gofiber/fiber/v2"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/rng"
+	mngr "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-manager.git/state/slots"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/tg"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/api/models"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/consts"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/config"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/fair"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/metrics"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/roundlock"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/state"
+)
+
+// GetFair returns the hash of the server seed for the provably-fair rounds of a player session.
+// The server commits to this hash before the rounds are played, and reveals the server seed on rotation.
+func GetFair(req *fiber.Ctx) (err error) {
+	started := time.Now()
+	defer func() { metrics.Metrics.AddDuration(metrics.ApiFair, started) }()
+
+	sessionID := req.Query("sessionId")
+
+	defer func() {
+		if e := recover(); e != nil {
+			err = sendErrorStack(req, consts.PathFair, e, sessionID, fiber.StatusInternalServerError, BodyInternalError(consts.ErrCdPanic, consts.ErrLvlFatal), debug.Stack())
+		}
+	}()
+
+	if !config.ProvablyFair {
+		return sendError(req, consts.PathFair, consts.ErrorFair, sessionID, fiber.StatusBadRequest, BodyFair(consts.ErrCdFairDisabled, consts.ErrLvlFatal))
+	}
+
+	if _, err2 := tg.VerifySessionID(sessionID); sessionID == "" || err2 != nil {
+		return sendError(req, consts.PathFair, FmtInvalidSession("verification", err2), sessionID, fiber.StatusBadRequest, BodyInvalidSession(consts.ErrCdSessionInvalid, consts.ErrLvlFatal))
+	}
+
+	// serialise with rounds, so a round cannot commit to another new server seed at the same time.
+	unlock, err3 := roundlock.Lock(sessionID)
+	if err3 != nil {
+		return sendLockError(req, consts.PathFair, err3, sessionID)
+	}
+	defer unlock()
+
+	seed, err4 := getFairSeed(sessionID)
+	if err4 != nil {
+		return sendError(req, consts.PathFair, FmtDstoreError(err4), sessionID, fiber.StatusInternalServerError, BodyDstoreError(err4))
+	}
+
+	s := fair.Get(seed)
+	b, _ := json.Marshal(&models.FairResponse{
+		ServerSeedHash: s.ServerSeedHash,
+		Nonce:          s.Nonce,
+		Rounds:         int64(s.Rounds),
+		Success:        true,
+	})
+
+	req.Set(consts.ContentType, consts.ApplicationJSON)
+	_, err = req.Write(b)
+	return err
+}
+
+// PostFairRotate reveals the server seed of a player session, so the player can verify the rounds played with it.
+// A new server seed is generated for the next rounds, and the hash of the new seed is returned.
+// The server seed is only replaced once the new seed is stored, so a seed is never discarded without being revealed.
+func PostFairRotate(req *fiber.Ctx) (err error) {
+	started := time.Now()
+	defer func() { metrics.Metrics.AddDuration(metrics.ApiFairRotate, started) }()
+
+	params := &struct {
+		SessionID string `json:"sessionId"`
+	}{}
+
+	defer func() {
+		if e := recover(); e != nil {
+			err = sendErrorStack(req, consts.PathFairRotate, e, params, fiber.StatusInternalServerError, BodyInternalError(consts.ErrCdPanic, consts.ErrLvlFatal), debug.Stack())
+		}
+	}()
+
+	if !config.ProvablyFair {
+		return sendError(req, consts.PathFairRotate, consts.ErrorFair, params, fiber.StatusBadRequest, BodyFair(consts.ErrCdFairDisabled, consts.ErrLvlFatal))
+	}
+
+	// decode request.
+	if err = req.BodyParser(params); err != nil {
+		return sendError(req, consts.PathFairRotate, err, params, fiber.StatusBadRequest, BodyBadRequest(consts.ErrCdParams, consts.ErrLvlFatal))
+	}
+
+	if _, err2 := tg.VerifySessionID(params.SessionID); params.SessionID == "" || err2 != nil {
+		return sendError(req, consts.PathFairRotate, FmtInvalidSession("verification", err2), params, fiber.StatusBadRequest, BodyInvalidSession(consts.ErrCdSessionInvalid, consts.ErrLvlFatal))
+	}
+
+	// serialise with rounds, so the server seed cannot be rotated while a round uses it.
+	unlock, err3 := roundlock.Lock(params.SessionID)
+	if err3 != nil {
+		return sendLockError(req, consts.PathFairRotate, err3, params)
+	}
+	defer unlock()
+
+	started2 := time.Now()
+	seed, err4 := state.Manager.GetFairSeed(params.SessionID)
+	metrics.Metrics.AddDuration(metrics.DsFairSeedGet, started2)
+
+	if err4 != nil {
+		return sendError(req, consts.PathFairRotate, FmtDstoreError(err4), params, fiber.StatusInternalServerError, BodyDstoreError(err4))
+	}
+	if seed == nil {
+		return sendError(req, consts.PathFairRotate, consts.ErrorNotFound, params, fiber.StatusNotFound, BodyNotFound(consts.ErrCdNotFound, consts.ErrLvlFatal))
+	}
+
+	next := fair.NewSeed()
+	if err = putFairSeed(params.SessionID, next); err != nil {
+		return sendError(req, consts.PathFairRotate, FmtDstoreError(err), params, fiber.StatusInternalServerError, BodyDstoreError(err))
+	}
+
+	revealed := fair.Reveal(seed)
+
+	b, _ := json.Marshal(&models.FairRotateResponse{
+		Revealed: &models.FairRotateResponseRevealed{
+			ServerSeed:     revealed.ServerSeed,
+			ServerSeedHash: revealed.ServerSeedHash,
+			Nonce:          revealed.Nonce,
+			Rounds:         int64(revealed.Rounds),
+		},
+		Next:    next.Hash,
+		Success: true,
+	})
+
+	req.Set(consts.ContentType, consts.ApplicationJSON)
+	_, err = req.Write(b)
+	return err
+}
+
+// nextFairSeeds returns the seeds for the next provably-fair round of a player session, with the commitment made before the round.
+// The nonce is used up by storing the server seed through the round manager before the round is played.
+// Errors caused by the request are reported by fair.IsSeedError.
+func nextFairSeeds(sessionID, clientSeed string, nonce uint64) (*rng.FairSeeds, string, error) {
+	seed, err := getFairSeed(sessionID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	seeds, err2 := fair.Next(seed, clientSeed, nonce)
+	if err2 != nil {
+		return nil, "", err2
+	}
+
+	if err = putFairSeed(sessionID, seed); err != nil {
+		return nil, "", err
+	}
+	return seeds, seed.Hash, nil
+}
+
+// getFairSeed returns the server seed of a player session from the round manager.
+// A new server seed is stored if the session does not have one yet, so the commitment cannot change once it is shown.
+// The caller must hold the round lock of the session.
+func getFairSeed(sessionID string) (*mngr.FairSeed, error) {
+	started := time.Now()
+	seed, err := state.Manager.GetFairSeed(sessionID)
+	metrics.Metrics.AddDuration(metrics.DsFairSeedGet, started)
+
+	if err != nil || seed != nil {
+		return seed, err
+	}
+
+	seed = fair.NewSeed()
+	if err = putFairSeed(sessionID, seed); err != nil {
+		return nil, err
+	}
+	return seed, nil
+}
+
+func putFairSeed(sessionID string, seed *mngr.FairSeed) error {
+	started := time.Now()
+	err := state.Manager.PutFairSeed(sessionID, seed)
+	metrics.Metrics.AddDuration(metrics.DsFairSeedPut, started)
+	return err
+}
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"bytes"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	store "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-manager.git/repository/slots"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/tg"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/api/models"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/config"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/log"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/state"
+)
+
+func TestProvablyFair(t *testing.T) {
+	log.Init()
+	state.Manager = store.NewMemory()
+
+	app := fiber.New()
+	require.NotNil(t, app)
+
+	app.Get("/v1/fair", GetFair)
+	app.Post("/v1/fair/rotate", PostFairRotate)
+	app.Post("/v1/round", PostRound)
+
+	sessionID, err := tg.MakeSessionID("bot", 92, 1)
+	require.NoError(t, err)
+	setTestSession(sessionID)
+
+	call := func(method, path, body string, out any) int {
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		require.NotNil(t, req)
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+
+		resp, err2 := app.Test(req, 1000)
+		require.NoError(t, err2)
+		require.NotNil(t, resp)
+
+		if out != nil && resp.StatusCode == fiber.StatusOK {
+			b, err3 := io.ReadAll(resp.Body)
+			require.NoError(t, err3)
+			require.NoError(t, json.Unmarshal(b, out))
+		}
+		return resp.StatusCode
+	}
+
+	round := func(nonce string, out any) int {
+		return call(fiber.MethodPost, "/v1/round", `{"sessionId":"`+sessionID+`","bet":100,"clientSeed":"lucky","nonce":`+nonce+`}`, out)
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		config.ProvablyFair = false
+		assert.Equal(t, fiber.StatusBadRequest, call(fiber.MethodGet, "/v1/fair?sessionId="+sessionID, "", nil))
+		assert.Equal(t, fiber.StatusBadRequest, round("1", nil))
+	})
+
+	config.ProvablyFair = true
+	defer func() { config.ProvablyFair = false }()
+
+	var commitment models.FairResponse
+	t.Run("commitment", func(t *testing.T) {
+		assert.Equal(t, fiber.StatusBadRequest, call(fiber.MethodGet, "/v1/fair?sessionId=x", "", nil))
+		require.Equal(t, fiber.StatusOK, call(fiber.MethodGet, "/v1/fair?sessionId="+sessionID, "", &commitment))
+		assert.Len(t, commitment.ServerSeedHash, 64)
+		assert.True(t, commitment.Success)
+	})
+
+	t.Run("round", func(t *testing.T) {
+		var resp models.RoundStartResponse
+		require.Equal(t, fiber.StatusOK, round("1", &resp))
+		require.NotNil(t, resp.Fair)
+		assert.Equal(t, commitment.ServerSeedHash, resp.Fair.ServerSeedHash)
+		assert.Equal(t, "lucky", resp.Fair.ClientSeed)
+		assert.Equal(t, uint64(1), resp.Fair.Nonce)
+
+		// the nonce cannot be reused.
+		assert.Equal(t, fiber.StatusBadRequest, round("1", nil))
+	})
+
+	t.Run("rotate", func(t *testing.T) {
+		var resp models.FairRotateResponse
+		require.Equal(t, fiber.StatusOK, call(fiber.MethodPost, "/v1/fair/rotate", `{"sessionId":"`+sessionID+`"}`, &resp))
+		require.NotNil(t, resp.Revealed)
+		assert.Equal(t, commitment.ServerSeedHash, resp.Revealed.ServerSeedHash)
+		assert.Len(t, resp.Revealed.ServerSeed, 64)
+		assert.Equal(t, uint64(1), resp.Revealed.Nonce)
+		assert.NotEqual(t, commitment.ServerSeedHash, resp.Next)
+
+		// the new server seed starts a new nonce sequence.
+		var resp2 models.RoundStartResponse
+		require.Equal(t, fiber.StatusOK, round("1", &resp2))
+		require.NotNil(t, resp2.Fair)
+		assert.Equal(t, resp.Next, resp2.Fair.ServerSeedHash)
+	})
+
+	t.Run("stored", func(t *testing.T) {
+		// the server seed is kept by the round manager, so every instance of the service uses the same commitment.
+		seed, err2 := state.Manager.GetFairSeed(sessionID)
+		require.NoError(t, err2)
+		require.NotNil(t, seed)
+		assert.Equal(t, uint64(1), seed.Nonce)
+		assert.Equal(t, 1, seed.Rounds)
+
+		var resp models.FairResponse
+		require.Equal(t, fiber.StatusOK, call(fiber.MethodGet, "/v1/fair?sessionId="+sessionID, "", &resp))
+		assert.Equal(t, seed.Hash, resp.ServerSeedHash)
+		assert.Equal(t, uint64(1), resp.Nonce)
+
+		// a session without a server seed has nothing to reveal.
+		other, err3 := tg.MakeSessionID("bot", 92, 2)
+		require.NoError(t, err3)
+		assert.Equal(t, fiber.StatusNotFound, call(fiber.MethodPost, "/v1/fair/rotate", `{"sessionId":"`+other+`"}`, nil))
+	})
+}
@@ -13,11 +13,13 @@ import (
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/tg"
 
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/api/models"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/clients/bo_backend"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/clients/i18n"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/consts"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/config"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/encode"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/game"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/jurisdiction"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/metrics"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/state"
 )
@@ -60,7 +62,18 @@ func GetGameInfo(req *fiber.Ctx) (err error) {
 	}
 	defer g.Release()
 
-	return sendResponse(consts.PathGameInfo, req, sessionID, encode.GameInfo(loc, sess, g, config.DebugMode))
+	// load preferences and jurisdiction rules.
+	prefs, casino, juris := bo_backend.GetGamePrefs(loc, sess)
+	defer prefs.Release()
+
+	if !juris.AllowsRTP(sess.RTP()) {
+		return sendError(req, consts.PathGameInfo, jurisdiction.ErrRTP, sessionID, fiber.StatusForbidden, BodyJurisdiction(consts.ErrCdJurisdictionRTP, consts.ErrLvlFatal))
+	}
+
+	campaign := activeCampaign(sessionID, sess.GameID())
+	jackpots := getJackpots(sess.GameID(), juris.Currency())
+
+	return sendResponse(consts.PathGameInfo, req, sessionID, encode.GameInfo(sess, g, prefs, casino, juris, campaign, jackpots, config.DebugMode))
 }
 
 func PostPreferences(req *fiber.Ctx) (err error) {
@@ -40,3 +40,11 @@ func GameHash(req *fiber.Ctx) error {
 	req.Set(consts.ContentType, consts.PlainText)
 	return req.Send([]byte(hash))
 }
+
+func GetGames(req *fiber.Ctx) error {
+	started := time.Now()
+	defer func() { metrics.Metrics.AddDuration(metrics.ApiGames, started) }()
+
+	// generate & send response.
+	return sendResponse(consts.PathGames, req, nil, encode.Games())
+}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/log"
+)
+
+func TestGetGames(t *testing.T) {
+	log.Init()
+
+	app := fiber.New()
+	require.NotNil(t, app)
+
+	app.Get("/v1/games", GetGames)
+
+	req := httptest.NewRequest(fiber.MethodGet, "/v1/games", nil)
+	require.NotNil(t, req)
+
+	resp, err := app.Test(req, 100)
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	games := struct {
+		Games []struct {
+			Game string `json:"game"`
+			RTPs []int  `json:"rtps"`
+		} `json:"games"`
+		Success bool `json:"success"`
+	}{}
+	require.NoError(t, json.Unmarshal(body, &games))
+	assert.True(t, games.Success)
+	require.NotEmpty(t, games.Games)
+	assert.Equal(t, "bot", games.Games[0].Game)
+	assert.Equal(t, []int{92, 94, 96}, games.Games[0].RTPs)
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	mngr "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-manager.git/state/slots"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/tg"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/consts"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/log"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/metrics"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/state"
+)
+
+// PutJackpot defines or updates a progressive jackpot pool for a game and currency.
+// The value, hidden reserve and hits of an existing pool are retained when its definition is updated.
+func PutJackpot(req *fiber.Ctx) (err error) {
+	started := time.Now()
+	defer func() { metrics.Metrics.AddDuration(metrics.ApiJackpot, started) }()
+
+	params := struct {
+		GameID      string  `json:"gameId"`
+		Currency    string  `json:"currency"`
+		Level       uint8   `json:"level"`
+		Seed        int64   `json:"seed"`
+		Rate        float64 `json:"rate"`
+		ReserveRate float64 `json:"reserveRate,omitempty"`
+		MustDropBy  int64   `json:"mustDropBy,omitempty"`
+	}{}
+
+	defer func() {
+		if e := recover(); e != nil {
+			err = sendErrorStack(req, consts.PathJackpot, e, params, fiber.StatusInternalServerError, BodyInternalError(consts.ErrCdPanic, consts.ErrLvlFatal), debug.Stack())
+		}
+	}()
+
+	// check api key; it must have been configured for the service.
+	if !checkAdminKey(req) {
+		return sendError(req, consts.PathJackpot, consts.ErrorInvalidApiKey, nil, http.StatusBadRequest, BodyBadRequest(consts.ErrCdApiKey, consts.ErrLvlFatal))
+	}
+
+	// decode request.
+	if err = req.BodyParser(&params); err != nil {
+		return sendError(req, consts.PathJackpot, err, params, fiber.StatusBadRequest, BodyBadRequest(consts.ErrCdParams, consts.ErrLvlFatal))
+	}
+
+	nr, err2 := tg.VerifyGameID(params.GameID)
+	if err2 != nil {
+		return sendError(req, consts.PathJackpot, err2, params, fiber.StatusBadRequest, BodyBadRequest(consts.ErrCdParams, consts.ErrLvlFatal))
+	}
+
+	j := &mngr.Jackpot{
+		Game:        nr.String(),
+		Currency:    params.Currency,
+		Level:       params.Level,
+		Seed:        params.Seed,
+		Rate:        params.Rate,
+		ReserveRate: params.ReserveRate,
+		MustDropBy:  params.MustDropBy,
+	}
+	if err = j.Validate(); err != nil {
+		return sendError(req, consts.PathJackpot, err, params, fiber.StatusBadRequest, BodyBadRequest(consts.ErrCdParams, consts.ErrLvlFatal))
+	}
+
+	started2 := time.Now()
+	err = state.Manager.PutJackpot(j)
+	metrics.Metrics.AddDuration(metrics.DsJackpotPut, started2)
+
+	if err != nil {
+		return sendError(req, consts.PathJackpot, FmtDstoreError(err), params, fiber.StatusInternalServerError, BodyDstoreError(err))
+	}
+
+	req.Set(consts.ContentType, consts.ApplicationJSON)
+	_, err = req.Write(consts.SuccessResponse)
+	return err
+}
+
+// getJackpots returns the progressive jackpot pools for the game and currency.
+// Failures are logged, as the pools are only informational for the player.
+func getJackpots(gameID, currency string) []*mngr.Jackpot {
+	started := time.Now()
+	list, err := state.Manager.GetJackpots(gameID, currency)
+	metrics.Metrics.AddDuration(metrics.DsJackpotsGet, started)
+
+	if err != nil {
+		log.Logger.Error(consts.MsgJackpotsFailed, consts.FieldGame, gameID, consts.FieldError, err)
+		return nil
+	}
+	return list
+}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	store "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-manager.git/repository/slots"
+	mngr "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-manager.git/state/slots"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/tg"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/config"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/log"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/state"
+)
+
+func TestPutJackpot(t *testing.T) {
+	log.Init()
+	state.Manager = store.NewMemory()
+
+	app := fiber.New()
+	require.NotNil(t, app)
+
+	app.Put("/v1/jackpot", PutJackpot)
+
+	put := func(body string, apiKey bool) int {
+		req := httptest.NewRequest(fiber.MethodPut, "/v1/jackpot", bytes.NewBufferString(body))
+		require.NotNil(t, req)
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		if apiKey {
+			req.Header.Set("X-API-KEY", config.ApiKey)
+		}
+
+		resp, err := app.Test(req, 100)
+		require.NoError(t, err)
+		require.NotNil(t, resp)
+		return resp.StatusCode
+	}
+
+	t.Run("default api key", func(t *testing.T) {
+		assert.Equal(t, fiber.StatusBadRequest, put(`{"gameId":"bot","currency":"EUR","level":1,"seed":10000,"rate":0.01}`, true))
+	})
+
+	config.ApiKeySet = true
+	defer func() { config.ApiKeySet = false }()
+
+	t.Run("no api key", func(t *testing.T) {
+		assert.Equal(t, fiber.StatusBadRequest, put(`{"gameId":"bot","currency":"EUR","level":1,"seed":10000,"rate":0.01}`, false))
+	})
+
+	t.Run("bad game", func(t *testing.T) {
+		assert.Equal(t, fiber.StatusBadRequest, put(`{"gameId":"xyz","currency":"EUR","level":1,"seed":10000,"rate":0.01}`, true))
+	})
+
+	t.Run("bad definition", func(t *testing.T) {
+		assert.Equal(t, fiber.StatusBadRequest, put(`{"gameId":"bot","currency":"EUR","seed":10000,"rate":0.01}`, true))
+		assert.Equal(t, fiber.StatusBadRequest, put(`{"gameId":"bot","currency":"EUR","level":1,"seed":10000,"rate":0.01,"mustDropBy":5000}`, true))
+		assert.Empty(t, getJackpots("bot", "EUR"))
+	})
+
+	t.Run("define and contribute", func(t *testing.T) {
+		assert.Equal(t, fiber.StatusOK, put(`{"gameId":"bot","currency":"EUR","level":1,"seed":10000,"rate":0.01,"reserveRate":0.005}`, true))
+
+		list := getJackpots("bot", "EUR")
+		require.Len(t, list, 1)
+		assert.Equal(t, int64(10000), list[0].Amount())
+		assert.Empty(t, getJackpots("bot", "GBP"))
+
+		sessionID, err := tg.MakeSessionID("bot", 92, 1)
+		require.NoError(t, err)
+
+		round := mngr.AcquireRound(state.Manager, mngr.RoundParams{SessionID: sessionID, GameID: "bot", Currency: "EUR", Bet: 1000, TotalBet: 1000})
+		defer round.Release()
+		round.Validate(false, false)
+		require.True(t, round.IsValid())
+		assert.Empty(t, round.JackpotHits())
+
+		list = getJackpots("bot", "EUR")
+		require.Len(t, list, 1)
+		assert.Equal(t, int64(10010), list[0].Amount())
+		assert.Equal(t, 5.0, list[0].Reserve)
+	})
+
+	t.Run("must drop", func(t *testing.T) {
+		assert.Equal(t, fiber.StatusOK, put(`{"gameId":"bot","currency":"EUR","level":2,"seed":100,"rate":0.5,"mustDropBy":101}`, true))
+
+		sessionID, err := tg.MakeSessionID("bot", 92, 2)
+		require.NoError(t, err)
+
+		round := mngr.AcquireRound(state.Manager, mngr.RoundParams{SessionID: sessionID, GameID: "bot", Currency: "EUR", Bet: 100, TotalBet: 100})
+		defer round.Release()
+		round.Validate(false, false)
+		require.True(t, round.IsValid())
+
+		require.Equal(t, []mngr.JackpotHit{{Level: 2, Amount: 150}}, round.JackpotHits())
+		assert.Equal(t, int64(150), round.JackpotWin())
+
+		list := getJackpots("bot", "EUR")
+		require.Len(t, list, 2)
+		assert.Equal(t, uint8(2), list[1].Level)
+		assert.Equal(t, int64(100), list[1].Amount())
+		assert.Equal(t, 1, list[1].Hits)
+	})
+}
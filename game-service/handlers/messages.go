@@ -33,7 +33,7 @@ func GetMessages(req *fiber.Ctx) (err error) {
 	msgs := events.GetMessages(params.SessionID)
 	if len(msgs) == 0 {
 		// no messages, so long poll... wait for http context cancelled or timed out, local poll timeout or new message(s).
-		trigger := make(chan struct{})
+		trigger := make(chan struct{}, 1)
 		timeout := time.Tick(config.LongPollTimeout)
 
 		events.AddSession(params.SessionID, trigger)
@@ -16,4 +16,5 @@ var (
 	// SUPERVISED-BUILD-REMOVE-END
 	roundNextRequestPool   = sync.Pool{New: func() any { return &models.RoundNextRequest{} }}
 	roundFinishRequestPool = sync.Pool{New: func() any { return &models.RoundFinishRequest{} }}
+	roundGambleRequestPool = sync.Pool{New: func() any { return &models.RoundGambleRequest{} }}
 )
@@ -50,6 +50,17 @@ func sendResponse(label string, ctx *fiber.Ctx, req any, resp *zjson.Encoder) er
 	return err
 }
 
+// sendStoredResponse sends the response of an earlier request with the same request id.
+func sendStoredResponse(label string, ctx *fiber.Ctx, req any, body []byte) error {
+	if log.API && log.Logger.Enabled(log2.DebugLevel) {
+		log.Logger.Debug(label, consts.FieldRequest, req, consts.FieldResponse, string(body))
+	}
+
+	ctx.Set(consts.ContentType, consts.ApplicationJSON)
+	_, err := ctx.Write(body)
+	return err
+}
+
 var (
 	FmtInvalidSession = func(reason string, err error) string {
 		if err != nil {
@@ -84,6 +95,42 @@ var (
 		j, _ := json.Marshal(&models.ErrorResponse{Message: consts.ErrorInvalidStatus, ErrorCode: int64(code), ErrorLevel: level})
 		return j
 	}
+	BodyJurisdiction = func(code consts.ErrorCode, level string) []byte {
+		j, _ := json.Marshal(&models.ErrorResponse{Message: consts.ErrorJurisdiction, ErrorCode: int64(code), ErrorLevel: level})
+		return j
+	}
+	BodyLimitReached = func(code consts.ErrorCode, level string) []byte {
+		j, _ := json.Marshal(&models.ErrorResponse{Message: consts.ErrorLimitReached, ErrorCode: int64(code), ErrorLevel: level})
+		return j
+	}
+	BodyAutoplay = func(code consts.ErrorCode, level string) []byte {
+		j, _ := json.Marshal(&models.ErrorResponse{Message: consts.ErrorAutoplay, ErrorCode: int64(code), ErrorLevel: level})
+		return j
+	}
+	BodyFair = func(code consts.ErrorCode, level string) []byte {
+		j, _ := json.Marshal(&models.ErrorResponse{Message: consts.ErrorFair, ErrorCode: int64(code), ErrorLevel: level})
+		return j
+	}
+	BodyAudit = func(code consts.ErrorCode, level string) []byte {
+		j, _ := json.Marshal(&models.ErrorResponse{Message: consts.ErrorAudit, ErrorCode: int64(code), ErrorLevel: level})
+		return j
+	}
+	BodySessionBusy = func(code consts.ErrorCode, level string) []byte {
+		j, _ := json.Marshal(&models.ErrorResponse{Message: consts.ErrorSessionBusy, ErrorCode: int64(code), ErrorLevel: level})
+		return j
+	}
+	BodyDuplicateRequest = func(code consts.ErrorCode, level string) []byte {
+		j, _ := json.Marshal(&models.ErrorResponse{Message: consts.ErrorDuplicate, ErrorCode: int64(code), ErrorLevel: level})
+		return j
+	}
+	BodyGamble = func(code consts.ErrorCode, level string) []byte {
+		j, _ := json.Marshal(&models.ErrorResponse{Message: consts.ErrorGamble, ErrorCode: int64(code), ErrorLevel: level})
+		return j
+	}
+	BodyRngHealth = func(code consts.ErrorCode, level string) []byte {
+		j, _ := json.Marshal(&models.ErrorResponse{Message: consts.ErrorRngHealth, ErrorCode: int64(code), ErrorLevel: level})
+		return j
+	}
 	BodyDstoreError = func(err error) []byte {
 		if e, ok := err.(*slots.APIerror); ok {
 			j, _ := json.Marshal(&models.ErrorResponse{Message: FmtDstoreError(err), ErrorCode: int64(e.Code), ErrorLevel: e.Level})
@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/consts"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/config"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/encode"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/metrics"
+)
+
+func GetRngHealth(req *fiber.Ctx) error {
+	started := time.Now()
+	defer func() { metrics.Metrics.AddDuration(metrics.ApiRngHealth, started) }()
+
+	// check api key.
+	if !bytes.Equal(req.Request().Header.Peek("X-API-KEY"), config.ApiKeyBytes) {
+		return sendError(req, consts.PathRngHealth, consts.ErrorInvalidApiKey, nil, http.StatusBadRequest, BodyBadRequest(consts.ErrCdApiKey, consts.ErrLvlFatal))
+	}
+
+	// generate & send response.
+	return sendResponse(consts.PathRngHealth, req, nil, encode.RngHealth())
+}
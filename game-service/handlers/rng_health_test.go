@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/config"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/log"
+)
+
+func TestGetRngHealth(t *testing.T) {
+	log.Init()
+
+	app := fiber.New()
+	require.NotNil(t, app)
+
+	app.Get("/v1/rng-health", GetRngHealth)
+
+	t.Run("no api key", func(t *testing.T) {
+		req := httptest.NewRequest(fiber.MethodGet, "/v1/rng-health", nil)
+		require.NotNil(t, req)
+
+		resp, err := app.Test(req, 100)
+		require.NoError(t, err)
+		require.NotNil(t, resp)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("health", func(t *testing.T) {
+		req := httptest.NewRequest(fiber.MethodGet, "/v1/rng-health", nil)
+		require.NotNil(t, req)
+		req.Header.Set("X-API-KEY", config.ApiKey)
+
+		resp, err := app.Test(req, 100)
+		require.NoError(t, err)
+		require.NotNil(t, resp)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		health := struct {
+			Backend string `json:"backend"`
+			Healthy bool   `json:"healthy"`
+		}{}
+		require.NoError(t, json.Unmarshal(body, &health))
+		assert.NotEmpty(t, health.Backend)
+		assert.True(t, health.Healthy)
+	})
+}
@@ -6,16 +6,25 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/rng"
 	util "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/utils"
 	mngr "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-manager.git/state/slots"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/tg"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/utils/conv"
 
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/api/models"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/clients/bo_backend"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/consts"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/config"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/encode"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/fair"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/game"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/idempotency"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/jurisdiction"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/limits"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/log"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/metrics"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/roundlock"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/state"
 )
 
@@ -34,7 +43,10 @@ func PostRound(req *fiber.Ctx) (err error) {
 	params = roundStartRequestPool.Get().(*models.RoundStartRequest)
 	defer func() {
 		params.SessionID = ""
+		params.RequestID = ""
 		params.Bet = 0
+		params.ClientSeed = ""
+		params.Nonce = 0
 		params.I18n = nil
 		roundStartRequestPool.Put(params)
 	}()
@@ -52,6 +64,40 @@ func PostRound(req *fiber.Ctx) (err error) {
 	// mark active session.
 	metrics.MarkSession(params.SessionID)
 
+	// serialise rounds for the session.
+	unlock, err3 := roundlock.Lock(params.SessionID)
+	if err3 != nil {
+		return sendLockError(req, consts.PathRound, err3, params)
+	}
+	defer unlock()
+
+	// return the response of the first request for a retried request.
+	if b, ok := idempotency.Get(params.SessionID, consts.PathRound, params.RequestID); ok {
+		return sendStoredResponse(consts.PathRound, req, params, b)
+	}
+
+	// play a free round at the fixed bet if the player has a campaign for the game.
+	campaign := activeCampaign(params.SessionID, sess.GameID())
+	if campaign != nil {
+		bet = campaign.Bet
+	}
+
+	// check if a provably-fair round is possible.
+	if params.ClientSeed != "" {
+		if !config.ProvablyFair {
+			return sendError(req, consts.PathRound, consts.ErrorFair, params, fiber.StatusBadRequest, BodyFair(consts.ErrCdFairDisabled, consts.ErrLvlFatal))
+		}
+		if g := game.Game(sess.GameNr()); sess.DSF() || g == nil || !g.Fair() {
+			return sendError(req, consts.PathRound, consts.ErrorFair, params, fiber.StatusBadRequest, BodyFair(consts.ErrCdFairUnsupported, consts.ErrLvlFatal))
+		}
+	}
+
+	// enforce the jurisdiction rules.
+	juris := bo_backend.Jurisdiction(sess)
+	if err = checkJurisdiction(req, consts.PathRound, params, sess, juris, bet, false, started); err != nil {
+		return err
+	}
+
 	// retrieve game state.
 	started2 := time.Now()
 	gs := state.GetGameState(params.SessionID)
@@ -63,14 +109,20 @@ func PostRound(req *fiber.Ctx) (err error) {
 
 	// play game round.
 	return execRound(req, &roundParams{
-		label:     consts.PathRound,
-		req:       params,
-		i18n:      params.I18n,
-		sessionID: params.SessionID,
-		gameNR:    sess.GameNr(),
-		rtp:       sess.RTP(),
-		bet:       bet,
-		state:     gs,
+		label:      consts.PathRound,
+		req:        params,
+		i18n:       params.I18n,
+		sessionID:  params.SessionID,
+		requestID:  params.RequestID,
+		gameNR:     sess.GameNr(),
+		rtp:        sess.RTP(),
+		bet:        bet,
+		state:      gs,
+		juris:      juris,
+		started:    started,
+		campaign:   campaign,
+		clientSeed: params.ClientSeed,
+		nonce:      params.Nonce,
 	})
 }
 
@@ -89,6 +141,7 @@ func PostRoundPaid(req *fiber.Ctx) (err error) {
 	params = roundPaidRequestPool.Get().(*models.RoundPaidRequest)
 	defer func() {
 		params.SessionID = ""
+		params.RequestID = ""
 		params.Feature = 0
 		params.Bet = 0
 		params.PlayerChoice = nil
@@ -108,6 +161,24 @@ func PostRoundPaid(req *fiber.Ctx) (err error) {
 	// mark active session.
 	metrics.MarkSession(params.SessionID)
 
+	// serialise rounds for the session.
+	unlock, err3 := roundlock.Lock(params.SessionID)
+	if err3 != nil {
+		return sendLockError(req, consts.PathRoundPaid, err3, params)
+	}
+	defer unlock()
+
+	// return the response of the first request for a retried request.
+	if b, ok := idempotency.Get(params.SessionID, consts.PathRoundPaid, params.RequestID); ok {
+		return sendStoredResponse(consts.PathRoundPaid, req, params, b)
+	}
+
+	// enforce the jurisdiction rules.
+	juris := bo_backend.Jurisdiction(sess)
+	if err = checkJurisdiction(req, consts.PathRoundPaid, params, sess, juris, params.Bet, true, started); err != nil {
+		return err
+	}
+
 	// decode player choices.
 	var choices map[string]string
 	if params.PlayerChoice != nil {
@@ -126,10 +197,13 @@ func PostRoundPaid(req *fiber.Ctx) (err error) {
 		req:       params,
 		i18n:      params.I18n,
 		sessionID: params.SessionID,
+		requestID: params.RequestID,
 		choices:   choices,
 		bet:       params.Bet,
 		gameNR:    sess.GameNr(),
 		rtp:       sess.RTP(),
+		juris:     juris,
+		started:   started,
 	})
 }
 
@@ -148,6 +222,7 @@ func PostRoundSecond(req *fiber.Ctx) (err error) {
 	params = roundSecondRequestPool.Get().(*models.RoundSecondRequest)
 	defer func() {
 		params.SessionID = ""
+		params.RequestID = ""
 		params.RoundID = ""
 		params.PlayerChoice = nil
 		params.I18n = nil
@@ -166,6 +241,18 @@ func PostRoundSecond(req *fiber.Ctx) (err error) {
 	// mark active session.
 	metrics.MarkSession(params.SessionID)
 
+	// serialise rounds for the session.
+	unlock, err3 := roundlock.Lock(params.SessionID)
+	if err3 != nil {
+		return sendLockError(req, consts.PathRoundSecond, err3, params)
+	}
+	defer unlock()
+
+	// return the response of the first request for a retried request.
+	if b, ok := idempotency.Get(params.SessionID, consts.PathRoundSecond, params.RequestID); ok {
+		return sendStoredResponse(consts.PathRoundSecond, req, params, b)
+	}
+
 	// retrieve game state.
 	started2 := time.Now()
 	gs := state.GetGameState(params.SessionID)
@@ -175,6 +262,15 @@ func PostRoundSecond(req *fiber.Ctx) (err error) {
 		return sendError(req, consts.PathRoundSecond, consts.ErrorInvalidStatus, params, fiber.StatusBadRequest, BodyInvalidStatus(consts.ErrCdSpinStateInvalid, consts.ErrLvlFatal))
 	}
 
+	// complete a free round against its campaign, so the win is capped and booked with the campaign.
+	campaign, err4 := roundCampaign(params.SessionID, gs)
+	if err4 == errCampaignNotFound {
+		return sendError(req, consts.PathRoundSecond, err4, params, fiber.StatusBadRequest, BodyInvalidStatus(consts.ErrCdSpinStateInvalid, consts.ErrLvlFatal))
+	}
+	if err4 != nil {
+		return sendError(req, consts.PathRoundSecond, FmtDstoreError(err4), params, fiber.StatusInternalServerError, BodyDstoreError(err4))
+	}
+
 	// decode player choices.
 	var choices map[string]string
 	if params.PlayerChoice != nil {
@@ -197,12 +293,15 @@ func PostRoundSecond(req *fiber.Ctx) (err error) {
 		req:       params,
 		i18n:      params.I18n,
 		sessionID: params.SessionID,
+		requestID: params.RequestID,
 		roundID:   params.RoundID,
 		choices:   choices,
 		bet:       gs.Bet(),
 		gameNR:    sess.GameNr(),
 		rtp:       sess.RTP(),
 		state:     gs,
+		juris:     bo_backend.Jurisdiction(sess),
+		campaign:  campaign,
 	})
 }
 
@@ -221,6 +320,7 @@ func PostRoundResume(req *fiber.Ctx) (err error) {
 	params = roundResumeRequestPool.Get().(*models.RoundResumeRequest)
 	defer func() {
 		params.SessionID = ""
+		params.RequestID = ""
 		params.RoundID = ""
 		params.PlayerChoice = nil
 		params.I18n = nil
@@ -239,6 +339,18 @@ func PostRoundResume(req *fiber.Ctx) (err error) {
 	// mark active session.
 	metrics.MarkSession(params.SessionID)
 
+	// serialise rounds for the session.
+	unlock, err3 := roundlock.Lock(params.SessionID)
+	if err3 != nil {
+		return sendLockError(req, consts.PathRoundResume, err3, params)
+	}
+	defer unlock()
+
+	// return the response of the first request for a retried request.
+	if b, ok := idempotency.Get(params.SessionID, consts.PathRoundResume, params.RequestID); ok {
+		return sendStoredResponse(consts.PathRoundResume, req, params, b)
+	}
+
 	// retrieve game state.
 	started2 := time.Now()
 	gs := state.GetGameState(params.SessionID)
@@ -248,6 +360,15 @@ func PostRoundResume(req *fiber.Ctx) (err error) {
 		return sendError(req, consts.PathRoundResume, consts.ErrorInvalidStatus, params, fiber.StatusBadRequest, BodyInvalidStatus(consts.ErrCdSpinStateInvalid, consts.ErrLvlFatal))
 	}
 
+	// complete a free round against its campaign, so the win is capped and booked with the campaign.
+	campaign, err4 := roundCampaign(params.SessionID, gs)
+	if err4 == errCampaignNotFound {
+		return sendError(req, consts.PathRoundResume, err4, params, fiber.StatusBadRequest, BodyInvalidStatus(consts.ErrCdSpinStateInvalid, consts.ErrLvlFatal))
+	}
+	if err4 != nil {
+		return sendError(req, consts.PathRoundResume, FmtDstoreError(err4), params, fiber.StatusInternalServerError, BodyDstoreError(err4))
+	}
+
 	// decode player choices.
 	var choices map[string]string
 	if params.PlayerChoice != nil {
@@ -265,36 +386,47 @@ func PostRoundResume(req *fiber.Ctx) (err error) {
 		req:       params,
 		i18n:      params.I18n,
 		sessionID: params.SessionID,
+		requestID: params.RequestID,
 		roundID:   params.RoundID,
 		choices:   choices,
 		bet:       gs.Bet(),
 		gameNR:    sess.GameNr(),
 		rtp:       sess.RTP(),
 		state:     gs,
+		juris:     bo_backend.Jurisdiction(sess),
+		campaign:  campaign,
 	})
 }
 
 type roundParams struct {
-	second    bool
-	resume    bool
-	debug     bool
-	paid      bool
-	rtp       uint8
-	bonusKind uint8
-	gameNR    tg.GameNR
-	scriptID  int32
-	bet       int64
-	req       any
-	i18n      *models.PrefetchI18n
-	state     *mngr.GameState
-	prefs     *mngr.GamePrefs
-	initial   util.Indexes
-	prngCache []int
-	flagged   []bool
-	choices   map[string]string
-	label     string
-	sessionID string
-	roundID   string
+	second     bool
+	resume     bool
+	debug      bool
+	paid       bool
+	rtp        uint8
+	bonusKind  uint8
+	gameNR     tg.GameNR
+	scriptID   int32
+	bet        int64
+	nonce      uint64
+	req        any
+	i18n       *models.PrefetchI18n
+	state      *mngr.GameState
+	prefs      *mngr.GamePrefs
+	juris      *jurisdiction.Profile
+	campaign   *mngr.Campaign
+	fair       *rng.FairSeeds
+	initial    util.Indexes
+	prngCache  []int
+	flagged    []bool
+	choices    map[string]string
+	label      string
+	sessionID  string
+	roundID    string
+	requestID  string
+	fairHash   string
+	clientSeed string
+	started    time.Time // start of a new round, as checked against the jurisdiction rules.
 }
 
 func execRound(req *fiber.Ctx, params *roundParams) error {
@@ -304,6 +436,11 @@ func execRound(req *fiber.Ctx, params *roundParams) error {
 		}
 	}()
 
+	// refuse to play if a health test of the PRNG has failed.
+	if !rng.Healthy() {
+		return sendRngHealthError(req, params)
+	}
+
 	// init the game.
 	g := initGame(params)
 	if g == nil {
@@ -311,6 +448,47 @@ func execRound(req *fiber.Ctx, params *roundParams) error {
 	}
 	defer g.Release()
 
+	// a provably-fair round must be complete; it cannot be resumed after a player choice.
+	if params.clientSeed != "" && g.AllowPlayerChoices() {
+		return sendError(req, params.label, consts.ErrorFair, params.req, fiber.StatusBadRequest, BodyFair(consts.ErrCdFairUnsupported, consts.ErrLvlFatal))
+	}
+
+	// apply the max win cap of the jurisdiction.
+	if params.juris != nil {
+		g.LimitMaxPayout(params.juris.MaxWin)
+	}
+
+	// refuse to play a new round if it could exceed the responsible gambling limits.
+	now := time.Now()
+	if !params.second && !params.resume {
+		var play mngr.PlayTotals
+		if params.state != nil {
+			play = params.state.Play()
+		}
+		bet := params.bet * betMultiplier(params, g)
+		if params.campaign != nil {
+			bet = 0
+		}
+		if err := limits.Check(params.sessionID, play, bet, now); err != nil {
+			code := consts.ErrCdLossLimit
+			if err == limits.ErrTimeLimit {
+				code = consts.ErrCdTimeLimit
+			}
+			return sendError(req, params.label, err, params.req, fiber.StatusForbidden, BodyLimitReached(code, consts.ErrLvlFatal))
+		}
+	}
+
+	// derive the PRNG of a provably-fair round from the seeds; this uses up the nonce.
+	if params.clientSeed != "" {
+		var err error
+		if params.fair, params.fairHash, err = nextFairSeeds(params.sessionID, params.clientSeed, params.nonce); err != nil {
+			if fair.IsSeedError(err) {
+				return sendError(req, params.label, err, params.req, fiber.StatusBadRequest, BodyFair(consts.ErrCdFairSeeds, consts.ErrLvlFatal))
+			}
+			return sendError(req, params.label, FmtDstoreError(err), params.req, fiber.StatusInternalServerError, BodyDstoreError(err))
+		}
+	}
+
 	// play and validate a game round.
 	round := playRound(params, g)
 	if round == nil {
@@ -318,14 +496,44 @@ func execRound(req *fiber.Ctx, params *roundParams) error {
 	}
 	defer round.Release()
 
+	// refuse to store the round if a health test of the PRNG failed while playing it.
+	if !rng.Healthy() {
+		return sendRngHealthError(req, params)
+	}
+
+	// the round adds itself to the play in its game state when it is stored; see mngr.PlayTotals.
+	gs := round.GameState()
+	play := gs.Play()
+	realityCheck := limits.CheckDue(params.sessionID, &play, now)
+	gs.SetPlay(play)
+
 	if err := validateRound(params, g, round); err != nil {
+		if round.IsDuplicate() {
+			// booked by an earlier request for which we no longer have the response.
+			return sendDuplicateRound(req, params, g, round, err)
+		}
 		status := fiber.StatusBadRequest
 		return sendError(req, params.label, FmtInvalidSession("validation", err), params.req, status, BodyDstoreError(err))
 	}
 
+	// only a validated round counts against the minimum spin duration of the jurisdiction.
+	if params.juris != nil && !params.started.IsZero() {
+		jurisdiction.RecordRound(params.sessionID, params.juris, params.started)
+	}
+
+	if realityCheck {
+		limits.RealityCheck(params.sessionID, gs.Play(), now)
+	}
+
 	// For double-spin feature we need to remember the roundID for the second spin!
 	if g.IsDoubleSpin() && params.state.SpinState() != nil {
-		saveRoundID(params, round.RoundID())
+		saveRoundID(params, round)
+	}
+
+	// Offer the gamble stage on the win of a round that completed in one go; not for free or provably-fair rounds.
+	if !params.second && !params.resume && params.campaign == nil && params.fair == nil &&
+		!(g.AllowPlayerChoices() && g.NeedPlayerChoice()) && !(g.IsDoubleSpin() && params.state.SpinState() != nil) {
+		openGamble(params, g, round)
 	}
 
 	// For CCB we need to update the symbol flags in the game prefs.
@@ -333,15 +541,71 @@ func execRound(req *fiber.Ctx, params *roundParams) error {
 		fixCCBflags(params)
 	}
 
-	// generate & send the response.
-	return sendResponse(params.label, req, params.req, encode.BuildRoundResponse(g, round, params.i18n))
+	// report the outcome, e.g. for autoplay.
+	if o, ok := req.Locals(localRoundOutcome).(*roundOutcome); ok {
+		o.bet = round.TotalBet()
+		o.win = round.TotalWin()
+		o.balance = round.PlayerBalance()
+		o.feature = len(round.Results()) > 1
+		o.choice = g.AllowPlayerChoices() && g.NeedPlayerChoice()
+	}
+
+	// generate & send the response; it is kept for a retry of the request.
+	resp := encode.BuildRoundResponse(g, round, params.i18n, params.fairResponse())
+	idempotency.Put(params.sessionID, params.label, params.requestID, resp.Bytes())
+	return sendResponse(params.label, req, params.req, resp)
+}
+
+// fairResponse returns the seeds of a provably-fair round for the response, or nil for a normal round.
+func (p *roundParams) fairResponse() *models.RoundStartResponseFair {
+	if p.fair == nil {
+		return nil
+	}
+	return &models.RoundStartResponseFair{ServerSeedHash: p.fairHash, ClientSeed: p.fair.ClientSeed, Nonce: p.fair.Nonce}
+}
+
+// checkJurisdiction verifies a new round against the jurisdiction rules, and sends an error response if it is not allowed.
+// The start of the round is only recorded once the round has been validated; see execRound.
+func checkJurisdiction(req *fiber.Ctx, label string, params any, sess *tg.SessionKey, juris *jurisdiction.Profile, bet int64, bonusBuy bool, started time.Time) error {
+	err := juris.Check(sess.RTP(), bet, bonusBuy)
+	if err == nil {
+		err = jurisdiction.CheckRound(sess.SessionID(), juris, started)
+	}
+
+	switch err {
+	case nil:
+		return nil
+	case jurisdiction.ErrRTP:
+		return sendError(req, label, err, params, fiber.StatusForbidden, BodyJurisdiction(consts.ErrCdJurisdictionRTP, consts.ErrLvlFatal))
+	case jurisdiction.ErrMaxBet:
+		return sendError(req, label, err, params, fiber.StatusForbidden, BodyJurisdiction(consts.ErrCdJurisdictionBet, consts.ErrLvlFatal))
+	case jurisdiction.ErrBonusBuy:
+		return sendError(req, label, err, params, fiber.StatusForbidden, BodyJurisdiction(consts.ErrCdJurisdictionBonusBuy, consts.ErrLvlFatal))
+	default:
+		return sendError(req, label, err, params, fiber.StatusTooManyRequests, BodyJurisdiction(consts.ErrCdJurisdictionSpinSpeed, consts.ErrLvlRetry))
+	}
+}
+
+func sendRngHealthError(req *fiber.Ctx, params *roundParams) error {
+	h, _ := rng.HealthStatus()
+	log.Logger.Error(consts.MsgRngHealthFailed, consts.FieldBackend, rng.Current(), consts.FieldHealth, h)
+	return sendError(req, params.label, consts.ErrorRngHealth, params.req, fiber.StatusServiceUnavailable, BodyRngHealth(consts.ErrCdRngHealth, consts.ErrLvlFatal))
+}
+
+func sendLockError(req *fiber.Ctx, label string, err error, params any) error {
+	if err == roundlock.ErrBusy {
+		return sendError(req, label, err, params, fiber.StatusConflict, BodySessionBusy(consts.ErrCdSessionBusy, consts.ErrLvlRetry))
+	}
+	return sendError(req, label, err, params, fiber.StatusInternalServerError, BodyDstoreError(err))
 }
 
-func saveRoundID(params *roundParams, id string) {
-	params.state.SetRoundID(id)
+// saveRoundID stores the game state of the round with the round id, so the second spin can complete it.
+func saveRoundID(params *roundParams, round *mngr.Round) {
+	gs := round.GameState()
+	gs.SetRoundID(round.RoundID())
 
 	started := time.Now()
-	err := state.Manager.PutGameState(params.sessionID, params.state)
+	err := state.Manager.PutGameState(params.sessionID, gs)
 	metrics.Metrics.AddDuration(metrics.DsSessionPut, started)
 
 	if err != nil {
@@ -20,6 +20,7 @@ import (
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/api/models"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/consts"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/metrics"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/roundlock"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/state"
 )
 
@@ -89,6 +90,13 @@ func doRoundDebug(req *fiber.Ctx, label string, second, resume bool) (err error)
 	// mark active session.
 	metrics.MarkSession(params.SessionID)
 
+	// serialise rounds for the session.
+	unlock, err3 := roundlock.Lock(params.SessionID)
+	if err3 != nil {
+		return sendLockError(req, label, err3, params)
+	}
+	defer unlock()
+
 	// retrieve game state.
 	var gs *mngr.GameState
 	if second || resume || params.RoundID == "" || params.Bet == 0 {
@@ -0,0 +1,237 @@
+package handlers
+
+import (
+	"runtime/debug"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/games/cards"
+	slot "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/games/slots"
+	rslt "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/results"
+	mngr "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-manager.git/state/slots"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/tg"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/api/models"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/clients/bo_backend"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/consts"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/encode"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/game"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/idempotency"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/limits"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/log"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/metrics"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/roundlock"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/state"
+)
+
+// PostRoundGamble plays a step of the gamble (double-up) stage on the win of the last round of a player session.
+// The player collects the win, or gambles it on the color (x2) or suit (x4) of a card.
+// Each step is posted to the RoundManager as part of the round, and the stage ends when the win is collected or lost,
+// or when the game no longer allows the win to be gambled.
+func PostRoundGamble(req *fiber.Ctx) (err error) {
+	started := time.Now()
+	defer func() { metrics.Metrics.AddDuration(metrics.ApiRoundGamble, started) }()
+
+	var params *models.RoundGambleRequest
+	defer func() {
+		if e := recover(); e != nil {
+			err = sendErrorStack(req, consts.PathRoundGamble, e, params, fiber.StatusInternalServerError, BodyInternalError(consts.ErrCdPanic, consts.ErrLvlFatal), debug.Stack())
+		}
+	}()
+
+	// decode request.
+	params = roundGambleRequestPool.Get().(*models.RoundGambleRequest)
+	defer func() {
+		params.SessionID = ""
+		params.RequestID = ""
+		params.RoundID = ""
+		params.Choice = ""
+		params.Pick = 0
+		roundGambleRequestPool.Put(params)
+	}()
+
+	if err = req.BodyParser(params); err != nil {
+		return sendError(req, consts.PathRoundGamble, err, params, fiber.StatusBadRequest, BodyBadRequest(consts.ErrCdParams, consts.ErrLvlFatal))
+	}
+
+	sess, err2 := tg.VerifySessionID(params.SessionID)
+	if params.SessionID == "" || params.RoundID == "" || err2 != nil {
+		return sendError(req, consts.PathRoundGamble, FmtInvalidSession("verification", err2), params, fiber.StatusBadRequest, BodyInvalidSession(consts.ErrCdSessionInvalid, consts.ErrLvlFatal))
+	}
+
+	kind, ok := gambleKind(params.Choice)
+	if !ok {
+		return sendError(req, consts.PathRoundGamble, consts.ErrorBadRequest, params, fiber.StatusBadRequest, BodyBadRequest(consts.ErrCdParams, consts.ErrLvlFatal))
+	}
+
+	// mark active session.
+	metrics.MarkSession(params.SessionID)
+
+	// serialise rounds for the session.
+	unlock, err3 := roundlock.Lock(params.SessionID)
+	if err3 != nil {
+		return sendLockError(req, consts.PathRoundGamble, err3, params)
+	}
+	defer unlock()
+
+	// return the response of the first request for a retried request.
+	if b, ok2 := idempotency.Get(params.SessionID, consts.PathRoundGamble, params.RequestID); ok2 {
+		return sendStoredResponse(consts.PathRoundGamble, req, params, b)
+	}
+
+	// retrieve game state.
+	started2 := time.Now()
+	gs := state.GetGameState(params.SessionID)
+	metrics.Metrics.AddDuration(metrics.DsSessionGet, started2)
+
+	if gs != nil {
+		defer gs.Release()
+	}
+	if gs == nil || gs.Bet() <= 0 || gs.GambleWin() <= 0 || gs.GambleRoundID() != params.RoundID {
+		return sendError(req, consts.PathRoundGamble, consts.ErrorInvalidStatus, params, fiber.StatusBadRequest, BodyInvalidStatus(consts.ErrCdGambleInvalid, consts.ErrLvlFatal))
+	}
+
+	// collect the win; it was booked with the round, or with the last step.
+	if kind == cards.GambleCollect {
+		win, steps := gs.GambleWin(), gs.GambleSteps()
+		closeGamble(params.SessionID, gs)
+
+		resp := encode.BuildRoundGambleResponse(params.RoundID, kind, false, nil, 0, win, steps, 0, false)
+		idempotency.Put(params.SessionID, consts.PathRoundGamble, params.RequestID, resp.Bytes())
+		return sendResponse(consts.PathRoundGamble, req, params, resp)
+	}
+
+	g := game.NewGame(sess.GameNr(), int(sess.RTP()))
+	if g == nil {
+		return sendError(req, consts.PathRoundGamble, FmtInvalidSession("game", nil), params, fiber.StatusBadRequest, BodyInvalidSession(consts.ErrCdGameInvalid, consts.ErrLvlFatal))
+	}
+	defer g.Release()
+
+	// apply the max win cap of the jurisdiction.
+	juris := bo_backend.Jurisdiction(sess)
+	if juris != nil {
+		g.LimitMaxPayout(juris.MaxWin)
+	}
+
+	// play the gamble step.
+	bet, stake, steps := gs.Bet(), gs.GambleWin(), gs.GambleSteps()
+	won, result := g.Gamble(kind, float64(stake)/float64(bet), steps, int(params.Pick))
+	if result == nil {
+		return sendError(req, consts.PathRoundGamble, consts.ErrorGamble, params, fiber.StatusForbidden, BodyGamble(consts.ErrCdGambleInvalid, consts.ErrLvlFatal))
+	}
+	defer result.Release()
+
+	var win int64
+	if won {
+		win = stake * int64(kind.Multiplier())
+	}
+	steps++
+
+	// the stage continues as long as the new win can be gambled again.
+	gamble := canGamble(g, win, bet, steps)
+	if gamble {
+		gs.SetGamble(params.RoundID, win, steps)
+	} else {
+		gs.SetGamble("", 0, 0)
+	}
+
+	// the step adds itself to the play in the game state when it is stored; see mngr.PlayTotals.
+	now := time.Now()
+	play := gs.Play()
+	realityCheck := limits.CheckDue(params.SessionID, &play, now)
+	gs.SetPlay(play)
+
+	params2 := mngr.RoundParams{
+		Gamble:    true,
+		SessionID: params.SessionID,
+		RoundID:   params.RoundID,
+		RequestID: params.RequestID,
+		Bet:       bet,
+		TotalBet:  stake,
+		TotalWin:  win,
+		Results:   rslt.Results{result},
+		GameState: gs,
+		GameID:    sess.GameNr().String(),
+	}
+	if juris != nil {
+		params2.Currency = juris.Currency()
+	}
+
+	round := mngr.AcquireRound(state.Manager, params2)
+	defer round.Release()
+
+	started2 = time.Now()
+	err = round.ValidateGamble(false).Error()
+	metrics.Metrics.AddDuration(metrics.DsRoundGamble, started2)
+
+	if err != nil {
+		if round.IsDuplicate() {
+			// booked by an earlier request for which we no longer have the response.
+			return sendError(req, consts.PathRoundGamble, err, params, fiber.StatusConflict, BodyDuplicateRequest(consts.ErrCdDuplicateRequest, consts.ErrLvlFatal))
+		}
+		return sendError(req, consts.PathRoundGamble, FmtInvalidSession("validation", err), params, fiber.StatusBadRequest, BodyDstoreError(err))
+	}
+
+	if realityCheck {
+		limits.RealityCheck(params.SessionID, round.GameState().Play(), now)
+	}
+
+	// generate & send the response; it is kept for a retry of the request.
+	resp := encode.BuildRoundGambleResponse(params.RoundID, kind, won, result, stake, win, steps, round.PlayerBalance(), gamble)
+	idempotency.Put(params.SessionID, consts.PathRoundGamble, params.RequestID, resp.Bytes())
+	return sendResponse(consts.PathRoundGamble, req, params, resp)
+}
+
+// gambleKind returns the gamble kind for the choice of the player.
+func gambleKind(choice string) (cards.GambleKind, bool) {
+	for _, kind := range []cards.GambleKind{cards.GambleCollect, cards.GambleColor, cards.GambleSuit} {
+		if choice == kind.String() {
+			return kind, true
+		}
+	}
+	return 0, false
+}
+
+// canGamble returns true if the win can be gambled on either the color or the suit of a card after the given number of steps.
+func canGamble(g *slot.Regular, win, bet int64, steps uint8) bool {
+	if win <= 0 || bet <= 0 {
+		return false
+	}
+	stake := float64(win) / float64(bet)
+	return g.CanGamble(cards.GambleColor, stake, steps) || g.CanGamble(cards.GambleSuit, stake, steps)
+}
+
+// openGamble opens the gamble stage on the win of a completed round, if the game allows it.
+// The stage is kept in the game state, so it ends when a new round is played.
+func openGamble(params *roundParams, g *slot.Regular, round *mngr.Round) {
+	if !canGamble(g, round.TotalWin(), round.Bet(), 0) {
+		return
+	}
+
+	// the game state of the round holds the play and offsets as stored with the round.
+	gs := round.GameState()
+	gs.SetGamble(round.RoundID(), round.TotalWin(), 0)
+
+	started := time.Now()
+	err := state.Manager.PutGameState(params.sessionID, gs)
+	metrics.Metrics.AddDuration(metrics.DsSessionPut, started)
+
+	if err != nil {
+		gs.SetGamble("", 0, 0)
+		log.Logger.Error(FmtDstoreError(err))
+	}
+}
+
+// closeGamble ends the gamble stage after the player collected the win.
+func closeGamble(sessionID string, gs *mngr.GameState) {
+	gs.SetGamble("", 0, 0)
+
+	started := time.Now()
+	err := state.Manager.PutGameState(sessionID, gs)
+	metrics.Metrics.AddDuration(metrics.DsSessionPut, started)
+
+	if err != nil {
+		log.Logger.Error(FmtDstoreError(err))
+	}
+}
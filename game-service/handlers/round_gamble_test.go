@@ -0,0 +1,254 @@
+package handlers
+
+import (
+	"bytes"
+	"io"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/definition"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/registry"
+	comp "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/components/cards"
+	slots "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/components/slots"
+	store "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-manager.git/repository/slots"
+	mngr "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-manager.git/state/slots"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/tg"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/api/models"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/consts"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/idempotency"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/log"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/state"
+)
+
+func TestPostRoundGamble(t *testing.T) {
+	log.Init()
+	state.Manager = store.NewMemory()
+	defer idempotency.Expire(0)
+
+	app := fiber.New()
+	require.NotNil(t, app)
+
+	app.Post("/v1/round/gamble", PostRoundGamble)
+
+	sessionID, err := tg.MakeSessionID("bot", 92, 1)
+	require.NoError(t, err)
+	setTestSession(sessionID)
+
+	// a winning round with an open gamble stage.
+	gs := mngr.AcquireGameState(nil, nil, 100)
+	defer gs.Release()
+
+	round := mngr.AcquireRound(state.Manager, mngr.RoundParams{SessionID: sessionID, Bet: 100, TotalBet: 100, TotalWin: 500, GameState: gs})
+	defer round.Release()
+	round.Validate(false, false)
+	require.True(t, round.IsValid())
+
+	gs.SetGamble(round.RoundID(), 500, 0)
+	require.NoError(t, state.Manager.PutGameState(sessionID, gs))
+
+	call := func(body string) (int, []byte) {
+		req := httptest.NewRequest(fiber.MethodPost, "/v1/round/gamble", bytes.NewBufferString(body))
+		require.NotNil(t, req)
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+
+		resp, err2 := app.Test(req, 5000)
+		require.NoError(t, err2)
+		require.NotNil(t, resp)
+
+		b, err3 := io.ReadAll(resp.Body)
+		require.NoError(t, err3)
+		return resp.StatusCode, b
+	}
+
+	errorCode := func(b []byte) int64 {
+		resp := &models.ErrorResponse{}
+		require.NoError(t, json.Unmarshal(b, resp))
+		return resp.ErrorCode
+	}
+
+	t.Run("bad choice", func(t *testing.T) {
+		status, _ := call(`{"sessionId":"` + sessionID + `","roundId":"` + round.RoundID() + `","choice":"double"}`)
+		assert.Equal(t, fiber.StatusBadRequest, status)
+	})
+
+	t.Run("wrong round", func(t *testing.T) {
+		status, b := call(`{"sessionId":"` + sessionID + `","roundId":"xyz","choice":"color","pick":1}`)
+		assert.Equal(t, fiber.StatusBadRequest, status)
+		assert.Equal(t, int64(consts.ErrCdGambleInvalid), errorCode(b))
+	})
+
+	t.Run("not allowed by game", func(t *testing.T) {
+		status, b := call(`{"sessionId":"` + sessionID + `","roundId":"` + round.RoundID() + `","choice":"suit","pick":3}`)
+		assert.Equal(t, fiber.StatusForbidden, status)
+		assert.Equal(t, int64(consts.ErrCdGambleInvalid), errorCode(b))
+	})
+
+	t.Run("collect", func(t *testing.T) {
+		status, b := call(`{"sessionId":"` + sessionID + `","roundId":"` + round.RoundID() + `","choice":"collect","requestId":"c1"}`)
+		require.Equal(t, fiber.StatusOK, status)
+
+		resp := &models.RoundGambleResponse{}
+		require.NoError(t, json.Unmarshal(b, resp))
+		assert.True(t, resp.Success)
+		assert.Equal(t, "collect", resp.Choice)
+		assert.Equal(t, int64(500), resp.Win)
+		assert.Zero(t, resp.Won)
+		assert.Zero(t, resp.Gamble)
+
+		gs2 := state.GetGameState(sessionID)
+		require.NotNil(t, gs2)
+		assert.Empty(t, gs2.GambleRoundID())
+		gs2.Release()
+
+		// a retry returns the same response, but the stage is closed.
+		status, b2 := call(`{"sessionId":"` + sessionID + `","roundId":"` + round.RoundID() + `","choice":"collect","requestId":"c1"}`)
+		assert.Equal(t, fiber.StatusOK, status)
+		assert.Equal(t, string(b), string(b2))
+
+		status, _ = call(`{"sessionId":"` + sessionID + `","roundId":"` + round.RoundID() + `","choice":"collect","requestId":"c2"}`)
+		assert.Equal(t, fiber.StatusBadRequest, status)
+	})
+}
+
+func TestPostRoundGambleSteps(t *testing.T) {
+	log.Init()
+	state.Manager = store.NewMemory()
+	defer idempotency.Expire(0)
+
+	app := fiber.New()
+	require.NotNil(t, app)
+
+	app.Post("/v1/round", PostRound)
+	app.Post("/v1/round/gamble", PostRoundGamble)
+
+	nr := registerGambleGame(t)
+	sessionID, err := tg.MakeSessionID(nr.String(), 92, 1)
+	require.NoError(t, err)
+	setTestSession(sessionID)
+
+	call := func(path, body string, out any) int {
+		req := httptest.NewRequest(fiber.MethodPost, path, bytes.NewBufferString(body))
+		require.NotNil(t, req)
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+
+		resp, err2 := app.Test(req, 5000)
+		require.NoError(t, err2)
+		require.NotNil(t, resp)
+
+		b, err3 := io.ReadAll(resp.Body)
+		require.NoError(t, err3)
+		require.NoError(t, json.Unmarshal(b, out))
+		return resp.StatusCode
+	}
+
+	stored := func() mngr.PlayTotals {
+		gs := state.GetGameState(sessionID)
+		require.NotNil(t, gs)
+		defer gs.Release()
+		return gs.Play()
+	}
+
+	// play rounds until the win of a round is gambled up to the max steps of the game.
+	var rounds, bets, wins int64
+	var capped bool
+	for ix := 0; ix < 1000 && !capped; ix++ {
+		round := &models.RoundStartResponse{}
+		require.Equal(t, fiber.StatusOK, call("/v1/round", `{"sessionId":"`+sessionID+`","bet":100}`, round))
+		require.NotNil(t, round.RoundData)
+
+		// the stored play includes the round, also when it opened the gamble stage.
+		play := stored()
+		rounds++
+		bets += 100
+		stake := play.Wins - wins
+		wins = play.Wins
+		require.Equal(t, rounds, play.Rounds)
+		require.Equal(t, bets, play.Bets)
+
+		if round.RoundData.Gamble == 0 {
+			continue
+		}
+		require.Positive(t, stake)
+
+		roundID := round.RoundData.RoundID
+		rr, balance, err2 := state.Manager.PostRoundNext(sessionID, roundID, nil, 1)
+		require.NoError(t, err2)
+		rr.Release()
+
+		body := `{"sessionId":"` + sessionID + `","roundId":"` + roundID + `","choice":"color","pick":` + strconv.Itoa(int(comp.Red)) + `}`
+		for step := int64(1); ; step++ {
+			resp := &models.RoundGambleResponse{}
+			require.Equal(t, fiber.StatusOK, call("/v1/round/gamble", body, resp))
+			require.True(t, resp.Success)
+			assert.Equal(t, step, resp.Steps)
+			assert.Equal(t, stake, resp.Stake)
+
+			// the step is booked against the balance and added to the stored play.
+			balance += resp.Win - stake
+			rounds++
+			bets += stake
+			wins += resp.Win
+			assert.Equal(t, balance, resp.Balance)
+
+			play = stored()
+			assert.Equal(t, rounds, play.Rounds)
+			assert.Equal(t, bets, play.Bets)
+			assert.Equal(t, wins, play.Wins)
+
+			if resp.Won == 0 {
+				assert.Zero(t, resp.Win)
+				assert.Zero(t, resp.Gamble)
+				break
+			}
+
+			assert.Equal(t, 2*stake, resp.Win)
+			stake = resp.Win
+			if step < 2 {
+				assert.NotZero(t, resp.Gamble)
+				continue
+			}
+
+			// the game allows two steps, so the stage ends with the win of the second.
+			assert.Zero(t, resp.Gamble)
+			capped = true
+
+			out := &models.ErrorResponse{}
+			assert.Equal(t, fiber.StatusBadRequest, call("/v1/round/gamble", body, out))
+			assert.Equal(t, int64(consts.ErrCdGambleInvalid), out.ErrorCode)
+			break
+		}
+	}
+	assert.True(t, capped)
+}
+
+// registerGambleGame registers a variant of Book of Tomes which allows the win of a round to be gambled twice.
+// It uses a game number without a registered game, so the variant can be played through the endpoints.
+func registerGambleGame(t *testing.T) tg.GameNR {
+	nr := tg.HOGnr
+	if registry.Get(nr) != nil {
+		return nr
+	}
+
+	d, err := definition.Export(nr.String(), tg.BOTnr.String(), 92)
+	require.NoError(t, err)
+	d.Options.GambleSteps = 2
+
+	g, err := d.Build()
+	require.NoError(t, err)
+
+	registry.Register(&registry.Game{
+		NR:         nr,
+		RTPs:       g.RTPs(),
+		New:        g.New,
+		NewLogged:  g.NewLogged,
+		AllSymbols: func() *slots.SymbolSet { return g.Slots(92).Symbols() },
+	})
+	return nr
+}
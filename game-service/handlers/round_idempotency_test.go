@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	store "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-manager.git/repository/slots"
+	mngr "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-manager.git/state/slots"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/tg"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/api/models"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/consts"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/idempotency"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/log"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/state"
+)
+
+func TestPostRoundIdempotent(t *testing.T) {
+	log.Init()
+	defer idempotency.Expire(0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m, err := store.NewEmbedded(ctx, "", 1000000, 0)
+	require.NoError(t, err)
+	state.Manager = m
+
+	app := fiber.New()
+	require.NotNil(t, app)
+
+	app.Post("/v1/round", PostRound)
+
+	sessionID, err := tg.MakeSessionID("bot", 92, 1)
+	require.NoError(t, err)
+	setTestSession(sessionID)
+
+	call := func(requestID string) (int, []byte) {
+		body := `{"sessionId":"` + sessionID + `","bet":100,"requestId":"` + requestID + `"}`
+		req := httptest.NewRequest(fiber.MethodPost, "/v1/round", bytes.NewBufferString(body))
+		require.NotNil(t, req)
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+
+		resp, err2 := app.Test(req, 5000)
+		require.NoError(t, err2)
+		require.NotNil(t, resp)
+
+		b, err3 := io.ReadAll(resp.Body)
+		require.NoError(t, err3)
+		return resp.StatusCode, b
+	}
+
+	status, first := call("r1")
+	require.Equal(t, fiber.StatusOK, status)
+
+	t.Run("retry", func(t *testing.T) {
+		status2, retry := call("r1")
+		require.Equal(t, fiber.StatusOK, status2)
+		assert.Equal(t, string(first), string(retry))
+	})
+
+	t.Run("new request", func(t *testing.T) {
+		status2, next := call("r2")
+		require.Equal(t, fiber.StatusOK, status2)
+		assert.NotEqual(t, string(first), string(next))
+	})
+
+	t.Run("booked without response", func(t *testing.T) {
+		// e.g. a timeout of the round manager, a restart, or a retry reaching another instance.
+		idempotency.Expire(0)
+
+		status2, b := call("r1")
+		require.Equal(t, fiber.StatusOK, status2)
+
+		var want, got struct {
+			RoundData map[string]any `json:"roundData"`
+			SpinData  map[string]any `json:"spinData"`
+		}
+		require.NoError(t, json.Unmarshal(first, &want))
+		require.NoError(t, json.Unmarshal(b, &got))
+		// the round sequence is not stored with the round, and the game state has moved on to r2.
+		assert.EqualValues(t, 1, want.RoundData["roundSeq"])
+		assert.EqualValues(t, 0, got.RoundData["roundSeq"])
+		delete(want.RoundData, "roundSeq")
+		delete(got.RoundData, "roundSeq")
+		assert.Equal(t, want.RoundData, got.RoundData)
+		assert.Equal(t, want.SpinData, got.SpinData)
+
+		// the rebuilt response is kept for the next retry.
+		status3, b2 := call("r1")
+		require.Equal(t, fiber.StatusOK, status3)
+		assert.Equal(t, string(b), string(b2))
+	})
+
+	t.Run("stored round missing", func(t *testing.T) {
+		idempotency.Expire(0)
+		state.Manager = store.NewMemory()
+
+		status2, _ := call("r3")
+		require.Equal(t, fiber.StatusOK, status2)
+
+		idempotency.Expire(0)
+		state.Manager = &noRounds{RoundManager: state.Manager}
+
+		status2, b := call("r3")
+		require.Equal(t, fiber.StatusConflict, status2)
+
+		resp := &models.ErrorResponse{}
+		require.NoError(t, json.Unmarshal(b, resp))
+		assert.Equal(t, int64(consts.ErrCdDuplicateRequest), resp.ErrorCode)
+	})
+}
+
+// noRounds is a round manager which has lost its stored rounds.
+type noRounds struct {
+	mngr.RoundManager
+}
+
+func (m *noRounds) GetRound(_, _ string) (*mngr.StoredRound, error) {
+	return nil, errors.New("round not found")
+}
@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	store "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-manager.git/repository/slots"
+	mngr "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-manager.git/state/slots"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/tg"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/api/models"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/consts"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/events"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/idempotency"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/jurisdiction"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/limits"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/log"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/state"
+)
+
+func TestPostRoundLimits(t *testing.T) {
+	log.Init()
+	defer idempotency.Expire(0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m, err := store.NewEmbedded(ctx, "", 1000000, 0)
+	require.NoError(t, err)
+	state.Manager = m
+
+	app := fiber.New()
+	require.NotNil(t, app)
+
+	app.Post("/v1/round", PostRound)
+	app.Post("/v1/round/paid", PostRoundPaid)
+	app.Post("/v1/round/resume", PostRoundResume)
+
+	call := func(path, body string, out any) int {
+		req := httptest.NewRequest(fiber.MethodPost, path, bytes.NewBufferString(body))
+		require.NotNil(t, req)
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+
+		resp, err2 := app.Test(req, 5000)
+		require.NoError(t, err2)
+		require.NotNil(t, resp)
+
+		b, err3 := io.ReadAll(resp.Body)
+		require.NoError(t, err3)
+		require.NoError(t, json.Unmarshal(b, out))
+		return resp.StatusCode
+	}
+
+	stored := func(sessionID string) mngr.PlayTotals {
+		gs := state.GetGameState(sessionID)
+		require.NotNil(t, gs)
+		defer gs.Release()
+		return gs.Play()
+	}
+
+	newSession := func(game string, l limits.Limits) string {
+		sessionID, err2 := tg.MakeSessionID(game, 92, 1)
+		require.NoError(t, err2)
+		jurisdiction.SetSession(sessionID, &jurisdiction.Profile{Code: "TEST", BonusBuy: true})
+		limits.SetLimits(sessionID, l)
+		return sessionID
+	}
+
+	t.Run("reality check", func(t *testing.T) {
+		sessionID := newSession("bot", limits.Limits{RealityCheck: 100})
+		body := `{"sessionId":"` + sessionID + `","bet":100}`
+
+		play := func() mngr.PlayTotals {
+			require.Equal(t, fiber.StatusOK, call("/v1/round", body, &models.RoundStartResponse{}))
+			return stored(sessionID)
+		}
+
+		first := play()
+		assert.Equal(t, int64(1), first.Rounds)
+		assert.Equal(t, first.Started, first.Checked)
+
+		second := play()
+		assert.Equal(t, first.Checked, second.Checked)
+		assert.Never(t, func() bool { return len(events.GetMessages(sessionID)) > 0 }, 20*time.Millisecond, time.Millisecond)
+
+		// the check is due with the first round after the interval, and is stored with that round.
+		time.Sleep(110 * time.Millisecond)
+		third := play()
+		assert.Equal(t, int64(3), third.Rounds)
+		assert.True(t, third.Checked.After(second.Checked))
+		require.Eventually(t, func() bool { return len(events.GetMessages(sessionID)) == 1 }, time.Second, time.Millisecond)
+
+		// the next check is only due after another interval.
+		fourth := play()
+		assert.Equal(t, third.Checked, fourth.Checked)
+		assert.Never(t, func() bool { return len(events.GetMessages(sessionID)) > 1 }, 20*time.Millisecond, time.Millisecond)
+	})
+
+	t.Run("loss limit", func(t *testing.T) {
+		sessionID := newSession("lam", limits.Limits{LossLimit: 150})
+
+		// the limit is checked before the round is played, so nothing is stored.
+		out := &models.ErrorResponse{}
+		require.Equal(t, fiber.StatusForbidden, call("/v1/round", `{"sessionId":"`+sessionID+`","bet":200}`, out))
+		assert.Equal(t, int64(consts.ErrCdLossLimit), out.ErrorCode)
+		assert.Nil(t, state.GetGameState(sessionID))
+
+		// the bet of a bonus buy counts with its multiplier.
+		out = &models.ErrorResponse{}
+		require.Equal(t, fiber.StatusForbidden, call("/v1/round/paid", `{"sessionId":"`+sessionID+`","bet":100,"feature":1}`, out))
+		assert.Equal(t, int64(consts.ErrCdLossLimit), out.ErrorCode)
+		assert.Nil(t, state.GetGameState(sessionID))
+
+		require.Equal(t, fiber.StatusOK, call("/v1/round", `{"sessionId":"`+sessionID+`","bet":100}`, &models.RoundStartResponse{}))
+		assert.Equal(t, int64(1), stored(sessionID).Rounds)
+	})
+
+	t.Run("player choice", func(t *testing.T) {
+		sessionID := newSession("lam", limits.Limits{})
+
+		// the bonus buy of La Modelo always ends with the choice of the wing.
+		round := &models.RoundStartResponse{}
+		require.Equal(t, fiber.StatusOK, call("/v1/round/paid", `{"sessionId":"`+sessionID+`","bet":10,"feature":1}`, round))
+		require.NotNil(t, round.RoundData)
+		require.NotZero(t, round.RoundData.RequireChoice)
+
+		play := stored(sessionID)
+		assert.Equal(t, int64(1), play.Rounds)
+		assert.Equal(t, int64(1500), play.Bets)
+
+		r, err2 := state.Manager.GetRound(sessionID, round.RoundData.RoundID)
+		require.NoError(t, err2)
+		assert.Equal(t, r.Win, play.Wins)
+		r.Release()
+
+		// completing the round only adds its remaining win.
+		body := `{"sessionId":"` + sessionID + `","roundId":"` + round.RoundData.RoundID + `","playerChoice":{"wing":"north"}}`
+		require.Equal(t, fiber.StatusOK, call("/v1/round/resume", body, &models.RoundStartResponse{}))
+
+		play = stored(sessionID)
+		assert.Equal(t, int64(1), play.Rounds)
+		assert.Equal(t, int64(1500), play.Bets)
+
+		r, err2 = state.Manager.GetRound(sessionID, round.RoundData.RoundID)
+		require.NoError(t, err2)
+		assert.Equal(t, r.Win, play.Wins)
+		r.Release()
+	})
+}
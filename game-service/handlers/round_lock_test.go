@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"bytes"
+	"io"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	store "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-manager.git/repository/slots"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/tg"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/api/models"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/consts"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/jurisdiction"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/limits"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/log"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/roundlock"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/state"
+)
+
+func TestPostRoundLocked(t *testing.T) {
+	log.Init()
+	state.Manager = store.NewMemory()
+
+	app := fiber.New()
+	require.NotNil(t, app)
+
+	app.Post("/v1/round", PostRound)
+
+	sessionID, err := tg.MakeSessionID("bot", 92, 1)
+	require.NoError(t, err)
+	setTestSession(sessionID)
+
+	call := func() (int, *models.ErrorResponse) {
+		req := httptest.NewRequest(fiber.MethodPost, "/v1/round", bytes.NewBufferString(`{"sessionId":"`+sessionID+`","bet":100}`))
+		require.NotNil(t, req)
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+
+		resp, err2 := app.Test(req, 5000)
+		require.NoError(t, err2)
+		require.NotNil(t, resp)
+
+		if resp.StatusCode == fiber.StatusOK {
+			return resp.StatusCode, nil
+		}
+
+		b, err3 := io.ReadAll(resp.Body)
+		require.NoError(t, err3)
+		out := &models.ErrorResponse{}
+		require.NoError(t, json.Unmarshal(b, out))
+		return resp.StatusCode, out
+	}
+
+	t.Run("busy", func(t *testing.T) {
+		roundlock.Use(roundlock.NewLocal(0))
+		defer roundlock.Use(roundlock.NewLocal(roundlock.DefaultWait))
+
+		unlock, err2 := roundlock.Lock(sessionID)
+		require.NoError(t, err2)
+
+		status, resp := call()
+		assert.Equal(t, fiber.StatusConflict, status)
+		require.NotNil(t, resp)
+		assert.Equal(t, int64(consts.ErrCdSessionBusy), resp.ErrorCode)
+		assert.Equal(t, consts.ErrLvlRetry, resp.ErrorLevel)
+
+		unlock()
+
+		status, _ = call()
+		assert.Equal(t, fiber.StatusOK, status)
+	})
+
+	t.Run("serialised", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				status, _ := call()
+				assert.Equal(t, fiber.StatusOK, status)
+			}()
+		}
+		wg.Wait()
+	})
+}
+
+// setTestSession caches a jurisdiction profile without rules and empty limits for the session,
+// like the back-office preferences of a session do.
+func setTestSession(sessionID string) {
+	jurisdiction.SetSession(sessionID, &jurisdiction.Profile{Code: "TEST"})
+	limits.SetLimits(sessionID, limits.Limits{})
+}
@@ -4,6 +4,8 @@ import (
 	"math"
 	"time"
 
+	"github.com/gofiber/fiber/v2"
+
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/components/slots"
 	slot "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/games/slots"
 	rslt "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/results"
@@ -13,7 +15,9 @@ import (
 
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/consts"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/config"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/encode"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/game"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/idempotency"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/log"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/metrics"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/state"
@@ -91,14 +95,7 @@ func playRound(params *roundParams, g *slot.Regular) *mngr.Round {
 	started := time.Now()
 	haveInitial, haveCache, haveScript := len(params.initial) > 0, len(params.prngCache) > 0, params.scriptID > 0
 
-	var betMultiplier int64 = 1
-	if params.paid {
-		if paid := g.ForSale(params.bonusKind); paid == nil {
-			params.paid = false
-		} else {
-			betMultiplier = int64(paid.BetMultiplier())
-		}
-	}
+	multiplier := betMultiplier(params, g)
 
 	var results rslt.Results
 	// SUPERVISED-BUILD-REMOVE-START
@@ -115,10 +112,14 @@ func playRound(params *roundParams, g *slot.Regular) *mngr.Round {
 	} else {
 		// SUPERVISED-BUILD-REMOVE-END
 		// else body remains
-		if params.resume {
+		switch {
+		case params.resume:
 			results = g.RoundResume(params.choices)
 			metrics.Metrics.AddDuration(metrics.GeRoundResume, started)
-		} else {
+		case params.fair != nil:
+			results = g.Fair(params.fair, params.bonusKind)
+			metrics.Metrics.AddDuration(metrics.GeRound, started)
+		default:
 			results = g.Round(params.bonusKind)
 			metrics.Metrics.AddDuration(metrics.GeRound, started)
 		}
@@ -131,8 +132,10 @@ func playRound(params *roundParams, g *slot.Regular) *mngr.Round {
 	}
 
 	var roundSeq int64
+	var play mngr.PlayTotals
 	if params.state != nil {
 		roundSeq = params.state.RoundSeq()
+		play = params.state.Play()
 		params.state.Release()
 		params.state = nil
 	}
@@ -142,6 +145,10 @@ func playRound(params *roundParams, g *slot.Regular) *mngr.Round {
 
 	params.state = mngr.AcquireGameState(g.SpinState(), g.SymbolsState(), params.bet)
 	params.state.SetRoundSeq(roundSeq)
+	params.state.SetPlay(play)
+	if params.campaign != nil {
+		params.state.SetCampaign(params.campaign.ID)
+	}
 	if params.roundID != "" {
 		params.state.SetRoundID(params.roundID)
 	}
@@ -149,12 +156,19 @@ func playRound(params *roundParams, g *slot.Regular) *mngr.Round {
 	params2 := mngr.RoundParams{
 		SessionID:  params.sessionID,
 		RoundID:    params.roundID,
+		RequestID:  params.requestID,
 		Paid:       params.paid,
 		BuyFeature: params.bonusKind,
 		Bet:        params.bet,
-		TotalBet:   params.bet * betMultiplier,
+		TotalBet:   params.bet * multiplier,
 		Results:    results,
 		GameState:  params.state,
+		Campaign:   params.campaign,
+		GameID:     params.gameNR.String(),
+	}
+
+	if params.juris != nil {
+		params2.Currency = params.juris.Currency()
 	}
 
 	if g.MaxPayoutReached() {
@@ -164,6 +178,18 @@ func playRound(params *roundParams, g *slot.Regular) *mngr.Round {
 	return mngr.AcquireRound(state.Manager, params2)
 }
 
+// betMultiplier returns the multiplier of the bet for a bonus buy feature, or 1 for a normal round.
+// A bonus buy of a feature which is not for sale is played as a normal round.
+func betMultiplier(params *roundParams, g *slot.Regular) int64 {
+	if params.paid {
+		if paid := g.ForSale(params.bonusKind); paid != nil {
+			return int64(paid.BetMultiplier())
+		}
+		params.paid = false
+	}
+	return 1
+}
+
 func validateRound(params *roundParams, g *slot.Regular, round *mngr.Round) error {
 	if s := round.GameState(); s != nil {
 		s.SetNextOffset(0)
@@ -221,3 +247,35 @@ func validateRound(params *roundParams, g *slot.Regular, round *mngr.Round) erro
 
 	return err
 }
+
+// sendDuplicateRound sends the response for a round that was booked by an earlier request with the same request id,
+// e.g. after a timeout of the round manager, a restart, or when the retry reaches another instance of the service.
+// The response is rebuilt from the stored round; it is only refused if the stored round cannot be retrieved.
+func sendDuplicateRound(req *fiber.Ctx, params *roundParams, g *slot.Regular, round *mngr.Round, err error) error {
+	started := time.Now()
+	stored, err2 := state.Manager.GetRound(params.sessionID, round.RoundID())
+	metrics.Metrics.AddDuration(metrics.DsRoundGet, started)
+
+	if err2 != nil || stored == nil || len(stored.Results) == 0 {
+		if err2 != nil {
+			err = err2
+		}
+		return sendError(req, params.label, err, params.req, fiber.StatusConflict, BodyDuplicateRequest(consts.ErrCdDuplicateRequest, consts.ErrLvlFatal))
+	}
+	defer stored.Release()
+
+	// a completed round continues at the offset used for round/next calls.
+	var offset int
+	if params.second || params.resume {
+		offset = int(round.GameState().NextOffset())
+	}
+
+	gs := state.GetGameState(params.sessionID)
+	if gs != nil {
+		defer gs.Release()
+	}
+
+	resp := encode.BuildStoredRoundResponse(g, stored, gs, round.PlayerBalance(), offset, params.i18n)
+	idempotency.Put(params.sessionID, params.label, params.requestID, resp.Bytes())
+	return sendResponse(params.label, req, params.req, resp)
+}
@@ -47,6 +47,9 @@ func GetSessionInfo(req *fiber.Ctx) (err error) {
 		return sendError(req, consts.PathSessionInfo, err2, params, fiber.StatusBadRequest, BodyBadRequest(consts.ErrCdParams, consts.ErrLvlFatal))
 	}
 
+	// add the free rounds left from campaigns.
+	resp.FreeRounds = freeRounds(params.SessionID, resp.GameID)
+
 	// encode and send the response.
 	b, _ := json.Marshal(resp)
 	req.Set(consts.ContentType, consts.ApplicationJSON)
@@ -0,0 +1,151 @@
+package audit
+
+import (
+	"math"
+	"slices"
+	"strconv"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/components/slots"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/results"
+
+	mngr "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-manager.git/state/slots"
+)
+
+// Diff describes a difference between a stored round and the replay of the round by the game engine.
+type Diff struct {
+	SpinSeq  int    `json:"spinSeq"`  // sequence of the spin result; 0 for the round as a whole.
+	Field    string `json:"field"`    // name of the field which differs.
+	Stored   string `json:"stored"`   // value in the stored round.
+	Replayed string `json:"replayed"` // value in the replayed round.
+}
+
+// Compare compares the stored results of a round with the results replayed by the game engine.
+// It compares the kind of each result, the total payout, the number of payouts, the initial grid of spins and the PRNG log.
+// Gamble steps on the win of the round are drawn after the round, so they are not part of the replay and are skipped.
+// It returns nil if the replayed round matches the stored round.
+func Compare(stored mngr.RoundResults, replayed results.Results) []Diff {
+	var out []Diff
+
+	stored = slices.DeleteFunc(slices.Clone(stored), isGamble)
+
+	if len(stored) != len(replayed) {
+		out = append(out, Diff{Field: FieldResults, Stored: strconv.Itoa(len(stored)), Replayed: strconv.Itoa(len(replayed))})
+	}
+
+	for ix := range min(len(stored), len(replayed)) {
+		s, r := stored[ix], replayed[ix]
+		seq := ix + 1
+
+		if k := kind(s); k != r.DataKind {
+			out = append(out, Diff{SpinSeq: seq, Field: FieldKind, Stored: strconv.Itoa(int(k)), Replayed: strconv.Itoa(int(r.DataKind))})
+			continue
+		}
+
+		if !samePayout(s.TotalPayout, r.Total) {
+			out = append(out, Diff{SpinSeq: seq, Field: FieldTotalPayout, Stored: formatFloat(s.TotalPayout), Replayed: formatFloat(r.Total)})
+		}
+
+		if len(s.Payouts) != len(r.Payouts) {
+			out = append(out, Diff{SpinSeq: seq, Field: FieldPayouts, Stored: strconv.Itoa(len(s.Payouts)), Replayed: strconv.Itoa(len(r.Payouts))})
+		}
+
+		if spin, ok := r.Data.(*slots.SpinResult); ok && s.SpinData != nil {
+			if i1, i2 := s.SpinData.Initial(), spin.Initial(); !slices.Equal(i1, i2) {
+				out = append(out, Diff{SpinSeq: seq, Field: FieldInitial, Stored: formatInts(i1), Replayed: formatInts(i2)})
+			}
+		}
+
+		if l1, ok := data(s).(prngLogger); ok {
+			if l2, ok2 := r.Data.(prngLogger); ok2 {
+				_, in1, out1 := l1.Log()
+				_, in2, out2 := l2.Log()
+				if !slices.Equal(in1, in2) {
+					out = append(out, Diff{SpinSeq: seq, Field: FieldRngIn, Stored: formatInts(in1), Replayed: formatInts(in2)})
+				}
+				if !slices.Equal(out1, out2) {
+					out = append(out, Diff{SpinSeq: seq, Field: FieldRngOut, Stored: formatInts(out1), Replayed: formatInts(out2)})
+				}
+			}
+		}
+	}
+
+	return out
+}
+
+// kind returns the kind of result data of a stored result.
+func kind(r *mngr.RoundResult) results.ResultDataKind {
+	switch {
+	case r.SpinData != nil:
+		return results.SpinData
+	case r.InstantBonus != nil:
+		return results.InstantBonusData
+	case r.BonusSelector != nil:
+		return results.BonusSelectorData
+	case r.BonusWheel != nil:
+		return results.BonusWheelData
+	case r.Pickem != nil:
+		return results.PickemData
+	default:
+		return 0
+	}
+}
+
+// isGamble returns true if the stored result is a gamble step on the win of the round.
+func isGamble(r *mngr.RoundResult) bool {
+	return r.CallColor != nil || r.CallSuit != nil
+}
+
+// data returns the result data of a stored result which can include a PRNG log.
+func data(r *mngr.RoundResult) any {
+	switch {
+	case r.SpinData != nil:
+		return r.SpinData
+	case r.InstantBonus != nil:
+		return r.InstantBonus
+	case r.BonusSelector != nil:
+		return r.BonusSelector
+	case r.BonusWheel != nil:
+		return r.BonusWheel
+	case r.Pickem != nil:
+		return r.Pickem
+	default:
+		return nil
+	}
+}
+
+// samePayout returns true if the stored total payout matches the replayed total payout.
+// Rounds stored by earlier versions have the total payout rounded to 2 significant digits, so that is accepted as well.
+func samePayout(stored, replayed float64) bool {
+	return math.Abs(stored-replayed) < 1e-9 || formatFloat(stored) == strconv.FormatFloat(replayed, 'g', 2, 64)
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+func formatInts[T ~uint16 | ~int](list []T) string {
+	b := make([]byte, 0, len(list)*4+2)
+	b = append(b, '[')
+	for ix := range list {
+		if ix > 0 {
+			b = append(b, ',')
+		}
+		b = strconv.AppendInt(b, int64(list[ix]), 10)
+	}
+	return string(append(b, ']'))
+}
+
+// prngLogger is implemented by result data which embeds the PRNG log.
+type prngLogger interface {
+	Log() (results.Events, []int, []int)
+}
+
+const (
+	FieldResults     = "results"
+	FieldKind        = "dataKind"
+	FieldTotalPayout = "totalPayout"
+	FieldPayouts     = "payouts"
+	FieldInitial     = "initial"
+	FieldRngIn       = "rngIn"
+	FieldRngOut      = "rngOut"
+)
@@ -0,0 +1,92 @@
+package audit
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/games/cards"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/results"
+	mngr "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-manager.git/state/slots"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/encode/zjson"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/tg"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/game"
+)
+
+func TestCompare(t *testing.T) {
+	g := game.NewGame(tg.BOTnr, 92)
+	require.NotNil(t, g)
+	defer g.Release()
+
+	for ix := 0; ix < 100; ix++ {
+		replayed := g.Round(0)
+		require.NotEmpty(t, replayed)
+
+		stored := store(t, replayed)
+
+		assert.Empty(t, Compare(stored, replayed))
+
+		stored[0].TotalPayout += 1.5
+		diffs := Compare(stored, replayed)
+		require.Len(t, diffs, 1)
+		assert.Equal(t, 1, diffs[0].SpinSeq)
+		assert.Equal(t, FieldTotalPayout, diffs[0].Field)
+		stored[0].TotalPayout -= 1.5
+
+		diffs = Compare(stored, replayed[:len(replayed)-1])
+		require.NotEmpty(t, diffs)
+		assert.Equal(t, Diff{Field: FieldResults, Stored: strconv.Itoa(len(replayed)), Replayed: strconv.Itoa(len(replayed) - 1)}, diffs[0])
+
+		_, step := cards.Gamble(cards.GambleColor, 1, 1)
+		require.NotNil(t, step)
+		gamble := mngr.AcquireRoundResult(len(stored)+1, step)
+		step.Release()
+		assert.Empty(t, Compare(append(stored, gamble), replayed))
+		gamble.Release()
+
+		for iy := range stored {
+			stored[iy].Release()
+		}
+	}
+}
+
+func TestCompareLog(t *testing.T) {
+	g := game.NewGame(tg.BOTnr, 92)
+	require.NotNil(t, g)
+	defer g.Release()
+
+	replayed := g.Round(0)
+	require.NotEmpty(t, replayed)
+
+	stored := store(t, replayed[:1])
+	defer stored[0].Release()
+
+	_, in, out := stored[0].SpinData.Log()
+	require.NotEmpty(t, in)
+	stored[0].SpinData.SetLog(in, append(append([]int{}, out[:len(out)-1]...), out[len(out)-1]+1))
+
+	diffs := Compare(stored, replayed[:1])
+	require.Len(t, diffs, 1)
+	assert.Equal(t, FieldRngOut, diffs[0].Field)
+}
+
+// store returns a copy of the results as they would be stored and retrieved by the round manager.
+func store(t *testing.T, res results.Results) mngr.RoundResults {
+	enc := zjson.AcquireEncoder(4096)
+	defer enc.Release()
+
+	out := make(mngr.RoundResults, len(res))
+	for ix := range res {
+		r := mngr.AcquireRoundResult(ix+1, res[ix])
+		enc.Reset()
+		enc.Object(r)
+		r.Release()
+
+		out[ix] = mngr.AcquireRoundResultFromJSON(enc.Bytes())
+		require.NotNil(t, out[ix])
+	}
+	return out
+}
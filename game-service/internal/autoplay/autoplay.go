@@ -0,0 +1,201 @@
+package autoplay
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/utils"
+)
+
+// Settings contains the number of rounds and the stop conditions for an autoplay sequence.
+// Amounts are in cents. Zero values mean the stop condition is not used.
+type Settings struct {
+	Rounds       int   `json:"rounds"`
+	WinAbove     int64 `json:"winAbove,omitempty"`     // stop if the win of a single round is higher.
+	BalanceBelow int64 `json:"balanceBelow,omitempty"` // stop if the balance drops below.
+	BalanceAbove int64 `json:"balanceAbove,omitempty"` // stop if the balance rises above.
+	OnFeature    bool  `json:"onFeature,omitempty"`    // stop if a feature is triggered (e.g. free spins).
+	LossLimit    int64 `json:"lossLimit,omitempty"`    // stop if the next round could exceed the net loss.
+}
+
+// Result contains the outcome of an autoplay round.
+type Result struct {
+	Bet     int64
+	Win     int64
+	Balance int64
+	Feature bool // a feature was triggered.
+	Choice  bool // the round requires a player choice.
+}
+
+// Status contains the progress of an autoplay sequence.
+type Status struct {
+	Settings Settings   `json:"settings"`
+	Bet      int64      `json:"bet"`
+	Played   int        `json:"played"`
+	Bets     int64      `json:"bets"`
+	Wins     int64      `json:"wins"`
+	Running  bool       `json:"running"`
+	Reason   StopReason `json:"reason,omitempty"`
+	Started  time.Time  `json:"started"`
+	Stopped  time.Time  `json:"stopped"`
+}
+
+// NetLoss returns the net loss of the sequence so far; it is negative if the player is winning.
+func (s *Status) NetLoss() int64 {
+	return s.Bets - s.Wins
+}
+
+// Start registers a new autoplay sequence for a player session.
+// It returns ErrRunning if the session already has a running sequence.
+// The sequence is cancelled when Stop is called, or when the context given to Cleanup is done.
+func Start(sessionID string, bet int64, s Settings) (*Run, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if r := runs[sessionID]; r != nil && r.running() {
+		return nil, ErrRunning
+	}
+
+	r := &Run{status: Status{Settings: s, Bet: bet, Running: true, Started: time.Now()}}
+	r.ctx, r.cancel = context.WithCancel(context.Background())
+	runs[sessionID] = r
+	return r, nil
+}
+
+// Get returns the status of the last autoplay sequence of a player session, or false if there is none.
+func Get(sessionID string) (Status, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if r := runs[sessionID]; r != nil {
+		return r.Status(), true
+	}
+	return Status{}, false
+}
+
+// Stop cancels the running autoplay sequence of a player session.
+// It returns false if the session has no running sequence.
+func Stop(sessionID string) bool {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if r := runs[sessionID]; r != nil && r.running() {
+		r.cancel()
+		return true
+	}
+	return false
+}
+
+// Expire removes the sequences which have been stopped for the given duration.
+// It returns the number of sequences removed.
+func Expire(idle time.Duration) int {
+	mu.Lock()
+	defer mu.Unlock()
+
+	var n int
+	limit := time.Now().Add(-idle)
+	for k, r := range runs {
+		if s := r.Status(); !s.Running && s.Stopped.Before(limit) {
+			delete(runs, k)
+			n++
+		}
+	}
+	return n
+}
+
+// Cleanup periodically removes stopped sequences until the context is done.
+// Sequences still running when the context is done are cancelled.
+func Cleanup(ctx context.Context, idle time.Duration) {
+	utils.Cleanup(ctx, idle, Expire)
+	cancelAll()
+}
+
+// Done returns a channel which is closed when the sequence is cancelled.
+func (r *Run) Done() <-chan struct{} {
+	return r.ctx.Done()
+}
+
+// Status returns the progress of the sequence.
+func (r *Run) Status() Status {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.status
+}
+
+// Record adds the result of a round to the sequence, and evaluates the stop conditions.
+// It returns StopNone if the sequence should continue.
+func (r *Run) Record(res Result) StopReason {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s := &r.status
+	s.Played++
+	s.Bets += res.Bet
+	s.Wins += res.Win
+
+	c := &s.Settings
+	switch {
+	case res.Choice:
+		return StopChoice
+	case c.WinAbove > 0 && res.Win > c.WinAbove:
+		return StopWin
+	case c.OnFeature && res.Feature:
+		return StopFeature
+	case c.BalanceBelow > 0 && res.Balance < c.BalanceBelow:
+		return StopBalanceBelow
+	case c.BalanceAbove > 0 && res.Balance > c.BalanceAbove:
+		return StopBalanceAbove
+	case c.LossLimit > 0 && s.NetLoss()+s.Bet > c.LossLimit:
+		return StopLossLimit
+	case s.Played >= c.Rounds:
+		return StopCompleted
+	default:
+		return StopNone
+	}
+}
+
+// Finish marks the sequence as stopped for the given reason.
+func (r *Run) Finish(reason StopReason) {
+	r.mu.Lock()
+	r.status.Running = false
+	r.status.Reason = reason
+	r.status.Stopped = time.Now()
+	r.mu.Unlock()
+
+	r.cancel()
+}
+
+func (r *Run) running() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.status.Running
+}
+
+// Run represents an autoplay sequence for a player session.
+type Run struct {
+	mu     sync.Mutex
+	status Status
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// cancelAll cancels all running sequences.
+func cancelAll() {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	for _, r := range runs {
+		r.cancel()
+	}
+}
+
+var (
+	mu   sync.RWMutex
+	runs = make(map[string]*Run, 128)
+)
+
+var (
+	ErrRunning = errors.New("autoplay already running")
+)
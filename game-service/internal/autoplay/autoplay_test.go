@@ -0,0 +1,147 @@
+package autoplay
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun_Record(t *testing.T) {
+	testCases := []struct {
+		name     string
+		settings Settings
+		results  []Result
+		want     []StopReason
+	}{
+		{
+			name:     "completed",
+			settings: Settings{Rounds: 3},
+			results:  []Result{{Bet: 100}, {Bet: 100, Win: 50}, {Bet: 100}},
+			want:     []StopReason{StopNone, StopNone, StopCompleted},
+		},
+		{
+			name:     "single win",
+			settings: Settings{Rounds: 10, WinAbove: 1000},
+			results:  []Result{{Bet: 100, Win: 1000}, {Bet: 100, Win: 1001}},
+			want:     []StopReason{StopNone, StopWin},
+		},
+		{
+			name:     "feature",
+			settings: Settings{Rounds: 10, OnFeature: true},
+			results:  []Result{{Bet: 100}, {Bet: 100, Win: 300, Feature: true}},
+			want:     []StopReason{StopNone, StopFeature},
+		},
+		{
+			name:     "feature ignored",
+			settings: Settings{Rounds: 2},
+			results:  []Result{{Bet: 100, Feature: true}, {Bet: 100}},
+			want:     []StopReason{StopNone, StopCompleted},
+		},
+		{
+			name:     "balance below",
+			settings: Settings{Rounds: 10, BalanceBelow: 5000},
+			results:  []Result{{Bet: 100, Balance: 5000}, {Bet: 100, Balance: 4900}},
+			want:     []StopReason{StopNone, StopBalanceBelow},
+		},
+		{
+			name:     "balance above",
+			settings: Settings{Rounds: 10, BalanceAbove: 20000},
+			results:  []Result{{Bet: 100, Balance: 19000}, {Bet: 100, Win: 2000, Balance: 20900}},
+			want:     []StopReason{StopNone, StopBalanceAbove},
+		},
+		{
+			name:     "loss limit",
+			settings: Settings{Rounds: 10, LossLimit: 250},
+			results:  []Result{{Bet: 100}, {Bet: 100, Win: 100}, {Bet: 100}},
+			want:     []StopReason{StopNone, StopNone, StopLossLimit},
+		},
+		{
+			name:     "player choice",
+			settings: Settings{Rounds: 10},
+			results:  []Result{{Bet: 100, Choice: true}},
+			want:     []StopReason{StopChoice},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sessionID := "record-" + tc.name
+			defer cleanup(sessionID)
+
+			r, err := Start(sessionID, 100, tc.settings)
+			require.NoError(t, err)
+			require.NotNil(t, r)
+
+			for ix := range tc.results {
+				assert.Equal(t, tc.want[ix], r.Record(tc.results[ix]), ix)
+			}
+
+			s := r.Status()
+			assert.Equal(t, len(tc.results), s.Played)
+			assert.True(t, s.Running)
+		})
+	}
+}
+
+func TestStartStop(t *testing.T) {
+	const sessionID = "start-stop"
+	defer cleanup(sessionID)
+
+	_, ok := Get(sessionID)
+	assert.False(t, ok)
+	assert.False(t, Stop(sessionID))
+
+	r, err := Start(sessionID, 100, Settings{Rounds: 10})
+	require.NoError(t, err)
+
+	_, err = Start(sessionID, 100, Settings{Rounds: 10})
+	assert.Equal(t, ErrRunning, err)
+
+	s, ok := Get(sessionID)
+	require.True(t, ok)
+	assert.True(t, s.Running)
+	assert.Equal(t, int64(100), s.Bet)
+
+	require.True(t, Stop(sessionID))
+	select {
+	case <-r.Done():
+	case <-time.After(time.Second):
+		t.Fatal("run not cancelled")
+	}
+
+	r.Finish(StopCancelled)
+	s, ok = Get(sessionID)
+	require.True(t, ok)
+	assert.False(t, s.Running)
+	assert.Equal(t, StopCancelled, s.Reason)
+	assert.False(t, Stop(sessionID))
+
+	// a new sequence can be started once the previous one has stopped.
+	r, err = Start(sessionID, 200, Settings{Rounds: 5})
+	require.NoError(t, err)
+	r.Finish(StopCompleted)
+
+	assert.Equal(t, 0, Expire(time.Hour))
+	assert.Equal(t, 1, Expire(0))
+	_, ok = Get(sessionID)
+	assert.False(t, ok)
+}
+
+func TestStopReason_MarshalText(t *testing.T) {
+	b, err := StopLossLimit.MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, "lossLimit", string(b))
+	assert.Equal(t, "unknown", StopReason(99).String())
+}
+
+func cleanup(sessionID string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if r := runs[sessionID]; r != nil {
+		r.cancel()
+		delete(runs, sessionID)
+	}
+}
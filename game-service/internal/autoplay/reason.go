@@ -0,0 +1,43 @@
+package autoplay
+
+// StopReason indicates why an autoplay sequence stopped.
+type StopReason uint8
+
+const (
+	StopNone StopReason = iota
+	StopCompleted
+	StopCancelled
+	StopWin
+	StopFeature
+	StopBalanceBelow
+	StopBalanceAbove
+	StopLossLimit
+	StopChoice
+	StopFailed
+)
+
+var reasonNames = []string{
+	"",
+	"completed",
+	"cancelled",
+	"win",
+	"feature",
+	"balanceBelow",
+	"balanceAbove",
+	"lossLimit",
+	"playerChoice",
+	"failed",
+}
+
+// String implements the Stringer interface.
+func (r StopReason) String() string {
+	if int(r) < len(reasonNames) {
+		return reasonNames[r]
+	}
+	return "unknown"
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (r StopReason) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
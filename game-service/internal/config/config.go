@@ -22,16 +22,29 @@ var (
 	MonitorKey       string
 	ConnCleanup      = 1 * time.Minute
 	LongPollTimeout  = 1 * time.Minute
+	JurisdictionIdle = 2 * time.Hour // idle time after which cached jurisdiction profiles are removed.
+	LimitsIdle       = 2 * time.Hour // idle time after which cached responsible gambling limits are removed.
+	AutoplayIdle     = 2 * time.Hour // time after which the status of a stopped autoplay sequence is removed.
 	MqBrokers        []string
 	EventsTopic      string
 	MessagesTopic    string
 	HashesTopic      string
 	ApiKey           = "abcdefghijklmn"
 	ApiKeyBytes      = []byte(ApiKey)
+	ApiKeySet        bool // indicates the API key was set explicitly; the admin endpoints refuse the built-in default.
 	NoDefaultHeaders bool
 	NoCors           bool
 	NoCompression    bool
-	DebugMode        = false // modified by compiler mode!
+	RngBackend       string                  // name of the PRNG backend; empty for the default (see game-engine rng package).
+	RngSeed          []byte                  // seed for the deterministic PRNG backend; only available in DEBUG mode!
+	RngNoHealth      bool                    // disables the online health tests of the PRNG backend.
+	Jurisdictions    string                  // path of the JSON file with jurisdiction profiles and casino jurisdictions (optional).
+	ProvablyFair     bool                    // enables provably-fair rounds with client seeds.
+	RoundLock        = consts.ValueLockLocal // serialises rounds per session in this instance ("local") or across instances ("lease").
+	RoundLockWait    = 2 * time.Second       // time a round waits for a round in progress for the same session.
+	RoundLockTTL     = 30 * time.Second      // time after which a lease on a session expires.
+	IdempotencyTTL   = 1 * time.Hour         // idle time after which the responses kept for request ids of a session are removed.
+	DebugMode        = false                 // modified by compiler mode!
 )
 
 func init() {
@@ -94,6 +107,7 @@ func init() {
 	if s := os.Getenv(consts.EnvApiKey); s != "" {
 		ApiKey = s
 		ApiKeyBytes = []byte(s)
+		ApiKeySet = true
 	}
 
 	if s := os.Getenv(consts.EnvNoDefaultHeaders); s != "" {
@@ -105,4 +119,46 @@ func init() {
 	if s := os.Getenv(consts.EnvNoCompression); s != "" {
 		NoCompression = s == consts.ValueTrue
 	}
+
+	if s := os.Getenv(consts.EnvRngBackend); s != "" {
+		RngBackend = s
+	}
+	if s := os.Getenv(consts.EnvRngNoHealth); s != "" {
+		RngNoHealth = s == consts.ValueTrue
+	}
+
+	Jurisdictions = os.Getenv(consts.EnvJurisdictions)
+
+	if s := os.Getenv(consts.EnvProvablyFair); s != "" {
+		ProvablyFair = s == consts.ValueTrue
+	}
+
+	if s := os.Getenv(consts.EnvRoundLock); s == consts.ValueLockLocal || s == consts.ValueLockLease {
+		RoundLock = s
+	}
+	if s := os.Getenv(consts.EnvRoundLockWait); s != "" {
+		if t, err := time.ParseDuration(s); err == nil && t >= 0 {
+			RoundLockWait = t
+		}
+	}
+	if s := os.Getenv(consts.EnvRoundLockTTL); s != "" {
+		if t, err := time.ParseDuration(s); err == nil && t > 0 {
+			RoundLockTTL = t
+		}
+	}
+	if s := os.Getenv(consts.EnvLimitsIdle); s != "" {
+		if t, err := time.ParseDuration(s); err == nil && t > 0 {
+			LimitsIdle = t
+		}
+	}
+	if s := os.Getenv(consts.EnvAutoplayIdle); s != "" {
+		if t, err := time.ParseDuration(s); err == nil && t > 0 {
+			AutoplayIdle = t
+		}
+	}
+	if s := os.Getenv(consts.EnvIdempotencyTTL); s != "" {
+		if t, err := time.ParseDuration(s); err == nil && t > 0 {
+			IdempotencyTTL = t
+		}
+	}
 }
@@ -17,4 +17,8 @@ func init() {
 	if strings.EqualFold(os.Getenv(consts.EnvRunEnv), consts.ValueDev) {
 		DebugMode = true
 	}
+
+	if s := os.Getenv(consts.EnvRngSeed); s != "" {
+		RngSeed = []byte(s)
+	}
 }
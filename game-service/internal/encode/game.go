@@ -6,20 +6,18 @@ import (
 	comp "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/components/slots"
 	game "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/games/slots"
 	util "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/utils"
+	mngr "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-manager.git/state/slots"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/encode/zjson"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/object-pool/object"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/object-pool/pool"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/tg"
 
-	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/clients/bo_backend"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/consts"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/hashes"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/jurisdiction"
 )
 
-func GameInfo(loc string, sess *tg.SessionKey, g *game.Regular, debugEnabled bool) *zjson.Encoder {
-	prefs, casino, juris := bo_backend.GetGamePrefs(loc, sess)
-	defer prefs.Release()
-
+func GameInfo(sess *tg.SessionKey, g *game.Regular, prefs *mngr.GamePrefs, casino map[string]any, juris *jurisdiction.Profile, campaign *mngr.Campaign, jackpots []*mngr.Jackpot, debugEnabled bool) *zjson.Encoder {
 	s := g.Slots()
 
 	info := gameInfoPool.Acquire().(*gameInfo)
@@ -28,17 +26,20 @@ func GameInfo(loc string, sess *tg.SessionKey, g *game.Regular, debugEnabled boo
 	info.roundMultiplier = s.RoundMultiplier()
 	info.progressMeter = s.ProgressMeter()
 	info.playerChoice = s.PlayerChoice()
-	info.bonusBuy = s.BonusBuy()
+	info.bonusBuy = s.BonusBuy() && juris.BonusBuy
 	info.symbolsState = s.SymbolsState()
 	info.reels = s.ReelCount()
 	info.rows = s.RowCount()
 	info.mask = s.ReelMask()
 	info.maxPayout = g.MaxPayout()
+	if juris.MaxWin > 0 && juris.MaxWin < info.maxPayout {
+		info.maxPayout = juris.MaxWin
+	}
 	info.targetRTP = s.RTP()
 	info.semVer = consts.SemVerShort
 	info.semVerFull = consts.SemVerFull
 	info.buildDate = consts.SemDate
-	info.bets = casino["bets"].([]int64)
+	info.bets = juris.Bets(casino["bets"].([]int64))
 	info.configHash = hashes.GameHashes[sess.GameID()+strconv.Itoa(int(info.targetRTP))]
 
 	switch sess.GameNr() {
@@ -82,6 +83,14 @@ func GameInfo(loc string, sess *tg.SessionKey, g *game.Regular, debugEnabled boo
 
 	encodePrefs(enc, juris)
 
+	if campaign != nil {
+		encodeCampaign(enc, campaign)
+	}
+
+	if len(jackpots) > 0 {
+		encodeJackpots(enc, jackpots)
+	}
+
 	enc.StartObjectField("gameData")
 	info.Encode(enc)
 	enc.EndObject()
@@ -117,25 +126,30 @@ func GameInfo(loc string, sess *tg.SessionKey, g *game.Regular, debugEnabled boo
 	return enc
 }
 
-func encodePrefs(enc *zjson.Encoder, m map[string]any) {
+func encodePrefs(enc *zjson.Encoder, juris *jurisdiction.Profile) {
 	enc.StartObjectField("jurisdiction")
 
-	jur, _ := m["code"].(string)
-	if jur == "" {
-		jur = "MGA"
-	}
-
-	wait, _ := m["spinWait"].(int64)
-	if wait == 0 {
-		wait = 1000
+	enc.StringFieldOpt("code", juris.Code)
+	enc.Int64Field("spinWait", juris.MinSpinDuration)
+	enc.Int64FieldOpt("maxBet", juris.MaxBet)
+	enc.IntBoolField("bonusBuy", juris.BonusBuy)
+	enc.IntBoolField("autoplay", juris.Autoplay)
+	enc.IntFieldOpt("autoplayMax", juris.AutoplayMax)
+	enc.IntBoolFieldOpt("autoplayLossLimit", juris.AutoplayLossLimit)
+	enc.FloatFieldOpt("maxWin", juris.MaxWin, 'f', 2)
+	enc.Int64FieldOpt("realityCheck", juris.RealityCheck)
+
+	if len(juris.RTPs) > 0 {
+		enc.StartArrayField("rtps")
+		for _, rtp := range juris.RTPs {
+			enc.Uint64(uint64(rtp))
+		}
+		enc.EndArray()
 	}
 
-	enc.StringFieldOpt("code", jur)
-	enc.Int64Field("spinWait", wait)
-
-	for k, v := range m {
+	for k, v := range juris.Prefs {
 		switch k {
-		case "code", "spinWait": // already done
+		case "code": // already done
 		default:
 			switch t := v.(type) {
 			case bool:
@@ -161,6 +175,39 @@ func encodePrefs(enc *zjson.Encoder, m map[string]any) {
 	enc.EndObject()
 }
 
+func encodeCampaign(enc *zjson.Encoder, c *mngr.Campaign) {
+	enc.StartObjectField("freeRounds")
+	enc.StringField("campaignId", c.ID)
+	enc.Int64Field("bet", c.Bet)
+	enc.IntField("remaining", c.Remaining())
+	if !c.Expires.IsZero() {
+		enc.TimestampField("expires", c.Expires)
+	}
+	enc.Int64FieldOpt("maxWin", c.MaxWin)
+	enc.Int64FieldOpt("won", c.Won)
+	enc.FloatFieldOpt("wagering", c.Wagering, 'g', -1)
+	if held := c.Held(); held > 0 {
+		enc.Int64Field("held", held)
+		enc.Int64Field("wageringLeft", max(c.WageringRequired()-c.Wagered, 0))
+	}
+	enc.EndObject()
+}
+
+// encodeJackpots encodes the current values of the progressive jackpot pools.
+// The hidden reserve and the drop value of must-drop pools are not disclosed.
+func encodeJackpots(enc *zjson.Encoder, list []*mngr.Jackpot) {
+	enc.StartArrayField("jackpots")
+	for _, j := range list {
+		enc.StartObject()
+		enc.Uint8Field("level", j.Level)
+		enc.StringField("currency", j.Currency)
+		enc.Int64Field("value", j.Amount())
+		enc.Int64FieldOpt("mustDropBy", j.MustDropBy)
+		enc.EndObject()
+	}
+	enc.EndArray()
+}
+
 func newSymbol(s *comp.Symbol) *gameSymbol {
 	s2 := gameSymbolPool.Acquire().(*gameSymbol)
 	s2.id = int(s.ID())
@@ -6,6 +6,7 @@ import (
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/encode/zjson"
 
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/config"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/game"
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/hashes"
 )
 
@@ -42,3 +43,26 @@ func Hashes() *zjson.Encoder {
 	enc.EndObject()
 	return enc
 }
+
+func Games() *zjson.Encoder {
+	enc := zjson.AcquireEncoder(1024)
+	enc.StartObject()
+
+	enc.StartArrayField("games")
+	for _, g := range game.Games() {
+		enc.StartObject()
+		enc.StringField("game", g.Code())
+		enc.StartArrayField("rtps")
+		for _, rtp := range g.RTPs {
+			enc.Int64(int64(rtp))
+		}
+		enc.EndArray()
+		enc.EndObject()
+	}
+	enc.EndArray()
+
+	enc.BoolField("success", true)
+
+	enc.EndObject()
+	return enc
+}
@@ -0,0 +1,27 @@
+package encode
+
+import (
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/rng"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/encode/zjson"
+)
+
+func RngHealth() *zjson.Encoder {
+	enc := zjson.AcquireEncoder(256)
+	enc.StartObject()
+
+	enc.StringField("backend", rng.Current())
+	enc.BoolField("healthy", rng.Healthy())
+
+	if h, err := rng.HealthStatus(); err == nil {
+		enc.StartObjectField("health")
+		enc.BoolField("enabled", h.Enabled)
+		enc.Uint64Field("buffers", h.Buffers)
+		enc.Uint64Field("samples", h.Samples)
+		enc.Uint64Field("repetitionFailures", h.RepetitionFailures)
+		enc.Uint64Field("proportionFailures", h.ProportionFailures)
+		enc.EndObject()
+	}
+
+	enc.EndObject()
+	return enc
+}
@@ -11,19 +11,94 @@ import (
 	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/api/models"
 )
 
-func BuildRoundResponse(game *game.Regular, round *mngr.Round, i18n *models.PrefetchI18n) *zjson.Encoder {
-	requireChoice := game.AllowPlayerChoices() && game.NeedPlayerChoice()
-	startBalance := round.PlayerBalance() - round.TotalWin()
+// BuildRoundResponse builds the response for a round played by the game.
+func BuildRoundResponse(game *game.Regular, round *mngr.Round, i18n *models.PrefetchI18n, fair *models.RoundStartResponseFair) *zjson.Encoder {
 	before, after := round.Balances(0)
-	win := after - before
-	bet := round.Bet()
-	totalWin := round.ProgressiveWin(0)
 
-	result := round.RoundResults()[0]
-	first := round.Results()[0]
+	r := roundResponse{
+		roundID:       round.RoundID(),
+		roundSeq:      round.GameState().RoundSeq(),
+		startBalance:  round.PlayerBalance() - round.TotalWin() - round.JackpotWin(), // jackpots are booked on top of the round.
+		before:        before,
+		after:         after,
+		bet:           round.Bet(),
+		totalWin:      round.ProgressiveWin(0),
+		count:         int64(len(round.RoundResults())),
+		result:        round.RoundResults()[0],
+		first:         round.Results()[0],
+		requireChoice: game.AllowPlayerChoices() && game.NeedPlayerChoice(),
+		gamble:        round.GameState().GambleRoundID() != "",
+		jackpotHits:   round.JackpotHits(),
+		jackpotWin:    round.JackpotWin(),
+	}
+	return r.encode(game, i18n, fair)
+}
+
+// BuildStoredRoundResponse rebuilds the response for a round that was booked by an earlier request with the same request id,
+// e.g. when the response of the earlier request never reached the player.
+// The response starts at the given offset in the results of the stored round, and balance is the player balance after the round.
+// The game state is only used while it still belongs to the stored round; it can be nil.
+// Jackpot hits are not stored with the round, so they are included in the win, but not listed separately.
+func BuildStoredRoundResponse(game *game.Regular, stored *mngr.StoredRound, gs *mngr.GameState, balance int64, offset int, i18n *models.PrefetchI18n) *zjson.Encoder {
+	if offset < 0 || offset >= len(stored.Results) {
+		offset = 0
+	}
+	result := stored.Results[offset]
+
+	var win int64
+	for ix := offset; ix < len(stored.Results); ix++ {
+		win += stored.Results[ix].Win
+	}
+
+	// the result shares its data with the stored round, so it must not be released; the caller releases the stored round.
+	first := result.Result()
+
+	r := roundResponse{
+		roundID:      stored.RoundID,
+		startBalance: balance - win,
+		before:       result.BalanceBefore,
+		after:        result.BalanceAfter,
+		bet:          stored.Bet,
+		totalWin:     result.ProgressiveWin,
+		count:        int64(len(stored.Results) - offset),
+		result:       result,
+		first:        first,
+	}
+	if gs != nil && gs.RoundID() == stored.RoundID {
+		r.roundSeq = gs.RoundSeq()
+		r.requireChoice = game.AllowPlayerChoices() && !game.IsDoubleSpin() && gs.SpinState() != nil
+		r.gamble = gs.GambleRoundID() == stored.RoundID
+	}
+	return r.encode(game, i18n, nil)
+}
+
+// roundResponse contains the values for the response of a round.
+type roundResponse struct {
+	requireChoice bool
+	gamble        bool
+	roundSeq      int64
+	startBalance  int64
+	before        int64
+	after         int64
+	bet           int64
+	totalWin      int64
+	count         int64
+	jackpotWin    int64
+	roundID       string
+	result        *mngr.RoundResult
+	first         *rslt.Result
+	jackpotHits   []mngr.JackpotHit
+}
+
+func (r *roundResponse) encode(game *game.Regular, i18n *models.PrefetchI18n, fair *models.RoundStartResponseFair) *zjson.Encoder {
+	win := r.after - r.before
+	bet := r.bet
+	totalWin := r.totalWin
+
+	result := r.result
+	first := r.first
 	first.Animations = game.BuildAnimations(first)
 	isSpin := first.DataKind == rslt.SpinData
-	count := int64(len(round.RoundResults()))
 	spinWin := result.SpinWin
 
 	if i18n != nil && first.Total > 0 {
@@ -38,16 +113,16 @@ func BuildRoundResponse(game *game.Regular, round *mngr.Round, i18n *models.Pref
 	enc.StartObject()
 
 	enc.StartObjectField("roundData")
-	enc.StringField("roundId", round.RoundID())
-	enc.Int64Field("roundSeq", round.GameState().RoundSeq())
+	enc.StringField("roundId", r.roundID)
+	enc.Int64Field("roundSeq", r.roundSeq)
 	enc.Uint8Field("dataKind", uint8(first.DataKind))
-	enc.Int64Field("nrOfResults", count)
-	enc.Int64Field("balanceBefore", startBalance+before)
-	enc.Int64Field("balanceAfter", startBalance+after)
+	enc.Int64Field("nrOfResults", r.count)
+	enc.Int64Field("balanceBefore", r.startBalance+r.before)
+	enc.Int64Field("balanceAfter", r.startBalance+r.after)
 	enc.Int64Field("bet", bet)
-	enc.Int64Field("win", after-before)
+	enc.Int64Field("win", r.after-r.before)
 	enc.FloatField("winFactor", winFactor, 'f', 2)
-	enc.IntBoolField("realWin", after-before > bet)
+	enc.IntBoolField("realWin", r.after-r.before > bet)
 	enc.Int64FieldOpt("spinWin", spinWin)
 	enc.FloatFieldOpt("spinFactor", spinFactor, 'f', 2)
 	enc.IntBoolField("realSpinWin", spinWin > bet)
@@ -55,7 +130,16 @@ func BuildRoundResponse(game *game.Regular, round *mngr.Round, i18n *models.Pref
 	enc.FloatField("totalFactor", totalFactor, 'f', 2)
 	enc.IntBoolField("realTotalWin", totalWin > bet)
 	enc.IntBoolFieldOpt("maxPayout", result.MaxPayout > 0)
-	enc.IntBoolFieldOpt("requireChoice", requireChoice)
+	enc.IntBoolFieldOpt("requireChoice", r.requireChoice)
+	enc.IntBoolFieldOpt("gamble", r.gamble)
+	if hits := r.jackpotHits; len(hits) > 0 {
+		enc.Int64Field("jackpotWin", r.jackpotWin)
+		enc.StartArrayField("jackpots")
+		for ix := range hits {
+			enc.Object(&hits[ix])
+		}
+		enc.EndArray()
+	}
 	enc.EndObject()
 
 	enc.StartObjectField("spinData")
@@ -70,6 +154,14 @@ func BuildRoundResponse(game *game.Regular, round *mngr.Round, i18n *models.Pref
 		enc.EndObject()
 	}
 
+	if fair != nil {
+		enc.StartObjectField("fair")
+		enc.StringField("serverSeedHash", fair.ServerSeedHash)
+		enc.StringField("clientSeed", fair.ClientSeed)
+		enc.Uint64Field("nonce", fair.Nonce)
+		enc.EndObject()
+	}
+
 	enc.BoolField("success", true)
 
 	enc.EndObject()
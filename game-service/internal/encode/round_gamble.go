@@ -0,0 +1,36 @@
+package encode
+
+import (
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/games/cards"
+	rslt "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/results"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/encode/zjson"
+)
+
+// BuildRoundGambleResponse encodes the outcome of a step of the gamble stage.
+// The result is nil if the player collected the win.
+func BuildRoundGambleResponse(roundID string, kind cards.GambleKind, won bool, result *rslt.Result, stake, win int64, steps uint8, balance int64, gamble bool) *zjson.Encoder {
+	enc := zjson.AcquireEncoder(512)
+	enc.StartObject()
+
+	enc.StringField("roundId", roundID)
+	enc.StringField("choice", kind.String())
+	enc.IntBoolFieldOpt("won", won)
+	enc.Int64FieldOpt("stake", stake)
+	enc.Int64Field("win", win)
+	enc.Uint8Field("steps", steps)
+	enc.Int64FieldOpt("balance", balance)
+	enc.IntBoolFieldOpt("gamble", gamble)
+
+	if result != nil {
+		switch d := result.Data.(type) {
+		case *cards.BonusCallColorData:
+			enc.ObjectField("card", d.Card)
+		case *cards.BonusCallSuitData:
+			enc.ObjectField("card", d.Card)
+		}
+	}
+
+	enc.BoolField("success", true)
+	enc.EndObject()
+	return enc
+}
@@ -19,7 +19,11 @@ func AddMessage(sessionID string, msg *Message) {
 	mutex.Unlock()
 
 	if ok2 && c != nil {
-		c <- struct{}{}
+		// don't block if the listener was already triggered.
+		select {
+		case c <- struct{}{}:
+		default:
+		}
 	}
 }
 
@@ -37,7 +41,7 @@ func CommitMessages(sessionID string, msgs []*Message) {
 		if l2 := len(m); l2 <= l1 {
 			m = m[:0]
 		} else {
-			m = m[:l1-l2]
+			m = m[l1:]
 		}
 		messages[sessionID] = m
 	}
@@ -0,0 +1,59 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/tg"
+)
+
+func TestCommitMessages(t *testing.T) {
+	const sessionID = "commit"
+
+	now := time.Now()
+	m1 := NewMessage(tg.MsgRealityCheck, tg.DisplayModal, now, 60)
+	m2 := NewMessage(tg.MsgRealityCheck, tg.DisplayModal, now, 60)
+	m3 := NewMessage(tg.MsgRealityCheck, tg.DisplayModal, now, 60)
+
+	AddMessage(sessionID, m1)
+	AddMessage(sessionID, m2)
+	sent := GetMessages(sessionID)
+	require.Len(t, sent, 2)
+
+	// a message added while the first ones were sent is kept.
+	AddMessage(sessionID, m3)
+	CommitMessages(sessionID, sent)
+	assert.Equal(t, []*Message{m3}, GetMessages(sessionID))
+
+	CommitMessages(sessionID, GetMessages(sessionID))
+	assert.Empty(t, GetMessages(sessionID))
+}
+
+func TestAddMessageTriggered(t *testing.T) {
+	const sessionID = "triggered"
+
+	trigger := make(chan struct{}, 1)
+	AddSession(sessionID, trigger)
+	defer RemoveSession(sessionID)
+
+	// the listener is triggered once; further messages must not block until it has picked them up.
+	done := make(chan struct{})
+	go func() {
+		for ix := 0; ix < 3; ix++ {
+			AddMessage(sessionID, NewMessage(tg.MsgRealityCheck, tg.DisplayModal, time.Now(), 60))
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("AddMessage blocked")
+	}
+
+	assert.Len(t, trigger, 1)
+	assert.Len(t, GetMessages(sessionID), 3)
+}
@@ -39,12 +39,32 @@ func NewMessageFromEvent(event *kafka.Event, created time.Time) (string, *Messag
 	return "", nil
 }
 
+// WithData adds a value to the message, e.g. the elapsed time for a reality check.
+func (m *Message) WithData(key string, value int64) *Message {
+	if m.data == nil {
+		m.data = make(map[string]int64, 4)
+	}
+	m.data[key] = value
+	return m
+}
+
+// WithJSON adds an encoded JSON value to the message, e.g. the result of an autoplay round.
+func (m *Message) WithJSON(key string, value []byte) *Message {
+	if m.json == nil {
+		m.json = make(map[string][]byte, 1)
+	}
+	m.json[key] = value
+	return m
+}
+
 // Message contains a UI message for a specific session.
 type Message struct {
 	messageID   tg.MessageKind
 	displayMode tg.DisplayMode
 	created     time.Time
 	expires     time.Time
+	data        map[string]int64
+	json        map[string][]byte
 }
 
 // Encode encodes the message to JSON.
@@ -54,5 +74,20 @@ func (m *Message) Encode(enc *zjson.Encoder, locale string) {
 	enc.IntField("mode", int(m.displayMode))
 	enc.IntField("kind", int(m.messageID))
 	enc.StringField("msg", i18n.GetMessage(m.messageID, locale))
+
+	if len(m.data) > 0 {
+		enc.StartObjectField("data")
+		for k, v := range m.data {
+			enc.Int64Field(k, v)
+		}
+		enc.EndObject()
+	}
+
+	for k, v := range m.json {
+		enc.Key(k)
+		enc.Raw(v)
+		enc.WriteByte(',')
+	}
+
 	enc.EndObject()
 }
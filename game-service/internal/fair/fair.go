@@ -0,0 +1,77 @@
+package fair
+
+import (
+	"encoding/hex"
+	"errors"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/rng"
+	mngr "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-manager.git/state/slots"
+)
+
+// Status contains the provably-fair commitment of a player session.
+type Status struct {
+	ServerSeedHash string `json:"serverSeedHash"` // SHA-256 hash of the current server seed.
+	Nonce          uint64 `json:"nonce"`          // nonce of the last round played with the current server seed.
+	Rounds         int    `json:"rounds"`         // number of rounds played with the current server seed.
+}
+
+// Revealed contains a server seed which is no longer in use, and can be used to verify the rounds played with it.
+type Revealed struct {
+	ServerSeed     string `json:"serverSeed"`     // hex encoded server seed.
+	ServerSeedHash string `json:"serverSeedHash"` // SHA-256 hash of the server seed.
+	Nonce          uint64 `json:"nonce"`          // nonce of the last round played with the server seed.
+	Rounds         int    `json:"rounds"`         // number of rounds played with the server seed.
+}
+
+// NewSeed generates a new server seed with the commitment to it.
+// The seed must be stored through the RoundManager before the commitment is shown to the player.
+func NewSeed() *mngr.FairSeed {
+	seed := rng.NewServerSeed()
+	return &mngr.FairSeed{Seed: seed, Hash: rng.FairCommitment(seed)}
+}
+
+// Get returns the commitment for the server seed of a player session.
+func Get(s *mngr.FairSeed) Status {
+	return Status{ServerSeedHash: s.Hash, Nonce: s.Nonce, Rounds: s.Rounds}
+}
+
+// Next returns the seeds for the next round played with the server seed, and updates the server seed.
+// The nonce must be higher than the nonce of the previous round with the same server seed,
+// so a player can never replay an earlier outcome. It returns ErrClientSeed or ErrNonce otherwise.
+// The updated server seed must be stored through the RoundManager before the round is played.
+func Next(s *mngr.FairSeed, clientSeed string, nonce uint64) (*rng.FairSeeds, error) {
+	if clientSeed == "" || len(clientSeed) > MaxClientSeed {
+		return nil, ErrClientSeed
+	}
+	if s.Rounds > 0 && nonce <= s.Nonce {
+		return nil, ErrNonce
+	}
+
+	s.Nonce = nonce
+	s.Rounds++
+	return &rng.FairSeeds{ServerSeed: s.Seed, ClientSeed: clientSeed, Nonce: nonce}, nil
+}
+
+// Reveal returns the server seed, so the player can verify the rounds played with it.
+// The server seed must be replaced through the RoundManager before it is revealed to the player.
+func Reveal(s *mngr.FairSeed) Revealed {
+	return Revealed{
+		ServerSeed:     hex.EncodeToString(s.Seed),
+		ServerSeedHash: s.Hash,
+		Nonce:          s.Nonce,
+		Rounds:         s.Rounds,
+	}
+}
+
+// IsSeedError returns true if the error is caused by the seeds or nonce in the request.
+func IsSeedError(err error) bool {
+	return errors.Is(err, ErrClientSeed) || errors.Is(err, ErrNonce)
+}
+
+// MaxClientSeed is the maximum length of a client seed.
+const MaxClientSeed = 64
+
+var (
+	ErrClientSeed = errors.New("invalid client seed")
+	ErrNonce      = errors.New("nonce must be higher than the nonce of the previous round")
+)
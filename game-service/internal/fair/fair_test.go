@@ -0,0 +1,63 @@
+package fair
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/rng"
+)
+
+func TestNext(t *testing.T) {
+	seed := NewSeed()
+
+	s := Get(seed)
+	assert.Len(t, s.ServerSeedHash, 64)
+	assert.Equal(t, rng.FairCommitment(seed.Seed), s.ServerSeedHash)
+	assert.Zero(t, s.Nonce)
+	assert.Zero(t, s.Rounds)
+	assert.NotEqual(t, s, Get(NewSeed()))
+
+	_, err := Next(seed, "", 1)
+	assert.Equal(t, ErrClientSeed, err)
+	assert.True(t, IsSeedError(err))
+
+	seeds, err := Next(seed, "client", 0)
+	require.NoError(t, err)
+	assert.True(t, seeds.Verify(s.ServerSeedHash))
+	assert.Equal(t, "client", seeds.ClientSeed)
+
+	_, err = Next(seed, "client", 0)
+	assert.Equal(t, ErrNonce, err)
+	assert.True(t, IsSeedError(err))
+
+	seeds, err = Next(seed, "other", 5)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), seeds.Nonce)
+
+	_, err = Next(seed, "other", 4)
+	assert.Equal(t, ErrNonce, err)
+
+	s = Get(seed)
+	assert.Equal(t, uint64(5), s.Nonce)
+	assert.Equal(t, 2, s.Rounds)
+}
+
+func TestReveal(t *testing.T) {
+	seed := NewSeed()
+
+	seeds, err := Next(seed, "client", 3)
+	require.NoError(t, err)
+
+	revealed := Reveal(seed)
+	assert.Equal(t, seed.Hash, revealed.ServerSeedHash)
+	assert.Equal(t, uint64(3), revealed.Nonce)
+	assert.Equal(t, 1, revealed.Rounds)
+
+	b, err := hex.DecodeString(revealed.ServerSeed)
+	require.NoError(t, err)
+	assert.Equal(t, seeds.ServerSeed, b)
+	assert.Equal(t, seed.Hash, rng.FairCommitment(b))
+}
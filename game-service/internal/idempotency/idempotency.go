@@ -0,0 +1,82 @@
+package idempotency
+
+import (
+	"sync"
+	"time"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/utils"
+)
+
+// Get returns the response sent for the request id of a player session.
+// Request ids are scoped per API path, like the D-store endpoints.
+func Get(sessionID, path, requestID string) ([]byte, bool) {
+	if requestID == "" {
+		return nil, false
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	s := sessions[sessionID]
+	if s == nil {
+		return nil, false
+	}
+
+	s.used = time.Now()
+	for ix := range s.responses {
+		if r := &s.responses[ix]; r.path == path && r.requestID == requestID {
+			return r.body, true
+		}
+	}
+	return nil, false
+}
+
+// Put remembers the response sent for the request id of a player session.
+// Only the last MaxResponses responses are kept per session.
+func Put(sessionID, path, requestID string, body []byte) {
+	if requestID == "" {
+		return
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	s := sessions[sessionID]
+	if s == nil {
+		s = &session{responses: make([]response, 0, MaxResponses)}
+		sessions[sessionID] = s
+	}
+
+	s.used = time.Now()
+	if len(s.responses) >= MaxResponses {
+		s.responses = append(s.responses[:0], s.responses[1:]...)
+	}
+	s.responses = append(s.responses, response{path: path, requestID: requestID, body: append([]byte(nil), body...)})
+}
+
+// Expire removes the sessions which have been idle for the given duration.
+// It returns the number of sessions removed.
+func Expire(idle time.Duration) int {
+	mu.Lock()
+	defer mu.Unlock()
+	return utils.ExpireIdle(sessions, idle, func(s *session) time.Time { return s.used })
+}
+
+// MaxResponses is the max number of responses kept per session.
+const MaxResponses = 16
+
+type session struct {
+	used      time.Time
+	responses []response
+}
+
+type response struct {
+	path      string
+	requestID string
+	body      []byte
+}
+
+var (
+	mu       sync.Mutex
+	sessions = make(map[string]*session, 1024)
+)
@@ -0,0 +1,54 @@
+package idempotency
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPut(t *testing.T) {
+	defer Expire(0)
+
+	_, ok := Get("s1", "/v1/round", "r1")
+	assert.False(t, ok)
+
+	Put("s1", "/v1/round", "r1", []byte(`{"a":1}`))
+	Put("s1", "/v1/round", "", []byte(`{"b":2}`))
+
+	b, ok := Get("s1", "/v1/round", "r1")
+	assert.True(t, ok)
+	assert.Equal(t, `{"a":1}`, string(b))
+
+	_, ok = Get("s1", "/v1/round/second", "r1")
+	assert.False(t, ok)
+	_, ok = Get("s2", "/v1/round", "r1")
+	assert.False(t, ok)
+	_, ok = Get("s1", "/v1/round", "")
+	assert.False(t, ok)
+}
+
+func TestMaxResponses(t *testing.T) {
+	defer Expire(0)
+
+	for ix := 0; ix <= MaxResponses; ix++ {
+		Put("s1", "/v1/round", strconv.Itoa(ix), []byte(strconv.Itoa(ix)))
+	}
+
+	_, ok := Get("s1", "/v1/round", "0")
+	assert.False(t, ok)
+
+	b, ok := Get("s1", "/v1/round", strconv.Itoa(MaxResponses))
+	assert.True(t, ok)
+	assert.Equal(t, strconv.Itoa(MaxResponses), string(b))
+}
+
+func TestExpire(t *testing.T) {
+	Put("s1", "/v1/round", "r1", []byte("1"))
+	assert.Zero(t, Expire(time.Hour))
+	assert.Equal(t, 1, Expire(0))
+
+	_, ok := Get("s1", "/v1/round", "r1")
+	assert.False(t, ok)
+}
@@ -0,0 +1,156 @@
+package jurisdiction
+
+import (
+	"errors"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/utils/conv"
+)
+
+// Profile contains the rules of a jurisdiction.
+// Durations are in milliseconds, and amounts in cents. Zero values mean there is no limit.
+type Profile struct {
+	Code              string         `json:"code"`
+	MinSpinDuration   int64          `json:"minSpinDuration,omitempty"`   // minimum time between the start of two rounds.
+	MaxBet            int64          `json:"maxBet,omitempty"`            // maximum bet per round.
+	BonusBuy          bool           `json:"bonusBuy"`                    // indicates if players can buy a bonus/free game.
+	Autoplay          bool           `json:"autoplay"`                    // indicates if autoplay is allowed.
+	AutoplayMax       int            `json:"autoplayMax,omitempty"`       // maximum number of rounds in an autoplay sequence.
+	AutoplayLossLimit bool           `json:"autoplayLossLimit,omitempty"` // indicates if autoplay requires a loss limit.
+	MaxWin            float64        `json:"maxWin,omitempty"`            // max win cap for a round as a multiple of the bet.
+	RTPs              []uint8        `json:"rtps,omitempty"`              // allowed RTP variants; empty allows all.
+	RealityCheck      int64          `json:"realityCheck,omitempty"`      // interval between reality checks.
+	Prefs             map[string]any `json:"-"`                           // additional preferences from the back-office.
+}
+
+// Clone returns a deep copy of the profile.
+func (p *Profile) Clone() *Profile {
+	out := *p
+	if p.RTPs != nil {
+		out.RTPs = append([]uint8{}, p.RTPs...)
+	}
+	if p.Prefs != nil {
+		out.Prefs = make(map[string]any, len(p.Prefs))
+		for k, v := range p.Prefs {
+			out.Prefs[k] = v
+		}
+	}
+	return &out
+}
+
+// AllowsRTP returns true if the RTP variant is allowed.
+func (p *Profile) AllowsRTP(rtp uint8) bool {
+	if len(p.RTPs) == 0 {
+		return true
+	}
+	for _, r := range p.RTPs {
+		if r == rtp {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsBet returns true if the bet is within the maximum bet.
+func (p *Profile) AllowsBet(bet int64) bool {
+	return p.MaxBet <= 0 || bet <= p.MaxBet
+}
+
+// Bets returns the bets which are allowed from the given list.
+func (p *Profile) Bets(bets []int64) []int64 {
+	if p.MaxBet <= 0 {
+		return bets
+	}
+
+	out := make([]int64, 0, len(bets))
+	for _, b := range bets {
+		if p.AllowsBet(b) {
+			out = append(out, b)
+		}
+	}
+	return out
+}
+
+// Check verifies a new round against the rules.
+// It returns ErrRTP, ErrMaxBet or ErrBonusBuy if the round is not allowed.
+func (p *Profile) Check(rtp uint8, bet int64, bonusBuy bool) error {
+	switch {
+	case !p.AllowsRTP(rtp):
+		return ErrRTP
+	case !p.AllowsBet(bet):
+		return ErrMaxBet
+	case bonusBuy && !p.BonusBuy:
+		return ErrBonusBuy
+	default:
+		return nil
+	}
+}
+
+// Currency returns the currency of the session from the back-office preferences, or DefaultCurrency if there is none.
+func (p *Profile) Currency() string {
+	if c := conv.StringFromAny(p.Prefs[keyCurrency]); c != "" {
+		return c
+	}
+	return DefaultCurrency
+}
+
+// Apply overrides the rules with the preferences from the back-office.
+// Keys which do not match a rule are kept in Prefs.
+func (p *Profile) Apply(m map[string]any) {
+	for k, v := range m {
+		switch k {
+		case keyMinSpinDuration, keySpinWait:
+			p.MinSpinDuration = int64(conv.IntFromAny(v, int(p.MinSpinDuration)))
+		case keyMaxBet:
+			p.MaxBet = int64(conv.IntFromAny(v, int(p.MaxBet)))
+		case keyBonusBuy:
+			p.BonusBuy = conv.BoolFromAny(v, p.BonusBuy)
+		case keyAutoplay:
+			p.Autoplay = conv.BoolFromAny(v, p.Autoplay)
+		case keyAutoplayMax:
+			p.AutoplayMax = conv.IntFromAny(v, p.AutoplayMax)
+		case keyAutoplayLossLimit:
+			p.AutoplayLossLimit = conv.BoolFromAny(v, p.AutoplayLossLimit)
+		case keyMaxWin:
+			p.MaxWin = conv.FloatFromAny(v, p.MaxWin)
+		case keyRTPs:
+			if l := conv.IntsFromAny(v); l != nil {
+				p.RTPs = make([]uint8, len(l))
+				for ix := range l {
+					p.RTPs[ix] = uint8(l[ix])
+				}
+			}
+		case keyRealityCheck:
+			p.RealityCheck = int64(conv.IntFromAny(v, int(p.RealityCheck)))
+		default:
+			if p.Prefs == nil {
+				p.Prefs = make(map[string]any, len(m))
+			}
+			p.Prefs[k] = v
+		}
+	}
+}
+
+const (
+	keyMinSpinDuration   = "minSpinDuration"
+	keySpinWait          = "spinWait"
+	keyMaxBet            = "maxBet"
+	keyBonusBuy          = "bonusBuy"
+	keyAutoplay          = "autoplay"
+	keyAutoplayMax       = "autoplayMax"
+	keyAutoplayLossLimit = "autoplayLossLimit"
+	keyMaxWin            = "maxWin"
+	keyRTPs              = "rtps"
+	keyRealityCheck      = "realityCheck"
+	keyCurrency          = "currency"
+)
+
+// DefaultCurrency is the currency of sessions for which the back-office does not provide one.
+const DefaultCurrency = "EUR"
+
+var (
+	ErrRTP       = errors.New("RTP variant not allowed in jurisdiction")
+	ErrMaxBet    = errors.New("bet exceeds the maximum bet of the jurisdiction")
+	ErrBonusBuy  = errors.New("bonus buy not allowed in jurisdiction")
+	ErrSpinSpeed = errors.New("round started before the minimum spin duration of the jurisdiction")
+	ErrNoCode    = errors.New("jurisdiction profile without code")
+)
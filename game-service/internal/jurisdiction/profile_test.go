@@ -0,0 +1,142 @@
+package jurisdiction
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfile_Check(t *testing.T) {
+	p := &Profile{Code: "XX", MaxBet: 100, RTPs: []uint8{92, 94}}
+
+	testCases := []struct {
+		name     string
+		rtp      uint8
+		bet      int64
+		bonusBuy bool
+		want     error
+	}{
+		{name: "ok", rtp: 92, bet: 100},
+		{name: "rtp", rtp: 96, bet: 100, want: ErrRTP},
+		{name: "max bet", rtp: 94, bet: 200, want: ErrMaxBet},
+		{name: "bonus buy", rtp: 94, bet: 50, bonusBuy: true, want: ErrBonusBuy},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, p.Check(tc.rtp, tc.bet, tc.bonusBuy))
+		})
+	}
+
+	t.Run("no limits", func(t *testing.T) {
+		p2 := &Profile{BonusBuy: true}
+		assert.NoError(t, p2.Check(96, 100000, true))
+	})
+}
+
+func TestProfile_Bets(t *testing.T) {
+	bets := []int64{10, 50, 100, 200, 500}
+	assert.Equal(t, bets, (&Profile{}).Bets(bets))
+	assert.Equal(t, []int64{10, 50, 100}, (&Profile{MaxBet: 100}).Bets(bets))
+}
+
+func TestProfile_Apply(t *testing.T) {
+	p := Get(CodeMGA)
+	p.Apply(map[string]any{
+		"spinWait":    float64(3000),
+		"maxBet":      float64(200),
+		"bonusBuy":    false,
+		"autoplayMax": "50",
+		"maxWin":      float64(5000),
+		"rtps":        []any{float64(92), float64(94)},
+		"theme":       "dark",
+	})
+
+	assert.Equal(t, CodeMGA, p.Code)
+	assert.Equal(t, int64(3000), p.MinSpinDuration)
+	assert.Equal(t, int64(200), p.MaxBet)
+	assert.False(t, p.BonusBuy)
+	assert.True(t, p.Autoplay)
+	assert.Equal(t, 50, p.AutoplayMax)
+	assert.Equal(t, 5000.0, p.MaxWin)
+	assert.Equal(t, []uint8{92, 94}, p.RTPs)
+	assert.Equal(t, map[string]any{"theme": "dark"}, p.Prefs)
+
+	// the registered profile must not be modified.
+	p2 := Get(CodeMGA)
+	assert.Equal(t, int64(1000), p2.MinSpinDuration)
+	assert.True(t, p2.BonusBuy)
+	assert.Nil(t, p2.Prefs)
+}
+
+func TestProfile_Currency(t *testing.T) {
+	p := Get(CodeMGA)
+	assert.Equal(t, DefaultCurrency, p.Currency())
+
+	p.Apply(map[string]any{"currency": "GBP"})
+	assert.Equal(t, "GBP", p.Currency())
+}
+
+func TestResolve(t *testing.T) {
+	testCases := []struct {
+		name   string
+		casino string
+		code   string
+		locale string
+		want   string
+	}{
+		{name: "default", want: CodeUKGC},
+		{name: "english", locale: "en-GB", want: CodeUKGC},
+		{name: "italian", locale: "it-IT", want: CodeADM},
+		{name: "code", code: CodeGGL, locale: "it-IT", want: CodeGGL},
+		{name: "unknown code", code: "XX", want: "XX"},
+		{name: "unknown casino", casino: "c1", locale: "it", want: CodeADM},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := Resolve(tc.casino, tc.code, tc.locale)
+			require.NotNil(t, p)
+			assert.Equal(t, tc.want, p.Code)
+		})
+	}
+
+	t.Run("unknown code gets default rules", func(t *testing.T) {
+		p := Resolve("", "XX", "")
+		assert.Equal(t, Get(CodeDefault).MinSpinDuration, p.MinSpinDuration)
+	})
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jurisdictions.json")
+
+	t.Run("no file", func(t *testing.T) {
+		assert.Error(t, Load(path))
+	})
+
+	t.Run("no code", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(path, []byte(`{"profiles":[{"maxBet":100}]}`), 0o600))
+		assert.ErrorIs(t, Load(path), ErrNoCode)
+	})
+
+	t.Run("load", func(t *testing.T) {
+		data := `{"profiles":[{"code":"SGA","minSpinDuration":3000,"bonusBuy":false,"rtps":[94]}],"casinos":{"casino-se":"SGA"}}`
+		require.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+		require.NoError(t, Load(path))
+		assert.Contains(t, Codes(), "SGA")
+
+		p := Resolve("casino-se", "", "en")
+		require.NotNil(t, p)
+		assert.Equal(t, "SGA", p.Code)
+		assert.Equal(t, int64(3000), p.MinSpinDuration)
+		assert.False(t, p.BonusBuy)
+		assert.True(t, p.AllowsRTP(94))
+		assert.False(t, p.AllowsRTP(96))
+
+		// the code from the back-office has priority.
+		assert.Equal(t, CodeGGL, Resolve("casino-se", CodeGGL, "en").Code)
+	})
+}
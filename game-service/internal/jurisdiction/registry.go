@@ -0,0 +1,136 @@
+package jurisdiction
+
+import (
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/goccy/go-json"
+)
+
+// Config contains the profiles and casino jurisdictions loaded from a file.
+type Config struct {
+	Profiles []*Profile        `json:"profiles"`
+	Casinos  map[string]string `json:"casinos"` // jurisdiction code for each casino ID.
+}
+
+// Load reads the jurisdiction profiles and casino jurisdictions from a JSON file.
+// Profiles replace the built-in profile with the same code.
+func Load(path string) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	cfg := &Config{}
+	if err = json.Unmarshal(b, cfg); err != nil {
+		return err
+	}
+
+	for _, p := range cfg.Profiles {
+		if p == nil || p.Code == "" {
+			return ErrNoCode
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	for _, p := range cfg.Profiles {
+		profiles[p.Code] = p
+	}
+	for k, v := range cfg.Casinos {
+		casinos[k] = v
+	}
+	return nil
+}
+
+// Codes returns the codes of the known jurisdictions.
+func Codes() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	out := make([]string, 0, len(profiles))
+	for k := range profiles {
+		out = append(out, k)
+	}
+	return out
+}
+
+// Get returns a copy of the profile for the given jurisdiction code.
+// Unknown jurisdictions get the rules of the default jurisdiction, with the given code.
+func Get(code string) *Profile {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if p := profiles[code]; p != nil {
+		return p.Clone()
+	}
+
+	p := profiles[CodeDefault].Clone()
+	if code != "" {
+		p.Code = code
+	}
+	return p
+}
+
+// Resolve returns a copy of the profile for a player session.
+// The jurisdiction code from the back-office has priority, followed by the jurisdiction configured for the casino.
+// Without either, the jurisdiction is derived from the locale of the player: ADM for Italian, UKGC for all others.
+func Resolve(casinoID, code, locale string) *Profile {
+	if code == "" && casinoID != "" {
+		mu.RLock()
+		code = casinos[casinoID]
+		mu.RUnlock()
+	}
+
+	if code == "" {
+		code = CodeUKGC
+		if strings.HasPrefix(locale, "it") {
+			code = CodeADM
+		}
+	}
+
+	return Get(code)
+}
+
+const (
+	CodeDefault = CodeMGA
+	CodeMGA     = "MGA"
+	CodeUKGC    = "UKGC"
+	CodeADM     = "ADM"
+	CodeGGL     = "GGL"
+)
+
+var (
+	mu sync.RWMutex
+
+	profiles = map[string]*Profile{
+		CodeMGA: {
+			Code:            CodeMGA,
+			MinSpinDuration: 1000,
+			BonusBuy:        true,
+			Autoplay:        true,
+		},
+		CodeUKGC: {
+			Code:            CodeUKGC,
+			MinSpinDuration: 2500,
+			BonusBuy:        true,
+			RealityCheck:    60 * 60 * 1000,
+		},
+		CodeADM: {
+			Code:            CodeADM,
+			MinSpinDuration: 1000,
+			BonusBuy:        true,
+			Autoplay:        true,
+		},
+		CodeGGL: {
+			Code:            CodeGGL,
+			MinSpinDuration: 5000,
+			MaxBet:          100,
+			RealityCheck:    60 * 60 * 1000,
+		},
+	}
+
+	casinos = make(map[string]string)
+)
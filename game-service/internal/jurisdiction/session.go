@@ -0,0 +1,92 @@
+package jurisdiction
+
+import (
+	"sync"
+	"time"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/utils"
+)
+
+// Session returns the cached profile for a player session, or nil if it is not cached.
+func Session(sessionID string) *Profile {
+	sessionsMu.RLock()
+	defer sessionsMu.RUnlock()
+
+	if s := sessions[sessionID]; s != nil {
+		return s.profile
+	}
+	return nil
+}
+
+// SetSession caches the profile for a player session.
+// The profile must not be modified after it is cached.
+func SetSession(sessionID string, p *Profile) {
+	sessionsMu.Lock()
+	defer sessionsMu.Unlock()
+
+	if s := sessions[sessionID]; s != nil {
+		s.profile = p
+		s.used = time.Now()
+		return
+	}
+	sessions[sessionID] = &session{profile: p, used: time.Now()}
+}
+
+// CheckRound verifies that a new round may start for a player session.
+// It returns ErrSpinSpeed if the previous round started less than the minimum spin duration ago.
+// It does not record the start of the round; see RecordRound.
+func CheckRound(sessionID string, p *Profile, now time.Time) error {
+	if p.MinSpinDuration <= 0 {
+		return nil
+	}
+
+	sessionsMu.RLock()
+	defer sessionsMu.RUnlock()
+
+	if s := sessions[sessionID]; s != nil && !s.started.IsZero() {
+		if now.Sub(s.started) < time.Duration(p.MinSpinDuration)*time.Millisecond-spinTolerance {
+			return ErrSpinSpeed
+		}
+	}
+	return nil
+}
+
+// RecordRound records the start of a round for a player session.
+// It must only be called once the round has been validated, so rejected or failed rounds do not count
+// against the minimum spin duration.
+// The timestamps are kept in memory, so the minimum spin duration is only enforced per service instance.
+func RecordRound(sessionID string, p *Profile, started time.Time) {
+	sessionsMu.Lock()
+	defer sessionsMu.Unlock()
+
+	s := sessions[sessionID]
+	if s == nil {
+		s = &session{profile: p}
+		sessions[sessionID] = s
+	}
+
+	s.started = started
+	s.used = time.Now()
+}
+
+// Expire removes the sessions which have been idle for the given duration.
+// It returns the number of sessions removed.
+func Expire(idle time.Duration) int {
+	sessionsMu.Lock()
+	defer sessionsMu.Unlock()
+	return utils.ExpireIdle(sessions, idle, func(s *session) time.Time { return s.used })
+}
+
+// spinTolerance allows for network jitter between the requests of consecutive rounds.
+const spinTolerance = 200 * time.Millisecond
+
+type session struct {
+	profile *Profile
+	started time.Time // start of the last round.
+	used    time.Time
+}
+
+var (
+	sessionsMu sync.RWMutex
+	sessions   = make(map[string]*session, 1024)
+)
@@ -0,0 +1,50 @@
+package jurisdiction
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSession(t *testing.T) {
+	p := Get(CodeUKGC)
+
+	assert.Nil(t, Session("s1"))
+	SetSession("s1", p)
+	assert.Equal(t, p, Session("s1"))
+
+	assert.Zero(t, Expire(time.Hour))
+	assert.Equal(t, p, Session("s1"))
+
+	assert.Equal(t, 1, Expire(0))
+	assert.Nil(t, Session("s1"))
+}
+
+func TestCheckRound(t *testing.T) {
+	p := &Profile{Code: "XX", MinSpinDuration: 2500}
+	now := time.Now()
+
+	require.NoError(t, CheckRound("s2", p, now))
+	RecordRound("s2", p, now)
+	assert.Equal(t, ErrSpinSpeed, CheckRound("s2", p, now.Add(time.Second)))
+	assert.NoError(t, CheckRound("s2", p, now.Add(2500*time.Millisecond-spinTolerance)))
+
+	// a round that is checked but not recorded, e.g. because it was rejected, does not count.
+	assert.NoError(t, CheckRound("s2", p, now.Add(3*time.Second)))
+	assert.NoError(t, CheckRound("s2", p, now.Add(3*time.Second)))
+
+	RecordRound("s2", p, now.Add(3*time.Second))
+	assert.Equal(t, ErrSpinSpeed, CheckRound("s2", p, now.Add(5*time.Second)))
+	assert.NoError(t, CheckRound("s2", p, now.Add(6*time.Second)))
+
+	t.Run("no minimum", func(t *testing.T) {
+		p2 := &Profile{Code: "YY"}
+		require.NoError(t, CheckRound("s3", p2, now))
+		RecordRound("s3", p2, now)
+		assert.NoError(t, CheckRound("s3", p2, now))
+	})
+
+	Expire(0)
+}
@@ -0,0 +1,147 @@
+package limits
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	mngr "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-manager.git/state/slots"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/go-utils.git/tg"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/events"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/utils"
+)
+
+// Limits contains the responsible gambling limits for a player session.
+// Durations are in milliseconds, and amounts in cents. Zero values mean there is no limit.
+type Limits struct {
+	LossLimit    int64 `json:"lossLimit,omitempty"`    // maximum net loss during the session.
+	TimeLimit    int64 `json:"timeLimit,omitempty"`    // maximum play time from the first round of the session.
+	RealityCheck int64 `json:"realityCheck,omitempty"` // interval between reality checks.
+}
+
+// SetLimits sets the limits for a player session, as retrieved from the back-office.
+// The play of the session is not kept here; it is stored with the game state of the session (see mngr.PlayTotals),
+// so it is shared by all instances of the service.
+func SetLimits(sessionID string, l Limits) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	s := get(sessionID)
+	s.limits = l
+}
+
+// Get returns the limits of a player session, or false if the session is unknown.
+func Get(sessionID string) (Limits, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if s := sessions[sessionID]; s != nil {
+		return s.limits, true
+	}
+	return Limits{}, false
+}
+
+// Check verifies if a round with the given total bet can be played after the play of the session so far.
+// It returns ErrTimeLimit if the play time is exhausted, or ErrLossLimit if the bet could exceed the loss limit.
+// The first time a limit is hit, a message is raised for the session.
+func Check(sessionID string, play mngr.PlayTotals, bet int64, now time.Time) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	s := get(sessionID)
+	l := &s.limits
+
+	if l.TimeLimit > 0 && play.Elapsed(now) >= time.Duration(l.TimeLimit)*time.Millisecond {
+		if !s.timeNotified {
+			s.timeNotified = true
+			raise(sessionID, tg.MsgTimeLimitReached, play, now)
+		}
+		return ErrTimeLimit
+	}
+
+	if l.LossLimit > 0 && play.NetLoss()+bet > l.LossLimit {
+		if !s.lossNotified {
+			s.lossNotified = true
+			raise(sessionID, tg.MsgLossLimitReached, play, now)
+		}
+		return ErrLossLimit
+	}
+
+	return nil
+}
+
+// CheckDue returns true if the reality check interval has elapsed since the previous reality check of a player session.
+// In that case the reality check is marked as done in the play, which is then stored with the next round,
+// and RealityCheck must be called once the round is stored.
+func CheckDue(sessionID string, play *mngr.PlayTotals, now time.Time) bool {
+	if play.Checked.IsZero() {
+		return false
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	s := get(sessionID)
+	if i := s.limits.RealityCheck; i > 0 && now.Sub(play.Checked) >= time.Duration(i)*time.Millisecond {
+		play.Checked = now
+		return true
+	}
+	return false
+}
+
+// RealityCheck raises a reality check message with the play of a player session, including the round just stored.
+func RealityCheck(sessionID string, play mngr.PlayTotals, now time.Time) {
+	raise(sessionID, tg.MsgRealityCheck, play, now)
+}
+
+// Expire removes the sessions which have been idle for the given duration.
+// It returns the number of sessions removed.
+func Expire(idle time.Duration) int {
+	mu.Lock()
+	defer mu.Unlock()
+	return utils.ExpireIdle(sessions, idle, func(s *session) time.Time { return s.used })
+}
+
+// get returns the session; the caller must hold the lock.
+func get(sessionID string) *session {
+	s := sessions[sessionID]
+	if s == nil {
+		s = &session{}
+		sessions[sessionID] = s
+	}
+	s.used = time.Now()
+	return s
+}
+
+// session contains the limits of a session, and whether a message was raised for them by this instance of the service.
+type session struct {
+	limits       Limits
+	used         time.Time
+	lossNotified bool
+	timeNotified bool
+}
+
+// raise adds a message with the play of the session so far to the events queue.
+func raise(sessionID string, kind tg.MessageKind, play mngr.PlayTotals, now time.Time) {
+	msg := events.NewMessage(kind, tg.DisplayModal, now, messageTTL).
+		WithData("elapsed", play.Elapsed(now).Milliseconds()).
+		WithData("rounds", play.Rounds).
+		WithData("bets", play.Bets).
+		WithData("wins", play.Wins).
+		WithData("netLoss", play.NetLoss())
+
+	go events.AddMessage(sessionID, msg)
+}
+
+const messageTTL = 300 // seconds.
+
+var (
+	mu       sync.RWMutex
+	sessions = make(map[string]*session, 1024)
+)
+
+var (
+	ErrLossLimit = errors.New("loss limit reached")
+	ErrTimeLimit = errors.New("session time limit reached")
+)
@@ -0,0 +1,130 @@
+package limits
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mngr "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-manager.git/state/slots"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-service/internal/events"
+)
+
+func TestLossLimit(t *testing.T) {
+	const sessionID = "loss-limit"
+	defer cleanup(sessionID)
+
+	now := time.Now()
+	SetLimits(sessionID, Limits{LossLimit: 500})
+
+	var play mngr.PlayTotals
+	require.NoError(t, Check(sessionID, play, 100, now))
+	play.Add(100, 0, now)
+	play.Add(100, 250, now)
+	play.Add(100, 0, now)
+
+	assert.Equal(t, int64(3), play.Rounds)
+	assert.Equal(t, int64(300), play.Bets)
+	assert.Equal(t, int64(250), play.Wins)
+	assert.Equal(t, int64(50), play.NetLoss())
+
+	require.NoError(t, Check(sessionID, play, 450, now))
+	assert.Equal(t, ErrLossLimit, Check(sessionID, play, 451, now))
+	assert.Equal(t, ErrLossLimit, Check(sessionID, play, 500, now))
+
+	require.Eventually(t, func() bool { return len(events.GetMessages(sessionID)) == 1 }, time.Second, time.Millisecond)
+}
+
+func TestTimeLimit(t *testing.T) {
+	const sessionID = "time-limit"
+	defer cleanup(sessionID)
+
+	now := time.Now()
+	SetLimits(sessionID, Limits{TimeLimit: 60000})
+
+	var play mngr.PlayTotals
+	require.NoError(t, Check(sessionID, play, 100, now))
+	play.Add(100, 0, now)
+
+	require.NoError(t, Check(sessionID, play, 100, now.Add(59*time.Second)))
+	assert.Equal(t, ErrTimeLimit, Check(sessionID, play, 100, now.Add(time.Minute)))
+	assert.Equal(t, ErrTimeLimit, Check(sessionID, play, 100, now.Add(2*time.Minute)))
+
+	require.Eventually(t, func() bool { return len(events.GetMessages(sessionID)) == 1 }, time.Second, time.Millisecond)
+}
+
+func TestRealityCheck(t *testing.T) {
+	const sessionID = "reality-check"
+	defer cleanup(sessionID)
+
+	now := time.Now()
+	SetLimits(sessionID, Limits{RealityCheck: 60000})
+
+	var play mngr.PlayTotals
+	var due []int
+	for ix := 0; ix < 10; ix++ {
+		require.NoError(t, Check(sessionID, play, 100, now))
+		if CheckDue(sessionID, &play, now) {
+			due = append(due, ix)
+		}
+		play.Add(100, 50, now)
+		if len(due) > 0 && due[len(due)-1] == ix {
+			RealityCheck(sessionID, play, now)
+		}
+		now = now.Add(25 * time.Second)
+	}
+
+	// checks after 75s, 150s and 225s.
+	assert.Equal(t, []int{3, 6, 9}, due)
+	require.Eventually(t, func() bool { return len(events.GetMessages(sessionID)) == 3 }, time.Second, time.Millisecond)
+}
+
+func TestStoredPlay(t *testing.T) {
+	const sessionID = "stored-play"
+	defer cleanup(sessionID)
+
+	now := time.Now()
+	SetLimits(sessionID, Limits{LossLimit: 500, TimeLimit: 60000})
+
+	// the play is taken from the game state, e.g. as stored by another instance of the service.
+	play := mngr.PlayTotals{Started: now.Add(-30 * time.Second), Checked: now, Rounds: 10, Bets: 1000, Wins: 600}
+	assert.Equal(t, ErrLossLimit, Check(sessionID, play, 101, now))
+	assert.NoError(t, Check(sessionID, play, 100, now))
+	assert.Equal(t, ErrTimeLimit, Check(sessionID, play, 100, now.Add(30*time.Second)))
+}
+
+func TestNoLimits(t *testing.T) {
+	const sessionID = "no-limits"
+	defer cleanup(sessionID)
+
+	now := time.Now()
+	var play mngr.PlayTotals
+	for ix := 0; ix < 100; ix++ {
+		require.NoError(t, Check(sessionID, play, 1000, now))
+		assert.False(t, CheckDue(sessionID, &play, now))
+		play.Add(1000, 0, now)
+		now = now.Add(time.Hour)
+	}
+
+	assert.Empty(t, events.GetMessages(sessionID))
+}
+
+func TestExpire(t *testing.T) {
+	SetLimits("expire", Limits{LossLimit: 100})
+
+	l, ok := Get("expire")
+	assert.True(t, ok)
+	assert.Equal(t, int64(100), l.LossLimit)
+	assert.Zero(t, Expire(time.Hour))
+
+	assert.Equal(t, 1, Expire(0))
+	_, ok = Get("expire")
+	assert.False(t, ok)
+}
+
+func cleanup(sessionID string) {
+	Expire(0)
+	events.CommitMessages(sessionID, events.GetMessages(sessionID))
+}
@@ -8,6 +8,7 @@ const (
 	ApiPing metrics.DurationType = iota
 	ApiBinHashes
 	ApiGameHash
+	ApiGames
 	ApiStrings
 	ApiPlural
 	ApiPlurals
@@ -27,6 +28,16 @@ const (
 	ApiRngConditions
 	ApiRngMagicTest
 	ApiRngMagic
+	ApiRngHealth
+	ApiCampaign
+	ApiAutoplay
+	ApiAutoplayStop
+	ApiAutoplayStatus
+	ApiFair
+	ApiFairRotate
+	ApiAudit
+	ApiJackpot
+	ApiRoundGamble
 	GeNewGame
 	GeRound
 	GeRoundResume
@@ -51,13 +62,22 @@ const (
 	DsGamePrefsGet
 	DsPlayerPrefsPut
 	DsPlayerPrefsGet
-	MaxDuration = DsPlayerPrefsGet
+	DsCampaignPut
+	DsCampaignsGet
+	DsJackpotPut
+	DsJackpotsGet
+	DsRoundGet
+	DsRoundGamble
+	DsFairSeedPut
+	DsFairSeedGet
+	MaxDuration = DsFairSeedGet
 )
 
 var durationNames = []string{
 	"API ping",
 	"API bin-hashes",
 	"API game hash",
+	"API games",
 	"API strings",
 	"API plural",
 	"API plurals",
@@ -77,6 +97,16 @@ var durationNames = []string{
 	"API rng-conditions-lu",
 	"API rng-magic test",
 	"API rng-magic",
+	"API rng-health",
+	"API campaign",
+	"API autoplay",
+	"API autoplay stop",
+	"API autoplay status",
+	"API fair",
+	"API fair rotate",
+	"API audit",
+	"API jackpot",
+	"API round gamble",
 	"GE new game",
 	"GE round",
 	"GE round resume",
@@ -101,4 +131,12 @@ var durationNames = []string{
 	"DS get game-prefs",
 	"DS put player-prefs",
 	"DS get player-prefs",
+	"DS put campaign",
+	"DS get campaigns",
+	"DS put jackpot",
+	"DS get jackpots",
+	"DS get round",
+	"DS round gamble",
+	"DS put fair-seed",
+	"DS get fair-seed",
 }
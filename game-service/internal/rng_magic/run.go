@@ -6,17 +6,6 @@ import (
 	"sync"
 	"time"
 
-	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/slots/ber"
-	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/slots/bot"
-	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/slots/btr"
-	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/slots/ccb"
-	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/slots/fpr"
-	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/slots/frm"
-	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/slots/lam"
-	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/slots/mgd"
-	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/slots/ofg"
-	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-config.git/slots/owl"
-	magic "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/analysis/simulation/slots"
 	comp "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/components/slots"
 	game "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/games/slots"
 	rslt "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-engine.git/results"
@@ -39,35 +28,14 @@ func RunRngStatement(g *game.Regular, gameNR tg.GameNR, statement string, count,
 		return nil, err
 	}
 
-	var newMatcher func(key string, params map[string]any, game *game.Regular) magic.Matcher
-	switch gameNR {
-	case tg.BOTnr:
-		newMatcher = bot.MakeMatcher
-	case tg.CCBnr:
-		newMatcher = ccb.MakeMatcher
-	case tg.MGDnr:
-		newMatcher = mgd.MakeMatcher
-	case tg.LAMnr:
-		newMatcher = lam.MakeMatcher
-	case tg.OWLnr:
-		newMatcher = owl.MakeMatcher
-	case tg.FRMnr:
-		newMatcher = frm.MakeMatcher
-	case tg.OFGnr:
-		newMatcher = ofg.MakeMatcher
-	case tg.FPRnr:
-		newMatcher = fpr.MakeMatcher
-	case tg.BTRnr:
-		newMatcher = btr.MakeMatcher
-	case tg.BERnr:
-		newMatcher = ber.MakeMatcher
-	default:
+	reg := registered(gameNR)
+	if reg == nil {
 		return nil, fmt.Errorf("cannot find MakeMatcher() function")
 	}
 
 	matcher.Init(FunctionInitParams{
 		g:          g,
-		newMatcher: newMatcher,
+		newMatcher: reg.MakeMatcher,
 		conditions: conditions,
 	})
 
@@ -0,0 +1,77 @@
+package roundlock
+
+import (
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// Leaser is implemented by a round manager which grants leases on sessions across service instances.
+type Leaser interface {
+	AcquireLease(sessionID, owner string, ttl time.Duration) (bool, error)
+	ReleaseLease(sessionID, owner string) error
+}
+
+// NewLease instantiates a locker for multiple service instances.
+// Rounds are first serialised locally, after which a lease on the session is acquired from the leaser.
+// The leaser is resolved for each lock, as the round manager may be set up after the locker.
+// A lease expires after the ttl, so a session is not locked forever when a service instance dies mid-round.
+// Acquiring the lease is retried at the given interval, until the wait time runs out.
+func NewLease(leaser func() Leaser, clientID string, ttl, wait, retry time.Duration) Locker {
+	if retry <= 0 {
+		retry = 50 * time.Millisecond
+	}
+	return &lease{
+		local:    NewLocal(wait),
+		leaser:   leaser,
+		clientID: clientID + "-",
+		ttl:      ttl,
+		wait:     wait,
+		retry:    retry,
+	}
+}
+
+// Lock implements the Locker interface.
+func (l *lease) Lock(sessionID string) (func(), error) {
+	start := time.Now()
+
+	unlock, err := l.local.Lock(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	m := l.leaser()
+	owner := l.clientID + strconv.FormatUint(l.seq.Add(1), 36)
+
+	for {
+		ok, err2 := m.AcquireLease(sessionID, owner, l.ttl)
+		if err2 != nil {
+			unlock()
+			return nil, err2
+		}
+
+		if ok {
+			return func() {
+				_ = m.ReleaseLease(sessionID, owner)
+				unlock()
+			}, nil
+		}
+
+		if time.Since(start)+l.retry > l.wait {
+			unlock()
+			return nil, ErrBusy
+		}
+
+		time.Sleep(l.retry)
+	}
+}
+
+type lease struct {
+	local    Locker
+	leaser   func() Leaser
+	clientID string
+	ttl      time.Duration
+	wait     time.Duration
+	retry    time.Duration
+	seq      atomic.Uint64
+}
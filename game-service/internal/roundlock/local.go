@@ -0,0 +1,71 @@
+package roundlock
+
+import (
+	"sync"
+	"time"
+)
+
+// NewLocal instantiates a locker for a single service instance.
+// Rounds for the same session wait up to the given time for each other; with a zero wait time they are rejected immediately.
+func NewLocal(wait time.Duration) Locker {
+	return &local{wait: wait, sessions: make(map[string]*entry, 1024)}
+}
+
+// Lock implements the Locker interface.
+func (l *local) Lock(sessionID string) (func(), error) {
+	l.mu.Lock()
+	e := l.sessions[sessionID]
+	if e == nil {
+		e = &entry{ch: make(chan struct{}, 1)}
+		l.sessions[sessionID] = e
+	}
+	e.refs++
+	l.mu.Unlock()
+
+	select {
+	case e.ch <- struct{}{}:
+		return func() { l.unlock(sessionID, e) }, nil
+	default:
+	}
+
+	if l.wait > 0 {
+		t := time.NewTimer(l.wait)
+		defer t.Stop()
+
+		select {
+		case e.ch <- struct{}{}:
+			return func() { l.unlock(sessionID, e) }, nil
+		case <-t.C:
+		}
+	}
+
+	l.release(sessionID, e)
+	return nil, ErrBusy
+}
+
+// unlock unlocks the session.
+func (l *local) unlock(sessionID string, e *entry) {
+	<-e.ch
+	l.release(sessionID, e)
+}
+
+// release removes the session when it is no longer in use.
+func (l *local) release(sessionID string, e *entry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if e.refs--; e.refs == 0 {
+		delete(l.sessions, sessionID)
+	}
+}
+
+type local struct {
+	wait     time.Duration
+	mu       sync.Mutex
+	sessions map[string]*entry
+}
+
+type entry struct {
+	ch   chan struct{}
+	refs int
+}
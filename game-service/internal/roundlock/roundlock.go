@@ -0,0 +1,42 @@
+package roundlock
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// Locker serialises the rounds of player sessions.
+type Locker interface {
+	// Lock locks the session, waiting for a round in progress for the session to finish.
+	// It returns the function to unlock the session, or ErrBusy if the session is still locked after the wait time.
+	Lock(sessionID string) (func(), error)
+}
+
+// Use sets the locker for the rounds of player sessions.
+func Use(l Locker) {
+	mu.Lock()
+	defer mu.Unlock()
+	current = l
+}
+
+// Lock locks the session with the current locker.
+// It returns the function to unlock the session, or ErrBusy if the session is still locked after the wait time.
+func Lock(sessionID string) (func(), error) {
+	mu.RLock()
+	l := current
+	mu.RUnlock()
+	return l.Lock(sessionID)
+}
+
+// DefaultWait is the default time to wait for a round in progress for the same session.
+const DefaultWait = 2 * time.Second
+
+var (
+	mu      sync.RWMutex
+	current Locker = NewLocal(DefaultWait)
+)
+
+var (
+	ErrBusy = errors.New("another round is in progress for the session")
+)
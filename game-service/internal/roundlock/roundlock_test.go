@@ -0,0 +1,125 @@
+package roundlock
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	store "git-codecommit.eu-central-1.amazonaws.com/v1/repos/game-manager.git/repository/slots"
+)
+
+func TestLocal(t *testing.T) {
+	l := NewLocal(0).(*local)
+
+	unlock, err := l.Lock("s1")
+	require.NoError(t, err)
+	require.NotNil(t, unlock)
+
+	_, err = l.Lock("s1")
+	assert.Equal(t, ErrBusy, err)
+
+	unlock2, err := l.Lock("s2")
+	require.NoError(t, err)
+
+	unlock()
+	unlock, err = l.Lock("s1")
+	require.NoError(t, err)
+
+	unlock()
+	unlock2()
+	assert.Empty(t, l.sessions)
+}
+
+func TestLocalWait(t *testing.T) {
+	l := NewLocal(time.Second).(*local)
+
+	unlock, err := l.Lock("s1")
+	require.NoError(t, err)
+
+	time.AfterFunc(20*time.Millisecond, unlock)
+
+	started := time.Now()
+	unlock, err = l.Lock("s1")
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(started), 20*time.Millisecond)
+
+	unlock()
+	assert.Empty(t, l.sessions)
+}
+
+func TestLocalSerialised(t *testing.T) {
+	l := NewLocal(5 * time.Second).(*local)
+
+	var active, maxActive atomic.Int32
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			unlock, err := l.Lock("s1")
+			require.NoError(t, err)
+			defer unlock()
+
+			n := active.Add(1)
+			if n > maxActive.Load() {
+				maxActive.Store(n)
+			}
+			time.Sleep(time.Millisecond)
+			active.Add(-1)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), maxActive.Load())
+	assert.Empty(t, l.sessions)
+}
+
+func TestLease(t *testing.T) {
+	m := store.NewMemory()
+	leaser := func() Leaser { return m }
+
+	// two service instances sharing the round manager.
+	l1 := NewLease(leaser, "pod1", time.Minute, 0, 0)
+	l2 := NewLease(leaser, "pod2", time.Minute, 50*time.Millisecond, 10*time.Millisecond)
+
+	unlock, err := l1.Lock("s1")
+	require.NoError(t, err)
+
+	started := time.Now()
+	_, err = l2.Lock("s1")
+	assert.Equal(t, ErrBusy, err)
+	assert.GreaterOrEqual(t, time.Since(started), 40*time.Millisecond)
+
+	unlock2, err := l2.Lock("s2")
+	require.NoError(t, err)
+
+	unlock()
+	unlock, err = l2.Lock("s1")
+	require.NoError(t, err)
+
+	unlock()
+	unlock2()
+}
+
+func TestLeaseExpired(t *testing.T) {
+	m := store.NewMemory()
+	leaser := func() Leaser { return m }
+
+	l1 := NewLease(leaser, "pod1", 10*time.Millisecond, 0, 0)
+	l2 := NewLease(leaser, "pod2", time.Minute, 0, 0)
+
+	unlock, err := l1.Lock("s1")
+	require.NoError(t, err)
+	defer unlock()
+
+	time.Sleep(20 * time.Millisecond)
+
+	unlock2, err := l2.Lock("s1")
+	require.NoError(t, err)
+	unlock2()
+}
@@ -1,6 +1,7 @@
 package state
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -19,10 +20,11 @@ import (
 )
 
 var (
-	DstoreHost     = ""
-	ApiKey         = "[none]"
-	DefaultTimeout = 10 * time.Second
-	Manager        manager.RoundManager
+	DstoreHost      = ""
+	ApiKey          = "[none]"
+	DefaultTimeout  = 10 * time.Second
+	EmbeddedBalance = int64(1000000) // start balance of new sessions in the embedded store.
+	Manager         manager.RoundManager
 )
 
 func GetGameState(sessionID string) *manager.GameState {
@@ -111,12 +113,30 @@ func Setup() {
 		}
 	}
 
-	if DstoreHost == "" {
+	if s := os.Getenv(consts.EnvEmbeddedBalance); s != "" {
+		if i, err := strconv.ParseInt(s, 10, 64); err == nil && i > 0 {
+			EmbeddedBalance = i
+		}
+	}
+
+	switch {
+	case DstoreHost != "":
+		Manager = store.NewDStore(DstoreHost, ApiKey, DefaultTimeout, rt, log.Logger, log.DsReq)
+		log.Logger.Info(consts.MsgValidationSetup, consts.FieldURI, DstoreHost)
+
+	case os.Getenv(consts.EnvEmbeddedStore) != "":
+		file := os.Getenv(consts.EnvEmbeddedStore)
+		m, err := store.NewEmbedded(context.Background(), file, EmbeddedBalance, 0)
+		if err != nil {
+			log.Logger.Panic(consts.MsgEmbeddedFailed, consts.FieldFile, file, consts.FieldError, err)
+		}
+		Manager = m
+		log.Logger.Warn(consts.MsgEmbeddedStore, consts.FieldFile, file)
+		metrics.PrintMetrics = 1 * time.Minute
+
+	default:
 		Manager = store.NewMemory()
 		log.Logger.Warn(consts.MsgNoValidation)
 		metrics.PrintMetrics = 1 * time.Minute
-	} else {
-		Manager = store.NewDStore(DstoreHost, ApiKey, DefaultTimeout, rt, log.Logger, log.DsReq)
-		log.Logger.Info(consts.MsgValidationSetup, consts.FieldURI, DstoreHost)
 	}
 }
@@ -0,0 +1,36 @@
+package utils
+
+import (
+	"context"
+	"time"
+)
+
+// ExpireIdle removes the entries of the map which have been idle for the given duration.
+// The used function returns the time an entry was last used. The caller must hold the lock of the map.
+// It returns the number of entries removed.
+func ExpireIdle[K comparable, V any](m map[K]V, idle time.Duration, used func(V) time.Time) int {
+	var n int
+	limit := time.Now().Add(-idle)
+	for k, v := range m {
+		if used(v).Before(limit) {
+			delete(m, k)
+			n++
+		}
+	}
+	return n
+}
+
+// Cleanup periodically calls expire with the given idle duration until the context is done.
+func Cleanup(ctx context.Context, idle time.Duration, expire func(idle time.Duration) int) {
+	ticker := time.NewTicker(idle / 4)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			expire(idle)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpireIdle(t *testing.T) {
+	now := time.Now()
+	m := map[string]time.Time{"old": now.Add(-time.Hour), "new": now}
+	used := func(t time.Time) time.Time { return t }
+
+	assert.Zero(t, ExpireIdle(m, 2*time.Hour, used))
+	assert.Equal(t, 1, ExpireIdle(m, time.Minute, used))
+	assert.Equal(t, map[string]time.Time{"new": now}, m)
+}
+
+func TestCleanup(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var calls atomic.Int32
+	done := make(chan struct{})
+	go func() {
+		Cleanup(ctx, 40*time.Millisecond, func(idle time.Duration) int {
+			assert.Equal(t, 40*time.Millisecond, idle)
+			calls.Add(1)
+			return 0
+		})
+		close(done)
+	}()
+
+	assert.Eventually(t, func() bool { return calls.Load() >= 2 }, time.Second, time.Millisecond)
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("cleanup did not stop")
+	}
+}
@@ -19,12 +19,22 @@ const (
 	MsgSessionExpires MessageKind = iota + 1
 	MsgSessionExpired
 	MsgComplexRoundTimeout
+	MsgRealityCheck
+	MsgLossLimitReached
+	MsgTimeLimitReached
+	MsgAutoplayRound
+	MsgAutoplayStopped
 )
 
 var messageCodes = []string{
 	"message.session-expires",
 	"message.session-expired",
 	"message.complex-round-timeout",
+	"message.reality-check",
+	"message.loss-limit-reached",
+	"message.time-limit-reached",
+	"message.autoplay-round",
+	"message.autoplay-stopped",
 }
 
 // String implements the Stringer interface and returns the i18n message code.
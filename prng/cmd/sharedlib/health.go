@@ -0,0 +1,42 @@
+package main
+
+import "C"
+
+import (
+	"unsafe"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/prng.git/rng"
+)
+
+// EnableHealthTests enables (enabled != 0) or disables (enabled == 0) the online health tests for all RNGs.
+//export EnableHealthTests
+func EnableHealthTests(enabled int) {
+	rng.EnableHealthTests(enabled != 0)
+}
+
+// GetHealth fills the out slice with the health test counters, in the order:
+// enabled (0 or 1), buffers, samples, repetition count test failures, adaptive proportion test failures.
+// If the slice is shorter, only the first counters are returned.
+//export GetHealth
+func GetHealth(a *uint64, aLen int) {
+	h := rng.HealthStatus()
+
+	var enabled uint64
+	if h.Enabled {
+		enabled = 1
+	}
+
+	if aLen > healthCounters {
+		aLen = healthCounters
+	}
+	out := (*[healthCounters]uint64)(unsafe.Pointer(a))[:aLen:aLen]
+	copy(out, []uint64{enabled, h.Buffers, h.Samples, h.RepetitionFailures, h.ProportionFailures})
+}
+
+// ResetHealth resets the health test counters.
+//export ResetHealth
+func ResetHealth() {
+	rng.ResetHealth()
+}
+
+const healthCounters = 5
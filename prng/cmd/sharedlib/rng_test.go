@@ -103,3 +103,36 @@ func TestGetIntsN(t *testing.T) {
 		}
 	})
 }
+
+func TestGetHealth(t *testing.T) {
+	defer func() {
+		EnableHealthTests(0)
+		ResetHealth()
+	}()
+
+	out := make([]uint64, 5)
+	GetHealth(&out[0], len(out))
+	assert.Equal(t, uint64(0), out[0])
+
+	EnableHealthTests(1)
+	r := NewRNG()
+	for ix := 0; ix < 10000; ix++ {
+		GetUInt64(r)
+	}
+	FreeRNG(r)
+
+	GetHealth(&out[0], len(out))
+	assert.Equal(t, uint64(1), out[0])
+	assert.Greater(t, out[1], uint64(0))
+	assert.Greater(t, out[2], uint64(0))
+	assert.Zero(t, out[3])
+	assert.Zero(t, out[4])
+
+	short := make([]uint64, 2)
+	GetHealth(&short[0], len(short))
+	assert.Equal(t, out[:2], short)
+
+	ResetHealth()
+	GetHealth(&out[0], len(out))
+	assert.Zero(t, out[1])
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/prng.git/rng"
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/prng.git/stats"
+)
+
+// stats runs the statistical test battery on the CPRNG, and prints a pass/fail report with p-values.
+// Without -seed the CPRNG is seeded from the "master" CPRNG, as in production.
+// With -out the report is also written to a file, e.g. for the evidences bundle of tools/certification.sh.
+// The exit code is 1 if any of the tests failed.
+//
+// usage: stats [-samples 1000000] [-alpha 0.01] [-rounds 12] [-seed abc] [-json] [-out report.txt]
+func main() {
+	samples := flag.Int("samples", 1000000, "number of random values for each test")
+	alpha := flag.Float64("alpha", 0.01, "significance level")
+	rounds := flag.Int("rounds", 12, "number of ChaCha rounds (8, 12 or 20)")
+	seed := flag.String("seed", "", "seed for a reproducible run (optional)")
+	asJSON := flag.Bool("json", false, "write the report as JSON")
+	out := flag.String("out", "", "also write the report to this file")
+	flag.Parse()
+
+	cfg := config{
+		samples: *samples,
+		alpha:   *alpha,
+		rounds:  *rounds,
+		seed:    *seed,
+		asJSON:  *asJSON,
+		out:     *out,
+	}
+
+	passed, err := run(cfg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if !passed {
+		os.Exit(1)
+	}
+}
+
+type config struct {
+	samples int
+	alpha   float64
+	rounds  int
+	seed    string
+	asJSON  bool
+	out     string
+}
+
+func run(cfg config) (bool, error) {
+	if cfg.rounds != 8 && cfg.rounds != 12 && cfg.rounds != 20 {
+		return false, fmt.Errorf("invalid rounds [%d]", cfg.rounds)
+	}
+
+	var r *rng.RNG
+	name := fmt.Sprintf("prng/rng (ChaCha%d)", cfg.rounds)
+	if cfg.seed != "" {
+		r = rng.NewRNGFromSeed([]byte(cfg.seed), cfg.rounds)
+		name += fmt.Sprintf(" seeded with [%s]", cfg.seed)
+	} else {
+		r = rng.NewRNGWithRounds(cfg.rounds)
+	}
+	defer r.ReturnToPool()
+
+	report := stats.Run(name, r, stats.Params{Samples: cfg.samples, Alpha: cfg.alpha})
+
+	var w io.Writer = os.Stdout
+	if cfg.out != "" {
+		f, err := os.Create(cfg.out)
+		if err != nil {
+			return false, err
+		}
+		defer f.Close()
+		w = io.MultiWriter(os.Stdout, f)
+	}
+
+	if err := write(w, report, cfg.asJSON); err != nil {
+		return false, err
+	}
+	return report.Passed, nil
+}
+
+func write(w io.Writer, report *stats.Report, asJSON bool) error {
+	if !asJSON {
+		return report.WriteText(w)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
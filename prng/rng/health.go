@@ -0,0 +1,159 @@
+package rng
+
+import (
+	"math"
+	"sync/atomic"
+)
+
+// Health contains the counters of the online health tests.
+// The tests are performed on every buffer refill of every CPRNG (including the "master" CPRNG), once enabled.
+//
+// The tests are modelled after the continuous health tests of NIST SP 800-90B, section 4.4, where every byte of
+// the buffer is a sample with a claimed min-entropy of 8 bits, and the false positive probability is 2^-40:
+//   - the repetition count test fails if a byte is repeated 6 or more times in a row;
+//   - the adaptive proportion test fails if the first byte of a window of 512 bytes occurs too often in that window.
+//
+// A failure does not stop the CPRNG, as it cannot return an error; callers must check Healthy() and refuse to use
+// the output when a test tripped.
+type Health struct {
+	Enabled            bool   `json:"enabled"`
+	Buffers            uint64 `json:"buffers"`            // number of buffers tested.
+	Samples            uint64 `json:"samples"`            // number of bytes tested.
+	RepetitionFailures uint64 `json:"repetitionFailures"` // number of repetition count test failures.
+	ProportionFailures uint64 `json:"proportionFailures"` // number of adaptive proportion test failures.
+}
+
+// Failures returns the total number of health test failures.
+func (h Health) Failures() uint64 {
+	return h.RepetitionFailures + h.ProportionFailures
+}
+
+// Healthy returns true if none of the health tests failed.
+func (h Health) Healthy() bool {
+	return h.Failures() == 0
+}
+
+// EnableHealthTests enables or disables the online health tests for all CPRNGs.
+// The tests are disabled by default, so the production path is unchanged unless they are enabled explicitly.
+func EnableHealthTests(enabled bool) {
+	var v int32
+	if enabled {
+		v = 1
+	}
+	atomic.StoreInt32(&healthEnabled, v)
+}
+
+// HealthStatus returns the current counters of the online health tests.
+func HealthStatus() Health {
+	return Health{
+		Enabled:            atomic.LoadInt32(&healthEnabled) != 0,
+		Buffers:            atomic.LoadUint64(&healthBuffers),
+		Samples:            atomic.LoadUint64(&healthSamples),
+		RepetitionFailures: atomic.LoadUint64(&healthRepetitions),
+		ProportionFailures: atomic.LoadUint64(&healthProportions),
+	}
+}
+
+// Healthy returns true if none of the online health tests failed since startup or the last call to ResetHealth().
+func Healthy() bool {
+	return atomic.LoadUint64(&healthRepetitions) == 0 && atomic.LoadUint64(&healthProportions) == 0
+}
+
+// ResetHealth resets the counters of the online health tests.
+// It should only be called after the cause of a failure was investigated.
+func ResetHealth() {
+	atomic.StoreUint64(&healthBuffers, 0)
+	atomic.StoreUint64(&healthSamples, 0)
+	atomic.StoreUint64(&healthRepetitions, 0)
+	atomic.StoreUint64(&healthProportions, 0)
+}
+
+// testHealth performs the health tests on the given buffer if they are enabled.
+func testHealth(buf byteSlice) {
+	if atomic.LoadInt32(&healthEnabled) == 0 {
+		return
+	}
+
+	repetitions, proportions := healthTests(buf)
+
+	atomic.AddUint64(&healthBuffers, 1)
+	atomic.AddUint64(&healthSamples, uint64(len(buf)))
+	if repetitions > 0 {
+		atomic.AddUint64(&healthRepetitions, uint64(repetitions))
+	}
+	if proportions > 0 {
+		atomic.AddUint64(&healthProportions, uint64(proportions))
+	}
+}
+
+// healthTests returns the number of repetition count and adaptive proportion test failures in the buffer.
+// The tests restart with every buffer, and an incomplete window at the end of the buffer is not tested.
+func healthTests(buf byteSlice) (int, int) {
+	var repetitions, proportions int
+
+	// repetition count test (SP 800-90B, section 4.4.1).
+	count := 1
+	for ix := 1; ix < len(buf); ix++ {
+		if buf[ix] != buf[ix-1] {
+			count = 1
+		} else if count++; count == repetitionCutoff {
+			repetitions++
+		}
+	}
+
+	// adaptive proportion test (SP 800-90B, section 4.4.2).
+	for start := 0; start+proportionWindow <= len(buf); start += proportionWindow {
+		window := buf[start : start+proportionWindow]
+		first, count := window[0], 0
+		for _, b := range window {
+			if b == first {
+				count++
+			}
+		}
+		if count >= proportionCutoff {
+			proportions++
+		}
+	}
+
+	return repetitions, proportions
+}
+
+// critBinom returns the smallest k for which the binomial cumulative distribution function of n trials
+// with probability p is at least 1-alpha.
+func critBinom(n int, p, alpha float64) int {
+	lp, lq := math.Log(p), math.Log1p(-p)
+	lgn, _ := math.Lgamma(float64(n + 1))
+
+	// sums the tail from the top down, to prevent loss of precision for very small alpha.
+	var tail float64
+	for k := n; k >= 0; k-- {
+		lgk, _ := math.Lgamma(float64(k + 1))
+		lgnk, _ := math.Lgamma(float64(n - k + 1))
+		pk := math.Exp(lgn - lgk - lgnk + float64(k)*lp + float64(n-k)*lq)
+		if tail+pk > alpha {
+			return k
+		}
+		tail += pk
+	}
+	return 0
+}
+
+const (
+	healthEntropy    = 8   // claimed min-entropy per byte in bits.
+	healthAlpha      = 40  // false positive probability as a negative power of 2.
+	proportionWindow = 512 // window size for the adaptive proportion test.
+
+	// repetitionCutoff is the cutoff for the repetition count test: 1 + ceil(alpha / H).
+	repetitionCutoff = 1 + (healthAlpha+healthEntropy-1)/healthEntropy
+)
+
+var (
+	// proportionCutoff is the cutoff for the adaptive proportion test: 1 + CRITBINOM(W, 2^-H, 1-alpha).
+	proportionCutoff = 1 + critBinom(proportionWindow, math.Exp2(-healthEntropy), math.Exp2(-healthAlpha))
+
+	healthEnabled     int32
+	healthBuffers     uint64
+	healthSamples     uint64
+	healthRepetitions uint64
+	healthProportions uint64
+)
@@ -0,0 +1,126 @@
+package rng
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthCutoffs(t *testing.T) {
+	// see NIST SP 800-90B, section 4.4.
+	assert.Equal(t, 6, repetitionCutoff)
+	assert.Equal(t, 19, proportionCutoff)
+	assert.Equal(t, 589, 1+critBinom(1024, 0.5, 1.0/(1<<20)))
+	assert.Equal(t, 311, 1+critBinom(512, 0.5, 1.0/(1<<20)))
+}
+
+func TestHealthTests(t *testing.T) {
+	random := func() byteSlice {
+		r := NewRNGFromSeed([]byte("health"), 20)
+		defer r.ReturnToPool()
+		b := make(byteSlice, defaultBufSize)
+		r.Read(b)
+		return b
+	}
+
+	testCases := []struct {
+		name        string
+		buf         func() byteSlice
+		repetitions int
+		proportions int
+	}{
+		{name: "random", buf: random},
+		{name: "zeroes", buf: func() byteSlice { return make(byteSlice, 2048) }, repetitions: 1, proportions: 4},
+		{
+			name: "5 repeats",
+			buf: func() byteSlice {
+				b := random()
+				copy(b[100:], []byte{7, 7, 7, 7, 7})
+				b[99], b[105] = 1, 2
+				return b
+			},
+		},
+		{
+			name: "6 repeats",
+			buf: func() byteSlice {
+				b := random()
+				copy(b[100:], []byte{7, 7, 7, 7, 7, 7})
+				b[99], b[106] = 1, 2
+				return b
+			},
+			repetitions: 1,
+		},
+		{
+			name: "proportion",
+			buf: func() byteSlice {
+				b := random()
+				for ix := 0; ix < proportionCutoff; ix++ {
+					b[512+ix*20] = b[512]
+				}
+				return b
+			},
+			proportions: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repetitions, proportions := healthTests(tc.buf())
+			assert.Equal(t, tc.repetitions, repetitions)
+			assert.Equal(t, tc.proportions, proportions)
+		})
+	}
+}
+
+func TestEnableHealthTests(t *testing.T) {
+	defer func() {
+		EnableHealthTests(false)
+		ResetHealth()
+	}()
+
+	ResetHealth()
+	r1 := NewRNGFromSeed([]byte("enabled"), 12)
+	want := make([]uint64, 10000)
+	for ix := range want {
+		want[ix] = r1.Uint64()
+	}
+	r1.ReturnToPool()
+
+	h := HealthStatus()
+	assert.False(t, h.Enabled)
+	assert.Zero(t, h.Buffers)
+
+	EnableHealthTests(true)
+
+	r2 := NewRNGFromSeed([]byte("enabled"), 12)
+	for ix := range want {
+		require.Equal(t, want[ix], r2.Uint64())
+	}
+	r2.ReturnToPool()
+
+	r3 := NewRNG()
+	for ix := 0; ix < 10000; ix++ {
+		r3.Uint64()
+	}
+	r3.ReturnToPool()
+
+	h = HealthStatus()
+	assert.True(t, h.Enabled)
+	assert.GreaterOrEqual(t, h.Buffers, uint64(10))
+	assert.GreaterOrEqual(t, h.Samples, h.Buffers*4000)
+	assert.True(t, h.Healthy())
+	assert.True(t, Healthy())
+
+	testHealth(make(byteSlice, 1024))
+	h = HealthStatus()
+	assert.False(t, h.Healthy())
+	assert.False(t, Healthy())
+	assert.Equal(t, uint64(1), h.RepetitionFailures)
+	assert.Equal(t, uint64(2), h.ProportionFailures)
+	assert.Equal(t, uint64(3), h.Failures())
+
+	ResetHealth()
+	assert.True(t, Healthy())
+	assert.Zero(t, HealthStatus().Buffers)
+}
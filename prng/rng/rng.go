@@ -32,11 +32,13 @@ var (
 // and prolonged use in simulators requiring a high speed PRNG.
 // However, because it doesn't use a mutex, RNG is not safe for concurrent use across multiple go-routines.
 type RNG struct {
-	buf     byteSlice // the byte buffer (including the seed).
-	ptr     byteSlice // offset into the buffer for the next random byte to retrieve.
-	rounds  int       // number of rounds for the ChaCha stream cipher.
-	lastN   int       // last N used in int31n().
-	lastMax uint32    // max used to prevent modulo bias for last N.
+	buf     byteSlice             // the byte buffer (including the seed).
+	ptr     byteSlice             // offset into the buffer for the next random byte to retrieve.
+	rounds  int                   // number of rounds for the ChaCha stream cipher.
+	lastN   int                   // last N used in int31n().
+	lastMax uint32                // max used to prevent modulo bias for last N.
+	seeded  bool                  // indicates the CPRNG was created from a seed (see NewRNGFromSeed).
+	key     [chacha.KeySize]uint8 // key of the current block; only used when seeded.
 }
 
 // NewRNG returns a new CPRNG from the memory pool, initialized with the default number of ChaCha rounds.
@@ -71,6 +73,8 @@ func (r *RNG) ReturnToPool() {
 			r.buf[ix] = 0
 		}
 		r.lastN = 0
+		r.seeded = false
+		r.key = [chacha.KeySize]uint8{}
 		rngPool.Put(r)
 	}
 }
@@ -196,8 +200,13 @@ func (r *RNG) int31n(n int) int {
 
 // fillBuffer (re-)populates the CPRNG internal buffer using the ChaCha stream cipher.
 func (r *RNG) fillBuffer() {
+	if r.seeded {
+		r.fillSeeded()
+		return
+	}
 	chacha.XORKeyStream(r.buf, r.buf, dummyNonce, r.buf[:chacha.KeySize], r.rounds)
 	r.ptr = r.buf[chacha.KeySize:]
+	testHealth(r.ptr)
 }
 
 // NewCustom returns a new RNG instance seeded with the "master" CPRNG and
@@ -207,7 +216,7 @@ func NewCustom(bufSize, rounds int) *RNG {
 	if bufSize < chacha.KeySize {
 		panic("invalid bufSize; must be at least 32: " + strconv.Itoa(bufSize))
 	}
-	if !(rounds == 8 || rounds == 12 || rounds == 20) {
+	if !validRounds(rounds) {
 		panic(" invalid rounds; must be 8, 12, or 20: " + strconv.Itoa(rounds))
 	}
 
@@ -0,0 +1,102 @@
+package rng
+
+import (
+	"crypto/sha256"
+	"errors"
+	"strconv"
+
+	"github.com/aead/chacha20/chacha"
+)
+
+// NewRNGFromSeed returns a new CPRNG from the memory pool, keyed with the SHA-256 hash of the given seed,
+// and initialized with the given rounds for the ChaCha cipher.
+// The CPRNG produces the same sequence of numbers for the same seed and rounds, across restarts and platforms,
+// so it can be used to deterministically re-run a simulation or a disputed game round.
+// Its current position can be exported with State() and restored with NewRNGFromState().
+// The function will panic if rounds is not 8, 12 or 20.
+//
+// A seeded CPRNG is only as unpredictable as its seed, so never use a guessable seed in production!
+func NewRNGFromSeed(seed []byte, rounds int) *RNG {
+	if !validRounds(rounds) {
+		panic("invalid rounds; must be 8, 12, or 20: " + strconv.Itoa(rounds))
+	}
+
+	key := sha256.Sum256(seed)
+	return newSeeded(key[:], rounds)
+}
+
+// State represents the position of a seeded CPRNG.
+// It contains the key of the current block, and the number of bytes consumed from that block.
+type State struct {
+	Key      []byte `json:"key"`
+	Rounds   int    `json:"rounds"`
+	Consumed int    `json:"consumed"`
+	Size     int    `json:"size"` // size of the block, excluding the key.
+}
+
+// State returns the current position of the CPRNG.
+// It returns ErrNotSeeded if the CPRNG was not created with NewRNGFromSeed() or NewRNGFromState().
+func (r *RNG) State() (*State, error) {
+	if !r.seeded {
+		return nil, ErrNotSeeded
+	}
+
+	s := &State{
+		Key:    make([]byte, chacha.KeySize),
+		Rounds: r.rounds,
+		Size:   len(r.buf) - chacha.KeySize,
+	}
+	copy(s.Key, r.key[:])
+	s.Consumed = s.Size - len(r.ptr)
+	return s, nil
+}
+
+// NewRNGFromState returns a new CPRNG from the memory pool, positioned at the given state.
+// It produces the same sequence of numbers as the CPRNG the state was exported from.
+// It returns ErrInvalidState if the state is corrupt or was exported with a different block size.
+func NewRNGFromState(s *State) (*RNG, error) {
+	if s == nil || len(s.Key) != chacha.KeySize || !validRounds(s.Rounds) || s.Size != defaultBufSize ||
+		s.Consumed < 0 || s.Consumed > s.Size {
+		return nil, ErrInvalidState
+	}
+
+	r := newSeeded(s.Key, s.Rounds)
+	r.ptr = r.ptr[s.Consumed:]
+	return r, nil
+}
+
+// newSeeded returns a seeded CPRNG from the memory pool with the default block size.
+func newSeeded(key []byte, rounds int) *RNG {
+	r := rngPool.Get().(*RNG)
+	if len(r.buf) != chacha.KeySize+defaultBufSize {
+		// the pool may contain a custom CPRNG; not using the memory pool!
+		r = &RNG{buf: make(byteSlice, chacha.KeySize+defaultBufSize)}
+	}
+
+	r.rounds = rounds
+	r.seeded = true
+	copy(r.buf[:chacha.KeySize], key)
+	r.fillBuffer()
+	return r
+}
+
+// fillSeeded (re-)populates the buffer of a seeded CPRNG.
+// Unlike fillBuffer() the block only depends on the key, so the position can be restored from the key alone.
+func (r *RNG) fillSeeded() {
+	copy(r.key[:], r.buf[:chacha.KeySize])
+	for ix := range r.buf {
+		r.buf[ix] = 0
+	}
+	chacha.XORKeyStream(r.buf, r.buf, dummyNonce, r.key[:], r.rounds)
+	r.ptr = r.buf[chacha.KeySize:]
+	testHealth(r.ptr)
+}
+
+func validRounds(rounds int) bool {
+	return rounds == 8 || rounds == 12 || rounds == 20
+}
+
+var (
+	ErrNotSeeded    = errors.New("CPRNG was not created from a seed")
+	ErrInvalidState = errors.New("invalid CPRNG state")
+)
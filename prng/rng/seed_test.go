@@ -0,0 +1,160 @@
+package rng
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRNGFromSeed(t *testing.T) {
+	testCases := []struct {
+		name   string
+		seed   []byte
+		rounds int
+	}{
+		{name: "nil seed", rounds: 12},
+		{name: "short seed 8 rounds", seed: []byte("abc"), rounds: 8},
+		{name: "round id 12 rounds", seed: []byte("ABCDEF-1234567890"), rounds: 12},
+		{name: "long seed 20 rounds", seed: make([]byte, 100), rounds: 20},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r1 := NewRNGFromSeed(tc.seed, tc.rounds)
+			require.NotNil(t, r1)
+			defer r1.ReturnToPool()
+
+			r2 := NewRNGFromSeed(tc.seed, tc.rounds)
+			require.NotNil(t, r2)
+			defer r2.ReturnToPool()
+
+			// crosses several block boundaries.
+			for ix := 0; ix < 10000; ix++ {
+				require.Equal(t, r1.Uint64(), r2.Uint64())
+				require.Equal(t, r1.IntN(37), r2.IntN(37))
+			}
+
+			r3 := NewRNGFromSeed(append(tc.seed, 1), tc.rounds)
+			require.NotNil(t, r3)
+			defer r3.ReturnToPool()
+			assert.NotEqual(t, r1.Uint64(), r3.Uint64())
+		})
+	}
+}
+
+func TestNewRNGFromSeed_Golden(t *testing.T) {
+	// the output for a given seed must never change, as it is used to re-run disputed rounds.
+	r := NewRNGFromSeed([]byte("golden"), 20)
+	defer r.ReturnToPool()
+
+	got := []uint64{r.Uint64(), r.Uint64(), uint64(r.Uint32()), uint64(r.IntN(1000))}
+	assert.Equal(t, goldenSeed, got)
+
+	// exhaust the first block.
+	for ix := 0; ix < defaultBufSize/8; ix++ {
+		r.Uint64()
+	}
+	assert.Equal(t, goldenSeedNext, r.Uint64())
+}
+
+func TestNewRNGFromSeed_Error(t *testing.T) {
+	assert.Panics(t, func() { NewRNGFromSeed([]byte("abc"), 10) })
+}
+
+func TestRNG_State(t *testing.T) {
+	testCases := []struct {
+		name  string
+		skip  int
+		check int
+	}{
+		{name: "start", skip: 0, check: 100},
+		{name: "within block", skip: 123, check: 5000},
+		{name: "end of block", skip: defaultBufSize / 8, check: 100},
+		{name: "several blocks", skip: 10000, check: 5000},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r1 := NewRNGFromSeed([]byte(tc.name), 12)
+			require.NotNil(t, r1)
+			defer r1.ReturnToPool()
+
+			for ix := 0; ix < tc.skip; ix++ {
+				r1.Uint64()
+			}
+
+			s, err := r1.State()
+			require.NoError(t, err)
+			require.NotNil(t, s)
+
+			// simulate a restart by serializing the state.
+			b, err := json.Marshal(s)
+			require.NoError(t, err)
+			s2 := &State{}
+			require.NoError(t, json.Unmarshal(b, s2))
+
+			r2, err := NewRNGFromState(s2)
+			require.NoError(t, err)
+			require.NotNil(t, r2)
+			defer r2.ReturnToPool()
+
+			for ix := 0; ix < tc.check; ix++ {
+				require.Equal(t, r1.Uint32(), r2.Uint32())
+				require.Equal(t, r1.IntN(100), r2.IntN(100))
+			}
+		})
+	}
+}
+
+func TestRNG_StateError(t *testing.T) {
+	t.Run("not seeded", func(t *testing.T) {
+		r := NewRNG()
+		defer r.ReturnToPool()
+
+		s, err := r.State()
+		require.ErrorIs(t, err, ErrNotSeeded)
+		assert.Nil(t, s)
+	})
+
+	t.Run("seed cleared in pool", func(t *testing.T) {
+		r := NewRNGFromSeed(nil, 12)
+		r.ReturnToPool()
+		r = NewRNG()
+		defer r.ReturnToPool()
+
+		_, err := r.State()
+		require.ErrorIs(t, err, ErrNotSeeded)
+	})
+
+	r := NewRNGFromSeed([]byte("state"), 8)
+	defer r.ReturnToPool()
+	good, err := r.State()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name  string
+		state func() *State
+	}{
+		{name: "nil", state: func() *State { return nil }},
+		{name: "short key", state: func() *State { s := *good; s.Key = s.Key[:16]; return &s }},
+		{name: "bad rounds", state: func() *State { s := *good; s.Rounds = 10; return &s }},
+		{name: "bad size", state: func() *State { s := *good; s.Size = 1000; return &s }},
+		{name: "negative", state: func() *State { s := *good; s.Consumed = -1; return &s }},
+		{name: "beyond block", state: func() *State { s := *good; s.Consumed = s.Size + 1; return &s }},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r2, err2 := NewRNGFromState(tc.state())
+			require.ErrorIs(t, err2, ErrInvalidState)
+			assert.Nil(t, r2)
+		})
+	}
+}
+
+var (
+	goldenSeed     = []uint64{7654405192032431721, 4407997503823280390, 2518518410, 113}
+	goldenSeedNext = uint64(5057962170905115241)
+)
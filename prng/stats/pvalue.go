@@ -0,0 +1,88 @@
+package stats
+
+import (
+	"math"
+)
+
+// chiSquare returns the chi-square statistic for the observed counts.
+func chiSquare(counts []int, expected []float64) float64 {
+	var x float64
+	for ix := range counts {
+		d := float64(counts[ix]) - expected[ix]
+		x += d * d / expected[ix]
+	}
+	return x
+}
+
+// chiSquareP returns the probability that a chi-square statistic with df degrees of freedom is at least x.
+func chiSquareP(x float64, df int) float64 {
+	return igamc(float64(df)/2, x/2)
+}
+
+// normalP returns the two-sided probability that a standard normal variable is at least |z|.
+func normalP(z float64) float64 {
+	return math.Erfc(math.Abs(z) / math.Sqrt2)
+}
+
+// igamc returns the regularized upper incomplete gamma function Q(a,x).
+// See Numerical Recipes, section 6.2.
+func igamc(a, x float64) float64 {
+	if x <= 0 {
+		return 1
+	}
+	if x < a+1 {
+		return 1 - igamSeries(a, x)
+	}
+	return igamFraction(a, x)
+}
+
+// igamSeries returns the regularized lower incomplete gamma function P(a,x) using its series representation.
+func igamSeries(a, x float64) float64 {
+	ap, sum := a, 1/a
+	del := sum
+	for ix := 0; ix < maxIterations; ix++ {
+		ap++
+		del *= x / ap
+		sum += del
+		if math.Abs(del) < math.Abs(sum)*epsilon {
+			break
+		}
+	}
+	return sum * math.Exp(-x+a*math.Log(x)-lgamma(a))
+}
+
+// igamFraction returns Q(a,x) using its continued fraction representation (modified Lentz's method).
+func igamFraction(a, x float64) float64 {
+	b := x + 1 - a
+	c := 1 / tiny
+	d := 1 / b
+	h := d
+	for ix := 1; ix <= maxIterations; ix++ {
+		an := -float64(ix) * (float64(ix) - a)
+		b += 2
+		if d = an*d + b; math.Abs(d) < tiny {
+			d = tiny
+		}
+		if c = b + an/c; math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		del := d * c
+		h *= del
+		if math.Abs(del-1) < epsilon {
+			break
+		}
+	}
+	return math.Exp(-x+a*math.Log(x)-lgamma(a)) * h
+}
+
+func lgamma(x float64) float64 {
+	l, _ := math.Lgamma(x)
+	return l
+}
+
+const (
+	maxIterations = 1000
+	epsilon       = 1e-15
+	tiny          = 1e-300
+)
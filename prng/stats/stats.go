@@ -0,0 +1,140 @@
+// Package stats implements a battery of statistical tests for PRNGs.
+//
+// The battery covers the core tests used during certification of the PRNG: monobit (frequency), runs,
+// chi-square on IntN buckets, serial correlation, birthday spacings, gap and poker tests.
+// Each test produces a p-value, and passes if the p-value is at least the significance level (alpha).
+// Chi-square tests also fail if the p-value exceeds 1-alpha, as a fit that is too good is equally unlikely for
+// random data (e.g. a counter produces perfectly uniform buckets).
+// With the default alpha of 0.01, a perfect PRNG is expected to fail a single test in about 1% of the runs,
+// so a failed test should be repeated with a fresh PRNG before drawing conclusions.
+package stats
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// Generator is the interface for the PRNG under test.
+// It is satisfied by the prng/rng.RNG, and by every interfaces.Generator of the game-engine.
+type Generator interface {
+	Uint32() uint32
+	Uint64() uint64
+	IntN(n int) int
+	IntsN(n int, out []int)
+}
+
+// Params contains the parameters for the test battery.
+type Params struct {
+	Samples int     // number of random values drawn for each test; defaults to 1,000,000.
+	Alpha   float64 // significance level; defaults to 0.01.
+	Buckets []int   // bucket counts for the chi-square tests on IntN; defaults to 2, 6, 37 and 1000.
+}
+
+// Result contains the outcome of a single test.
+type Result struct {
+	Name      string  `json:"name"`
+	Samples   int     `json:"samples"`   // number of random values drawn.
+	Statistic float64 `json:"statistic"` // test statistic (e.g. z-score or chi-square).
+	PValue    float64 `json:"pValue"`
+	Passed    bool    `json:"passed"`
+	upper     bool    // indicates the test also fails if the p-value exceeds 1-alpha.
+}
+
+// Report contains the outcome of the test battery.
+type Report struct {
+	Generator string        `json:"generator"`
+	Samples   int           `json:"samples"`
+	Alpha     float64       `json:"alpha"`
+	Started   time.Time     `json:"started"`
+	Duration  time.Duration `json:"duration"`
+	Results   []*Result     `json:"results"`
+	Passed    bool          `json:"passed"` // indicates all tests passed.
+}
+
+// Run performs the test battery on the given generator.
+// The name identifies the generator in the report.
+// The generator is used sequentially, so it doesn't need to be safe for concurrent use.
+func Run(name string, g Generator, params Params) *Report {
+	params.defaults()
+
+	r := &Report{
+		Generator: name,
+		Samples:   params.Samples,
+		Alpha:     params.Alpha,
+		Started:   time.Now().UTC(),
+		Results:   make([]*Result, 0, 8+len(params.Buckets)),
+		Passed:    true,
+	}
+
+	add := func(res *Result) {
+		res.Passed = res.PValue >= params.Alpha && (!res.upper || res.PValue <= 1-params.Alpha)
+		r.Passed = r.Passed && res.Passed
+		r.Results = append(r.Results, res)
+	}
+
+	n := params.Samples
+	add(Monobit(g, n))
+	add(Runs(g, n))
+	for _, k := range params.Buckets {
+		add(ChiSquare(g, n, k))
+	}
+	add(SerialCorrelation(g, n))
+	add(BirthdaySpacings(g, n))
+	add(Gap(g, n))
+	add(Poker(g, n))
+
+	r.Duration = time.Since(r.Started)
+	return r
+}
+
+// Failed returns the number of failed tests.
+func (r *Report) Failed() int {
+	var count int
+	for _, res := range r.Results {
+		if !res.Passed {
+			count++
+		}
+	}
+	return count
+}
+
+// WriteText writes the report in a human-readable format.
+func (r *Report) WriteText(w io.Writer) error {
+	_, err := fmt.Fprintf(w, "generator:  %s\nsamples:    %d per test\nalpha:      %g\nstarted:    %s\nduration:   %s\n\n",
+		r.Generator, r.Samples, r.Alpha, r.Started.Format(time.RFC3339), r.Duration.Round(time.Millisecond))
+	if err != nil {
+		return err
+	}
+
+	if _, err = fmt.Fprintf(w, "%-24s %12s %16s %10s  %s\n", "test", "samples", "statistic", "p-value", "result"); err != nil {
+		return err
+	}
+	for _, res := range r.Results {
+		if _, err = fmt.Fprintf(w, "%-24s %12d %16.6f %10.6f  %s\n", res.Name, res.Samples, res.Statistic, res.PValue, passFail(res.Passed)); err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintf(w, "\noverall:    %s (%d/%d passed)\n", passFail(r.Passed), len(r.Results)-r.Failed(), len(r.Results))
+	return err
+}
+
+func passFail(passed bool) string {
+	if passed {
+		return "PASS"
+	}
+	return "FAIL"
+}
+
+func (p *Params) defaults() {
+	if p.Samples <= 0 {
+		p.Samples = 1000000
+	}
+	if p.Alpha <= 0 || p.Alpha >= 1 {
+		p.Alpha = 0.01
+	}
+	if len(p.Buckets) == 0 {
+		p.Buckets = []int{2, 6, 37, 1000}
+	}
+}
@@ -0,0 +1,167 @@
+package stats
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"git-codecommit.eu-central-1.amazonaws.com/v1/repos/prng.git/rng"
+)
+
+func TestRun(t *testing.T) {
+	r := rng.NewRNGFromSeed([]byte("stats"), 12)
+	defer r.ReturnToPool()
+
+	report := Run("seeded", r, Params{Samples: 200000})
+	require.NotNil(t, report)
+
+	assert.Equal(t, "seeded", report.Generator)
+	assert.Equal(t, 200000, report.Samples)
+	assert.Equal(t, 0.01, report.Alpha)
+	assert.Equal(t, 10, len(report.Results))
+	assert.True(t, report.Passed)
+	assert.Zero(t, report.Failed())
+
+	for _, res := range report.Results {
+		assert.True(t, res.Passed, res.Name)
+		assert.Greater(t, res.PValue, 0.01, res.Name)
+		assert.LessOrEqual(t, res.PValue, 1.0, res.Name)
+		assert.NotZero(t, res.Samples, res.Name)
+	}
+
+	b := &bytes.Buffer{}
+	require.NoError(t, report.WriteText(b))
+	assert.Contains(t, b.String(), "chi-square IntN(37)")
+	assert.Contains(t, b.String(), "overall:    PASS (10/10 passed)")
+
+	j, err := json.Marshal(report)
+	require.NoError(t, err)
+	assert.Contains(t, string(j), `"pValue"`)
+}
+
+func TestRun_Bad(t *testing.T) {
+	testCases := []struct {
+		name   string
+		gen    Generator
+		failed []string
+	}{
+		{
+			name:   "counter",
+			gen:    &counter{},
+			failed: []string{"monobit", "runs", "chi-square IntN(6)", "chi-square IntN(37)", "serial correlation", "birthday spacings", "gap", "poker"},
+		},
+		{
+			name:   "biased bits",
+			gen:    &biased{r: rng.NewRNGFromSeed([]byte("biased"), 12)},
+			failed: []string{"monobit"},
+		},
+		{
+			name:   "biased ints",
+			gen:    &biased{r: rng.NewRNGFromSeed([]byte("biased"), 12), ints: true},
+			failed: []string{"chi-square IntN(6)", "chi-square IntN(37)", "birthday spacings", "gap"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			report := Run(tc.name, tc.gen, Params{Samples: 100000, Buckets: []int{6, 37}})
+			require.NotNil(t, report)
+			assert.False(t, report.Passed)
+
+			failed := make(map[string]bool)
+			for _, res := range report.Results {
+				if !res.Passed {
+					failed[res.Name] = true
+				}
+			}
+			for _, name := range tc.failed {
+				assert.True(t, failed[name], name)
+			}
+		})
+	}
+}
+
+func TestChiSquareP(t *testing.T) {
+	testCases := []struct {
+		x  float64
+		df int
+		p  float64
+	}{
+		{x: 0, df: 5, p: 1},
+		{x: 3.841459, df: 1, p: 0.05},
+		{x: 6.634897, df: 1, p: 0.01},
+		{x: 18.307038, df: 10, p: 0.05},
+		{x: 23.209251, df: 10, p: 0.01},
+		{x: 9.341818, df: 10, p: 0.5},
+		{x: 63.690740, df: 40, p: 0.01},
+	}
+
+	for _, tc := range testCases {
+		assert.InDelta(t, tc.p, chiSquareP(tc.x, tc.df), 1e-6, "%f %d", tc.x, tc.df)
+	}
+}
+
+func TestNormalP(t *testing.T) {
+	assert.Equal(t, 1.0, normalP(0))
+	assert.InDelta(t, 0.05, normalP(1.959964), 1e-6)
+	assert.InDelta(t, 0.01, normalP(-2.575829), 1e-6)
+}
+
+// counter is a generator which produces consecutive numbers.
+type counter struct {
+	n uint64
+}
+
+func (c *counter) Uint32() uint32 { return uint32(c.Uint64()) }
+
+func (c *counter) Uint64() uint64 {
+	c.n++
+	return c.n
+}
+
+func (c *counter) IntN(n int) int { return int(c.Uint64() % uint64(n)) }
+
+func (c *counter) IntsN(n int, out []int) {
+	for ix := range out {
+		out[ix] = c.IntN(n)
+	}
+}
+
+// biased is a generator which sets slightly too many bits, or produces slightly too many zeroes.
+type biased struct {
+	r    *rng.RNG
+	ints bool
+	n    int
+}
+
+func (b *biased) Uint32() uint32 { return uint32(b.Uint64()) }
+
+func (b *biased) Uint64() uint64 {
+	v := b.r.Uint64()
+	if !b.ints {
+		// sets the lowest bit in 1 of 8 values.
+		if b.n++; b.n%8 == 0 {
+			v |= 1
+		}
+	}
+	return v
+}
+
+func (b *biased) IntN(n int) int {
+	v := b.r.IntN(n)
+	if b.ints {
+		if b.n++; b.n%20 == 0 {
+			v = 0
+		}
+	}
+	return v
+}
+
+func (b *biased) IntsN(n int, out []int) {
+	for ix := range out {
+		out[ix] = b.IntN(n)
+	}
+}
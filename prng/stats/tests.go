@@ -0,0 +1,236 @@
+package stats
+
+import (
+	"math"
+	"math/bits"
+	"sort"
+	"strconv"
+)
+
+// Monobit performs the frequency (monobit) test on the bits of n random uint64 values.
+// The proportion of ones should be close to 1/2. See NIST SP 800-22, section 2.1.
+func Monobit(g Generator, n int) *Result {
+	var ones int
+	for ix := 0; ix < n; ix++ {
+		ones += bits.OnesCount64(g.Uint64())
+	}
+
+	count := float64(n) * 64
+	s := (2*float64(ones) - count) / math.Sqrt(count)
+	return &Result{Name: "monobit", Samples: n, Statistic: s, PValue: normalP(s)}
+}
+
+// Runs performs the runs test on the bits of n random uint64 values.
+// The number of uninterrupted sequences of identical bits should match that of a random sequence.
+// See NIST SP 800-22, section 2.3.
+func Runs(g Generator, n int) *Result {
+	var ones, transitions int
+	var last uint64
+	for ix := 0; ix < n; ix++ {
+		v := g.Uint64()
+		ones += bits.OnesCount64(v)
+		transitions += bits.OnesCount64((v ^ v>>1) & (math.MaxUint64 >> 1))
+		if ix > 0 && v&1 != last {
+			transitions++
+		}
+		last = v >> 63
+	}
+
+	count := float64(n) * 64
+	pi := float64(ones) / count
+	res := &Result{Name: "runs", Samples: n}
+	if math.Abs(pi-0.5) >= 2/math.Sqrt(count) {
+		// the monobit prerequisite failed; the runs test is not applicable.
+		res.Statistic = pi
+		return res
+	}
+
+	runs := float64(transitions + 1)
+	expected := 2 * count * pi * (1 - pi)
+	res.Statistic = (runs - expected) / (2 * math.Sqrt(2*count) * pi * (1 - pi))
+	res.PValue = math.Erfc(math.Abs(res.Statistic))
+	return res
+}
+
+// ChiSquare performs a chi-square goodness-of-fit test on n random values from IntN(buckets).
+// Every bucket should receive the same number of values.
+func ChiSquare(g Generator, n, buckets int) *Result {
+	counts := make([]int, buckets)
+	for ix := 0; ix < n; ix++ {
+		counts[g.IntN(buckets)]++
+	}
+
+	expected := make([]float64, buckets)
+	for ix := range expected {
+		expected[ix] = float64(n) / float64(buckets)
+	}
+
+	x := chiSquare(counts, expected)
+	return &Result{Name: "chi-square IntN(" + strconv.Itoa(buckets) + ")", Samples: n, upper: true, Statistic: x, PValue: chiSquareP(x, buckets-1)}
+}
+
+// SerialCorrelation performs the serial correlation test on n uniform random values in [0,1).
+// The correlation between successive values should be close to 0. See Knuth TAOCP vol. 2, section 3.3.2.
+func SerialCorrelation(g Generator, n int) *Result {
+	var sum, squares, products float64
+	first := uniform(g)
+	prev := first
+	for ix := 0; ix < n; ix++ {
+		u := first
+		if ix < n-1 {
+			u = uniform(g)
+		}
+		// uses the cyclic sequence, so the last value is paired with the first value.
+		sum += prev
+		squares += prev * prev
+		products += prev * u
+		prev = u
+	}
+
+	count := float64(n)
+	c := (count*products - sum*sum) / (count*squares - sum*sum)
+	z := (c + 1/(count-1)) * math.Sqrt(count)
+	return &Result{Name: "serial correlation", Samples: n, Statistic: c, PValue: normalP(z)}
+}
+
+// BirthdaySpacings performs Marsaglia's birthday spacings test using IntN.
+// Each repetition draws 512 birthdays from a year of 2^24 days; the number of duplicate spacings between the
+// sorted birthdays is Poisson distributed with a mean of 2. The counts are tested with a chi-square test.
+func BirthdaySpacings(g Generator, n int) *Result {
+	const (
+		birthdays = 512
+		days      = 1 << 24
+		lambda    = float64(birthdays) * birthdays * birthdays / (4 * days)
+		bins      = 7 // 0..5 duplicates, and 6 or more.
+	)
+
+	reps := n / birthdays
+	counts := make([]int, bins)
+	b := make([]int, birthdays)
+	spacings := make([]int, birthdays)
+
+	for ix := 0; ix < reps; ix++ {
+		for iy := range b {
+			b[iy] = g.IntN(days)
+		}
+		sort.Ints(b)
+
+		spacings[0] = b[0]
+		for iy := 1; iy < birthdays; iy++ {
+			spacings[iy] = b[iy] - b[iy-1]
+		}
+		sort.Ints(spacings)
+
+		var dups int
+		for iy := 1; iy < birthdays; iy++ {
+			if spacings[iy] == spacings[iy-1] {
+				dups++
+			}
+		}
+		if dups >= bins-1 {
+			dups = bins - 1
+		}
+		counts[dups]++
+	}
+
+	expected := make([]float64, bins)
+	p, tail := math.Exp(-lambda), 1.0
+	for k := 0; k < bins-1; k++ {
+		expected[k] = p * float64(reps)
+		tail -= p
+		p *= lambda / float64(k+1)
+	}
+	expected[bins-1] = tail * float64(reps)
+
+	x := chiSquare(counts, expected)
+	return &Result{Name: "birthday spacings", Samples: reps * birthdays, upper: true, Statistic: x, PValue: chiSquareP(x, bins-1)}
+}
+
+// Gap performs the gap test on n random values from IntN(10).
+// The lengths of the gaps between occurrences of 0 should be geometrically distributed.
+// Gaps of 40 or more are counted together. See Knuth TAOCP vol. 2, section 3.3.2.
+func Gap(g Generator, n int) *Result {
+	const (
+		digits = 10
+		limit  = 40
+		p      = 1.0 / digits
+	)
+
+	counts := make([]int, limit+1)
+	var gap, gaps int
+	for ix := 0; ix < n; ix++ {
+		if g.IntN(digits) != 0 {
+			gap++
+			continue
+		}
+		if gap > limit {
+			gap = limit
+		}
+		counts[gap]++
+		gaps++
+		gap = 0
+	}
+
+	expected := make([]float64, limit+1)
+	q := 1.0
+	for r := 0; r < limit; r++ {
+		expected[r] = float64(gaps) * p * q
+		q *= 1 - p
+	}
+	expected[limit] = float64(gaps) * q
+
+	x := chiSquare(counts, expected)
+	return &Result{Name: "gap", Samples: n, upper: true, Statistic: x, PValue: chiSquareP(x, limit)}
+}
+
+// Poker performs the (simplified) poker test on hands of 5 random digits from IntsN(10).
+// The number of distinct digits in each hand should match the expected distribution.
+// Hands with 1 or 2 distinct digits are counted together. See Knuth TAOCP vol. 2, section 3.3.2.
+func Poker(g Generator, n int) *Result {
+	const (
+		digits = 10
+		size   = 5
+	)
+
+	hands := n / size
+	hand := make([]int, size)
+	counts := make([]int, size-1)
+
+	for ix := 0; ix < hands; ix++ {
+		g.IntsN(digits, hand)
+
+		var seen, distinct int
+		for _, d := range hand {
+			if seen&(1<<d) == 0 {
+				seen |= 1 << d
+				distinct++
+			}
+		}
+		if distinct < 2 {
+			distinct = 2
+		}
+		counts[distinct-2]++
+	}
+
+	// P(r distinct) = d!/(d-r)! * S(k,r) / d^k, where S(k,r) is the Stirling number of the second kind.
+	stirling := []float64{0, 1, 15, 25, 10, 1}
+	expected := make([]float64, size-1)
+	falling := 1.0
+	for r := 1; r <= size; r++ {
+		falling *= float64(digits - r + 1)
+		p := falling * stirling[r] / math.Pow(digits, size)
+		if r < 2 {
+			expected[0] += p * float64(hands)
+		} else {
+			expected[r-2] += p * float64(hands)
+		}
+	}
+
+	x := chiSquare(counts, expected)
+	return &Result{Name: "poker", Samples: hands * size, upper: true, Statistic: x, PValue: chiSquareP(x, size-2)}
+}
+
+// uniform returns a uniform random value in [0,1) with 53 bits of precision.
+func uniform(g Generator) float64 {
+	return float64(g.Uint64()>>11) / (1 << 53)
+}
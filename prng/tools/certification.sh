@@ -37,6 +37,7 @@ mkdir -p ${EVIDENCE_PATH}
 mkdir ${EVIDENCE_PATH}/cards
 mkdir ${EVIDENCE_PATH}/rng
 mkdir ${EVIDENCE_PATH}/chacha20
+mkdir ${EVIDENCE_PATH}/stats
 mkdir -p ${EVIDENCE_PATH}/cmd/sharedlib
 mkdir ${EVIDENCE_PATH}/bin
 echo ""
@@ -47,6 +48,7 @@ echo "cards/shuffle.go"
 echo "rng/rng.go"
 echo "chacha/chacha.go"
 echo "cmd/sharedlib/*.go"
+echo "stats/*.go"
 echo "go.mod & go.sum"
 cp cards/shuffle.go ${EVIDENCE_PATH}/cards/
 cp rng/rng.go ${EVIDENCE_PATH}/rng/
@@ -54,6 +56,7 @@ cp "${CHACHA20}/chacha/chacha.go" ${EVIDENCE_PATH}/chacha20/
 cp cmd/sharedlib/main.go ${EVIDENCE_PATH}/cmd/sharedlib/
 cp cmd/sharedlib/rng.go ${EVIDENCE_PATH}/cmd/sharedlib/
 cp cmd/sharedlib/shuffle.go ${EVIDENCE_PATH}/cmd/sharedlib/
+cp stats/stats.go stats/tests.go stats/pvalue.go ${EVIDENCE_PATH}/stats/
 cp go.mod ${EVIDENCE_PATH}/
 cp go.sum ${EVIDENCE_PATH}/
 echo ""
@@ -92,13 +95,21 @@ read -n 1 -s -r -p "<Press any key to continue>"
 echo ""
 
 echo "============================================================="
-echo "step 7 - copy build process script"
+echo "step 7 - statistical test battery (report with p-values)"
+echo "go run cmd/stats/main.go -samples 10000000 -rounds 12 -out ${EVIDENCE_PATH}/stats/report.txt"
+go run cmd/stats/main.go -samples 10000000 -rounds 12 -out ${EVIDENCE_PATH}/stats/report.txt
+echo ""
+read -n 1 -s -r -p "<Press any key to continue>"
+echo ""
+
+echo "============================================================="
+echo "step 8 - copy build process script"
 echo "tools/certification.sh"
 cp tools/certification.sh ${EVIDENCE_PATH}/
 echo ""
 
 echo "============================================================="
-echo "step 8 - list evidences directory"
+echo "step 9 - list evidences directory"
 cd ${EVIDENCE_PATH}
 tree
 cd - > /dev/null
@@ -107,7 +118,7 @@ read -n 1 -s -r -p "<Press any key to continue>"
 echo ""
 
 echo "============================================================="
-echo "step 9 - zip evidences directory with password"
+echo "step 10 - zip evidences directory with password"
 cd output
 rm -Rf ${EVIDENCE}.zip
 zip -re ${EVIDENCE}.zip ${EVIDENCE}
@@ -115,7 +126,7 @@ cd - > /dev/null
 echo ""
 
 echo "============================================================="
-echo "step 10 - sha1 checksum of evidences zip"
+echo "step 11 - sha1 checksum of evidences zip"
 sha1sum output/${EVIDENCE}.zip
 echo ""
 